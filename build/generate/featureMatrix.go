@@ -73,29 +73,37 @@ func featureEmoji(
 
 func matrixData() *FeatureMatrix {
 	const (
-		OfficialSupport      = "Official Support" // vs. community supported
-		ProviderDNSProvider  = "DNS Provider"
-		ProviderRegistrar    = "Registrar"
-		ProviderThreadSafe   = "Concurrency Verified"
-		DomainModifierAlias  = "[`ALIAS`](language-reference/domain-modifiers/ALIAS.md)"
-		DomainModifierCaa    = "[`CAA`](language-reference/domain-modifiers/CAA.md)"
-		DomainModifierDnssec = "[`AUTODNSSEC`](language-reference/domain-modifiers/AUTODNSSEC_ON.md)"
-		DomainModifierHTTPS  = "[`HTTPS`](language-reference/domain-modifiers/HTTPS.md)"
-		DomainModifierLoc    = "[`LOC`](language-reference/domain-modifiers/LOC.md)"
-		DomainModifierNaptr  = "[`NAPTR`](language-reference/domain-modifiers/NAPTR.md)"
-		DomainModifierPtr    = "[`PTR`](language-reference/domain-modifiers/PTR.md)"
-		DomainModifierSoa    = "[`SOA`](language-reference/domain-modifiers/SOA.md)"
-		DomainModifierSrv    = "[`SRV`](language-reference/domain-modifiers/SRV.md)"
-		DomainModifierSshfp  = "[`SSHFP`](language-reference/domain-modifiers/SSHFP.md)"
-		DomainModifierSvcb   = "[`SVCB`](language-reference/domain-modifiers/SVCB.md)"
-		DomainModifierTlsa   = "[`TLSA`](language-reference/domain-modifiers/TLSA.md)"
-		DomainModifierDs     = "[`DS`](language-reference/domain-modifiers/DS.md)"
-		DomainModifierDhcid  = "[`DHCID`](language-reference/domain-modifiers/DHCID.md)"
-		DomainModifierDname  = "[`DNAME`](language-reference/domain-modifiers/DNAME.md)"
-		DomainModifierDnskey = "[`DNSKEY`](language-reference/domain-modifiers/DNSKEY.md)"
-		DualHost             = "dual host"
-		CreateDomains        = "create-domains"
-		GetZones             = "get-zones"
+		OfficialSupport          = "Official Support" // vs. community supported
+		ProviderDNSProvider      = "DNS Provider"
+		ProviderRegistrar        = "Registrar"
+		ProviderThreadSafe       = "Concurrency Verified"
+		DomainModifierAlias      = "[`ALIAS`](language-reference/domain-modifiers/ALIAS.md)"
+		DomainModifierCaa        = "[`CAA`](language-reference/domain-modifiers/CAA.md)"
+		DomainModifierDnssec     = "[`AUTODNSSEC`](language-reference/domain-modifiers/AUTODNSSEC_ON.md)"
+		DomainModifierHTTPS      = "[`HTTPS`](language-reference/domain-modifiers/HTTPS.md)"
+		DomainModifierLoc        = "[`LOC`](language-reference/domain-modifiers/LOC.md)"
+		DomainModifierNaptr      = "[`NAPTR`](language-reference/domain-modifiers/NAPTR.md)"
+		DomainModifierPtr        = "[`PTR`](language-reference/domain-modifiers/PTR.md)"
+		DomainModifierSoa        = "[`SOA`](language-reference/domain-modifiers/SOA.md)"
+		DomainModifierSrv        = "[`SRV`](language-reference/domain-modifiers/SRV.md)"
+		DomainModifierSshfp      = "[`SSHFP`](language-reference/domain-modifiers/SSHFP.md)"
+		DomainModifierSvcb       = "[`SVCB`](language-reference/domain-modifiers/SVCB.md)"
+		DomainModifierTlsa       = "[`TLSA`](language-reference/domain-modifiers/TLSA.md)"
+		DomainModifierDs         = "[`DS`](language-reference/domain-modifiers/DS.md)"
+		DomainModifierDhcid      = "[`DHCID`](language-reference/domain-modifiers/DHCID.md)"
+		DomainModifierDname      = "[`DNAME`](language-reference/domain-modifiers/DNAME.md)"
+		DomainModifierDnskey     = "[`DNSKEY`](language-reference/domain-modifiers/DNSKEY.md)"
+		DomainModifierOpenpgpkey = "[`OPENPGPKEY`](language-reference/domain-modifiers/OPENPGPKEY.md)"
+		DomainModifierEui48      = "[`EUI48`](language-reference/domain-modifiers/EUI48.md)"
+		DomainModifierEui64      = "[`EUI64`](language-reference/domain-modifiers/EUI64.md)"
+		DomainModifierCert       = "[`CERT`](language-reference/domain-modifiers/CERT.md)"
+		DomainModifierIlnp       = "[`NID`/`L32`/`L64`/`LP`](language-reference/domain-modifiers/NID.md)"
+		DomainModifierAfsdb      = "[`AFSDB`](language-reference/domain-modifiers/AFSDB.md)"
+		DomainModifierRp         = "[`RP`](language-reference/domain-modifiers/RP.md)"
+		DomainModifierHip        = "[`HIP`](language-reference/domain-modifiers/HIP.md)"
+		DualHost                 = "dual host"
+		CreateDomains            = "create-domains"
+		GetZones                 = "get-zones"
 	)
 
 	matrix := &FeatureMatrix{
@@ -121,6 +129,14 @@ func matrixData() *FeatureMatrix {
 			DomainModifierDhcid,
 			DomainModifierDname,
 			DomainModifierDnskey,
+			DomainModifierOpenpgpkey,
+			DomainModifierEui48,
+			DomainModifierEui64,
+			DomainModifierCert,
+			DomainModifierIlnp,
+			DomainModifierAfsdb,
+			DomainModifierRp,
+			DomainModifierHip,
 			DualHost,
 			CreateDomains,
 			//NoPurge,
@@ -211,10 +227,34 @@ func matrixData() *FeatureMatrix {
 			DomainModifierDnskey,
 			providers.CanUseDNSKEY,
 		)
+		setCapability(
+			DomainModifierAfsdb,
+			providers.CanUseAFSDB,
+		)
+		setCapability(
+			DomainModifierHip,
+			providers.CanUseHIP,
+		)
+		setCapability(
+			DomainModifierCert,
+			providers.CanUseCERT,
+		)
+		setCapability(
+			DomainModifierEui48,
+			providers.CanUseEUI48,
+		)
+		setCapability(
+			DomainModifierEui64,
+			providers.CanUseEUI64,
+		)
 		setCapability(
 			DomainModifierHTTPS,
 			providers.CanUseHTTPS,
 		)
+		setCapability(
+			DomainModifierIlnp,
+			providers.CanUseILNP,
+		)
 		setCapability(
 			DomainModifierLoc,
 			providers.CanUseLOC,
@@ -223,10 +263,18 @@ func matrixData() *FeatureMatrix {
 			DomainModifierNaptr,
 			providers.CanUseNAPTR,
 		)
+		setCapability(
+			DomainModifierOpenpgpkey,
+			providers.CanUseOPENPGPKEY,
+		)
 		setCapability(
 			DomainModifierPtr,
 			providers.CanUsePTR,
 		)
+		setCapability(
+			DomainModifierRp,
+			providers.CanUseRP,
+		)
 		setCapability(
 			DomainModifierSoa,
 			providers.CanUseSOA,
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// axfrCredName is the creds.json entry name used by get-zones --axfr,
+// which doesn't read creds.json.
+const axfrCredName = "axfr"
+
+// axfrProviderConfig returns the config of an AXFRDDNS provider that
+// transfers zones from server, authenticated by the TSIG key if it isn't
+// "". The key is "algorithm:name:secret" (as in the transfer-key of an
+// AXFRDDNS creds.json entry), or "@file" to read it from a BIND key file
+// such as the output of tsig-keygen.
+func axfrProviderConfig(server, key string) (map[string]string, error) {
+	if server == "" {
+		return nil, fmt.Errorf("--axfr requires --server")
+	}
+	if file, ok := strings.CutPrefix(key, "@"); ok {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("--key: %w", err)
+		}
+		if key, err = parseBINDKey(string(b)); err != nil {
+			return nil, fmt.Errorf("--key: %s: %w", file, err)
+		}
+	}
+	config := map[string]string{
+		"TYPE":   "AXFRDDNS",
+		"master": server,
+	}
+	if key != "" {
+		config["transfer-key"] = key
+	}
+	return config, nil
+}
+
+var (
+	bindKeyName      = regexp.MustCompile(`key\s+"?([^"\s{]+)"?\s*{`)
+	bindKeyAlgorithm = regexp.MustCompile(`algorithm\s+"?([^";\s]+)"?\s*;`)
+	bindKeySecret    = regexp.MustCompile(`secret\s+"([^"]+)"\s*;`)
+)
+
+// parseBINDKey converts the first key statement of a BIND key file to the
+// "algorithm:name:secret" form.
+func parseBINDKey(text string) (string, error) {
+	name := bindKeyName.FindStringSubmatch(text)
+	algo := bindKeyAlgorithm.FindStringSubmatch(text)
+	secret := bindKeySecret.FindStringSubmatch(text)
+	if name == nil || algo == nil || secret == nil {
+		return "", fmt.Errorf(`not a BIND key file (expected key "name" { algorithm ...; secret "..."; };)`)
+	}
+	return algo[1] + ":" + name[1] + ":" + secret[1], nil
+}
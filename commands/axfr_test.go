@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_axfrProviderConfig(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "transfer.key")
+	if err := os.WriteFile(keyFile, []byte("key \"transfer\" {\n\talgorithm hmac-sha256;\n\tsecret \"c2VjcmV0\";\n};\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	badFile := filepath.Join(dir, "bad.key")
+	if err := os.WriteFile(badFile, []byte("secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		server, key string
+		wantKey     string
+		wantErr     bool
+	}{
+		{"ns1.example.com", "", "", false},
+		{"ns1.example.com:5353", "hmac-sha512:name:c2VjcmV0", "hmac-sha512:name:c2VjcmV0", false},
+		{"ns1.example.com", "@" + keyFile, "hmac-sha256:transfer:c2VjcmV0", false},
+		{"ns1.example.com", "@" + badFile, "", true},
+		{"ns1.example.com", "@" + filepath.Join(dir, "missing.key"), "", true},
+		{"", "", "", true},
+	}
+	for _, tst := range tests {
+		config, err := axfrProviderConfig(tst.server, tst.key)
+		if (err != nil) != tst.wantErr {
+			t.Errorf("%q %q: got error %v, want error=%v", tst.server, tst.key, err, tst.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if config["TYPE"] != "AXFRDDNS" || config["master"] != tst.server || config["transfer-key"] != tst.wantKey {
+			t.Errorf("%q %q: got %v", tst.server, tst.key, config)
+		}
+	}
+}
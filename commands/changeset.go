@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// changesetFormats maps the formats that --changeset accepts to the
+// provider type whose corrections can be output in that format.
+var changesetFormats = map[string]string{
+	"route53": "ROUTE53",
+}
+
+// changeset is the corrections of a preview as the API requests that
+// would make them. It is the runReporter of --changeset.
+type changeset struct {
+	nopReporter
+	file         string
+	providerType string
+
+	mu      sync.Mutex
+	entries []changesetEntry
+	skipped map[string]bool // providers whose corrections can't be output
+}
+
+// changesetEntry is one request, e.g. a Route 53 change batch, in the
+// order that push would send it.
+type changesetEntry struct {
+	Domain      string `json:"domain"`
+	Provider    string `json:"provider"`
+	Description string `json:"description"`
+	Input       any    `json:"input"`
+}
+
+// newChangeset returns the changeset for the --changeset format, written
+// to file, or nil if no changeset was requested.
+func newChangeset(format, file string, push bool) (*changeset, error) {
+	if format == "" {
+		return nil, nil
+	}
+	if push {
+		return nil, fmt.Errorf("--changeset can't be used with push")
+	}
+	providerType, ok := changesetFormats[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("--changeset: unknown format %q (valid: route53)", format)
+	}
+	return &changeset{file: file, providerType: providerType, skipped: map[string]bool{}}, nil
+}
+
+// addChanges records the requests of the corrections of the domain at a
+// DNS provider. Corrections without a request (e.g. reports) are ignored;
+// the corrections of other types of providers are reported once per
+// provider.
+func (c *changeset) addChanges(z zoneChanges) {
+	if z.providerType == "" || len(z.corrections) == 0 {
+		return
+	}
+	domain, provider, providerType := z.domain(), z.provider, z.providerType
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if providerType != c.providerType {
+		if !c.skipped[provider] {
+			c.skipped[provider] = true
+			printer.Warnf("--changeset: the corrections of %s (%s) aren't in the changeset\n", provider, providerType)
+		}
+		return
+	}
+	for _, corr := range z.corrections {
+		if corr.F == nil {
+			continue
+		}
+		if corr.Changeset == nil {
+			printer.Warnf("--changeset: %s: %s: a correction isn't in the changeset: %s\n", domain, provider, strings.TrimSpace(colorCodes.ReplaceAllString(corr.Msg, "")))
+			continue
+		}
+		c.entries = append(c.entries, changesetEntry{
+			Domain:      domain,
+			Provider:    provider,
+			Description: strings.TrimSpace(colorCodes.ReplaceAllString(corr.Msg, "")),
+			Input:       corr.Changeset,
+		})
+	}
+}
+
+// finish writes the requests to the file as a JSON list.
+func (c *changeset) finish(out printer.CLI) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries := c.entries
+	if entries == nil {
+		entries = []changesetEntry{}
+	}
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("writing the changeset: %w", err)
+	}
+	if err := os.WriteFile(c.file, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing the changeset: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+func Test_changeset(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "changeset.json")
+	if c, err := newChangeset("", file, false); c != nil || err != nil {
+		t.Errorf("no --changeset: got (%v, %v)", c, err)
+	}
+	if _, err := newChangeset("route53", file, true); err == nil {
+		t.Errorf("--changeset with push: expected an error")
+	}
+	if _, err := newChangeset("azure", file, false); err == nil {
+		t.Errorf("--changeset=azure: expected an error")
+	}
+
+	c, err := newChangeset("route53", file, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noop := func() error { return nil }
+	dc := testDomain("example.com")
+	c.addChanges(zoneChanges{dc: dc, provider: "r53", providerType: "ROUTE53", corrections: []*models.Correction{
+		{Msg: "report only"},
+		{Msg: "\x1b[32m+ CREATE www.example.com A 192.0.2.1\x1b[0m", F: noop, Changeset: map[string]string{"HostedZoneId": "Z1"}},
+	}})
+	c.addChanges(zoneChanges{dc: dc, provider: "bind", providerType: "BIND", corrections: []*models.Correction{
+		{Msg: "+ CREATE www.example.com A 192.0.2.1", F: noop},
+	}})
+	c.addChanges(zoneChanges{dc: dc, provider: "none", corrections: []*models.Correction{
+		{Msg: "Update nameservers", F: noop},
+	}})
+
+	if err := c.finish(printer.ConsolePrinter{Writer: &strings.Builder{}}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []changesetEntry
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("%s: %v", b, err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d entries, expected 1:\n%s", len(got), b)
+	}
+	if e := got[0]; e.Domain != "example.com" || e.Provider != "r53" || e.Description != "+ CREATE www.example.com A 192.0.2.1" {
+		t.Errorf("got %+v", e)
+	}
+	if !c.skipped["bind"] || c.skipped["none"] {
+		t.Errorf("skipped = %v, want the BIND corrections reported, and the registrar's ignored", c.skipped)
+	}
+}
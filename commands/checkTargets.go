@@ -0,0 +1,227 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/gobwas/glob"
+	"github.com/miekg/dns"
+	"github.com/urfave/cli/v2"
+)
+
+// CheckTargetsArgs encapsulates the flags that resolve the targets of the
+// CNAME and ALIAS records that point outside of dnsconfig.js.
+type CheckTargetsArgs struct {
+	CheckTargets         bool
+	CheckTargetsResolver string
+}
+
+func (args *CheckTargetsArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:        "check-targets",
+			Destination: &args.CheckTargets,
+			Usage:       "Resolve the targets of the CNAME and ALIAS records outside of dnsconfig.js (live DNS), and report those that don't exist or don't match RESOLVES_TO() (warnings)",
+		},
+		&cli.StringFlag{
+			Name:        "check-targets-resolver",
+			Destination: &args.CheckTargetsResolver,
+			Usage:       "The resolver that --check-targets queries, as host or host:port (default the first nameserver of /etc/resolv.conf)",
+		},
+	}
+}
+
+// targetAnswer is what a resolver answered for the addresses of a name.
+type targetAnswer struct {
+	nxdomain bool
+	names    []string // The name and its CNAME chain.
+	addrs    []net.IP
+}
+
+// lookupTarget resolves the A and AAAA records of name, asking resolver.
+// Tests replace it.
+var lookupTarget = func(resolver, name string) (targetAnswer, error) {
+	ans := targetAnswer{names: []string{dns.Fqdn(name)}}
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		m := new(dns.Msg)
+		m.SetQuestion(dns.Fqdn(name), qtype)
+		r, err := dnsExchange(resolver, m)
+		if err != nil {
+			return ans, err
+		}
+		switch r.Rcode {
+		case dns.RcodeSuccess:
+		case dns.RcodeNameError:
+			ans.nxdomain = true
+			return ans, nil
+		default:
+			return ans, fmt.Errorf("the resolver %s answered %s", resolver, dns.RcodeToString[r.Rcode])
+		}
+		for _, rr := range r.Answer {
+			switch rr := rr.(type) {
+			case *dns.CNAME:
+				if qtype == dns.TypeA {
+					ans.names = append(ans.names, strings.ToLower(rr.Target))
+				}
+			case *dns.A:
+				ans.addrs = append(ans.addrs, rr.A)
+			case *dns.AAAA:
+				ans.addrs = append(ans.addrs, rr.AAAA)
+			}
+		}
+	}
+	return ans, nil
+}
+
+// targetExpectation is the RESOLVES_TO() of a record.
+type targetExpectation struct {
+	names  []glob.Glob
+	ranges []*net.IPNet
+}
+
+// parseResolvesTo parses the patterns of RESOLVES_TO(): address ranges
+// (CIDR) and names (globs).
+func parseResolvesTo(patterns []string) (*targetExpectation, error) {
+	e := &targetExpectation{}
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if _, ipnet, err := net.ParseCIDR(p); err == nil {
+			e.ranges = append(e.ranges, ipnet)
+			continue
+		}
+		if net.ParseIP(p) != nil {
+			return nil, fmt.Errorf("RESOLVES_TO(%q): an address must be a range, such as %q", p, p+"/32")
+		}
+		g, err := glob.Compile(dns.Fqdn(strings.ToLower(p)))
+		if err != nil {
+			return nil, fmt.Errorf("RESOLVES_TO(%q): %w", p, err)
+		}
+		e.names = append(e.names, g)
+	}
+	return e, nil
+}
+
+// matches returns true if one of the names of ans matches one of the
+// names of e, or if all the addresses of ans are in the ranges of e.
+func (e *targetExpectation) matches(ans targetAnswer) bool {
+	for _, name := range ans.names {
+		for _, g := range e.names {
+			if g.Match(name) {
+				return true
+			}
+		}
+	}
+	if len(e.ranges) == 0 || len(ans.addrs) == 0 {
+		return false
+	}
+	for _, ip := range ans.addrs {
+		found := false
+		for _, r := range e.ranges {
+			if r.Contains(ip) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// reportTargets resolves the targets of the CNAME and ALIAS records that
+// are outside of the domains of cfg, and writes what each resolves to. It
+// returns the number of targets that don't exist, have no address or
+// don't match the RESOLVES_TO() of their record: a CNAME to a resource
+// that was deprovisioned lets whoever claims the resource take over the
+// name. Each target is looked up once. If includeComputed is true, the
+// addresses of the target of an ALIAS record are added to its
+// computed_alias metadata (print-ir --include-computed).
+func reportTargets(w io.Writer, cfg *models.DNSConfig, resolver string, includeComputed bool) int {
+	var zones []string
+	for _, dc := range cfg.Domains {
+		zones = append(zones, dc.Name)
+	}
+	inConfig := func(name string) bool {
+		name = strings.ToLower(strings.TrimSuffix(name, "."))
+		for _, z := range zones {
+			if name == z || strings.HasSuffix(name, "."+z) {
+				return true
+			}
+		}
+		return false
+	}
+
+	answers := map[string]targetAnswer{}
+	lookupErrs := map[string]error{}
+	problems, checked := 0, 0
+	for _, dc := range cfg.Domains {
+		for _, rec := range dc.Records {
+			if rec.Type != "CNAME" && rec.Type != "ALIAS" {
+				continue
+			}
+			target := strings.ToLower(rec.GetTargetField())
+			if inConfig(target) {
+				continue
+			}
+			checked++
+			desc := fmt.Sprintf("%s %s %s", rec.GetLabelFQDN(), rec.Type, rec.GetTargetField())
+			problem := func(format string, args ...any) {
+				problems++
+				fmt.Fprintf(w, "%s: %s\n", desc, fmt.Sprintf(format, args...))
+			}
+
+			var expect *targetExpectation
+			if patterns := rec.ResolvesTo(); patterns != nil {
+				var err error
+				if expect, err = parseResolvesTo(patterns); err != nil {
+					problem("ERROR: %s", err)
+					continue
+				}
+			}
+			ans, ok := answers[target]
+			err := lookupErrs[target]
+			if !ok && err == nil {
+				if ans, err = lookupTarget(resolver, target); err != nil {
+					lookupErrs[target] = err
+				} else {
+					answers[target] = ans
+				}
+			}
+			if includeComputed && rec.Type == "ALIAS" && err == nil && len(ans.addrs) != 0 {
+				setComputed(rec, "computed_alias", "resolves to "+describeAnswer(ans))
+			}
+			switch {
+			case err != nil:
+				problem("ERROR: %s", err)
+			case ans.nxdomain:
+				problem("NXDOMAIN: the target doesn't exist; if it was a resource that was deleted, whoever creates it again takes over %s", rec.GetLabelFQDN())
+			case len(ans.addrs) == 0:
+				problem("NO ADDRESS: the target has no A or AAAA records")
+			case expect != nil && !expect.matches(ans):
+				problem("UNEXPECTED: resolves to %s, which RESOLVES_TO(%s) doesn't expect", describeAnswer(ans), strings.Join(rec.ResolvesTo(), ", "))
+			default:
+				fmt.Fprintf(w, "%s: OK (%s)\n", desc, describeAnswer(ans))
+			}
+		}
+	}
+	fmt.Fprintf(w, "%d of %d CNAME and ALIAS targets outside of dnsconfig.js resolve as expected.\n", checked-problems, checked)
+	return problems
+}
+
+// describeAnswer returns the CNAME chain and the addresses of ans.
+func describeAnswer(ans targetAnswer) string {
+	var parts []string
+	if len(ans.names) > 1 {
+		parts = append(parts, "via "+strings.Join(ans.names[1:], " "))
+	}
+	var addrs []string
+	for _, ip := range ans.addrs {
+		addrs = append(addrs, ip.String())
+	}
+	parts = append(parts, strings.Join(addrs, " "))
+	return strings.Join(parts, ": ")
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_reportTargets(t *testing.T) {
+	answers := map[string]targetAnswer{
+		"app.cdn.example.net.": {names: []string{"app.cdn.example.net.", "edge.cdn.example.net."}, addrs: []net.IP{net.ParseIP("192.0.2.10")}},
+		"gone.cloud.example.":  {nxdomain: true},
+		"empty.example.net.":   {names: []string{"empty.example.net."}},
+		"lb.example.net.":      {names: []string{"lb.example.net."}, addrs: []net.IP{net.ParseIP("198.51.100.1")}},
+	}
+	lookups := 0
+	defer func(f func(string, string) (targetAnswer, error)) { lookupTarget = f }(lookupTarget)
+	lookupTarget = func(resolver, name string) (targetAnswer, error) {
+		lookups++
+		if ans, ok := answers[name]; ok {
+			return ans, nil
+		}
+		return targetAnswer{}, fmt.Errorf("timeout")
+	}
+
+	rec := func(typ, label, target, resolvesTo string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: typ, Metadata: map[string]string{}}
+		if resolvesTo != "" {
+			rc.Metadata["resolves_to"] = resolvesTo
+		}
+		rc.SetLabel(label, "example.com")
+		rc.SetTarget(target)
+		return rc
+	}
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{{Name: "example.com", Records: models.Records{
+		rec("CNAME", "www", "app.cdn.example.net.", "192.0.2.0/24"),
+		rec("CNAME", "www2", "app.cdn.example.net.", "*.cdn.example.net."),
+		rec("ALIAS", "@", "lb.example.net.", "192.0.2.0/24,2001:db8::/32"),
+		rec("CNAME", "shop", "gone.cloud.example.", ""),
+		rec("CNAME", "empty", "empty.example.net.", ""),
+		rec("CNAME", "slow", "slow.example.net.", ""),
+		rec("CNAME", "bad", "lb.example.net.", "192.0.2.1"),
+		rec("CNAME", "inzone", "www.example.com.", ""),
+		rec("A", "a", "192.0.2.1", ""),
+	}}}}
+
+	var buf bytes.Buffer
+	if problems := reportTargets(&buf, cfg, "resolver", true); problems != 5 {
+		t.Errorf("got %d problems, want 5", problems)
+	}
+	if lookups != 5 {
+		t.Errorf("got %d lookups, want 5 (one per target)", lookups)
+	}
+	for _, want := range []string{
+		"www.example.com CNAME app.cdn.example.net.: OK (via edge.cdn.example.net.: 192.0.2.10)\n",
+		"www2.example.com CNAME app.cdn.example.net.: OK",
+		"example.com ALIAS lb.example.net.: UNEXPECTED: resolves to 198.51.100.1, which RESOLVES_TO(192.0.2.0/24, 2001:db8::/32) doesn't expect\n",
+		"shop.example.com CNAME gone.cloud.example.: NXDOMAIN",
+		"empty.example.com CNAME empty.example.net.: NO ADDRESS",
+		"slow.example.com CNAME slow.example.net.: ERROR: timeout\n",
+		`bad.example.com CNAME lb.example.net.: ERROR: RESOLVES_TO("192.0.2.1"): an address must be a range, such as "192.0.2.1/32"`,
+		"2 of 7 CNAME and ALIAS targets outside of dnsconfig.js resolve as expected.\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), "inzone") {
+		t.Errorf("the target in dnsconfig.js should not be checked:\n%s", buf.String())
+	}
+	if got := cfg.Domains[0].Records[2].Metadata["computed_alias"]; got != "resolves to 198.51.100.1" {
+		t.Errorf("computed_alias = %q", got)
+	}
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/urfave/cli/v2"
+)
+
+// CheckpointArgs encapsulates the flags that let an interrupted push be
+// resumed.
+type CheckpointArgs struct {
+	File   string
+	Resume bool
+}
+
+func (args *CheckpointArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "checkpoint",
+			Destination: &args.File,
+			Usage:       `Record the domains that were pushed successfully in this file`,
+		},
+		&cli.BoolFlag{
+			Name:        "resume",
+			Destination: &args.Resume,
+			Usage:       `Skip the domains that the --checkpoint file records as pushed`,
+		},
+	}
+}
+
+// checkpoint records the domains that a push has completed. It is written
+// after each domain, and removed once a push completes without errors.
+type checkpoint struct {
+	ConfigHash string   `json:"config_hash"`
+	Done       []string `json:"done"`
+
+	file string
+	done map[string]bool
+	mu   sync.Mutex
+}
+
+// openCheckpoint returns the checkpoint for args, or nil if no checkpoint
+// file was requested. With --resume, the domains already recorded in the
+// file are kept, unless the file was written for a different configuration.
+func openCheckpoint(args CheckpointArgs, cfg *models.DNSConfig) (*checkpoint, error) {
+	if args.File == "" {
+		if args.Resume {
+			return nil, fmt.Errorf("--resume requires --checkpoint")
+		}
+		return nil, nil
+	}
+
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(b)
+	c := &checkpoint{ConfigHash: hex.EncodeToString(sum[:]), file: args.File, done: map[string]bool{}}
+	if !args.Resume {
+		return c, c.save()
+	}
+
+	b, err = os.ReadFile(args.File)
+	if errors.Is(err, fs.ErrNotExist) {
+		printer.Printf("Note: checkpoint file %s not found; pushing all domains\n", args.File)
+		return c, c.save()
+	} else if err != nil {
+		return nil, fmt.Errorf("reading checkpoint: %w", err)
+	}
+	var old checkpoint
+	if err := json.Unmarshal(b, &old); err != nil {
+		return nil, fmt.Errorf("reading checkpoint %s: %w", args.File, err)
+	}
+	if old.ConfigHash != c.ConfigHash {
+		printer.Printf("Note: the configuration changed since checkpoint %s was written; pushing all domains\n", args.File)
+		return c, c.save()
+	}
+	for _, name := range old.Done {
+		c.Done = append(c.Done, name)
+		c.done[name] = true
+	}
+	return c, nil
+}
+
+// isDone reports whether the domain was pushed by a previous run.
+func (c *checkpoint) isDone(uniquename string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done[uniquename]
+}
+
+// markDone records that the domain was pushed.
+func (c *checkpoint) markDone(uniquename string) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.done[uniquename] {
+		c.done[uniquename] = true
+		c.Done = append(c.Done, uniquename)
+	}
+	return c.save()
+}
+
+// save writes the checkpoint. The file is replaced atomically so that an
+// interruption can't leave it half-written.
+func (c *checkpoint) save() error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := c.file + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, c.file); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	return nil
+}
+
+// remove deletes the checkpoint file. It is called when the push completed
+// without errors, so that there is nothing left to resume.
+func (c *checkpoint) remove() error {
+	if c == nil {
+		return nil
+	}
+	if err := os.Remove(c.file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
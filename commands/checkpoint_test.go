@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_checkpoint(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "checkpoint.json")
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{{Name: "a.com"}, {Name: "b.com"}}}
+
+	c, err := openCheckpoint(CheckpointArgs{File: file}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.markDone("a.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Resuming with the same configuration skips a.com.
+	c, err = openCheckpoint(CheckpointArgs{File: file, Resume: true}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.isDone("a.com") || c.isDone("b.com") {
+		t.Errorf("after resume: a.com done=%v, b.com done=%v", c.isDone("a.com"), c.isDone("b.com"))
+	}
+
+	// A changed configuration invalidates the checkpoint.
+	cfg.Domains = append(cfg.Domains, &models.DomainConfig{Name: "c.com"})
+	c, err = openCheckpoint(CheckpointArgs{File: file, Resume: true}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.isDone("a.com") {
+		t.Error("a.com is done after the configuration changed")
+	}
+
+	if err := c.remove(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("checkpoint not removed: %v", err)
+	}
+
+	if _, err := openCheckpoint(CheckpointArgs{Resume: true}, cfg); err == nil {
+		t.Error("--resume without --checkpoint: expected an error")
+	}
+	var none *checkpoint
+	if none.isDone("a.com") || none.markDone("a.com") != nil || none.remove() != nil {
+		t.Error("a nil checkpoint should do nothing")
+	}
+}
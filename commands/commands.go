@@ -2,15 +2,20 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/StackExchange/dnscontrol/v4/pkg/js"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/ratelimit"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/urfave/cli/v2"
 
 	"github.com/fatih/color"
@@ -40,6 +45,9 @@ var _ = cmd(catDebug, &cli.Command{
 	},
 })
 
+// noRateLimitPacing is set by --no-rate-limit-pacing.
+var noRateLimitPacing bool
+
 // Run will execute the CLI
 func Run(v string) int {
 	version = v
@@ -80,6 +88,24 @@ func Run(v string) int {
 			Destination: &color.NoColor,
 			Value:       false,
 		},
+		&cli.BoolFlag{
+			Name:        "github-actions",
+			Usage:       "Also output the validation warnings and errors of check, preview and push as GitHub Actions annotations (on stderr)",
+			EnvVars:     []string{"GITHUB_ACTIONS"},
+			Destination: &githubActions,
+		},
+		&cli.BoolFlag{
+			Name:        "no-rate-limit-pacing",
+			Usage:       "Do not slow down HTTP requests based on the provider's rate-limit headers",
+			Destination: &noRateLimitPacing,
+		},
+	}
+	app.Before = func(ctx *cli.Context) error {
+		if !noRateLimitPacing {
+			ratelimit.Install()
+		}
+		runctx.Install()
+		return nil
 	}
 	sort.Sort(cli.CommandsByName(commands))
 	app.Commands = commands
@@ -103,6 +129,12 @@ func Run(v string) int {
 		dnscontrolPrintCommandSuggestions(app.Commands, cCtx.App.Writer)
 	}
 	if err := app.Run(os.Args); err != nil {
+		// The errors of the flag Actions (such as --list-checks) are
+		// returned rather than handled by urfave/cli.
+		var exitErr cli.ExitCoder
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
 		return 1
 	}
 	return 0
@@ -114,7 +146,18 @@ func Run(v string) int {
 // Could come from parsing js, or from stored json
 type GetDNSConfigArgs struct {
 	ExecuteDSLArgs
-	JSONFile string
+	JSONFile        string
+	DisableChecks   cli.StringSlice
+	SunsetDays      int
+	SunsetErrors    bool
+	RelativeTargets string
+	LowTTL          uint
+	LowTTLTypes     string
+	MergeSPF        bool
+	DropUnsupported bool
+	TypePolicy      string
+	RecordPolicy    string
+	Strict          bool
 }
 
 func (args *GetDNSConfigArgs) flags() []cli.Flag {
@@ -130,9 +173,126 @@ func (args *GetDNSConfigArgs) flags() []cli.Flag {
 			Hidden:      true,
 			Usage:       "same as -ir. only here for backwards compatibility, hence hidden",
 		},
+		&cli.StringSliceFlag{
+			Destination: &args.DisableChecks,
+			Name:        "disable-check",
+			Usage:       "Disable this normalization check (repeatable; see --list-checks)",
+		},
+		&cli.IntFlag{
+			Destination: &args.SunsetDays,
+			Name:        "sunset-warn-days",
+			Value:       30,
+			Usage:       "Warn about records whose SUNSET() date is this many days away or less",
+		},
+		&cli.BoolFlag{
+			Destination: &args.SunsetErrors,
+			Name:        "sunset-errors",
+			Usage:       "Records past their SUNSET() date are errors, not warnings",
+		},
+		&cli.StringFlag{
+			Destination: &args.RelativeTargets,
+			Name:        "relative-targets",
+			Value:       "allow",
+			Usage:       "How targets without a trailing dot (relative to the domain) are handled: allow, warn or error",
+		},
+		&cli.UintFlag{
+			Destination: &args.LowTTL,
+			Name:        "low-ttl",
+			Usage:       "Warn about records of the --low-ttl-types whose TTL is below this, such as 300 (0 turns the check off)",
+		},
+		&cli.StringFlag{
+			Destination: &args.LowTTLTypes,
+			Name:        "low-ttl-types",
+			Value:       "NS,SOA,MX,@A,@AAAA",
+			Usage:       "The record types that --low-ttl checks; @TYPE checks only the records at the apex",
+		},
+		&cli.BoolFlag{
+			Destination: &args.MergeSPF,
+			Name:        "merge-spf",
+			Usage:       "Merge the SPF records at the same name into one, instead of reporting an error",
+		},
+		&cli.BoolFlag{
+			Destination: &args.DropUnsupported,
+			Name:        "drop-unsupported",
+			Usage:       "Leave the records of types a DNS provider doesn't support out of that provider (warnings), instead of reporting an error",
+		},
+		&cli.StringFlag{
+			Destination: &args.TypePolicy,
+			Name:        "type-policy",
+			Usage:       "Read the record types each domain may have from this JSON file, and report the others as errors",
+		},
+		&cli.StringFlag{
+			Destination: &args.RecordPolicy,
+			Name:        "policy",
+			Usage:       "Read the records each domain must have from this YAML (or JSON) file, and report the missing ones as errors",
+		},
+		&cli.BoolFlag{
+			Destination: &args.Strict,
+			Name:        "strict",
+			Usage:       "Addresses that aren't public (private, loopback, documentation, etc.) in public zones are errors, not warnings",
+		},
+		&cli.BoolFlag{
+			Name:  "list-checks",
+			Usage: "List the normalization checks that --disable-check accepts, then exit",
+			Action: func(ctx *cli.Context, v bool) error {
+				if !v {
+					return nil
+				}
+				for _, c := range normalize.Checks {
+					fmt.Printf("%-14s %s\n", c.Name, c.Description)
+				}
+				return cli.Exit("", 0)
+			},
+		},
 	)
 }
 
+// normalizeOptions returns the options of normalize.ValidateAndNormalizeConfig
+// that the flags set. Each disabled check is mentioned, so that it isn't
+// silently hidden.
+func (args GetDNSConfigArgs) normalizeOptions() (normalize.Options, error) {
+	opts := normalize.Options{
+		DisabledChecks:  args.DisableChecks.Value(),
+		MergeSPF:        args.MergeSPF,
+		DropUnsupported: args.DropUnsupported,
+		Strict:          args.Strict,
+		Sunset: normalize.SunsetPolicy{
+			WarnBefore: time.Duration(args.SunsetDays) * 24 * time.Hour,
+			Error:      args.SunsetErrors,
+		},
+	}
+	if err := normalize.ValidateChecks(opts.DisabledChecks); err != nil {
+		return opts, fmt.Errorf("--disable-check: %w", err)
+	}
+	var err error
+	if opts.RelativeTargets, err = normalize.ParseRelativeTargets(args.RelativeTargets); err != nil {
+		return opts, fmt.Errorf("--relative-targets: %w", err)
+	}
+	if opts.LowTTL, err = normalize.ParseLowTTLPolicy(uint32(args.LowTTL), args.LowTTLTypes); err != nil {
+		return opts, fmt.Errorf("--low-ttl-types: %w", err)
+	}
+	if opts.TypePolicy, err = normalize.ReadTypePolicy(args.TypePolicy); err != nil {
+		return opts, fmt.Errorf("--type-policy: %w", err)
+	}
+	if opts.RecordPolicy, err = normalize.ReadRecordPolicy(args.RecordPolicy); err != nil {
+		return opts, fmt.Errorf("--policy: %w", err)
+	}
+	for _, name := range opts.DisabledChecks {
+		printer.Printf("Normalization check %q is disabled (--disable-check)\n", name)
+	}
+	return opts, nil
+}
+
+// qualifyTargets adds the trailing dot back to the hostname targets of the
+// normalized cfg (see models.QualifyTargets). Every command but print-ir
+// and diff-revisions, which show the IR itself, calls it after
+// normalize.ValidateAndNormalizeConfig.
+func qualifyTargets(cfg *models.DNSConfig) {
+	for _, dc := range cfg.Domains {
+		models.QualifyTargets(dc.Records)
+	}
+}
+
 // GetDNSConfig reads the json-formatted IR file. Or executes javascript. All depending on flags provided.
 func GetDNSConfig(args GetDNSConfigArgs) (*models.DNSConfig, error) {
 	var err error
@@ -141,12 +301,15 @@ func GetDNSConfig(args GetDNSConfigArgs) (*models.DNSConfig, error) {
 	if args.JSONFile == "" {
 		// No IR file specified. Generate the IR by running dnsconfig.json
 		// as normal.
+		annotationFile = args.JSFile
 		cfg, err = ExecuteDSL(args.ExecuteDSLArgs)
 		if err != nil {
+			annotate("error", err)
 			return nil, err
 		}
 	} else {
 		// Read an IR file.
+		annotationFile = args.JSONFile
 		f, err := os.Open(args.JSONFile)
 		if err != nil {
 			return nil, err
@@ -156,6 +319,16 @@ func GetDNSConfig(args GetDNSConfigArgs) (*models.DNSConfig, error) {
 		if err = dec.Decode(cfg); err != nil {
 			return nil, err
 		}
+		// The output of print-ir --fqdn.
+		if err = relativeLabels(cfg); err != nil {
+			return nil, fmt.Errorf("%s: %w", args.JSONFile, err)
+		}
+		// The output of print-ir without --raw: a target such as
+		// "foo.example.com" is a FQDN, not relative to the domain.
+		if cfg.Normalized {
+			qualifyTargets(cfg)
+			cfg.Normalized = false
+		}
 	}
 
 	return preloadProviders(cfg)
@@ -1,6 +1,11 @@
 package commands
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
 
 func Test_domainInList(t *testing.T) {
 	type args struct {
@@ -61,3 +66,20 @@ func Test_domainInList(t *testing.T) {
 		})
 	}
 }
+
+func Test_listChecksExits(t *testing.T) {
+	var args GetDNSConfigArgs
+	ran := false
+	app := &cli.App{Flags: args.flags(), Action: func(*cli.Context) error {
+		ran = true
+		return nil
+	}}
+	err := app.Run([]string{"dnscontrol", "--list-checks"})
+	var exitErr cli.ExitCoder
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 0 {
+		t.Errorf("got %v, want an exit error with code 0", err)
+	}
+	if ran {
+		t.Errorf("--list-checks ran the command")
+	}
+}
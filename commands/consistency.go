@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// checkConsistency implements get-zones --check-consistency. The zones
+// are downloaded from every provider listed (comma-separated) in
+// args.CredName and the differences are written to w.
+func checkConsistency(args GetZoneArgs, providerConfigs map[string]map[string]string, w io.Writer) error {
+	credNames := strings.Split(args.CredName, ",")
+	if len(credNames) < 2 {
+		return fmt.Errorf("--check-consistency requires at least 2 comma-separated credkeys, got %q", args.CredName)
+	}
+
+	var dsps []providers.DNSServiceProvider
+	for _, name := range credNames {
+		p, err := providers.CreateDNSProvider(args.ProviderName, providerConfigs[name], nil)
+		if err != nil {
+			return fmt.Errorf("failed GetZone CDP(%q): %w", name, err)
+		}
+		dsps = append(dsps, p)
+	}
+
+	zones := args.ZoneNames
+	if len(zones) == 1 && zones[0] == "all" {
+		lister, ok := dsps[0].(providers.ZoneLister)
+		if !ok {
+			return fmt.Errorf("provider %s cannot list zones to use the 'all' feature", credNames[0])
+		}
+		var err error
+		zones, err = lister.ListZones()
+		if err != nil {
+			return fmt.Errorf("failed GetZone LZ: %w", err)
+		}
+	}
+
+	total := 0
+	for _, zone := range zones {
+		recs := make([]models.Records, len(dsps))
+		for i, p := range dsps {
+			r, err := p.GetZoneRecords(zone, nil)
+			if err != nil {
+				return fmt.Errorf("failed GetZone gzr(%q, %q): %w", credNames[i], zone, err)
+			}
+			models.Downcase(r)
+			models.CanonicalizeTargets(r, zone)
+			recs[i] = r
+		}
+		diffs := compareProviderZones(zone, credNames, recs)
+		for _, d := range diffs {
+			fmt.Fprintln(w, d)
+		}
+		total += len(diffs)
+	}
+
+	if total != 0 {
+		return fmt.Errorf("found %d inconsistencies between providers %s", total, strings.Join(credNames, ", "))
+	}
+	fmt.Fprintf(w, "No inconsistencies found between providers %s.\n", strings.Join(credNames, ", "))
+	return nil
+}
+
+// compareProviderZones compares the records of zone as returned by
+// each provider. names[i] is the name of the provider that returned
+// recs[i]. Each record that is missing from some of the providers, or
+// has a different TTL, results in one message.  The SOA and apex NS
+// records are skipped since they are expected to differ.
+func compareProviderZones(zone string, names []string, recs []models.Records) []string {
+	type key struct{ fqdn, rtype, target string }
+	ttls := map[key][]string{} // key -> per-provider TTL ("" if absent)
+	var keys []key
+
+	for i, rs := range recs {
+		for _, rc := range rs {
+			if rc.Type == "SOA" || (rc.Type == "NS" && rc.GetLabel() == "@") {
+				continue
+			}
+			k := key{rc.GetLabelFQDN(), rc.Type, rc.ToComparableNoTTL()}
+			if _, ok := ttls[k]; !ok {
+				ttls[k] = make([]string, len(names))
+				keys = append(keys, k)
+			}
+			ttls[k][i] = fmt.Sprintf("%d", rc.TTL)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.fqdn != b.fqdn {
+			return a.fqdn < b.fqdn
+		}
+		if a.rtype != b.rtype {
+			return a.rtype < b.rtype
+		}
+		return a.target < b.target
+	})
+
+	var msgs []string
+	for _, k := range keys {
+		var missing, ttlList []string
+		ttlDiffers := false
+		first := ""
+		for i, ttl := range ttls[k] {
+			if ttl == "" {
+				missing = append(missing, names[i])
+				continue
+			}
+			if first == "" {
+				first = ttl
+			} else if first != ttl {
+				ttlDiffers = true
+			}
+			ttlList = append(ttlList, names[i]+"="+ttl)
+		}
+		rec := fmt.Sprintf("%s: %s %s %s", zone, k.fqdn, k.rtype, k.target)
+		if len(missing) != 0 {
+			msgs = append(msgs, fmt.Sprintf("%s: missing from %s", rec, strings.Join(missing, ",")))
+		}
+		if ttlDiffers {
+			msgs = append(msgs, fmt.Sprintf("%s: TTL differs (%s)", rec, strings.Join(ttlList, " ")))
+		}
+	}
+	return msgs
+}
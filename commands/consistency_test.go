@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_compareProviderZones(t *testing.T) {
+	rec := func(typ, label, target string, ttl uint32) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: typ, TTL: ttl}
+		rc.SetLabel(label, "example.com")
+		rc.SetTarget(target)
+		return rc
+	}
+
+	primary := models.Records{
+		rec("NS", "@", "ns1.primary.net.", 300),
+		rec("A", "www", "1.2.3.4", 300),
+		rec("A", "mail", "1.2.3.5", 300),
+		rec("A", "old", "1.2.3.6", 300),
+	}
+	secondary := models.Records{
+		rec("NS", "@", "ns1.secondary.net.", 300),
+		rec("A", "www", "1.2.3.4", 300),
+		rec("A", "mail", "1.2.3.5", 600),
+		rec("A", "new", "1.2.3.7", 300),
+	}
+
+	got := compareProviderZones("example.com", []string{"p", "s"}, []models.Records{primary, secondary})
+	want := []string{
+		"example.com: mail.example.com A 1.2.3.5: TTL differs (p=300 s=600)",
+		"example.com: new.example.com A 1.2.3.7: missing from p",
+		"example.com: old.example.com A 1.2.3.6: missing from s",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("compareProviderZones():\n got %q\nwant %q", got, want)
+	}
+
+	if got := compareProviderZones("example.com", []string{"p", "p2"}, []models.Records{primary, primary}); len(got) != 0 {
+		t.Errorf("identical zones reported differences: %q", got)
+	}
+}
@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/urfave/cli/v2"
+)
+
+// CostArgs encapsulates the flags that estimate what the changes cost.
+type CostArgs struct {
+	CostFile string
+}
+
+func (args *CostArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "cost",
+			Destination: &args.CostFile,
+			Usage:       `Estimate the change of the monthly bill of the providers, from the prices per zone and per record in this JSON file (preview only)`,
+		},
+	}
+}
+
+// costModel is what a provider bills each month.
+type costModel struct {
+	Zone     float64 `json:"zone"`     // per zone
+	Record   float64 `json:"record"`   // per record
+	Currency string  `json:"currency"` // Ex: "USD"; only printed
+}
+
+// loadCostModels reads the cost models of the --cost file, which is keyed
+// by creds.json entry:
+//
+//	{ "r53": { "zone": 0.50, "record": 0, "currency": "USD" } }
+func loadCostModels(file string, providerConfigs map[string]map[string]string) (map[string]costModel, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("--cost: %w", err)
+	}
+	var prices map[string]costModel
+	if err := json.Unmarshal(b, &prices); err != nil {
+		return nil, fmt.Errorf("--cost: %s: %w", file, err)
+	}
+	for name := range prices {
+		if _, ok := providerConfigs[name]; !ok {
+			return nil, fmt.Errorf("--cost: %s: %q is not an entry of creds.json", file, name)
+		}
+	}
+	return prices, nil
+}
+
+// costEstimate counts the zones and records that a preview would add or
+// remove, by provider. It is the runReporter of --cost.
+type costEstimate struct {
+	nopReporter
+	prices map[string]costModel
+
+	mu      sync.Mutex
+	zones   map[string]int // provider -> zones created
+	created map[string]int // provider -> records created
+	deleted map[string]int // provider -> records deleted
+}
+
+func newCostEstimate(prices map[string]costModel) *costEstimate {
+	return &costEstimate{prices: prices, zones: map[string]int{}, created: map[string]int{}, deleted: map[string]int{}}
+}
+
+// addMissingZone records that the zone would be created at the provider,
+// with the records of dc.
+func (e *costEstimate) addMissingZone(dc *models.DomainConfig, provider string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.zones[provider]++
+	e.created[provider] += len(dc.Records)
+}
+
+// addChanges counts the records that the corrections of a DNS provider
+// create and delete.
+func (e *costEstimate) addChanges(z zoneChanges) {
+	if z.providerType == "" {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, c := range z.corrections {
+		for _, line := range strings.Split(c.Msg, "\n") {
+			switch kind, _, ok := lineImpact(z.dc.Name, line); {
+			case !ok:
+			case kind == impactCreate:
+				e.created[z.provider]++
+			case kind == impactDelete:
+				e.deleted[z.provider]++
+			}
+		}
+	}
+}
+
+// finish prints the estimate of each provider that has changes, and the
+// total (by currency).
+func (e *costEstimate) finish(out printer.CLI) error {
+	changed := map[string]bool{}
+	for _, counts := range []map[string]int{e.zones, e.created, e.deleted} {
+		for name := range counts {
+			changed[name] = true
+		}
+	}
+	var names, unpriced []string
+	for name := range changed {
+		if _, ok := e.prices[name]; ok {
+			names = append(names, name)
+		} else {
+			unpriced = append(unpriced, name)
+		}
+	}
+	sort.Strings(names)
+	sort.Strings(unpriced)
+
+	out.Printf("Estimated change of the monthly cost (--cost):\n")
+	totals := map[string]float64{}
+	for _, name := range names {
+		m := e.prices[name]
+		cost := float64(e.zones[name])*m.Zone + float64(e.created[name]-e.deleted[name])*m.Record
+		totals[m.Currency] += cost
+		out.Printf("  %s: +%d %s, +%d/-%d records: %s\n", name,
+			e.zones[name], plural(e.zones[name], "zone", "zones"), e.created[name], e.deleted[name],
+			formatCost(cost, m.Currency))
+	}
+	var currencies []string
+	for c := range totals {
+		currencies = append(currencies, c)
+	}
+	sort.Strings(currencies)
+	var total []string
+	for _, c := range currencies {
+		total = append(total, formatCost(totals[c], c))
+	}
+	if len(total) == 0 {
+		total = append(total, formatCost(0, ""))
+	}
+	out.Printf("  Total: %s\n", strings.Join(total, ", "))
+	if len(unpriced) > 0 {
+		out.Printf("  (%s %s changes but no price in the --cost file)\n", strings.Join(unpriced, ", "), plural(len(unpriced), "has", "have"))
+	}
+	return nil
+}
+
+func formatCost(cost float64, currency string) string {
+	return strings.TrimSpace(fmt.Sprintf("%+.2f %s", cost, currency))
+}
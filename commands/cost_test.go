@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+func Test_costEstimate(t *testing.T) {
+	e := newCostEstimate(map[string]costModel{
+		"r53":   {Zone: 0.50, Currency: "USD"},
+		"other": {Record: 0.01, Currency: "EUR"},
+	})
+	e.addMissingZone(&models.DomainConfig{Name: "new.example", Records: make(models.Records, 3)}, "r53")
+	e.addMissingZone(&models.DomainConfig{Name: "new2.example"}, "r53")
+	dc := testDomain("example.com")
+	e.addChanges(zoneChanges{dc: dc, provider: "other", providerType: "OTHER", corrections: []*models.Correction{
+		{Msg: "+ CREATE a.example.com A 192.0.2.1 ttl=300\n+ CREATE b.example.com A 192.0.2.2 ttl=300\n+ CREATE c.example.com A 192.0.2.3 ttl=300"},
+		{Msg: "\x1b[31m- DELETE d.example.com A 192.0.2.4 ttl=300\x1b[0m"},
+		{Msg: "± MODIFY e.example.com A (192.0.2.5 ttl=300) -> (192.0.2.6 ttl=300)"},
+	}})
+	e.addChanges(zoneChanges{dc: dc, provider: "bind", providerType: "BIND", corrections: []*models.Correction{{Msg: "+ CREATE f.example.com A 192.0.2.7 ttl=300"}}})
+	// The registrar's corrections don't change the records.
+	e.addChanges(zoneChanges{dc: dc, provider: "r53", corrections: []*models.Correction{{Msg: "+ CREATE g.example.com A 192.0.2.8 ttl=300"}}})
+
+	var buf strings.Builder
+	if err := e.finish(printer.ConsolePrinter{Writer: &buf}); err != nil {
+		t.Fatal(err)
+	}
+	want := `Estimated change of the monthly cost (--cost):
+  other: +0 zones, +3/-1 records: +0.02 EUR
+  r53: +2 zones, +3/-0 records: +1.00 USD
+  Total: +0.02 EUR, +1.00 USD
+  (bind has changes but no price in the --cost file)
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func Test_loadCostModels(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cost.json")
+	os.WriteFile(file, []byte(`{"r53": {"zone": 0.5, "currency": "USD"}}`), 0o644)
+	prices, err := loadCostModels(file, map[string]map[string]string{"r53": {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prices["r53"].Zone != 0.5 || prices["r53"].Currency != "USD" {
+		t.Errorf("got %+v", prices)
+	}
+	if _, err := loadCostModels(file, map[string]map[string]string{"gcloud": {}}); err == nil || !strings.Contains(err.Error(), `"r53" is not an entry of creds.json`) {
+		t.Errorf("got %v, want an error about the unknown entry", err)
+	}
+}
@@ -56,7 +56,10 @@ func CreateDomains(args CreateDomainsArgs) error {
 		for _, provider := range domain.DNSProviderInstances {
 			if creator, ok := provider.Driver.(providers.ZoneCreator); ok {
 				fmt.Println("  -", provider.Name)
-				err := creator.EnsureZoneExists(domain.Name)
+				err := providers.CheckWrite("create zone " + domain.Name)
+				if err == nil {
+					err = creator.EnsureZoneExists(domain.Name)
+				}
 				if err != nil {
 					fmt.Printf("Error creating domain: %s\n", err)
 				}
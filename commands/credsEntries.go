@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// missingCredsEntries cross-references the providers used by the domains
+// of cfg with the entries of the creds file credsFile, and returns an
+// error for each provider that has no entry, naming the domains that use
+// it. If untypedOnly is true, providers whose type is given in
+// dnsconfig.js are skipped: they still work without an entry.
+func missingCredsEntries(cfg *models.DNSConfig, providerConfigs map[string]map[string]string, credsFile string, untypedOnly bool) []error {
+	type usage struct {
+		kind, name string
+	}
+	var order []usage
+	domains := map[usage][]string{}
+	add := func(kind string, p models.ProviderBase, domain string) {
+		if _, ok := providerConfigs[p.Name]; ok {
+			return
+		}
+		if untypedOnly && p.ProviderType != "" && p.ProviderType != "-" {
+			return
+		}
+		u := usage{kind, p.Name}
+		if _, ok := domains[u]; !ok {
+			order = append(order, u)
+		}
+		domains[u] = append(domains[u], domain)
+	}
+	for _, d := range cfg.Domains {
+		if d.RegistrarInstance != nil {
+			add("registrar", d.RegistrarInstance.ProviderBase, d.Name)
+		}
+		for _, p := range d.DNSProviderInstances {
+			add("DNS provider", p.ProviderBase, d.Name)
+		}
+	}
+
+	var errs []error
+	for _, u := range order {
+		errs = append(errs, fmt.Errorf("%s has no entry for %s %q, which is used by %s (See %s#missing)",
+			credsFile, u.kind, u.name, strings.Join(domains[u], ", "), url))
+	}
+	return errs
+}
@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/fatih/color"
+)
+
+// diffChange is a change in the message of a correction, such as
+// "+ CREATE www.example.com A 192.0.2.1 ttl=300", split into its fields.
+type diffChange struct {
+	verb, name, rtype, rest string
+}
+
+// parseDiffChange parses a line of the message of a correction. ok is
+// false if the line isn't a change.
+func parseDiffChange(line string) (ch diffChange, ok bool) {
+	fields := strings.SplitN(colorCodes.ReplaceAllString(line, ""), " ", 5)
+	if len(fields) < 5 {
+		return ch, false
+	}
+	switch fields[1] {
+	case "CREATE", "DELETE", "MODIFY", "MODIFY-TTL":
+	default:
+		return ch, false
+	}
+	return diffChange{verb: fields[1], name: fields[2], rtype: fields[3], rest: fields[4]}, true
+}
+
+// line formats the change, colored as diff2 does.
+func (ch diffChange) line() string {
+	switch ch.verb {
+	case "CREATE":
+		return color.GreenString("+ CREATE %s %s %s", ch.name, ch.rtype, ch.rest)
+	case "DELETE":
+		return color.RedString("- DELETE %s %s %s", ch.name, ch.rtype, ch.rest)
+	}
+	return color.YellowString("± %s %s %s %s", ch.verb, ch.name, ch.rtype, ch.rest)
+}
+
+// target returns the target of a created or deleted record, without its TTL.
+func (ch diffChange) target() string {
+	if i := strings.LastIndex(ch.rest, " ttl="); i >= 0 {
+		return ch.rest[:i]
+	}
+	return ch.rest
+}
+
+// diffBatch is the creations or the deletions of the records of a type.
+type diffBatch struct {
+	verb, rtype string
+}
+
+// condenseCorrections returns the corrections of a domain as --diff-context
+// shows them:
+//
+//   - Of the creations, and of the deletions, of each record type, only the
+//     first n are listed. The others are summarized by one line, such as
+//     "+ 197 more A records in 10.0.0.0/24".
+//   - A modification shows only the fields of the target that changed, and
+//     n fields on each side of them; the others are elided.
+//
+// The corrections that are emptied are dropped. For preview only: the
+// corrections returned can't be run.
+func condenseCorrections(corrections []*models.Correction, n int) []*models.Correction {
+	// Count the batches, and collect the targets of those that are summarized.
+	counts := map[diffBatch]int{}
+	for _, c := range corrections {
+		if c.F == nil {
+			continue
+		}
+		for _, line := range strings.Split(c.Msg, "\n") {
+			if ch, ok := parseDiffChange(line); ok && (ch.verb == "CREATE" || ch.verb == "DELETE") {
+				counts[diffBatch{ch.verb, ch.rtype}]++
+			}
+		}
+	}
+	targets := map[diffBatch][]string{} // the targets of the records summarized
+	seen := map[diffBatch]int{}
+	for _, c := range corrections {
+		if c.F == nil {
+			continue
+		}
+		for _, line := range strings.Split(c.Msg, "\n") {
+			if ch, ok := parseDiffChange(line); ok {
+				b := diffBatch{ch.verb, ch.rtype}
+				if counts[b] > n {
+					if seen[b]++; seen[b] > n {
+						targets[b] = append(targets[b], ch.target())
+					}
+				}
+			}
+		}
+	}
+
+	var condensed []*models.Correction
+	seen = map[diffBatch]int{}
+	for _, c := range corrections {
+		if c.F == nil {
+			condensed = append(condensed, c)
+			continue
+		}
+		var lines []string
+		skipping := false // the lines (e.g. of --explain) that follow a summarized change
+		for _, line := range strings.Split(c.Msg, "\n") {
+			ch, ok := parseDiffChange(line)
+			if !ok {
+				if !skipping {
+					lines = append(lines, line)
+				}
+				continue
+			}
+			skipping = false
+			b := diffBatch{ch.verb, ch.rtype}
+			switch {
+			case counts[b] > n:
+				seen[b]++
+				if seen[b] == n+1 {
+					lines = append(lines, batchSummary(b, targets[b]))
+				}
+				if seen[b] > n {
+					skipping = true
+					continue
+				}
+			case ch.verb == "MODIFY":
+				ch.rest = condenseModify(ch.rest, n)
+				line = ch.line()
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		if msg := strings.Join(lines, "\n"); msg != c.Msg {
+			cp := *c
+			cp.Msg = msg
+			c = &cp
+		}
+		condensed = append(condensed, c)
+	}
+	return condensed
+}
+
+// batchSummary is the line that replaces the changes of b after the
+// first ones, whose targets are given.
+func batchSummary(b diffBatch, targets []string) string {
+	s := fmt.Sprintf("%d more %s %s", len(targets), b.rtype, plural(len(targets), "record", "records"))
+	if p, ok := coveringPrefix(targets); ok {
+		s += " in " + p.String()
+	}
+	s += " (--full lists them)"
+	if b.verb == "DELETE" {
+		return color.RedString("- %s", s)
+	}
+	return color.GreenString("+ %s", s)
+}
+
+// coveringPrefix returns the smallest prefix that contains all the
+// addresses. ok is false if they aren't all addresses of the same family.
+func coveringPrefix(addrs []string) (netip.Prefix, bool) {
+	var p netip.Prefix
+	for i, s := range addrs {
+		a, err := netip.ParseAddr(s)
+		if err != nil {
+			return netip.Prefix{}, false
+		}
+		if i == 0 {
+			p = netip.PrefixFrom(a, a.BitLen())
+			continue
+		}
+		if a.BitLen() != p.Addr().BitLen() {
+			return netip.Prefix{}, false
+		}
+		for !p.Contains(a) {
+			// Shorten until it contains a; /0 contains everything.
+			p, _ = p.Addr().Prefix(p.Bits() - 1)
+		}
+	}
+	return p, len(addrs) > 0
+}
+
+// modifyTargets matches the old and new targets of a modification, as
+// formatted by diff2: "(10 mx1.example.com. ttl=300) -> (20 mx1.example.com. ttl=300)".
+var modifyTargets = regexp.MustCompile(`^\((.*)\) -> \((.*)\)$`)
+
+// condenseModify elides the fields of the old and new targets of a
+// modification that are the same and more than n fields away from one
+// that changed. Targets with a different number of fields are kept as
+// they are.
+func condenseModify(rest string, n int) string {
+	m := modifyTargets.FindStringSubmatch(rest)
+	if m == nil {
+		return rest
+	}
+	a, b := strings.Fields(m[1]), strings.Fields(m[2])
+	if len(a) != len(b) {
+		return rest
+	}
+	keep := make([]bool, len(a))
+	for i := range a {
+		if a[i] == b[i] {
+			continue
+		}
+		for j := max(0, i-n); j <= min(len(a)-1, i+n); j++ {
+			keep[j] = true
+		}
+	}
+	elide := func(fields []string) string {
+		var out []string
+		for i, f := range fields {
+			switch {
+			case keep[i]:
+				out = append(out, f)
+			case i == 0 || keep[i-1]:
+				out = append(out, "…")
+			}
+		}
+		return strings.Join(out, " ")
+	}
+	return fmt.Sprintf("(%s) -> (%s)", elide(a), elide(b))
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_condenseCorrections(t *testing.T) {
+	noop := func() error { return nil }
+	var corrections []*models.Correction
+	for i := 1; i <= 5; i++ {
+		corrections = append(corrections, &models.Correction{
+			Msg: fmt.Sprintf("\x1b[32m+ CREATE h%d.example.com A 10.0.0.%d ttl=300\x1b[0m", i, i*20),
+			F:   noop,
+		})
+	}
+	corrections = append(corrections,
+		&models.Correction{Msg: "Report only"},
+		&models.Correction{Msg: "- DELETE old.example.com TXT \"v=1\" ttl=300", F: noop},
+		&models.Correction{Msg: "± MODIFY example.com SRV (10 20 5060 sip1.example.com. ttl=300) -> (10 20 5061 sip1.example.com. ttl=300)", F: noop},
+		// A zone file: one correction makes all the changes.
+		&models.Correction{Msg: "- DELETE a.example.com AAAA 2001:db8::1 ttl=300\n  annotation\n- DELETE b.example.com AAAA 2001:db8::2:1 ttl=300\n  annotation\n- DELETE c.example.com AAAA 2001:db8::3 ttl=300\n  annotation", F: noop},
+	)
+
+	var got []string
+	for _, c := range condenseCorrections(corrections, 1) {
+		got = append(got, colorCodes.ReplaceAllString(c.Msg, ""))
+	}
+	want := []string{
+		"+ CREATE h1.example.com A 10.0.0.20 ttl=300",
+		"+ 4 more A records in 10.0.0.0/25 (--full lists them)",
+		"Report only",
+		"- DELETE old.example.com TXT \"v=1\" ttl=300",
+		"± MODIFY example.com SRV (… 20 5060 sip1.example.com. …) -> (… 20 5061 sip1.example.com. …)",
+		"- DELETE a.example.com AAAA 2001:db8::1 ttl=300\n  annotation\n- 2 more AAAA records in 2001:db8::/110 (--full lists them)",
+	}
+	if strings.Join(got, "\n--\n") != strings.Join(want, "\n--\n") {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n--\n"), strings.Join(want, "\n--\n"))
+	}
+	if !strings.HasPrefix(corrections[8].Msg, "- DELETE a.example.com AAAA 2001:db8::1 ttl=300\n  annotation\n- DELETE b") {
+		t.Errorf("the message of the original correction was changed: %q", corrections[8].Msg)
+	}
+}
+
+func Test_condenseModify(t *testing.T) {
+	tests := []struct {
+		rest string
+		n    int
+		want string
+	}{
+		{"(10 mx1.example.com. ttl=300) -> (20 mx1.example.com. ttl=300)", 0, "(10 …) -> (20 …)"},
+		{"(1 2 3 4 5 6 7) -> (1 2 3 x 5 6 7)", 1, "(… 3 4 5 …) -> (… 3 x 5 …)"},
+		{"(1 2 3) -> (1 2 3 4)", 1, "(1 2 3) -> (1 2 3 4)"},
+		{"192.0.2.1 ttl=(300->600)", 1, "192.0.2.1 ttl=(300->600)"},
+	}
+	for _, tt := range tests {
+		if got := condenseModify(tt.rest, tt.n); got != tt.want {
+			t.Errorf("condenseModify(%q, %d) = %q, want %q", tt.rest, tt.n, got, tt.want)
+		}
+	}
+}
+
+func Test_coveringPrefix(t *testing.T) {
+	tests := []struct {
+		addrs []string
+		want  string
+	}{
+		{[]string{"10.0.0.1"}, "10.0.0.1/32"},
+		{[]string{"10.0.0.1", "10.0.0.255"}, "10.0.0.0/24"},
+		{[]string{"10.0.0.1", "192.168.0.1"}, "0.0.0.0/0"},
+		{[]string{"10.0.0.1", "2001:db8::1"}, "invalid Prefix"},
+		{[]string{"www.example.com."}, "invalid Prefix"},
+	}
+	for _, tt := range tests {
+		if got, _ := coveringPrefix(tt.addrs); got.String() != tt.want {
+			t.Errorf("coveringPrefix(%v) = %s, want %s", tt.addrs, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args DiffIRArgs
+	return &cli.Command{
+		Name:  "diff-ir",
+		Usage: "Compare the records of two IR (json) files",
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 2 {
+				return cli.Exit("diff-ir requires exactly two arguments: old.json new.json", 1)
+			}
+			args.OldFile = c.Args().Get(0)
+			args.NewFile = c.Args().Get(1)
+			return exit(DiffIR(args))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol diff-ir [command options] old.json new.json",
+		Description: `Compare two IR files, as written by "dnscontrol print-ir", and list
+the records that were added, removed or changed in each domain.
+
+The comparison is of the records, not of the text: the order of the records
+and the formatting of the files don't matter. A record is "changed" when it is
+the only record of its name and type on both sides, or when only its TTL
+differs. No provider is accessed.
+
+EXAMPLES:
+   dnscontrol print-ir --out new.json && dnscontrol diff-ir old.json new.json`,
+	}
+}())
+
+// DiffIRArgs encapsulates the flags/arguments for the diff-ir command.
+type DiffIRArgs struct {
+	OldFile    string
+	NewFile    string
+	ExitCode   bool
+	OutputFile string
+}
+
+func (args *DiffIRArgs) flags() []cli.Flag {
+	var flags []cli.Flag
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "exit-code",
+		Destination: &args.ExitCode,
+		Usage:       "Exit with an error if there are differences",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "out",
+		Destination: &args.OutputFile,
+		Usage:       "Instead of stdout, write to this file",
+	})
+	return flags
+}
+
+// DiffIR implements the diff-ir subcommand.
+func DiffIR(args DiffIRArgs) error {
+	oldCfg, err := readIRFile(args.OldFile)
+	if err != nil {
+		return err
+	}
+	newCfg, err := readIRFile(args.NewFile)
+	if err != nil {
+		return err
+	}
+
+	w := os.Stdout
+	if args.OutputFile != "" {
+		if w, err = os.Create(args.OutputFile); err != nil {
+			return fmt.Errorf("failed diff-ir Create(%q): %w", args.OutputFile, err)
+		}
+		defer w.Close()
+	}
+
+	n := writeIRDiff(w, diffIR(oldCfg, newCfg))
+	if n == 0 {
+		fmt.Fprintln(w, "No differences.")
+	} else if args.ExitCode {
+		return fmt.Errorf("found %d differences", n)
+	}
+	return nil
+}
+
+func readIRFile(name string) (*models.DNSConfig, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	cfg := &models.DNSConfig{}
+	if err := json.NewDecoder(f).Decode(cfg); err != nil {
+		return nil, fmt.Errorf("failed reading IR %q: %w", name, err)
+	}
+	// The FQDN of records isn't in the IR.
+	for _, dc := range cfg.Domains {
+		for _, rc := range dc.Records {
+			rc.SetLabel(rc.Name, dc.Name)
+		}
+	}
+	return cfg, nil
+}
+
+// irDomainDiff lists the differences in one domain. Each entry is a
+// record, formatted for display.
+type irDomainDiff struct {
+	Domain  string
+	Status  string // "added", "removed", or "" if the domain is in both files
+	Added   []string
+	Removed []string
+	Changed [][2]string // old, new
+}
+
+// diffIR compares the records of the domains of a and b. Domains without
+// differences are omitted. The result is sorted by domain.
+func diffIR(a, b *models.DNSConfig) []irDomainDiff {
+	oldDomains := map[string]*models.DomainConfig{}
+	newDomains := map[string]*models.DomainConfig{}
+	var names []string
+	for _, dc := range a.Domains {
+		oldDomains[irDomainName(dc)] = dc
+		names = append(names, irDomainName(dc))
+	}
+	for _, dc := range b.Domains {
+		newDomains[irDomainName(dc)] = dc
+		if _, ok := oldDomains[irDomainName(dc)]; !ok {
+			names = append(names, irDomainName(dc))
+		}
+	}
+	sort.Strings(names)
+
+	var diffs []irDomainDiff
+	for _, name := range names {
+		d := irDomainDiff{Domain: name}
+		var oldRecs, newRecs models.Records
+		if dc, ok := oldDomains[name]; ok {
+			oldRecs = dc.Records
+		} else {
+			d.Status = "added"
+		}
+		if dc, ok := newDomains[name]; ok {
+			newRecs = dc.Records
+		} else {
+			d.Status = "removed"
+		}
+		diffRecords(&d, oldRecs, newRecs)
+		if d.Status != "" || len(d.Added)+len(d.Removed)+len(d.Changed) != 0 {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+// irDomainName returns the name of dc including its split horizon tag.
+// The unique name is only set once the IR has been normalized.
+func irDomainName(dc *models.DomainConfig) string {
+	if u := dc.GetUniqueName(); u != "" {
+		return u
+	}
+	return dc.Name
+}
+
+func diffRecords(d *irDomainDiff, oldRecs, newRecs models.Records) {
+	type key struct{ fqdn, rtype string }
+	type set struct {
+		old, new map[string]*models.RecordConfig
+	}
+	sets := map[key]*set{}
+	var keys []key
+	add := func(rc *models.RecordConfig, isNew bool) {
+		k := key{rc.GetLabelFQDN(), rc.Type}
+		s, ok := sets[k]
+		if !ok {
+			s = &set{old: map[string]*models.RecordConfig{}, new: map[string]*models.RecordConfig{}}
+			sets[k] = s
+			keys = append(keys, k)
+		}
+		if isNew {
+			s.new[rc.ToComparableNoTTL()] = rc
+		} else {
+			s.old[rc.ToComparableNoTTL()] = rc
+		}
+	}
+	for _, rc := range oldRecs {
+		add(rc, false)
+	}
+	for _, rc := range newRecs {
+		add(rc, true)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].fqdn != keys[j].fqdn {
+			return keys[i].fqdn < keys[j].fqdn
+		}
+		return keys[i].rtype < keys[j].rtype
+	})
+
+	for _, k := range keys {
+		s := sets[k]
+		var removed, added []*models.RecordConfig
+		for _, c := range sortedKeys(s.old) {
+			o := s.old[c]
+			if n, ok := s.new[c]; !ok {
+				removed = append(removed, o)
+			} else if o.TTL != n.TTL {
+				d.Changed = append(d.Changed, [2]string{irRecordString(o), irRecordString(n)})
+			}
+		}
+		for _, c := range sortedKeys(s.new) {
+			if _, ok := s.old[c]; !ok {
+				added = append(added, s.new[c])
+			}
+		}
+		if len(removed) == 1 && len(added) == 1 {
+			d.Changed = append(d.Changed, [2]string{irRecordString(removed[0]), irRecordString(added[0])})
+			continue
+		}
+		for _, rc := range removed {
+			d.Removed = append(d.Removed, irRecordString(rc))
+		}
+		for _, rc := range added {
+			d.Added = append(d.Added, irRecordString(rc))
+		}
+	}
+}
+
+func sortedKeys(m map[string]*models.RecordConfig) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func irRecordString(rc *models.RecordConfig) string {
+	return fmt.Sprintf("%s %s %s ttl=%d", rc.GetLabelFQDN(), rc.Type, rc.ToComparableNoTTL(), rc.TTL)
+}
+
+// writeIRDiff writes diffs to w and returns the number of differences.
+func writeIRDiff(w io.Writer, diffs []irDomainDiff) int {
+	n := 0
+	for _, d := range diffs {
+		if d.Status != "" {
+			fmt.Fprintf(w, "%s: (domain %s)\n", d.Domain, d.Status)
+			n++
+		} else {
+			fmt.Fprintf(w, "%s:\n", d.Domain)
+		}
+		for _, r := range d.Removed {
+			fmt.Fprintf(w, "  - %s\n", r)
+		}
+		for _, r := range d.Added {
+			fmt.Fprintf(w, "  + %s\n", r)
+		}
+		for _, c := range d.Changed {
+			fmt.Fprintf(w, "  ~ %s -> %s\n", c[0], c[1])
+		}
+		n += len(d.Removed) + len(d.Added) + len(d.Changed)
+	}
+	return n
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_diffIR(t *testing.T) {
+	rec := func(typ, label, domain, target string, ttl uint32) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: typ, TTL: ttl}
+		rc.SetLabel(label, domain)
+		rc.SetTarget(target)
+		return rc
+	}
+	oldCfg := &models.DNSConfig{Domains: []*models.DomainConfig{
+		{Name: "example.com", Records: models.Records{
+			rec("A", "www", "example.com", "1.2.3.4", 300),
+			rec("A", "ttl", "example.com", "1.2.3.4", 300),
+			rec("A", "gone", "example.com", "1.2.3.4", 300),
+			rec("A", "same", "example.com", "1.2.3.4", 300),
+		}},
+		{Name: "old.com", Records: models.Records{rec("A", "@", "old.com", "1.2.3.4", 300)}},
+		{Name: "unchanged.com"},
+	}}
+	newCfg := &models.DNSConfig{Domains: []*models.DomainConfig{
+		{Name: "unchanged.com"},
+		{Name: "example.com", Records: models.Records{
+			// Different order.
+			rec("A", "same", "example.com", "1.2.3.4", 300),
+			rec("A", "ttl", "example.com", "1.2.3.4", 600),
+			rec("A", "www", "example.com", "5.6.7.8", 300),
+			rec("TXT", "new", "example.com", "hello", 300),
+		}},
+	}}
+
+	var buf bytes.Buffer
+	n := writeIRDiff(&buf, diffIR(oldCfg, newCfg))
+	want := `example.com:
+  - gone.example.com A 1.2.3.4 ttl=300
+  + new.example.com TXT "hello" ttl=300
+  ~ ttl.example.com A 1.2.3.4 ttl=300 -> ttl.example.com A 1.2.3.4 ttl=600
+  ~ www.example.com A 1.2.3.4 ttl=300 -> www.example.com A 5.6.7.8 ttl=300
+old.com: (domain removed)
+  - old.com A 1.2.3.4 ttl=300
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	if n != 6 {
+		t.Errorf("n = %d, want 6", n)
+	}
+}
@@ -0,0 +1,325 @@
+package commands
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args DiffRevisionsArgs
+	return &cli.Command{
+		Name:  "diff-revisions",
+		Usage: "Compare the records of dnsconfig.js at two git revisions",
+		Action: func(c *cli.Context) error {
+			if c.NArg() < 1 || c.NArg() > 2 {
+				return cli.Exit("diff-revisions requires one or two arguments: OLD [NEW]", 1)
+			}
+			args.OldRev = c.Args().Get(0)
+			args.NewRev = c.Args().Get(1)
+			return exit(DiffRevisions(args))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol diff-revisions [command options] OLD [NEW]",
+		Description: `Run and normalize dnsconfig.js as it is at the git revisions OLD and NEW,
+and list the records that were added, removed or changed in each domain, as
+"diff-ir" does. Without NEW, OLD is compared to the files as they are now
+(including the changes that aren't committed). The working tree isn't
+touched: each revision is extracted into a temporary directory, and
+dnsconfig.js is run there, so that the files it requires are those of the
+revision. Providers are not accessed and creds.json is not read.
+
+The same flags (--variable, --disable-check, --type-policy, etc.) are used
+for both revisions, so that only the changes of dnsconfig.js (and of the
+files it requires) show.
+
+With --each, each commit from OLD to NEW (default: HEAD) is compared to its
+parent, for a change log of the DNS. The commits without differences are
+left out.
+
+EXAMPLES:
+   dnscontrol diff-revisions HEAD~1
+   dnscontrol diff-revisions v1.0 v2.0 --domains example.com
+   dnscontrol diff-revisions --each main~20 main > dns-changelog.txt`,
+	}
+}())
+
+// DiffRevisionsArgs encapsulates the flags/arguments for the
+// diff-revisions command.
+type DiffRevisionsArgs struct {
+	GetDNSConfigArgs
+	FilterArgs
+	OldRev     string
+	NewRev     string // "" for the working tree.
+	Each       bool
+	ExitCode   bool
+	OutputFile string
+}
+
+func (args *DiffRevisionsArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, &cli.StringFlag{
+		Name:        "domains",
+		Destination: &args.Domains,
+		Usage:       `Comma separated list of domain names to include`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "each",
+		Destination: &args.Each,
+		Usage:       "Compare each commit from OLD to NEW with its parent",
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "exit-code",
+		Destination: &args.ExitCode,
+		Usage:       "Exit with an error if there are differences",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "out",
+		Destination: &args.OutputFile,
+		Usage:       "Instead of stdout, write to this file",
+	})
+	return flags
+}
+
+// DiffRevisions implements the diff-revisions subcommand.
+func DiffRevisions(args DiffRevisionsArgs) error {
+	if args.JSONFile != "" {
+		return fmt.Errorf("diff-revisions can't be used with --ir")
+	}
+	w := os.Stdout
+	if args.OutputFile != "" {
+		var err error
+		if w, err = os.Create(args.OutputFile); err != nil {
+			return fmt.Errorf("failed diff-revisions Create(%q): %w", args.OutputFile, err)
+		}
+		defer w.Close()
+	}
+
+	var n int
+	var err error
+	if args.Each {
+		n, err = diffEachRevision(w, args)
+	} else {
+		n, err = diffTwoRevisions(w, args)
+	}
+	if err != nil {
+		return err
+	}
+	if n != 0 && args.ExitCode {
+		return fmt.Errorf("found %d differences", n)
+	}
+	return nil
+}
+
+// diffTwoRevisions writes the differences between OldRev and NewRev.
+func diffTwoRevisions(w io.Writer, args DiffRevisionsArgs) (int, error) {
+	oldCfg, err := revisionConfig(args.GetDNSConfigArgs, args.OldRev)
+	if err != nil {
+		return 0, err
+	}
+	newCfg, err := revisionConfig(args.GetDNSConfigArgs, args.NewRev)
+	if err != nil {
+		return 0, err
+	}
+	n := writeIRDiff(w, filterIRDiffs(diffIR(oldCfg, newCfg), &args.FilterArgs))
+	if n == 0 {
+		fmt.Fprintln(w, "No differences.")
+	}
+	return n, nil
+}
+
+// diffEachRevision writes the differences of each commit from OldRev to
+// NewRev (or HEAD) with its parent.
+func diffEachRevision(w io.Writer, args DiffRevisionsArgs) (int, error) {
+	newRev := args.NewRev
+	if newRev == "" {
+		newRev = "HEAD"
+	}
+	out, err := git("rev-list", "--reverse", "--first-parent", args.OldRev+".."+newRev)
+	if err != nil {
+		return 0, err
+	}
+	prev, err := revisionConfig(args.GetDNSConfigArgs, args.OldRev)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, commit := range strings.Fields(out) {
+		cfg, err := revisionConfig(args.GetDNSConfigArgs, commit)
+		if err != nil {
+			return total, err
+		}
+		diffs := filterIRDiffs(diffIR(prev, cfg), &args.FilterArgs)
+		prev = cfg
+		if len(diffs) == 0 {
+			continue
+		}
+		subject, err := git("log", "-1", "--format=%h %an %as %s", commit)
+		if err != nil {
+			return total, err
+		}
+		if total != 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "commit %s\n", strings.TrimSpace(subject))
+		total += writeIRDiff(w, diffs)
+	}
+	if total == 0 {
+		fmt.Fprintln(w, "No differences.")
+	}
+	return total, nil
+}
+
+// filterIRDiffs returns the diffs of the domains that args selects.
+func filterIRDiffs(diffs []irDomainDiff, args *FilterArgs) []irDomainDiff {
+	var kept []irDomainDiff
+	for _, d := range diffs {
+		if args.shouldRunDomain(d.Domain) {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+// revisionConfig returns the normalized config of dnsconfig.js at the git
+// revision rev, or as it is now if rev is "". dnsconfig.js is run in the
+// current directory of the revision, so that the files it requires, by a
+// path from there or from dnsconfig.js, are those of the revision too.
+func revisionConfig(args GetDNSConfigArgs, rev string) (*models.DNSConfig, error) {
+	what := rev
+	if rev == "" {
+		what = "the working tree"
+	} else {
+		if filepath.IsAbs(args.JSFile) {
+			return nil, fmt.Errorf("--config must be a path from the current directory with diff-revisions")
+		}
+		dir, err := os.MkdirTemp("", "dnscontrol-revision-")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+		wd, err := extractRevision(dir, rev)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(filepath.Join(wd, args.JSFile)); err != nil {
+			return nil, fmt.Errorf("%s has no %s", rev, args.JSFile)
+		}
+		// The type and record policies are the same for both revisions,
+		// like the other flags.
+		if args.TypePolicy != "" {
+			if args.TypePolicy, err = filepath.Abs(args.TypePolicy); err != nil {
+				return nil, err
+			}
+		}
+		if args.RecordPolicy != "" {
+			if args.RecordPolicy, err = filepath.Abs(args.RecordPolicy); err != nil {
+				return nil, err
+			}
+		}
+		old, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Chdir(wd); err != nil {
+			return nil, err
+		}
+		defer os.Chdir(old)
+	}
+	opts, err := args.normalizeOptions()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", what, err)
+	}
+	cfg, err := GetDNSConfig(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", what, err)
+	}
+	errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
+	if PrintValidationErrors(errs) {
+		return nil, fmt.Errorf("%s: exiting due to validation errors", what)
+	}
+	return cfg, nil
+}
+
+// extractRevision writes the files of the git revision rev into dir, and
+// returns the current directory in it.
+func extractRevision(dir, rev string) (string, error) {
+	top, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	prefix, err := git("rev-parse", "--show-prefix")
+	if err != nil {
+		return "", err
+	}
+	archive, err := git("-C", strings.TrimSpace(top), "archive", "--format=tar", rev)
+	if err != nil {
+		return "", err
+	}
+	if err := untar(dir, strings.NewReader(archive)); err != nil {
+		return "", fmt.Errorf("extracting %s: %w", rev, err)
+	}
+	wd := filepath.Join(dir, filepath.FromSlash(strings.TrimSpace(prefix)))
+	return wd, os.MkdirAll(wd, 0o755)
+}
+
+// untar writes the regular files and directories of the tar archive r
+// into dir. Other entries, such as symlinks, are skipped.
+func untar(dir string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if !strings.HasPrefix(name, filepath.Clean(dir)+string(filepath.Separator)) {
+			return fmt.Errorf("%q is outside the archive", hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(name, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+				return err
+			}
+			f, err := os.Create(name)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// git runs git in the current directory and returns its output.
+func git(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_DiffRevisions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=a", "GIT_AUTHOR_EMAIL=a@example.com",
+			"GIT_COMMITTER_NAME=a", "GIT_COMMITTER_EMAIL=a@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(message, records string) {
+		t.Helper()
+		config := `var REG = NewRegistrar("none"); var DSP = NewDnsProvider("none");
+require("sub/records.js");
+D("example.com", REG, DnsProvider(DSP), RECORDS);`
+		if err := os.MkdirAll(filepath.Join(dir, "dns", "sub"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		os.WriteFile(filepath.Join(dir, "dns", "dnsconfig.js"), []byte(config), 0o644)
+		os.WriteFile(filepath.Join(dir, "dns", "sub", "records.js"), []byte("var RECORDS = ["+records+"];"), 0o644)
+		if message != "" {
+			run("add", "-A")
+			run("commit", "-q", "-m", message)
+		}
+	}
+	run("init", "-q")
+	commit("first", `A("www", "192.0.2.1")`)
+	commit("unrelated", `A("www", "192.0.2.1")   `)
+	commit("second", `A("www", "192.0.2.2")`)
+	commit("", `A("www", "192.0.2.2"), TXT("new", "hello")`) // Not committed.
+
+	wd, _ := os.Getwd()
+	if err := os.Chdir(filepath.Join(dir, "dns")); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	diff := func(args DiffRevisionsArgs) string {
+		t.Helper()
+		args.JSFile = "dnsconfig.js"
+		args.OutputFile = filepath.Join(t.TempDir(), "out.txt")
+		if err := DiffRevisions(args); err != nil {
+			t.Fatal(err)
+		}
+		out, _ := os.ReadFile(args.OutputFile)
+		return string(out)
+	}
+
+	if got, want := diff(DiffRevisionsArgs{OldRev: "HEAD~2", NewRev: "HEAD"}), `example.com:
+  ~ www.example.com A 192.0.2.1 ttl=300 -> www.example.com A 192.0.2.2 ttl=300
+`; got != want {
+		t.Errorf("HEAD~2 HEAD:\n%s\nwant:\n%s", got, want)
+	}
+	if got, want := diff(DiffRevisionsArgs{OldRev: "HEAD"}), `example.com:
+  + new.example.com TXT "hello" ttl=300
+`; got != want {
+		t.Errorf("HEAD and the working tree:\n%s\nwant:\n%s", got, want)
+	}
+	if got := diff(DiffRevisionsArgs{OldRev: "HEAD~1", NewRev: "HEAD", FilterArgs: FilterArgs{Domains: "example.net"}}); got != "No differences.\n" {
+		t.Errorf("--domains example.net:\n%s", got)
+	}
+
+	got := diff(DiffRevisionsArgs{OldRev: "HEAD~2", Each: true})
+	if !strings.HasPrefix(got, "commit ") || !strings.Contains(got, " second\nexample.com:\n  ~ www.example.com A 192.0.2.1") {
+		t.Errorf("--each:\n%s", got)
+	}
+	if strings.Contains(got, "unrelated") {
+		t.Errorf("--each lists a commit without differences:\n%s", got)
+	}
+
+	err := DiffRevisions(DiffRevisionsArgs{GetDNSConfigArgs: GetDNSConfigArgs{ExecuteDSLArgs: ExecuteDSLArgs{JSFile: "dnsconfig.js"}},
+		OldRev: "HEAD~1", ExitCode: true, OutputFile: filepath.Join(t.TempDir(), "out.txt")})
+	if err == nil || err.Error() != "found 2 differences" {
+		t.Errorf("--exit-code: %v", err)
+	}
+}
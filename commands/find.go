@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args FindArgs
+	return &cli.Command{
+		Name:  "find",
+		Usage: "Search the records of all domains by label, type or target",
+		Action: func(c *cli.Context) error {
+			return exit(Find(args, os.Stdout))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol find [command options]",
+		Description: `Search the (normalized) records in dnsconfig.js.  Providers are not accessed.
+
+The patterns are globs ("*" and "?") unless --regex is used.  Matching is
+case insensitive. A label pattern is matched against the short name ("www")
+and the FQDN ("www.example.com").  A target pattern is matched against the
+target field of a record: the IP address of an A record, the hostname of an
+MX or SRV record, the text of a TXT record, etc.
+
+The output columns (TAB separated) are:
+   Domain, FQDN, TTL, Type, Target and arguments
+
+EXAMPLES:
+   dnscontrol find --target 1.2.3.4
+   dnscontrol find --type MX --target '*.google.com.'
+   dnscontrol find --label 'mail*'
+   dnscontrol find --regex --target '^10\.'`,
+	}
+}())
+
+// FindArgs encapsulates the flags/arguments for the find command.
+type FindArgs struct {
+	GetDNSConfigArgs
+	Label  string
+	Type   string
+	Target string
+	Regex  bool
+}
+
+func (args *FindArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, &cli.StringFlag{
+		Name:        "label",
+		Destination: &args.Label,
+		Usage:       "Only records whose label (short or FQDN) matches this pattern",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "type",
+		Destination: &args.Type,
+		Usage:       "Only records of this type (comma separated list)",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "target",
+		Destination: &args.Target,
+		Usage:       "Only records whose target matches this pattern",
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "regex",
+		Destination: &args.Regex,
+		Usage:       "Patterns are regular expressions instead of globs",
+	})
+	return flags
+}
+
+// Find implements the find subcommand.
+func Find(args FindArgs, w io.Writer) error {
+	if args.Label == "" && args.Type == "" && args.Target == "" {
+		return fmt.Errorf("at least one of --label, --type or --target is required")
+	}
+
+	labelMatch, err := newMatcher(args.Label, args.Regex)
+	if err != nil {
+		return fmt.Errorf("invalid --label: %w", err)
+	}
+	targetMatch, err := newMatcher(args.Target, args.Regex)
+	if err != nil {
+		return fmt.Errorf("invalid --target: %w", err)
+	}
+	types := map[string]bool{}
+	for _, t := range strings.Split(args.Type, ",") {
+		if t != "" {
+			types[strings.ToUpper(t)] = true
+		}
+	}
+
+	opts, err := args.GetDNSConfigArgs.normalizeOptions()
+	if err != nil {
+		return err
+	}
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		return err
+	}
+	errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
+	if PrintValidationErrors(errs) {
+		return fmt.Errorf("exiting due to validation errors")
+	}
+	qualifyTargets(cfg)
+
+	for _, dc := range cfg.Domains {
+		for _, rec := range findRecords(dc.Records, labelMatch, types, targetMatch) {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+				dc.GetUniqueName(), rec.GetLabelFQDN(), rec.TTL, rec.Type, rec.GetTargetCombinedFunc(nil))
+		}
+	}
+	return nil
+}
+
+// findRecords returns the records that match all the criteria. A nil
+// matcher or empty types map matches everything.
+func findRecords(recs models.Records, label func(string) bool, types map[string]bool, target func(string) bool) models.Records {
+	var found models.Records
+	for _, rec := range recs {
+		if len(types) != 0 && !types[rec.Type] {
+			continue
+		}
+		if label != nil && !label(rec.GetLabel()) && !label(rec.GetLabelFQDN()) {
+			continue
+		}
+		if target != nil && !target(rec.GetTargetField()) {
+			continue
+		}
+		found = append(found, rec)
+	}
+	return found
+}
+
+// newMatcher returns a case-insensitive function that matches the
+// glob (or regular expression) pattern. It returns nil if the pattern
+// is empty.
+func newMatcher(pattern string, isRegex bool) (func(string) bool, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	if isRegex {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+	pattern = strings.ToLower(pattern)
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	return func(s string) bool {
+		ok, _ := path.Match(pattern, strings.ToLower(s))
+		return ok
+	}, nil
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// makeRec returns a record of example.com with a TTL of 300. target is
+// all the fields of the record, as in a zone file.
+func makeRec(typ, label, target string) *models.RecordConfig {
+	rc := &models.RecordConfig{Type: typ, TTL: 300, Metadata: map[string]string{}}
+	rc.SetLabel(label, "example.com")
+	if err := rc.PopulateFromString(typ, target, "example.com"); err != nil {
+		panic(err)
+	}
+	return rc
+}
+
+func Test_findRecords(t *testing.T) {
+	recs := models.Records{
+		makeRec("A", "www", "1.2.3.4"),
+		makeRec("A", "mail", "10.0.0.1"),
+		makeRec("MX", "@", "10 mail.example.com."),
+		makeRec("CNAME", "webmail", "mail.example.com."),
+	}
+
+	glob := func(p string) func(string) bool {
+		m, err := newMatcher(p, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	regex := func(p string) func(string) bool {
+		m, err := newMatcher(p, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name   string
+		label  func(string) bool
+		types  map[string]bool
+		target func(string) bool
+		want   []string // FQDNs
+	}{
+		{"target ip", nil, nil, glob("1.2.3.4"), []string{"www.example.com"}},
+		{"target glob", nil, nil, glob("MAIL.*"), []string{"example.com", "webmail.example.com"}},
+		{"target glob mx only", nil, map[string]bool{"MX": true}, glob("mail.*"), []string{"example.com"}},
+		{"label short", glob("*mail"), nil, nil, []string{"mail.example.com", "webmail.example.com"}},
+		{"label fqdn", glob("www.example.com"), nil, nil, []string{"www.example.com"}},
+		{"regex", nil, nil, regex(`^10\.`), []string{"mail.example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, r := range findRecords(recs, tt.label, tt.types, tt.target) {
+				got = append(got, r.GetLabelFQDN())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	if _, err := newMatcher("[", false); err == nil {
+		t.Error("expected an error for an invalid glob")
+	}
+}
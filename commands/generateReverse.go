@@ -0,0 +1,300 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/StackExchange/dnscontrol/v4/pkg/transform"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args GenerateReverseArgs
+	return &cli.Command{
+		Name:  "generate-reverse",
+		Usage: "Generate PTR records for the reverse zones from the A/AAAA records",
+		Action: func(c *cli.Context) error {
+			return exit(GenerateReverse(args))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol generate-reverse [command options]",
+		Description: `Generate the PTR records that match the A and AAAA records in dnsconfig.js.
+
+The output is JavaScript that adds the PTRs to the reverse zones with
+D_EXTEND(). Save it to a file and require() it at the end of dnsconfig.js.
+
+The reverse zones are the in-addr.arpa and ip6.arpa domains in dnsconfig.js,
+or the ones listed with --zones. Classless (RFC2317 and RFC4183) in-addr.arpa
+zones are supported. An address is put in the most specific zone that
+contains it.
+
+--pattern SUBNET=TEMPLATE also generates a PTR for every address of SUBNET,
+named from TEMPLATE (Ex: 10.1.2.0/24=host-{last-octet}.dc1.example.com). The
+placeholders are {ip} (the address with "-" instead of "." or ":"), {host}
+(the part of the address that is not in SUBNET: octets of IPv4, or nibbles
+of IPv6 in groups of --nibble-group), and {octet1} to {octet4} and
+{last-octet} for IPv4. An address that has an A or AAAA record gets the
+name of that record instead.
+
+If an address is used by more than one name, or a reverse zone already has a
+different PTR for it, a warning is printed and the first name (in sorted
+order) is used. PTRs that already exist are not output again. The generated
+PTRs are tagged (metadata "generated":"generate-reverse") so that, when the
+output file is already loaded by dnsconfig.js, it is regenerated in full.
+
+EXAMPLES:
+   dnscontrol generate-reverse --out reverse.js
+   dnscontrol generate-reverse --zones 2.0.192.in-addr.arpa,8.b.d.0.1.0.0.2.ip6.arpa
+   dnscontrol generate-reverse --pattern '10.1.2.0/24=host-{last-octet}.dc1.example.com'
+   dnscontrol generate-reverse --pattern '2001:db8:1::/112=h{host}.dc1.example.com' --nibble-group 2`,
+	}
+}())
+
+// GenerateReverseArgs encapsulates the flags/arguments for the generate-reverse command.
+type GenerateReverseArgs struct {
+	GetDNSConfigArgs
+	Zones       string // comma-separated list of reverse zones
+	Patterns    cli.StringSlice
+	NibbleGroup int
+	OutputFile  string
+}
+
+func (args *GenerateReverseArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, &cli.StringFlag{
+		Name:        "zones",
+		Destination: &args.Zones,
+		Usage:       "Reverse zones to generate PTRs for (comma separated; default: the .arpa domains in dnsconfig.js)",
+	})
+	flags = append(flags, &cli.StringSliceFlag{
+		Name:        "pattern",
+		Destination: &args.Patterns,
+		Usage:       "SUBNET=TEMPLATE: generate a PTR for every address of SUBNET, named from TEMPLATE (repeatable)",
+	})
+	flags = append(flags, &cli.IntFlag{
+		Name:        "nibble-group",
+		Destination: &args.NibbleGroup,
+		Value:       4,
+		Usage:       "Group the nibbles of the {host} of IPv6 addresses by this many, separated by \"-\" (0 for no groups)",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "out",
+		Destination: &args.OutputFile,
+		Usage:       "Instead of stdout, write to this file",
+	})
+	return flags
+}
+
+// GenerateReverse implements the generate-reverse subcommand.
+func GenerateReverse(args GenerateReverseArgs) error {
+	var patterns []*ptrPattern
+	for _, spec := range args.Patterns.Value() {
+		p, err := parsePTRPattern(spec)
+		if err != nil {
+			return err
+		}
+		patterns = append(patterns, p)
+	}
+	if args.NibbleGroup < 0 {
+		return fmt.Errorf("--nibble-group must be 0 or more")
+	}
+
+	opts, err := args.GetDNSConfigArgs.normalizeOptions()
+	if err != nil {
+		return err
+	}
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		return err
+	}
+	errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
+	if PrintValidationErrors(errs) {
+		return fmt.Errorf("exiting due to validation errors")
+	}
+	qualifyTargets(cfg)
+
+	var zones []string
+	if args.Zones != "" {
+		for _, z := range strings.Split(args.Zones, ",") {
+			zones = append(zones, strings.TrimSuffix(strings.ToLower(strings.TrimSpace(z)), "."))
+		}
+	}
+
+	ptrs, warnings := reversePTRs(cfg, zones, patterns, args.NibbleGroup)
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "WARNING: %s\n", w)
+	}
+
+	w := os.Stdout
+	if args.OutputFile != "" {
+		if w, err = os.Create(args.OutputFile); err != nil {
+			return fmt.Errorf("failed generate-reverse Create(%q): %w", args.OutputFile, err)
+		}
+		defer w.Close()
+	}
+	writeReversePTRs(w, ptrs)
+	return nil
+}
+
+// Metadata that marks the PTRs made by generate-reverse.
+const (
+	generatedMetaKey   = "generated"
+	generatedByReverse = "generate-reverse"
+)
+
+// reversePTR is a PTR record to be added to a reverse zone.
+type reversePTR struct {
+	IP     string // the address, which PTR() turns into the label
+	Target string // FQDN with a trailing dot
+}
+
+// reversePTRs returns the PTRs each reverse zone needs, keyed by zone. If
+// zones is empty, the .arpa domains of cfg are used. The addresses of
+// patterns that have no A or AAAA record are named by their pattern.
+func reversePTRs(cfg *models.DNSConfig, zones []string, patterns []*ptrPattern, nibbleGroup int) (map[string][]reversePTR, []string) {
+	existing := map[string]map[string]string{} // zone -> label -> target
+	for _, dc := range cfg.Domains {
+		if !isReverseZone(dc.Name) || (len(zones) != 0 && !slices.Contains(zones, dc.Name)) {
+			continue
+		}
+		e := map[string]string{}
+		for _, rec := range dc.Records {
+			if rec.Type == "PTR" && rec.Metadata[generatedMetaKey] != generatedByReverse {
+				e[rec.GetLabel()] = rec.GetTargetField()
+			}
+		}
+		existing[dc.Name] = e
+	}
+	if len(zones) == 0 {
+		for z := range existing {
+			zones = append(zones, z)
+		}
+	}
+	// Most specific zone first.
+	sort.Slice(zones, func(i, j int) bool {
+		if len(zones[i]) != len(zones[j]) {
+			return len(zones[i]) > len(zones[j])
+		}
+		return zones[i] < zones[j]
+	})
+
+	// Collect the names of each address.
+	names := map[string][]string{}
+	for _, dc := range cfg.Domains {
+		if isReverseZone(dc.Name) {
+			continue
+		}
+		for _, rec := range dc.Records {
+			if rec.Type != "A" && rec.Type != "AAAA" {
+				continue
+			}
+			ip := rec.GetTargetIP().String()
+			fqdn := rec.GetLabelFQDN() + "."
+			if !slices.Contains(names[ip], fqdn) {
+				names[ip] = append(names[ip], fqdn)
+			}
+		}
+	}
+	var warnings []string
+	fromPattern := map[string]*ptrPattern{}
+	for _, p := range patterns {
+		overlaps, outside := 0, 0
+		for _, addr := range p.addrs() {
+			ip := addr.String()
+			if _, ok := names[ip]; ok {
+				if fromPattern[ip] != nil {
+					overlaps++
+				}
+				continue
+			}
+			if zone, _ := reverseZoneFor(ip, zones); zone == "" {
+				outside++
+				continue
+			}
+			names[ip] = []string{p.name(addr, nibbleGroup)}
+			fromPattern[ip] = p
+		}
+		if overlaps > 0 {
+			warnings = append(warnings, fmt.Sprintf("--pattern %s: %d %s also in an earlier --pattern, which is used", p.spec, overlaps, plural(overlaps, "address is", "addresses are")))
+		}
+		if outside > 0 {
+			warnings = append(warnings, fmt.Sprintf("--pattern %s: %d %s in none of the reverse zones", p.spec, outside, plural(outside, "address is", "addresses are")))
+		}
+	}
+
+	ips := make([]string, 0, len(names))
+	for ip := range names {
+		ips = append(ips, ip)
+	}
+	sort.Slice(ips, func(i, j int) bool {
+		return string(net.ParseIP(ips[i]).To16()) < string(net.ParseIP(ips[j]).To16())
+	})
+
+	ptrs := map[string][]reversePTR{}
+	for _, ip := range ips {
+		zone, label := reverseZoneFor(ip, zones)
+		if zone == "" {
+			continue
+		}
+		fqdns := names[ip]
+		sort.Strings(fqdns)
+		target := fqdns[0]
+		if len(fqdns) > 1 {
+			warnings = append(warnings, fmt.Sprintf("%s is the address of %s; using %s for the PTR",
+				ip, strings.Join(fqdns, ", "), target))
+		}
+		if old, ok := existing[zone][label]; ok {
+			if old != target {
+				msg := fmt.Sprintf("%s already has PTR %s in %s; not adding %s", ip, old, zone, target)
+				if p := fromPattern[ip]; p != nil {
+					msg += fmt.Sprintf(" (--pattern %s)", p.spec)
+				}
+				warnings = append(warnings, msg)
+			}
+			continue
+		}
+		ptrs[zone] = append(ptrs[zone], reversePTR{IP: ip, Target: target})
+	}
+	return ptrs, warnings
+}
+
+// reverseZoneFor returns the first of zones that ip belongs in, and the
+// label it would have there.
+func reverseZoneFor(ip string, zones []string) (string, string) {
+	for _, zone := range zones {
+		label, err := transform.PtrNameMagic(ip, zone)
+		if err == nil && label != ip {
+			return zone, label
+		}
+	}
+	return "", ""
+}
+
+func isReverseZone(name string) bool {
+	return strings.HasSuffix(name, ".in-addr.arpa") || strings.HasSuffix(name, ".ip6.arpa")
+}
+
+func writeReversePTRs(w io.Writer, ptrs map[string][]reversePTR) {
+	zones := make([]string, 0, len(ptrs))
+	for z := range ptrs {
+		zones = append(zones, z)
+	}
+	sort.Strings(zones)
+
+	fmt.Fprintln(w, `// Generated by "dnscontrol generate-reverse". Do not edit.`)
+	for _, zone := range zones {
+		fmt.Fprintf(w, "\nD_EXTEND(%s", jsonQuoted(zone))
+		for _, p := range ptrs[zone] {
+			fmt.Fprintf(w, ",\n\tPTR(%s, %s, {%s: %s})", jsonQuoted(p.IP), jsonQuoted(p.Target),
+				jsonQuoted(generatedMetaKey), jsonQuoted(generatedByReverse))
+		}
+		fmt.Fprintln(w, "\n);")
+	}
+}
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_reversePTRs(t *testing.T) {
+	rec := func(typ, label, domain, target string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: typ, Metadata: map[string]string{}}
+		rc.SetLabel(label, domain)
+		rc.SetTarget(target)
+		return rc
+	}
+	generated := rec("PTR", "9", "2.0.192.in-addr.arpa", "old.example.com.")
+	generated.Metadata[generatedMetaKey] = generatedByReverse
+
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{
+		{Name: "example.com", Records: models.Records{
+			rec("A", "@", "example.com", "192.0.2.1"),
+			rec("A", "www", "example.com", "192.0.2.1"),
+			rec("A", "mail", "example.com", "192.0.2.70"),
+			rec("A", "host", "example.com", "192.0.2.9"),
+			rec("A", "elsewhere", "example.com", "198.51.100.1"),
+			rec("AAAA", "v6", "example.com", "2001:db8::5"),
+		}},
+		{Name: "example.net", Records: models.Records{
+			rec("A", "@", "example.net", "192.0.2.7"),
+			rec("A", "same", "example.net", "192.0.2.8"),
+		}},
+		{Name: "2.0.192.in-addr.arpa", Records: models.Records{
+			rec("PTR", "7", "2.0.192.in-addr.arpa", "legacy.example.net."),
+			rec("PTR", "8", "2.0.192.in-addr.arpa", "same.example.net."),
+			generated,
+		}},
+		{Name: "64-26.2.0.192.in-addr.arpa"},
+		{Name: "8.b.d.0.1.0.0.2.ip6.arpa"},
+	}}
+
+	ptrs, warnings := reversePTRs(cfg, nil, nil, 4)
+	want := map[string][]reversePTR{
+		"2.0.192.in-addr.arpa": {
+			{"192.0.2.1", "example.com."},
+			{"192.0.2.9", "host.example.com."},
+		},
+		"64-26.2.0.192.in-addr.arpa": {{"192.0.2.70", "mail.example.com."}},
+		"8.b.d.0.1.0.0.2.ip6.arpa":   {{"2001:db8::5", "v6.example.com."}},
+	}
+	if !reflect.DeepEqual(ptrs, want) {
+		t.Errorf("ptrs = %v, want %v", ptrs, want)
+	}
+	wantWarnings := []string{
+		"192.0.2.1 is the address of example.com., www.example.com.; using example.com. for the PTR",
+		"192.0.2.7 already has PTR legacy.example.net. in 2.0.192.in-addr.arpa; not adding example.net.",
+	}
+	if !reflect.DeepEqual(warnings, wantWarnings) {
+		t.Errorf("warnings = %q, want %q", warnings, wantWarnings)
+	}
+
+	// Only the zones asked for.
+	ptrs, _ = reversePTRs(cfg, []string{"8.b.d.0.1.0.0.2.ip6.arpa"}, nil, 4)
+	if len(ptrs) != 1 || len(ptrs["8.b.d.0.1.0.0.2.ip6.arpa"]) != 1 {
+		t.Errorf("with --zones: ptrs = %v", ptrs)
+	}
+}
+
+func Test_reversePTRsPatterns(t *testing.T) {
+	rec := func(typ, label, domain, target string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: typ, Metadata: map[string]string{}}
+		rc.SetLabel(label, domain)
+		rc.SetTarget(target)
+		return rc
+	}
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{
+		{Name: "example.com", Records: models.Records{
+			rec("A", "www", "example.com", "192.0.2.1"),
+		}},
+		{Name: "2.0.192.in-addr.arpa", Records: models.Records{
+			rec("PTR", "2", "2.0.192.in-addr.arpa", "legacy.example.com."),
+		}},
+	}}
+	var patterns []*ptrPattern
+	for _, spec := range []string{"192.0.2.0/30=host-{last-octet}.dc1.example.com", "192.0.2.2/31=other-{ip}.example.com", "198.51.100.0/31=h{host}.example.com"} {
+		p, err := parsePTRPattern(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		patterns = append(patterns, p)
+	}
+
+	ptrs, warnings := reversePTRs(cfg, nil, patterns, 4)
+	want := map[string][]reversePTR{
+		"2.0.192.in-addr.arpa": {
+			{"192.0.2.0", "host-0.dc1.example.com."},
+			{"192.0.2.1", "www.example.com."},
+			{"192.0.2.3", "host-3.dc1.example.com."},
+		},
+	}
+	if !reflect.DeepEqual(ptrs, want) {
+		t.Errorf("ptrs = %v, want %v", ptrs, want)
+	}
+	wantWarnings := []string{
+		"--pattern 192.0.2.2/31=other-{ip}.example.com: 2 addresses are also in an earlier --pattern, which is used",
+		"--pattern 198.51.100.0/31=h{host}.example.com: 2 addresses are in none of the reverse zones",
+		"192.0.2.2 already has PTR legacy.example.com. in 2.0.192.in-addr.arpa; not adding host-2.dc1.example.com. (--pattern 192.0.2.0/30=host-{last-octet}.dc1.example.com)",
+	}
+	if !reflect.DeepEqual(warnings, wantWarnings) {
+		t.Errorf("warnings = %q, want %q", warnings, wantWarnings)
+	}
+}
+
+func Test_ptrPatternName(t *testing.T) {
+	tests := []struct {
+		spec  string
+		ip    string
+		group int
+		want  string
+	}{
+		{"10.1.2.0/24=host-{last-octet}.example.com", "10.1.2.3", 4, "host-3.example.com."},
+		{"10.1.0.0/16=h{host}.example.com", "10.1.2.3", 4, "h2-3.example.com."},
+		{"10.1.2.0/24=h-{octet1}-{octet2}.{octet3}.example.com", "10.1.2.3", 4, "h-10-1.2.example.com."},
+		{"10.1.2.0/24=ip-{ip}.example.com", "10.1.2.3", 4, "ip-10-1-2-3.example.com."},
+		{"2001:db8::/112=h{host}.example.com", "2001:db8::1a", 4, "h001a.example.com."},
+		{"2001:db8::/112=h{host}.example.com", "2001:db8::1a", 2, "h00-1a.example.com."},
+		{"2001:db8::/116=h{host}.example.com", "2001:db8::1a", 2, "h0-1a.example.com."},
+		{"2001:db8::/112=h{host}.example.com", "2001:db8::1a", 0, "h001a.example.com."},
+		{"2001:db8::/120=ip-{ip}.example.com", "2001:db8::1a", 4, "ip-2001-db8--1a.example.com."},
+	}
+	for _, tst := range tests {
+		p, err := parsePTRPattern(tst.spec)
+		if err != nil {
+			t.Errorf("%s: %v", tst.spec, err)
+			continue
+		}
+		if got := p.name(netip.MustParseAddr(tst.ip), tst.group); got != tst.want {
+			t.Errorf("%s: name(%s, %d) = %q, want %q", tst.spec, tst.ip, tst.group, got, tst.want)
+		}
+	}
+
+	for _, spec := range []string{
+		"10.1.2.0/24",
+		"10.1.2.0/24=host.example.com",
+		"10.1.2.0/24=host-{nope}.example.com",
+		"10.0.0.0/8=host-{ip}.example.com",
+		"2001:db8::/112=host-{last-octet}.example.com",
+		"10.1.2.3=host-{ip}.example.com",
+	} {
+		if _, err := parsePTRPattern(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+}
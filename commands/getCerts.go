@@ -136,14 +136,19 @@ func GetCerts(args GetCertsArgs) error {
 	}
 
 	// load dns config
+	opts, err := args.GetDNSConfigArgs.normalizeOptions()
+	if err != nil {
+		return err
+	}
 	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
 	if err != nil {
 		return err
 	}
-	errs := normalize.ValidateAndNormalizeConfig(cfg)
+	errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
 	if PrintValidationErrors(errs) {
 		return fmt.Errorf("exiting due to validation errors")
 	}
+	qualifyTargets(cfg)
 	providerConfigs, err := credsfile.LoadProviderConfigs(args.CredsFile)
 	if err != nil {
 		return err
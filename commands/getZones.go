@@ -9,6 +9,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/credsfile"
 	"github.com/StackExchange/dnscontrol/v4/pkg/prettyzone"
+	"github.com/StackExchange/dnscontrol/v4/pkg/txtutil"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/urfave/cli/v2"
 )
@@ -20,6 +21,15 @@ var _ = cmd(catUtils, func() *cli.Command {
 		Aliases: []string{"get-zone"},
 		Usage:   "gets a zone from a provider (stand-alone)",
 		Action: func(ctx *cli.Context) error {
+			if args.AXFR {
+				if ctx.NArg() < 1 {
+					return cli.Exit("Arguments should be: zone(s) (Ex: --axfr --server ns1.example.com example.com)", 1)
+				}
+				args.CredName = axfrCredName
+				args.ProviderName = "-"
+				args.ZoneNames = ctx.Args().Slice()
+				return exit(GetZone(args))
+			}
 			if ctx.NArg() < 3 {
 				return cli.Exit("Arguments should be: credskey providername zone(s) (Ex: r53 ROUTE53 example.com)", 1)
 			}
@@ -53,6 +63,7 @@ FORMATS:
    --format=djs       js with disco commas (leading commas)
    --format=zone      BIND zonefile format
    --format=tsv       TAB separated value (useful for AWK)
+   --format=tinydns   The data file of tinydns (djbdns)
    --format=nameonly  Just print the zone names
 
 The columns in --format=tsv are:
@@ -65,12 +76,46 @@ The columns in --format=tsv are:
 
 The --ttl flag only applies to zone/js/djs formats.
 
+The --types flag limits the output to records of the listed types:
+   --types=MX         only MX records
+   --types=A,AAAA     only A and AAAA records
+
+The --record-order flag only applies to the zone format:
+   --record-order=name              group by name, SOA and NS first (default)
+   --record-order=type              group by type, then by name
+   --record-order=name:NS,MX,A      group by name, listing these types first
+   --record-order=type:SOA,NS,MX    group by type, listing these types first
+
+With --dedupe-txt, TXT records that the provider returns as several quoted
+strings ("v=DKIM1; p=MIIB" "IjAN") are output as their single value, as
+they would be written in dnsconfig.js.
+
+With --check-consistency, credkey is a comma-separated list of creds.json
+entries. The zone(s) are downloaded from each of them and any record that is
+missing from a provider, or has a different TTL, is listed. The SOA and the
+NS records at the apex are not compared. --format is ignored.
+
+With --snapshot, the zone(s) are saved in IR format to
+snapshot-CREDKEY-TIMESTAMP.json (or the --out file) and --format is ignored.
+"dnscontrol restore" pushes such a snapshot back, rolling the zones back to
+the state they were in.
+
+With --axfr, the zone(s) are transferred from the DNS server --server
+(host or host:port) by AXFR, and the only arguments are the zones;
+creds.json is not read. --key is the TSIG key, either as
+"algorithm:name:secret" (Ex: hmac-sha256:transfer:c2VjcmV0) or as
+"@FILE" to read a BIND key file. The generated dnsconfig.js uses the
+creds.json entry "axfr"; "all" is not supported.
+
 EXAMPLES:
    dnscontrol get-zones myr53 ROUTE53 example.com
    dnscontrol get-zones gmain GANDI_V5 example.com other.com
    dnscontrol get-zones cfmain CLOUDFLAREAPI all
    dnscontrol get-zones --format=tsv bind BIND example.com
-   dnscontrol get-zones --format=djs --out=draft.js gcloud GCLOUD example.com`,
+   dnscontrol get-zones --format=djs --out=draft.js gcloud GCLOUD example.com
+   dnscontrol get-zones --check-consistency primary,secondary - example.com
+   dnscontrol get-zones --snapshot r53 - example.com
+   dnscontrol get-zones --axfr --server ns1.example.com --key @transfer.key --format=js example.com`,
 	}
 }())
 
@@ -129,6 +174,14 @@ type GetZoneArgs struct {
 	OutputFormat       string   // Output format
 	OutputFile         string   // Filename to send output ("" means stdout)
 	DefaultTTL         int      // default TTL for providers where it is unknown
+	RecordOrder        string   // order of records in --format=zone (see prettyzone.ParseRecordOrder)
+	Types              string   // comma-separated list of rtypes to output ("" means all)
+	DedupeTXT          bool     // merge TXT records returned as quoted strings into one value
+	CheckConsistency   bool     // compare the zones at multiple providers
+	Snapshot           bool     // write the zones as IR, for restore
+	AXFR               bool     // transfer the zones from AXFRServer, without creds.json
+	AXFRServer         string   // host[:port] to transfer the zones from
+	AXFRKey            string   // TSIG key: "algorithm:name:secret" or "@file"
 }
 
 func (args *GetZoneArgs) flags() []cli.Flag {
@@ -137,7 +190,7 @@ func (args *GetZoneArgs) flags() []cli.Flag {
 		Name:        "format",
 		Destination: &args.OutputFormat,
 		Value:       "zone",
-		Usage:       `Output format: js djs zone tsv nameonly`,
+		Usage:       `Output format: js djs zone tsv tinydns nameonly`,
 	})
 	flags = append(flags, &cli.StringFlag{
 		Name:        "out",
@@ -149,6 +202,47 @@ func (args *GetZoneArgs) flags() []cli.Flag {
 		Destination: &args.DefaultTTL,
 		Usage:       `Default TTL (0 picks the most common TTL)`,
 	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "record-order",
+		Destination: &args.RecordOrder,
+		Usage:       `Record order for --format=zone: name, type, or a list of types to put first (Ex: type:SOA,NS,MX)`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "types",
+		Aliases:     []string{"only-types"},
+		Destination: &args.Types,
+		Usage:       `Only output records of these types (Ex: A,MX,TXT)`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "dedupe-txt",
+		Destination: &args.DedupeTXT,
+		Usage:       `Merge the quoted strings of TXT records ("a" "b") into one value, as written in dnsconfig.js`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "check-consistency",
+		Destination: &args.CheckConsistency,
+		Usage:       `Compare the zone(s) at multiple providers (credkey is a comma-separated list) and report differences`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "snapshot",
+		Destination: &args.Snapshot,
+		Usage:       `Save the zone(s) as IR to a timestamped file (or --out) for use with "dnscontrol restore"`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "axfr",
+		Destination: &args.AXFR,
+		Usage:       `Transfer the zone(s) from --server by AXFR instead of using a creds.json entry`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "server",
+		Destination: &args.AXFRServer,
+		Usage:       `(--axfr) DNS server to transfer the zone(s) from (host or host:port)`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "key",
+		Destination: &args.AXFRKey,
+		Usage:       `(--axfr) TSIG key as algorithm:name:secret, or @FILE to read a BIND key file`,
+	})
 	return flags
 }
 
@@ -157,11 +251,31 @@ func GetZone(args GetZoneArgs) error {
 	var providerConfigs map[string]map[string]string
 	var err error
 
-	// Read it in:
-	providerConfigs, err = credsfile.LoadProviderConfigs(args.CredsFile)
+	order, err := prettyzone.ParseRecordOrder(args.RecordOrder)
 	if err != nil {
+		return fmt.Errorf("failed GetZone --record-order: %w", err)
+	}
+
+	// Read it in:
+	if args.AXFR {
+		config, err := axfrProviderConfig(args.AXFRServer, args.AXFRKey)
+		if err != nil {
+			return err
+		}
+		providerConfigs = map[string]map[string]string{args.CredName: config}
+	} else if providerConfigs, err = credsfile.LoadProviderConfigs(args.CredsFile); err != nil {
 		return fmt.Errorf("failed GetZone LoadProviderConfigs(%q): %w", args.CredsFile, err)
 	}
+	if args.CheckConsistency {
+		w := os.Stdout
+		if args.OutputFile != "" {
+			if w, err = os.Create(args.OutputFile); err != nil {
+				return fmt.Errorf("failed GetZone Create(%q): %w", args.OutputFile, err)
+			}
+			defer w.Close()
+		}
+		return checkConsistency(args, providerConfigs, w)
+	}
 	provider, err := providers.CreateDNSProvider(args.ProviderName, providerConfigs[args.CredName], nil)
 	if err != nil {
 		return fmt.Errorf("failed GetZone CDP: %w", err)
@@ -180,6 +294,10 @@ func GetZone(args GetZoneArgs) error {
 		}
 	}
 
+	if args.Snapshot {
+		return snapshotZones(args, provider, providerConfigs[args.CredName], zones)
+	}
+
 	// first open output stream and print initial header (if applicable)
 	w := os.Stdout
 	if args.OutputFile != "" {
@@ -204,7 +322,12 @@ func GetZone(args GetZoneArgs) error {
 		if err != nil {
 			return fmt.Errorf("failed GetZone gzr: %w", err)
 		}
-		zoneRecs[i] = recs
+		zoneRecs[i] = filterRecordTypes(recs, args.Types)
+		if args.DedupeTXT {
+			if n := dedupeTXT(zoneRecs[i]); n > 0 {
+				fmt.Fprintf(os.Stderr, "--dedupe-txt: merged the strings of %d TXT records of %s\n", n, zone)
+			}
+		}
 	}
 
 	// Write the heading:
@@ -232,7 +355,7 @@ func GetZone(args GetZoneArgs) error {
 
 		case "zone":
 			fmt.Fprintf(w, "$ORIGIN %s.\n", zoneName)
-			prettyzone.WriteZoneFileRC(w, z.Records, zoneName, uint32(args.DefaultTTL), nil)
+			prettyzone.WriteZoneFileRCOrdered(w, z.Records, zoneName, uint32(args.DefaultTTL), nil, order)
 			fmt.Fprintln(w)
 
 		case "js", "djs":
@@ -279,6 +402,10 @@ func GetZone(args GetZoneArgs) error {
 				fmt.Fprint(w, "\nEND);\n\n")
 			}
 
+		case "tinydns":
+			fmt.Fprintf(w, "# %s\n", zoneName)
+			writeTinydns(w, z.Records)
+
 		case "tsv":
 			for _, rec := range recs {
 
@@ -304,6 +431,49 @@ func GetZone(args GetZoneArgs) error {
 	return nil
 }
 
+// filterRecordTypes returns the records whose type is in the
+// comma-separated list types. An empty list keeps all records.
+func filterRecordTypes(recs models.Records, types string) models.Records {
+	if types == "" {
+		return recs
+	}
+	keep := map[string]bool{}
+	for _, t := range strings.Split(types, ",") {
+		keep[strings.ToUpper(strings.TrimSpace(t))] = true
+	}
+	filtered := models.Records{}
+	for _, rec := range recs {
+		if keep[rec.Type] {
+			filtered = append(filtered, rec)
+		}
+	}
+	return filtered
+}
+
+// dedupeTXT merges the strings of the TXT records whose value is still in
+// the quoted form of a zone file, such as `"v=DKIM1; k=rsa; p=MIIB" "IjAN"`,
+// which some providers return for records split into several strings. It
+// returns the number of records changed.
+func dedupeTXT(recs models.Records) int {
+	n := 0
+	for _, rec := range recs {
+		if rec.Type != "TXT" {
+			continue
+		}
+		txt := rec.GetTargetTXTJoined()
+		if len(txt) < 2 || txt[0] != '"' || txt[len(txt)-1] != '"' {
+			continue
+		}
+		joined, err := txtutil.ParseQuoted(txt)
+		if err != nil {
+			continue // Not quoted strings after all; keep it as is.
+		}
+		rec.SetTargetTXT(joined)
+		n++
+	}
+	return n
+}
+
 // jsonQuoted returns a properly escaped JSON string (without quotes).
 func jsonQuoted(i string) string {
 	// https://stackoverflow.com/questions/51691901
@@ -333,12 +503,24 @@ func formatDsl(rec *models.RecordConfig, defaultTTL uint32) string {
 	}
 
 	switch rec.Type { // #rtype_variations
+	case "AFSDB":
+		target = fmt.Sprintf(`%d, "%s"`, rec.AfsdbSubtype, rec.GetTargetField())
 	case "CAA":
 		return makeCaa(rec, ttlop)
+	case "CERT":
+		target = fmt.Sprintf(`%d, %d, %d, "%s"`, rec.CertType, rec.CertKeyTag, rec.CertAlgorithm, rec.GetTargetField())
 	case "DS":
 		target = fmt.Sprintf(`%d, %d, %d, "%s"`, rec.DsKeyTag, rec.DsAlgorithm, rec.DsDigestType, rec.DsDigest)
 	case "DNSKEY":
 		target = fmt.Sprintf(`%d, %d, %d, "%s"`, rec.DnskeyFlags, rec.DnskeyProtocol, rec.DnskeyAlgorithm, rec.DnskeyPublicKey)
+	case "HIP":
+		servers := make([]string, len(rec.HipServers))
+		for i, s := range rec.HipServers {
+			servers[i] = jsonQuoted(s)
+		}
+		target = fmt.Sprintf(`%d, "%s", "%s", [%s]`, rec.HipPkAlgorithm, rec.GetTargetField(), rec.HipPublicKey, strings.Join(servers, ", "))
+	case "L32", "L64", "LP", "NID":
+		target = fmt.Sprintf(`%d, "%s"`, rec.IlnpPreference, rec.GetTargetField())
 	case "MX":
 		target = fmt.Sprintf(`%d, "%s"`, rec.MxPreference, rec.GetTargetField())
 	case "NAPTR":
@@ -350,6 +532,8 @@ func formatDsl(rec *models.RecordConfig, defaultTTL uint32) string {
 			jsonQuoted(rec.NaptrRegexp),      // regex
 			jsonQuoted(rec.GetTargetField()), // .
 		)
+	case "RP":
+		target = fmt.Sprintf(`"%s", "%s"`, rec.GetTargetField(), rec.RpTxt)
 	case "SSHFP":
 		target = fmt.Sprintf(`%d, %d, "%s"`, rec.SshfpAlgorithm, rec.SshfpFingerprint, rec.GetTargetField())
 	case "SOA":
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/robertkrimen/otto"
+)
+
+// githubActions is set by --github-actions, or by the GITHUB_ACTIONS
+// environment variable that GitHub Actions sets. The validation warnings
+// and errors of check, preview and push are then also output as workflow
+// commands, which GitHub shows as annotations of the pull request.
+var githubActions bool
+
+// annotations is where annotate writes the workflow commands; nil (none)
+// unless enableAnnotations was called.
+var annotations io.Writer
+
+// enableAnnotations makes annotate write to stderr if githubActions is
+// set. It is called by check, preview and push; stderr keeps the workflow
+// commands out of the output of the commands, such as print-ir's JSON.
+func enableAnnotations() {
+	if githubActions {
+		annotations = os.Stderr
+	}
+}
+
+// annotationFile is the file that the annotations refer to: the
+// dnsconfig.js (or IR file) read by GetDNSConfig.
+var annotationFile string
+
+// githubAnnotation formats a workflow command such as
+// "::warning file=dnsconfig.js,line=3::message". level is "warning" or
+// "error"; file and line are omitted if they are "" and 0.
+func githubAnnotation(level, file string, line, col int, msg string) string {
+	var props []string
+	if file != "" {
+		props = append(props, "file="+escapeAnnotationProperty(file))
+		if line > 0 {
+			props = append(props, "line="+strconv.Itoa(line))
+			if col > 0 {
+				props = append(props, "col="+strconv.Itoa(col))
+			}
+		}
+	}
+	cmd := "::" + level
+	if len(props) > 0 {
+		cmd += " " + strings.Join(props, ",")
+	}
+	return cmd + "::" + escapeAnnotationData(msg)
+}
+
+// The escaping of workflow commands, as done by @actions/core.
+var (
+	annotationDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	annotationPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func escapeAnnotationData(s string) string     { return annotationDataEscaper.Replace(s) }
+func escapeAnnotationProperty(s string) string { return annotationPropertyEscaper.Replace(s) }
+
+// jsSyntaxPosition matches the position in the message of a syntax error,
+// e.g. "(anonymous): Line 2:11 Unexpected string".
+var jsSyntaxPosition = regexp.MustCompile(`\(anonymous\): Line (\d+):(\d+)`)
+
+// jsStackPosition matches the position of an exception in dnsconfig.js
+// itself (not in helpers.js or a require()d file) in an otto stack trace.
+var jsStackPosition = regexp.MustCompile(`at <anonymous>:(\d+):(\d+)`)
+
+// jsErrorPosition returns the line and column in dnsconfig.js where err
+// happened, or 0, 0 if that isn't known.
+func jsErrorPosition(err error) (line, col int) {
+	text := err.Error()
+	var oe *otto.Error
+	if errors.As(err, &oe) {
+		text = oe.String()
+	}
+	m := jsSyntaxPosition.FindStringSubmatch(text)
+	if m == nil {
+		m = jsStackPosition.FindStringSubmatch(text)
+	}
+	if m == nil {
+		return 0, 0
+	}
+	line, _ = strconv.Atoi(m[1])
+	col, _ = strconv.Atoi(m[2])
+	return line, col
+}
+
+// annotate writes err as a workflow command if enableAnnotations was
+// called.
+func annotate(level string, err error) {
+	if annotations == nil {
+		return
+	}
+	line, col := jsErrorPosition(err)
+	fmt.Fprintln(annotations, githubAnnotation(level, annotationFile, line, col, err.Error()))
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_githubAnnotation(t *testing.T) {
+	tests := []struct {
+		level, file string
+		line, col   int
+		msg, want   string
+	}{
+		{"warning", "", 0, 0, "plain", "::warning::plain"},
+		{"error", "dnsconfig.js", 0, 0, "no line", "::error file=dnsconfig.js::no line"},
+		{"error", "dnsconfig.js", 3, 12, "two\nlines 100%", "::error file=dnsconfig.js,line=3,col=12::two%0Alines 100%25"},
+		{"warning", "a,b:c.js", 7, 0, "m", "::warning file=a%2Cb%3Ac.js,line=7::m"},
+	}
+	for _, tst := range tests {
+		if got := githubAnnotation(tst.level, tst.file, tst.line, tst.col, tst.msg); got != tst.want {
+			t.Errorf("got %q, want %q", got, tst.want)
+		}
+	}
+}
+
+func Test_jsErrorPosition(t *testing.T) {
+	tests := []struct {
+		err       error
+		line, col int
+	}{
+		{errors.New("(anonymous): Line 2:11 Unexpected string (and 1 more errors)"), 2, 11},
+		{fmt.Errorf("executing dnsconfig.js: %w", errors.New("(anonymous): Line 14:3 Unexpected token )")), 14, 3},
+		{errors.New("record has no FQDN"), 0, 0},
+	}
+	for _, tst := range tests {
+		if line, col := jsErrorPosition(tst.err); line != tst.line || col != tst.col {
+			t.Errorf("jsErrorPosition(%q) = %d, %d; want %d, %d", tst.err, line, col, tst.line, tst.col)
+		}
+	}
+}
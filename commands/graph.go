@@ -0,0 +1,361 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args GraphArgs
+	return &cli.Command{
+		Name:  "graph",
+		Usage: "Output the records and the names they point at as a graph (Graphviz DOT or JSON)",
+		Action: func(c *cli.Context) error {
+			return exit(Graph(args, os.Stdout, os.Stderr))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol graph [command options]",
+		Description: `Output the (normalized) records of dnsconfig.js as a dependency graph.  Providers are not accessed.
+
+There is a node for each record set (the records with the same name and type)
+and an edge from a record set to the record sets at the name its records
+point at: the target of a CNAME, ALIAS, DNAME, MX, NS or SRV record.  A name
+that isn't in dnsconfig.js is a dashed node.
+
+A loop of CNAME, ALIAS or DNAME records (which can't be resolved) is
+printed in red, and reported on stderr.
+
+EXAMPLES:
+   dnscontrol graph | dot -Tsvg > dnsconfig.svg
+   dnscontrol graph --domains example.com --format json`,
+	}
+}())
+
+// GraphArgs encapsulates the flags/arguments for the graph command.
+type GraphArgs struct {
+	GetDNSConfigArgs
+	FilterArgs
+	Format string
+}
+
+func (args *GraphArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, &cli.StringFlag{
+		Name:        "domains",
+		Destination: &args.Domains,
+		Usage:       `Comma separated list of domain names to include`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "format",
+		Destination: &args.Format,
+		Value:       "dot",
+		Usage:       `Output format: dot (Graphviz) or json`,
+	})
+	return flags
+}
+
+// graphSchemaVersion is the version of the JSON output. It changes only if
+// a field is removed or changes meaning.
+const graphSchemaVersion = 1
+
+// recordGraph is the JSON output of the graph command.
+type recordGraph struct {
+	SchemaVersion int         `json:"schema_version"`
+	Nodes         []graphNode `json:"nodes"`
+	Edges         []graphEdge `json:"edges"`
+	Cycles        [][]string  `json:"cycles"` // the IDs of the nodes of each loop
+}
+
+// graphNode is a record set, or a name outside of dnsconfig.js that a
+// record points at.
+type graphNode struct {
+	ID       string   `json:"id"`
+	Domain   string   `json:"domain,omitempty"` // The unique name ("example.com!tag")
+	FQDN     string   `json:"fqdn"`
+	Type     string   `json:"type,omitempty"`
+	Targets  []string `json:"targets,omitempty"`
+	External bool     `json:"external,omitempty"`
+	Cycle    bool     `json:"cycle,omitempty"`
+}
+
+// graphEdge is a reference from the records of a node to a name.
+type graphEdge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Cycle bool   `json:"cycle,omitempty"`
+}
+
+// graphAliasTypes are the types that make the resolver follow the target;
+// a loop of these can't be resolved.
+var graphAliasTypes = map[string]bool{"ALIAS": true, "CNAME": true, "DNAME": true, "R53_ALIAS": true}
+
+// Graph implements the graph subcommand. The loops are reported on stderr.
+func Graph(args GraphArgs, w, stderr io.Writer) error {
+	if args.Format != "dot" && args.Format != "json" {
+		return fmt.Errorf("unknown --format %q (want dot or json)", args.Format)
+	}
+	opts, err := args.GetDNSConfigArgs.normalizeOptions()
+	if err != nil {
+		return err
+	}
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		return err
+	}
+	errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
+	if PrintValidationErrors(errs) {
+		return fmt.Errorf("exiting due to validation errors")
+	}
+	qualifyTargets(cfg)
+
+	g := buildGraph(cfg, &args.FilterArgs)
+	for _, c := range g.Cycles {
+		fmt.Fprintf(stderr, "WARNING: loop of records that can't be resolved: %s\n", strings.Join(c, ", "))
+	}
+	if args.Format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(g)
+	}
+	writeGraphDot(w, g)
+	return nil
+}
+
+// buildGraph returns the graph of the domains that filter selects. The
+// nodes are in the order of the domains in dnsconfig.js and, in each
+// domain, sorted by FQDN and type.
+func buildGraph(cfg *models.DNSConfig, filter *FilterArgs) *recordGraph {
+	g := &recordGraph{SchemaVersion: graphSchemaVersion, Nodes: []graphNode{}, Edges: []graphEdge{}, Cycles: [][]string{}}
+
+	type recordSet struct {
+		node int
+		dc   *models.DomainConfig
+		recs models.Records
+	}
+	var sets []recordSet
+	byFQDN := map[string][]recordSet{}
+	for _, dc := range cfg.Domains {
+		if !filter.shouldRunDomain(dc.GetUniqueName()) {
+			continue
+		}
+		groups := dc.Records.GroupedByKey()
+		keys := make([]models.RecordKey, 0, len(groups))
+		for k := range groups {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].NameFQDN != keys[j].NameFQDN {
+				return keys[i].NameFQDN < keys[j].NameFQDN
+			}
+			return keys[i].Type < keys[j].Type
+		})
+		for _, k := range keys {
+			n := graphNode{ID: k.NameFQDN + " " + k.Type, Domain: dc.GetUniqueName(), FQDN: k.NameFQDN, Type: k.Type}
+			if tag := dc.Metadata[models.DomainTag]; tag != "" {
+				n.ID = k.NameFQDN + "!" + tag + " " + k.Type
+			}
+			for _, rec := range groups[k] {
+				n.Targets = append(n.Targets, rec.GetTargetCombinedFunc(nil))
+			}
+			rs := recordSet{node: len(g.Nodes), dc: dc, recs: groups[k]}
+			g.Nodes = append(g.Nodes, n)
+			sets = append(sets, rs)
+			byFQDN[k.NameFQDN] = append(byFQDN[k.NameFQDN], rs)
+		}
+	}
+
+	external := map[string]int{}
+	seen := map[[2]int]bool{}
+	var alias [][2]int                 // the edges that can make a loop
+	adj := make([][]int, len(g.Nodes)) // alias edges only, for the loops
+	for _, rs := range sets {
+		for _, rec := range rs.recs {
+			if rec.Type == "AZURE_ALIAS" {
+				continue // The target is the ID of an Azure resource.
+			}
+			for _, dep := range rec.GetDependencies() {
+				name := strings.ToLower(strings.TrimSuffix(dep, "."))
+				// The records of the same domain (with the same tag) first.
+				var to []int
+				for _, t := range byFQDN[name] {
+					if t.dc == rs.dc {
+						to = append(to, t.node)
+					}
+				}
+				if len(to) == 0 {
+					for _, t := range byFQDN[name] {
+						to = append(to, t.node)
+					}
+				}
+				if len(to) == 0 {
+					i, ok := external[name]
+					if !ok {
+						i = len(g.Nodes)
+						external[name] = i
+						g.Nodes = append(g.Nodes, graphNode{ID: name, FQDN: name, External: true})
+					}
+					to = []int{i}
+				}
+				for _, t := range to {
+					if seen[[2]int{rs.node, t}] {
+						continue
+					}
+					seen[[2]int{rs.node, t}] = true
+					g.Edges = append(g.Edges, graphEdge{From: g.Nodes[rs.node].ID, To: g.Nodes[t].ID})
+					if graphAliasTypes[rec.Type] && t < len(adj) {
+						adj[rs.node] = append(adj[rs.node], t)
+						alias = append(alias, [2]int{rs.node, t})
+					}
+				}
+			}
+		}
+	}
+
+	component := map[int]int{}
+	for c, scc := range stronglyConnected(adj) {
+		if len(scc) == 1 && !slices.Contains(adj[scc[0]], scc[0]) {
+			continue
+		}
+		sort.Ints(scc)
+		var ids []string
+		for _, i := range scc {
+			g.Nodes[i].Cycle = true
+			component[i] = c
+			ids = append(ids, g.Nodes[i].ID)
+		}
+		g.Cycles = append(g.Cycles, ids)
+	}
+	sort.Slice(g.Cycles, func(i, j int) bool { return g.Cycles[i][0] < g.Cycles[j][0] })
+	loop := map[[2]string]bool{}
+	for _, e := range alias {
+		if c, ok := component[e[0]]; ok && g.Nodes[e[1]].Cycle && component[e[1]] == c {
+			loop[[2]string{g.Nodes[e[0]].ID, g.Nodes[e[1]].ID}] = true
+		}
+	}
+	for i, e := range g.Edges {
+		g.Edges[i].Cycle = loop[[2]string{e.From, e.To}]
+	}
+	return g
+}
+
+// stronglyConnected returns the strongly connected components of the
+// graph (Tarjan's algorithm).
+func stronglyConnected(adj [][]int) [][]int {
+	index := make([]int, len(adj))
+	low := make([]int, len(adj))
+	onStack := make([]bool, len(adj))
+	for i := range index {
+		index[i] = -1
+	}
+	var stack []int
+	var sccs [][]int
+	next := 0
+	var visit func(v int)
+	visit = func(v int) {
+		index[v], low[v] = next, next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, u := range adj[v] {
+			if index[u] < 0 {
+				visit(u)
+				low[v] = min(low[v], low[u])
+			} else if onStack[u] {
+				low[v] = min(low[v], index[u])
+			}
+		}
+		if low[v] == index[v] {
+			var scc []int
+			for {
+				u := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[u] = false
+				scc = append(scc, u)
+				if u == v {
+					break
+				}
+			}
+			sccs = append(sccs, scc)
+		}
+	}
+	for v := range adj {
+		if index[v] < 0 {
+			visit(v)
+		}
+	}
+	return sccs
+}
+
+// writeGraphDot writes the graph in the DOT language of Graphviz, with a
+// cluster for each domain.
+func writeGraphDot(w io.Writer, g *recordGraph) {
+	fmt.Fprintf(w, "digraph dnsconfig {\n")
+	fmt.Fprintf(w, "  rankdir=LR;\n")
+	fmt.Fprintf(w, "  node [shape=box];\n")
+	domain := ""
+	for i, n := range g.Nodes {
+		if n.Domain != domain {
+			if domain != "" {
+				fmt.Fprintf(w, "  }\n")
+			}
+			domain = n.Domain
+			if domain != "" {
+				fmt.Fprintf(w, "  subgraph %s {\n", strconv.Quote("cluster_"+strconv.Itoa(i)))
+				fmt.Fprintf(w, "    label=%s;\n", strconv.Quote(domain))
+			}
+		}
+		indent := "  "
+		if domain != "" {
+			indent = "    "
+		}
+		var attrs []string
+		if n.External {
+			attrs = append(attrs, "label="+strconv.Quote(n.FQDN), "shape=ellipse", "style=dashed")
+		} else {
+			attrs = append(attrs, "label="+strconv.Quote(graphDotLabel(n)))
+		}
+		if n.Cycle {
+			attrs = append(attrs, "color=red", "fontcolor=red")
+		}
+		fmt.Fprintf(w, "%s%s [%s];\n", indent, strconv.Quote(n.ID), strings.Join(attrs, ", "))
+	}
+	if domain != "" {
+		fmt.Fprintf(w, "  }\n")
+	}
+	for _, e := range g.Edges {
+		attr := ""
+		if e.Cycle {
+			attr = " [color=red, penwidth=2]"
+		}
+		fmt.Fprintf(w, "  %s -> %s%s;\n", strconv.Quote(e.From), strconv.Quote(e.To), attr)
+	}
+	fmt.Fprintf(w, "}\n")
+}
+
+// graphDotLabel is the label of a record set: its name and type, then its
+// targets (shortened, and at most 5 of them).
+func graphDotLabel(n graphNode) string {
+	const maxTargets, maxLen = 5, 40
+	lines := []string{n.FQDN + " " + n.Type}
+	for i, t := range n.Targets {
+		if i == maxTargets {
+			lines = append(lines, fmt.Sprintf("(%d more)", len(n.Targets)-maxTargets))
+			break
+		}
+		if r := []rune(t); len(r) > maxLen {
+			t = string(r[:maxLen-3]) + "..."
+		}
+		lines = append(lines, t)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_buildGraph(t *testing.T) {
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRec("A", "mail", "192.0.2.1"),
+			makeRec("A", "mail", "192.0.2.2"),
+			makeRec("MX", "@", "10 mail.example.com."),
+			makeRec("CNAME", "www", "lb.example.net."),
+			makeRec("CNAME", "a", "b.example.com."),
+			makeRec("CNAME", "b", "a.example.com."),
+			makeRec("MX", "b", "10 mail.example.com."), // Not part of the loop.
+		},
+	}
+	dc.UpdateSplitHorizonNames()
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{dc}}
+
+	g := buildGraph(cfg, &FilterArgs{})
+
+	var nodes []string
+	for _, n := range g.Nodes {
+		nodes = append(nodes, n.ID)
+	}
+	wantNodes := []string{
+		"a.example.com CNAME", "b.example.com CNAME", "b.example.com MX", "example.com MX",
+		"mail.example.com A", "www.example.com CNAME", "lb.example.net",
+	}
+	if !reflect.DeepEqual(nodes, wantNodes) {
+		t.Errorf("got nodes %v, want %v", nodes, wantNodes)
+	}
+	if mail := g.Nodes[4]; len(mail.Targets) != 2 {
+		t.Errorf("got targets %v, want the 2 addresses", mail.Targets)
+	}
+	if !g.Nodes[6].External {
+		t.Errorf("lb.example.net should be external")
+	}
+
+	var edges []string
+	for _, e := range g.Edges {
+		s := e.From + " -> " + e.To
+		if e.Cycle {
+			s += " (loop)"
+		}
+		edges = append(edges, s)
+	}
+	wantEdges := []string{
+		"a.example.com CNAME -> b.example.com CNAME (loop)",
+		"a.example.com CNAME -> b.example.com MX",
+		"b.example.com CNAME -> a.example.com CNAME (loop)",
+		"b.example.com MX -> mail.example.com A",
+		"example.com MX -> mail.example.com A",
+		"www.example.com CNAME -> lb.example.net",
+	}
+	if !reflect.DeepEqual(edges, wantEdges) {
+		t.Errorf("got edges %v, want %v", edges, wantEdges)
+	}
+	if want := [][]string{{"a.example.com CNAME", "b.example.com CNAME"}}; !reflect.DeepEqual(g.Cycles, want) {
+		t.Errorf("got cycles %v, want %v", g.Cycles, want)
+	}
+
+	var buf bytes.Buffer
+	writeGraphDot(&buf, g)
+	for _, want := range []string{
+		`"a.example.com CNAME" [label="a.example.com CNAME\nb.example.com.", color=red, fontcolor=red];`,
+		`"a.example.com CNAME" -> "b.example.com CNAME" [color=red, penwidth=2];`,
+		`"lb.example.net" [label="lb.example.net", shape=ellipse, style=dashed];`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+}
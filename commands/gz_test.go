@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/StackExchange/dnscontrol/v4/models"
 	_ "github.com/StackExchange/dnscontrol/v4/providers/_all"
 	"github.com/andreyvit/diff"
 )
@@ -16,6 +18,7 @@ func TestFormatTypes(t *testing.T) {
 	  test_data/$DOMAIN.zone   js              test_data/$DOMAIN.zone.js
 	  test_data/$DOMAIN.zone   tsv             test_data/$DOMAIN.zone.tsv
 	  test_data/$DOMAIN.zone   zone            test_data/$DOMAIN.zone.zone
+	  test_data/$DOMAIN.zone   tinydns         test_data/$DOMAIN.zone.tinydns
 	*/
 
 	for _, domain := range []string{"simple.com", "example.org", "apex.com", "ds.com"} {
@@ -23,6 +26,53 @@ func TestFormatTypes(t *testing.T) {
 		t.Run(domain+"/djs", func(t *testing.T) { testFormat(t, domain, "djs") })
 		t.Run(domain+"/tsv", func(t *testing.T) { testFormat(t, domain, "tsv") })
 		t.Run(domain+"/zone", func(t *testing.T) { testFormat(t, domain, "zone") })
+		t.Run(domain+"/tinydns", func(t *testing.T) { testFormat(t, domain, "tinydns") })
+	}
+}
+
+func TestGetZoneTypes(t *testing.T) {
+	for _, tst := range []struct {
+		types    string
+		expected string
+	}{
+		{"mx", "MX MX MX MX MX"},
+		{"CNAME, srv", "CNAME CNAME CNAME CNAME SRV"},
+		{"AAAA", ""},
+	} {
+		t.Run(tst.types, func(t *testing.T) {
+			outfile, err := os.CreateTemp("", "simple.com.types.*.txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(outfile.Name())
+
+			gzargs := GetZoneArgs{
+				ZoneNames:    []string{"simple.com"},
+				OutputFormat: "tsv",
+				OutputFile:   outfile.Name(),
+				CredName:     "bind",
+				ProviderName: "BIND",
+				Types:        tst.types,
+			}
+			gzargs.CredsFile = "test_data/bind-creds.json"
+			if err := GetZone(gzargs); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := os.ReadFile(outfile.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var found []string
+			for _, line := range strings.Split(strings.TrimSpace(string(got)), "\n") {
+				if fields := strings.Split(line, "\t"); len(fields) > 4 {
+					found = append(found, fields[4])
+				}
+			}
+			if g := strings.Join(found, " "); g != tst.expected {
+				t.Errorf("got types %q, want %q", g, tst.expected)
+			}
+		})
 	}
 }
 
@@ -75,3 +125,26 @@ func testFormat(t *testing.T, domain, format string) {
 		t.Errorf("testFormat mismatch (-got +want):\n%s", diff.LineDiff(g, w))
 	}
 }
+
+func TestDedupeTXT(t *testing.T) {
+	txt := func(target string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: "TXT"}
+		rc.SetLabel("@", "example.com")
+		rc.SetTargetTXT(target)
+		return rc
+	}
+	recs := models.Records{
+		txt(`"v=DKIM1; k=rsa; p=MIIB" "IjAN"`),
+		txt(`"quoted \"word\""`),
+		txt(`v=spf1 -all`),
+		txt(`"unterminated\"`),
+	}
+	if n := dedupeTXT(recs); n != 2 {
+		t.Errorf("dedupeTXT() = %d, want 2", n)
+	}
+	for i, want := range []string{`v=DKIM1; k=rsa; p=MIIBIjAN`, `quoted "word"`, `v=spf1 -all`, `"unterminated\"`} {
+		if got := recs[i].GetTargetTXTJoined(); got != want {
+			t.Errorf("record %d: got %q, want %q", i, got, want)
+		}
+	}
+}
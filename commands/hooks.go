@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/google/shlex"
+	"github.com/urfave/cli/v2"
+)
+
+// PushHookArgs encapsulates the flags for the external commands that
+// are run before and after corrections are pushed.
+type PushHookArgs struct {
+	PreHook  string
+	PostHook string
+}
+
+func (args *PushHookArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "pre-push-hook",
+			Destination: &args.PreHook,
+			Usage:       `Command to run once before any correction is pushed. Receives the corrections of all the zones as JSON on stdin; a non-zero exit aborts the push`,
+		},
+		&cli.StringFlag{
+			Name:        "post-push-hook",
+			Destination: &args.PostHook,
+			Usage:       `Command to run once after the corrections are pushed. Receives them as JSON on stdin`,
+		},
+	}
+}
+
+// hookZone is the corrections of a domain at a provider, as sent to the
+// hooks in a JSON array.
+type hookZone struct {
+	Domain      string   `json:"domain"`
+	Provider    string   `json:"provider"`
+	Corrections []string `json:"corrections"`
+	Errors      *int     `json:"errors,omitempty"` // post-push-hook only
+}
+
+// newHookZone returns the corrections that have an action.
+func newHookZone(domain, provider string, corrections []*models.Correction) hookZone {
+	z := hookZone{Domain: domain, Provider: provider, Corrections: []string{}}
+	for _, c := range corrections {
+		if c.F != nil {
+			z.Corrections = append(z.Corrections, c.Msg)
+		}
+	}
+	return z
+}
+
+// appendPushed adds the corrections that a push ran, and how many of them
+// failed, to the zones for the post-push hook.
+func appendPushed(zones []hookZone, domain, provider string, corrections []*models.Correction, errCount int) []hookZone {
+	z := newHookZone(domain, provider, corrections)
+	z.Errors = &errCount
+	return append(zones, z)
+}
+
+// runPushHook runs command once, with the zones that have corrections
+// serialized to its stdin. It returns the combined output of the command,
+// and an error if the command could not be run or exited non-zero.
+func runPushHook(command string, zones []hookZone) (string, error) {
+	if command == "" {
+		return "", nil
+	}
+
+	payload := []hookZone{}
+	for _, z := range zones {
+		if len(z.Corrections) > 0 {
+			payload = append(payload, z)
+		}
+	}
+	if len(payload) == 0 {
+		return "", nil
+	}
+	input, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	argv, err := shlex.Split(command)
+	if err != nil {
+		return "", fmt.Errorf("parsing hook %q: %w", command, err)
+	}
+	if len(argv) == 0 {
+		return "", nil
+	}
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(output), fmt.Errorf("hook %q failed: %w", command, err)
+	}
+	return string(output), nil
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+func Test_runPushHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires POSIX utilities")
+	}
+	corrections := []*models.Correction{
+		{Msg: "report only"},
+		{Msg: "+ CREATE www.example.com A 1.2.3.4", F: func() error { return nil }},
+	}
+	zones := []hookZone{
+		newHookZone("example.com", "bind", corrections),
+		newHookZone("example.net", "bind", corrections[:1]),
+	}
+
+	// No hook, nothing to do.
+	if out, err := runPushHook("", zones); err != nil || out != "" {
+		t.Errorf("empty hook: got (%q, %v)", out, err)
+	}
+
+	// The hook receives only the zones with actionable corrections on stdin.
+	out, err := runPushHook("cat", zones)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"domain":"example.com","provider":"bind","corrections":["+ CREATE www.example.com A 1.2.3.4"]}]`
+	if out != want {
+		t.Errorf("stdin:\n got %s\nwant %s", out, want)
+	}
+
+	// A failing hook is reported as an error.
+	if _, err := runPushHook("false", zones); err == nil {
+		t.Errorf("expected an error from a failing hook")
+	}
+
+	// Without actionable corrections the hook is not run.
+	if _, err := runPushHook("false", zones[1:]); err != nil {
+		t.Errorf("hook ran without corrections: %v", err)
+	}
+}
+
+// hookTestProvider has no records, and a correction that creates one.
+type hookTestProvider struct{ ran *int }
+
+func (p hookTestProvider) GetNameservers(string) ([]*models.Nameserver, error) { return nil, nil }
+
+func (p hookTestProvider) GetZoneRecords(string, map[string]string) (models.Records, error) {
+	return nil, nil
+}
+
+func (p hookTestProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existing models.Records) ([]*models.Correction, error) {
+	return []*models.Correction{{Msg: "+ CREATE www." + dc.Name, F: func() error { *p.ran++; return nil }}}, nil
+}
+
+func Test_preHookGatesPush(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires POSIX utilities")
+	}
+	ran := 0
+	providers.RegisterDomainServiceProviderType("HOOKTEST", providers.DspFuncs{
+		Initializer: func(map[string]string, json.RawMessage) (providers.DNSServiceProvider, error) {
+			return hookTestProvider{ran: &ran}, nil
+		},
+		RecordAuditor: func([]*models.RecordConfig) []error { return nil },
+	})
+	dir := t.TempDir()
+	ir := `{
+  "registrars": [{"name": "none", "type": "-"}],
+  "dns_providers": [{"name": "test", "type": "-"}],
+  "domains": [
+    {"name": "example.com", "registrar": "none", "dnsProviders": {"test": -1}, "records": []},
+    {"name": "example.net", "registrar": "none", "dnsProviders": {"test": -1}, "records": []}
+  ]
+}`
+	for name, content := range map[string]string{"ir.json": ir, "creds.json": `{"none": {"TYPE": "NONE"}, "test": {"TYPE": "HOOKTEST"}}`} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	args := PushArgs{PreviewArgs: PreviewArgs{
+		GetDNSConfigArgs:   GetDNSConfigArgs{JSONFile: filepath.Join(dir, "ir.json")},
+		GetCredentialsArgs: GetCredentialsArgs{CredsFile: filepath.Join(dir, "creds.json")},
+	}}
+	out := printer.ConsolePrinter{Writer: &strings.Builder{}}
+
+	// A rejection runs none of the corrections, not even those of the
+	// domains before the one the hook objects to.
+	args.PreHook = "false"
+	if err := run(args, true, out); err == nil || !strings.Contains(err.Error(), "push aborted") {
+		t.Errorf("got %v, want the push aborted", err)
+	}
+	if ran != 0 {
+		t.Errorf("%d corrections ran although the pre-push-hook rejected the push", ran)
+	}
+
+	// The hooks are run once, with the corrections of all the domains.
+	input := filepath.Join(dir, "input")
+	args.PreHook = "sh -c 'cat >> " + input + "'"
+	args.PostHook = args.PreHook
+	if err := run(args, true, out); err != nil {
+		t.Fatal(err)
+	}
+	if ran != 2 {
+		t.Errorf("%d corrections ran, want 2", ran)
+	}
+	b, err := os.ReadFile(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"domain":"example.com","provider":"test","corrections":["+ CREATE www.example.com"]},{"domain":"example.net","provider":"test","corrections":["+ CREATE www.example.net"]}]` +
+		`[{"domain":"example.com","provider":"test","corrections":["+ CREATE www.example.com"],"errors":0},{"domain":"example.net","provider":"test","corrections":["+ CREATE www.example.net"],"errors":0}]`
+	if string(b) != want {
+		t.Errorf("the hooks got:\n%s\nwant:\n%s", b, want)
+	}
+}
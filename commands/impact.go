@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// impactEnvironments are the values of the tag or of the "environment"
+// metadata of a domain that make --sort-by-impact list it first.
+var impactEnvironments = map[string]bool{"prod": true, "production": true}
+
+// isProduction reports whether the domain is tagged as production, by its
+// tag (D("example.com!prod", ...)) or its metadata
+// (D("example.com", REG, {environment: "production"}, ...)).
+func isProduction(dc *models.DomainConfig) bool {
+	return impactEnvironments[strings.ToLower(dc.Metadata[models.DomainTag])] ||
+		impactEnvironments[strings.ToLower(dc.Metadata["environment"])]
+}
+
+// sortDomainsByImpact returns the domains with the production domains
+// first, otherwise in the order of dnsconfig.js.
+func sortDomainsByImpact(domains []*models.DomainConfig) []*models.DomainConfig {
+	sorted := append([]*models.DomainConfig(nil), domains...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return isProduction(sorted[i]) && !isProduction(sorted[j])
+	})
+	return sorted
+}
+
+// The kinds of corrections, most impactful first.
+const (
+	impactReport = iota // a report (F == nil), kept first
+	impactDelete
+	impactModify
+	impactCreate
+)
+
+// lineImpact returns the kind of a line of the message of a correction,
+// such as "- DELETE old.example.com A 192.0.2.7", and whether it is about
+// the apex of the domain. ok is false if the line isn't a change.
+func lineImpact(domain, line string) (kind int, apex bool, ok bool) {
+	fields := strings.Fields(colorCodes.ReplaceAllString(line, ""))
+	if len(fields) < 3 {
+		return 0, false, false
+	}
+	switch fields[1] {
+	case "DELETE":
+		kind = impactDelete
+	case "CREATE":
+		kind = impactCreate
+	case "MODIFY", "MODIFY-TTL":
+		kind = impactModify
+	default:
+		return 0, false, false
+	}
+	return kind, strings.EqualFold(strings.TrimSuffix(fields[2], "."), domain), true
+}
+
+// impactGroup is a change in the message of a correction, with the lines
+// that follow it (e.g. the --explain annotations).
+type impactGroup struct {
+	lines []string
+	kind  int
+	apex  bool
+}
+
+// lessImpact orders by kind, then the apex first.
+func lessImpact(kind1 int, apex1 bool, kind2 int, apex2 bool) bool {
+	if kind1 != kind2 {
+		return kind1 < kind2
+	}
+	return apex1 && !apex2
+}
+
+// sortMsgByImpact splits the message of a correction into its changes and
+// sorts them. It returns the lines before the first change, the changes,
+// and the kind and apex of the most impactful one. A message without
+// changes counts as a modification.
+func sortMsgByImpact(domain, msg string) (prefix []string, groups []impactGroup, kind int, apex bool) {
+	for _, line := range strings.Split(msg, "\n") {
+		if k, a, ok := lineImpact(domain, line); ok {
+			groups = append(groups, impactGroup{[]string{line}, k, a})
+		} else if len(groups) == 0 {
+			prefix = append(prefix, line)
+		} else {
+			g := &groups[len(groups)-1]
+			g.lines = append(g.lines, line)
+		}
+	}
+	if len(groups) == 0 {
+		return prefix, nil, impactModify, false
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return lessImpact(groups[i].kind, groups[i].apex, groups[j].kind, groups[j].apex)
+	})
+	return prefix, groups, groups[0].kind, groups[0].apex
+}
+
+// sortByImpact returns the corrections of the domain with the deletions
+// first, then the modifications and then the creations; within each, the
+// changes of the apex come first. The changes inside the message of a
+// correction that makes several (e.g. a zone file) are sorted the same
+// way. For preview only: push must make the corrections in the order the
+// provider returned them.
+func sortByImpact(domain string, corrections []*models.Correction) []*models.Correction {
+	type ranked struct {
+		c    *models.Correction
+		kind int
+		apex bool
+	}
+	rs := make([]ranked, len(corrections))
+	for i, c := range corrections {
+		if c.F == nil {
+			rs[i] = ranked{c, impactReport, false}
+			continue
+		}
+		prefix, groups, kind, apex := sortMsgByImpact(domain, c.Msg)
+		if len(groups) > 1 {
+			lines := prefix
+			for _, g := range groups {
+				lines = append(lines, g.lines...)
+			}
+			cp := *c
+			cp.Msg = strings.Join(lines, "\n")
+			c = &cp
+		}
+		rs[i] = ranked{c, kind, apex}
+	}
+	sort.SliceStable(rs, func(i, j int) bool {
+		return lessImpact(rs[i].kind, rs[i].apex, rs[j].kind, rs[j].apex)
+	})
+	sorted := make([]*models.Correction, len(rs))
+	for i, r := range rs {
+		sorted[i] = r.c
+	}
+	return sorted
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_sortByImpact(t *testing.T) {
+	noop := func() error { return nil }
+	corrections := []*models.Correction{
+		{Msg: "+ CREATE www.example.com A 192.0.2.9", F: noop},
+		{Msg: "± MODIFY www.example.com A (192.0.2.1) -> (192.0.2.2)", F: noop},
+		{Msg: "- DELETE old.example.com A 192.0.2.7", F: noop},
+		{Msg: "± MODIFY example.com MX (10 mx1.example.com.) -> (10 mx2.example.com.)", F: noop},
+		{Msg: "Custom change", F: noop},
+		{Msg: "Report only"},
+		{Msg: "\x1b[32m+ CREATE a.example.com A 192.0.2.3\x1b[0m\n\x1b[31m- DELETE example.com TXT \"old\"\x1b[0m\n  annotation", F: noop},
+	}
+	var got []string
+	for _, c := range sortByImpact("example.com", corrections) {
+		got = append(got, colorCodes.ReplaceAllString(c.Msg, ""))
+	}
+	want := []string{
+		"Report only",
+		"- DELETE example.com TXT \"old\"\n  annotation\n+ CREATE a.example.com A 192.0.2.3",
+		"- DELETE old.example.com A 192.0.2.7",
+		"± MODIFY example.com MX (10 mx1.example.com.) -> (10 mx2.example.com.)",
+		"± MODIFY www.example.com A (192.0.2.1) -> (192.0.2.2)",
+		"Custom change",
+		"+ CREATE www.example.com A 192.0.2.9",
+	}
+	if strings.Join(got, "\n--\n") != strings.Join(want, "\n--\n") {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n--\n"), strings.Join(want, "\n--\n"))
+	}
+	if !strings.HasPrefix(corrections[6].Msg, "\x1b[32m+ CREATE") {
+		t.Errorf("the message of the original correction was changed: %q", corrections[6].Msg)
+	}
+}
+
+func Test_sortDomainsByImpact(t *testing.T) {
+	dc := func(name string, meta map[string]string) *models.DomainConfig {
+		return &models.DomainConfig{Name: name, Metadata: meta}
+	}
+	domains := []*models.DomainConfig{
+		dc("a.example", nil),
+		dc("b.example", map[string]string{models.DomainTag: "prod"}),
+		dc("c.example", map[string]string{models.DomainTag: "staging"}),
+		dc("d.example", map[string]string{"environment": "Production"}),
+	}
+	var got []string
+	for _, d := range sortDomainsByImpact(domains) {
+		got = append(got, d.Name)
+	}
+	if want := "b.example d.example a.example c.example"; strings.Join(got, " ") != want {
+		t.Errorf("got %v, want %s", got, want)
+	}
+}
@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/miekg/dns"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args InventoryArgs
+	return &cli.Command{
+		Name:  "inventory",
+		Usage: "List every managed record, with its fields, domain and providers, for an asset database",
+		Action: func(c *cli.Context) error {
+			return exit(Inventory(args, os.Stdout))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol inventory [command options]",
+		Description: `List the (normalized) records of all domains in dnsconfig.js.  Providers are not accessed.
+
+Each record is listed with its FQDN, its fields, the registrar and the DNS
+providers that serve it, and the metadata of the record and of its domain.
+
+The output is TAB separated unless --json or --openmetrics is given:
+   Domain, FQDN, TTL, Type, Target and arguments, DNS providers
+
+EXAMPLES:
+   dnscontrol inventory
+   dnscontrol inventory --json > inventory.json
+   dnscontrol inventory --openmetrics > /var/lib/node_exporter/dnscontrol.prom`,
+	}
+}())
+
+// InventoryArgs encapsulates the flags/arguments for the inventory command.
+type InventoryArgs struct {
+	GetDNSConfigArgs
+	FilterArgs
+	JSON        bool
+	OpenMetrics bool
+}
+
+func (args *InventoryArgs) flags() []cli.Flag {
+	var flags []cli.Flag
+	for _, f := range args.GetDNSConfigArgs.flags() {
+		// --json is the hidden, old name of --ir. Here it is the format.
+		if !slices.Contains(f.Names(), "json") {
+			flags = append(flags, f)
+		}
+	}
+	flags = append(flags, &cli.StringFlag{
+		Name:        "domains",
+		Destination: &args.Domains,
+		Usage:       `Comma separated list of domain names to include`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "json",
+		Destination: &args.JSON,
+		Usage:       "Output the inventory as JSON",
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "openmetrics",
+		Destination: &args.OpenMetrics,
+		Usage:       "Output the inventory as OpenMetrics, one dnscontrol_record_info series per record",
+	})
+	return flags
+}
+
+// inventorySchemaVersion is the version of the JSON output. It changes
+// only if a field is removed or changes meaning.
+const inventorySchemaVersion = 1
+
+// inventory is the JSON output of the inventory command.
+type inventory struct {
+	SchemaVersion int             `json:"schema_version"`
+	Records       []inventoryItem `json:"records"`
+}
+
+// inventoryItem is a record, with what is known about it from its domain.
+type inventoryItem struct {
+	Domain         string            `json:"domain"`
+	Tag            string            `json:"tag,omitempty"`
+	FQDN           string            `json:"fqdn"`
+	Name           string            `json:"name"`
+	Type           string            `json:"type"`
+	TTL            uint32            `json:"ttl"`
+	Target         string            `json:"target"`
+	Fields         map[string]string `json:"fields,omitempty"`
+	Registrar      string            `json:"registrar"`
+	DNSProviders   []string          `json:"dns_providers"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+	DomainMetadata map[string]string `json:"domain_metadata,omitempty"`
+}
+
+// Inventory implements the inventory subcommand.
+func Inventory(args InventoryArgs, w io.Writer) error {
+	if args.JSON && args.OpenMetrics {
+		return fmt.Errorf("--json and --openmetrics can't be used together")
+	}
+	opts, err := args.GetDNSConfigArgs.normalizeOptions()
+	if err != nil {
+		return err
+	}
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		return err
+	}
+	errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
+	if PrintValidationErrors(errs) {
+		return fmt.Errorf("exiting due to validation errors")
+	}
+	qualifyTargets(cfg)
+
+	items := inventoryItems(cfg, &args.FilterArgs)
+	switch {
+	case args.JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(inventory{SchemaVersion: inventorySchemaVersion, Records: items})
+	case args.OpenMetrics:
+		writeInventoryMetrics(w, items)
+	default:
+		for _, it := range items {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n", it.Domain, it.FQDN, it.TTL, it.Type, it.Target, strings.Join(it.DNSProviders, ","))
+		}
+	}
+	return nil
+}
+
+// inventoryItems flattens the records of the domains that filter selects,
+// in the order of the domains in dnsconfig.js and, in each domain, sorted
+// by FQDN, type and target so that the output is stable.
+func inventoryItems(cfg *models.DNSConfig, filter *FilterArgs) []inventoryItem {
+	items := []inventoryItem{}
+	for _, dc := range cfg.Domains {
+		if !filter.shouldRunDomain(dc.GetUniqueName()) {
+			continue
+		}
+		var providers []string
+		for name := range dc.DNSProviderNames {
+			providers = append(providers, name)
+		}
+		sort.Strings(providers)
+		var domainMeta map[string]string
+		for k, v := range dc.Metadata {
+			if strings.HasPrefix(k, "dnscontrol_") {
+				continue // the uniquename and tag, which are fields
+			}
+			if domainMeta == nil {
+				domainMeta = map[string]string{}
+			}
+			domainMeta[k] = v
+		}
+
+		start := len(items)
+		for _, rec := range dc.Records {
+			recProviders := providers
+			if only := rec.OnlyProviders(); only != nil {
+				recProviders = nil
+				for _, p := range providers {
+					if slices.Contains(only, p) {
+						recProviders = append(recProviders, p)
+					}
+				}
+			}
+			items = append(items, inventoryItem{
+				Domain:         dc.Name,
+				Tag:            dc.Metadata[models.DomainTag],
+				FQDN:           rec.GetLabelFQDN(),
+				Name:           rec.GetLabel(),
+				Type:           rec.Type,
+				TTL:            rec.TTL,
+				Target:         rec.GetTargetCombinedFunc(nil),
+				Fields:         inventoryFields(rec),
+				Registrar:      dc.RegistrarName,
+				DNSProviders:   append([]string{}, recProviders...),
+				Metadata:       rec.Metadata,
+				DomainMetadata: domainMeta,
+			})
+		}
+		domainItems := items[start:]
+		sort.SliceStable(domainItems, func(i, j int) bool {
+			a, b := domainItems[i], domainItems[j]
+			if a.FQDN != b.FQDN {
+				return a.FQDN < b.FQDN
+			}
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+			return a.Target < b.Target
+		})
+	}
+	return items
+}
+
+// inventoryFields returns the rdata fields of the record by their
+// (lowercase) names in miekg/dns, such as "preference" and "mx" for an MX
+// record. It returns nil for the types that miekg/dns doesn't know, such
+// as ALIAS.
+func inventoryFields(rec *models.RecordConfig) map[string]string {
+	if _, ok := dns.StringToType[rec.Type]; !ok {
+		return nil
+	}
+	rr := rec.ToRR()
+	st := reflect.TypeOf(rr).Elem()
+	fields := map[string]string{}
+	for i := 1; i <= dns.NumField(rr); i++ {
+		// Field 0 of each RR struct is the header.
+		fields[strings.ToLower(st.Field(i).Name)] = dns.Field(rr, i)
+	}
+	return fields
+}
+
+// writeInventoryMetrics writes the inventory in the OpenMetrics text
+// format: an info metric per record.
+func writeInventoryMetrics(w io.Writer, items []inventoryItem) {
+	fmt.Fprintf(w, "# HELP dnscontrol_record_info A record managed by DNSControl.\n# TYPE dnscontrol_record_info gauge\n")
+	for _, it := range items {
+		fmt.Fprintf(w, "dnscontrol_record_info{%s} 1\n", labels(
+			"domain", it.Domain,
+			"fqdn", it.FQDN,
+			"type", it.Type,
+			"target", it.Target,
+			"ttl", fmt.Sprint(it.TTL),
+			"registrar", it.Registrar,
+			"dns_providers", strings.Join(it.DNSProviders, ","),
+		))
+	}
+	fmt.Fprintf(w, "# EOF\n")
+}
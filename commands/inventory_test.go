@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_inventoryItems(t *testing.T) {
+	rec := func(typ, label, target string, meta map[string]string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: typ, TTL: 300, Metadata: meta}
+		rc.SetLabel(label, "example.com")
+		rc.SetTarget(target)
+		return rc
+	}
+	mx := rec("MX", "@", "mx.example.com.", nil)
+	mx.MxPreference = 10
+	dc := &models.DomainConfig{
+		Name:             "example.com!internal",
+		RegistrarName:    "none",
+		DNSProviderNames: map[string]int{"r53": -1, "bind": -1},
+		Metadata:         map[string]string{"environment": "prod"},
+		Records: models.Records{
+			rec("A", "www", "192.0.2.1", nil),
+			mx,
+			rec("ALIAS", "@", "lb.example.net.", map[string]string{"only_providers": "r53"}),
+		},
+	}
+	dc.UpdateSplitHorizonNames()
+	other := &models.DomainConfig{Name: "example.org"}
+	other.UpdateSplitHorizonNames()
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{dc, other}}
+
+	items := inventoryItems(cfg, &FilterArgs{Domains: "example.com!internal"})
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3: %+v", len(items), items)
+	}
+	// Sorted by FQDN, then type.
+	var got []string
+	for _, it := range items {
+		got = append(got, it.FQDN+" "+it.Type)
+	}
+	if want := []string{"example.com ALIAS", "example.com MX", "www.example.com A"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	alias, mxItem, a := items[0], items[1], items[2]
+	if a.Domain != "example.com" || a.Tag != "internal" || a.Name != "www" || a.Registrar != "none" {
+		t.Errorf("unexpected item: %+v", a)
+	}
+	if want := []string{"bind", "r53"}; !reflect.DeepEqual(a.DNSProviders, want) {
+		t.Errorf("got DNS providers %v, want %v", a.DNSProviders, want)
+	}
+	if want := []string{"r53"}; !reflect.DeepEqual(alias.DNSProviders, want) {
+		t.Errorf("ONLY_PROVIDERS: got DNS providers %v, want %v", alias.DNSProviders, want)
+	}
+	if alias.Fields != nil {
+		t.Errorf("ALIAS: got fields %v, want none", alias.Fields)
+	}
+	if want := map[string]string{"preference": "10", "mx": "mx.example.com."}; !reflect.DeepEqual(mxItem.Fields, want) {
+		t.Errorf("MX: got fields %v, want %v", mxItem.Fields, want)
+	}
+	if mxItem.Target != "10 mx.example.com." {
+		t.Errorf("MX: got target %q", mxItem.Target)
+	}
+	if want := map[string]string{"environment": "prod"}; !reflect.DeepEqual(a.DomainMetadata, want) {
+		t.Errorf("got domain metadata %v, want %v", a.DomainMetadata, want)
+	}
+}
+
+func Test_writeInventoryMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	writeInventoryMetrics(&buf, []inventoryItem{{
+		Domain: "example.com", FQDN: "example.com", Type: "TXT", TTL: 300, Target: `"v=spf1 -all"`,
+		Registrar: "none", DNSProviders: []string{"bind", "r53"},
+	}})
+	want := `dnscontrol_record_info{domain="example.com",fqdn="example.com",type="TXT",target="\"v=spf1 -all\"",ttl="300",registrar="none",dns_providers="bind,r53"} 1`
+	if !strings.Contains(buf.String(), want+"\n") || !strings.HasSuffix(buf.String(), "# EOF\n") {
+		t.Errorf("got\n%s\nwant a line\n%s", buf.String(), want)
+	}
+}
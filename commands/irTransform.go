@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// IRTransform reshapes the IR that print-ir outputs. It is given the
+// config, after validation and normalization (unless --raw is given), and
+// returns the value that is output as JSON instead, with or without
+// --pretty. It may change cfg: print-ir outputs it and exits.
+type IRTransform func(cfg *models.DNSConfig) (any, error)
+
+// irTransforms stores the IR transforms by name.
+var irTransforms = map[string]IRTransform{}
+
+// RegisterIRTransform adds an IR transform that print-ir --ir-transform=name
+// applies. It is meant to be called from the init() of a package that is
+// compiled into a dnscontrol binary of your own, like an out-of-tree
+// provider.
+func RegisterIRTransform(name string, fn IRTransform) {
+	if _, ok := irTransforms[name]; ok {
+		log.Fatalf("Cannot register IR transform %q multiple times", name)
+	}
+	irTransforms[name] = fn
+}
+
+// applyIRTransform returns the output of the IR transform name for cfg.
+func applyIRTransform(name string, cfg *models.DNSConfig) (any, error) {
+	fn, ok := irTransforms[name]
+	if !ok {
+		names := make([]string, 0, len(irTransforms))
+		for n := range irTransforms {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("unknown IR transform %q (none are registered)", name)
+		}
+		return nil, fmt.Errorf("unknown IR transform %q (registered: %s)", name, strings.Join(names, ", "))
+	}
+	out, err := fn(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("IR transform %q: %w", name, err)
+	}
+	return out, nil
+}
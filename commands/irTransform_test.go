@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_applyIRTransform(t *testing.T) {
+	saved := irTransforms
+	defer func() { irTransforms = saved }()
+	irTransforms = map[string]IRTransform{}
+
+	type flatRecord struct {
+		FQDN, Type, Value string
+	}
+	RegisterIRTransform("flat", func(cfg *models.DNSConfig) (any, error) {
+		var recs []flatRecord
+		for _, dc := range cfg.Domains {
+			for _, rc := range dc.Records {
+				recs = append(recs, flatRecord{rc.GetLabelFQDN(), rc.Type, rc.GetTargetField()})
+			}
+		}
+		return recs, nil
+	})
+	RegisterIRTransform("broken", func(cfg *models.DNSConfig) (any, error) {
+		return nil, errors.New("no")
+	})
+
+	rc := &models.RecordConfig{Type: "A"}
+	rc.SetLabel("www", "example.com")
+	rc.SetTarget("1.2.3.4")
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{{Name: "example.com", Records: models.Records{rc}}}}
+
+	got, err := applyIRTransform("flat", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []flatRecord{{"www.example.com", "A", "1.2.3.4"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flat = %v, want %v", got, want)
+	}
+
+	if _, err := applyIRTransform("broken", cfg); err == nil || err.Error() != `IR transform "broken": no` {
+		t.Errorf("broken: err = %v", err)
+	}
+	if _, err := applyIRTransform("missing", cfg); err == nil || !strings.Contains(err.Error(), "registered: broken, flat") {
+		t.Errorf("missing: err = %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// junitReport is the result of each domain of a run, as the test cases of
+// a JUnit XML report. It is the runReporter of --junit.
+type junitReport struct {
+	nopReporter
+	file    string
+	command string // "preview" or "push"
+	start   time.Time
+
+	mu      sync.Mutex
+	domains []string // in the order they were run
+	cases   map[string]*junitCase
+}
+
+// junitCase is the result of one domain.
+type junitCase struct {
+	elapsed time.Duration
+	changes []string // "provider: change"
+	errors  []string
+	failed  bool
+	skipped string // why the domain wasn't run, or ""
+}
+
+func newJUnitReport(file string, push bool) *junitReport {
+	command := "preview"
+	if push {
+		command = "push"
+	}
+	return &junitReport{file: file, command: command, start: time.Now(), cases: map[string]*junitCase{}}
+}
+
+// domain returns the result of the domain, adding it if it is new. The
+// caller holds r.mu.
+func (r *junitReport) domain(domain string) *junitCase {
+	c, ok := r.cases[domain]
+	if !ok {
+		c = &junitCase{}
+		r.cases[domain] = c
+		r.domains = append(r.domains, domain)
+	}
+	return c
+}
+
+// addChanges records the corrections of the zone, one change per line of
+// their messages, without their colors.
+func (r *junitReport) addChanges(z zoneChanges) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.domain(z.domain())
+	for _, corr := range z.corrections {
+		for _, line := range strings.Split(corr.Msg, "\n") {
+			if line = strings.TrimSpace(colorCodes.ReplaceAllString(line, "")); line != "" {
+				c.changes = append(c.changes, z.provider+": "+line)
+			}
+		}
+	}
+}
+
+// addError records an error of the domain at the provider.
+func (r *junitReport) addError(domain, provider string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.domain(domain)
+	c.errors = append(c.errors, fmt.Sprintf("%s: %s", provider, err))
+}
+
+// domainDone records how long the domain took, and whether it failed.
+func (r *junitReport) domainDone(domain string, elapsed time.Duration, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.domain(domain)
+	c.elapsed = elapsed
+	c.failed = c.failed || failed
+}
+
+// skipDomain records that the domain wasn't run, and why.
+func (r *junitReport) skipDomain(domain, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.domain(domain).skipped = reason
+}
+
+// finish writes the report to its file.
+func (r *junitReport) finish(out printer.CLI) error {
+	f, err := os.Create(r.file)
+	if err != nil {
+		return fmt.Errorf("writing the JUnit report: %w", err)
+	}
+	err = r.write(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("writing the JUnit report: %w", err)
+	}
+	return nil
+}
+
+// The elements of a JUnit XML report, as CI servers read them.
+type (
+	junitTestSuites struct {
+		XMLName xml.Name        `xml:"testsuites"`
+		Suites  []junitXMLSuite `xml:"testsuite"`
+	}
+	junitXMLSuite struct {
+		Name      string         `xml:"name,attr"`
+		Tests     int            `xml:"tests,attr"`
+		Failures  int            `xml:"failures,attr"`
+		Skipped   int            `xml:"skipped,attr"`
+		Time      string         `xml:"time,attr"`
+		Timestamp string         `xml:"timestamp,attr"`
+		Cases     []junitXMLCase `xml:"testcase"`
+	}
+	junitXMLCase struct {
+		Name      string           `xml:"name,attr"`
+		Classname string           `xml:"classname,attr"`
+		Time      string           `xml:"time,attr"`
+		Failure   *junitXMLMessage `xml:"failure,omitempty"`
+		Skipped   *junitXMLMessage `xml:"skipped,omitempty"`
+		SystemOut string           `xml:"system-out,omitempty"`
+	}
+	junitXMLMessage struct {
+		Message string `xml:"message,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// write writes the report as JUnit XML: one test suite, with one test
+// case per domain. A domain with errors is a failure; its changes are its
+// output.
+func (r *junitReport) write(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	suite := junitXMLSuite{
+		Name:      "dnscontrol " + r.command,
+		Tests:     len(r.domains),
+		Time:      junitSeconds(time.Since(r.start)),
+		Timestamp: r.start.UTC().Format("2006-01-02T15:04:05"),
+	}
+	for _, d := range r.domains {
+		c := r.cases[d]
+		tc := junitXMLCase{
+			Name:      d,
+			Classname: "dnscontrol." + r.command,
+			Time:      junitSeconds(c.elapsed),
+			SystemOut: strings.Join(c.changes, "\n"),
+		}
+		switch {
+		case c.skipped != "":
+			suite.Skipped++
+			tc.Skipped = &junitXMLMessage{Message: c.skipped}
+		case c.failed || len(c.errors) > 0:
+			suite.Failures++
+			msg := "errors occurred; see the log"
+			if len(c.errors) > 0 {
+				msg = c.errors[0]
+			}
+			tc.Failure = &junitXMLMessage{Message: msg, Text: strings.Join(c.errors, "\n")}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitTestSuites{Suites: []junitXMLSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitSeconds formats d in seconds, as the time attributes are.
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_junitReport(t *testing.T) {
+	r := newJUnitReport("junit.xml", true)
+	r.addChanges(zoneChanges{dc: testDomain("example.com"), provider: "bind", providerType: "BIND", corrections: []*models.Correction{
+		{Msg: "\x1b[32m+ CREATE www.example.com A 1.2.3.4 ttl=300\x1b[0m"},
+	}})
+	r.domainDone("example.com", 1500*time.Millisecond, false)
+	r.addError("broken.example", "r53", fmt.Errorf("access <denied>"))
+	r.domainDone("broken.example", 0, true)
+	r.skipDomain("late.example", "not started before the timeout (--timeout)")
+
+	var buf bytes.Buffer
+	if err := r.write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<testsuite name="dnscontrol push" tests="3" failures="1" skipped="1" `,
+		`<testcase name="example.com" classname="dnscontrol.push" time="1.500">`,
+		`<system-out>bind: + CREATE www.example.com A 1.2.3.4 ttl=300</system-out>`,
+		`<failure message="r53: access &lt;denied&gt;">r53: access &lt;denied&gt;</failure>`,
+		`<skipped message="not started before the timeout (--timeout)"></skipped>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "example.com") > strings.Index(got, "broken.example") {
+		t.Errorf("the domains aren't in the order they were run:\n%s", got)
+	}
+}
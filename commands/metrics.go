@@ -0,0 +1,202 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/urfave/cli/v2"
+)
+
+// MetricsArgs encapsulates the flag that writes the metrics of a run.
+type MetricsArgs struct {
+	MetricsFile string
+}
+
+func (args *MetricsArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "metrics-file",
+			Destination: &args.MetricsFile,
+			Usage:       `Write metrics of the run (changes, durations, API calls, errors) to this file in the Prometheus text format`,
+		},
+	}
+}
+
+// runMetrics are the metrics of one preview or push, a runReporter.
+type runMetrics struct {
+	nopReporter
+	file  string
+	start time.Time
+	push  bool
+
+	mu        sync.Mutex
+	changes   map[labelSet]int // domain, provider, kind, rtype
+	durations map[string]time.Duration
+	apiCalls  map[labelSet]int // provider, call
+	errors    map[labelSet]int // domain, provider
+}
+
+// labelSet is the label values of a metric; unused ones are "".
+type labelSet [4]string
+
+func newRunMetrics(args MetricsArgs, push bool) *runMetrics {
+	return &runMetrics{
+		file:      args.MetricsFile,
+		start:     time.Now(),
+		push:      push,
+		changes:   map[labelSet]int{},
+		durations: map[string]time.Duration{},
+		apiCalls:  map[labelSet]int{},
+		errors:    map[labelSet]int{},
+	}
+}
+
+// addChanges counts the changes of the corrections by kind ("create",
+// "modify", "delete" or "other") and record type. A correction that makes
+// several changes lists them on separate lines of its message.
+func (m *runMetrics) addChanges(z zoneChanges) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range z.corrections {
+		for _, line := range strings.Split(c.Msg, "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			kind, rtype := classifyChange(line)
+			m.changes[labelSet{z.domain(), z.provider, kind, rtype}]++
+		}
+	}
+}
+
+// classifyChange returns the kind and record type of a line of a
+// correction message, such as "+ CREATE www.example.com A 1.2.3.4".
+func classifyChange(line string) (kind, rtype string) {
+	fields := strings.Fields(line)
+	for i, f := range fields {
+		switch {
+		case strings.HasSuffix(f, "CREATE"):
+			kind = "create"
+		case strings.HasSuffix(f, "DELETE"):
+			kind = "delete"
+		case strings.HasSuffix(f, "MODIFY"), strings.HasSuffix(f, "MODIFY-TTL"):
+			kind = "modify"
+		default:
+			continue
+		}
+		if i+2 < len(fields) {
+			rtype = fields[i+2]
+		}
+		return kind, rtype
+	}
+	return "other", ""
+}
+
+// apiCall counts a call of the provider's API, e.g. "GetZoneRecords".
+func (m *runMetrics) apiCall(provider, call string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.apiCalls[labelSet{provider, call}]++
+}
+
+// addError counts an error of the domain at the provider.
+func (m *runMetrics) addError(domain, provider string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors[labelSet{domain, provider}]++
+}
+
+// domainDone records how long the domain took.
+func (m *runMetrics) domainDone(domain string, elapsed time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.durations[domain] += elapsed
+}
+
+// finish writes the metrics to the file.
+func (m *runMetrics) finish(out printer.CLI) error {
+	f, err := os.Create(m.file)
+	if err != nil {
+		return fmt.Errorf("writing metrics: %w", err)
+	}
+	m.write(f, time.Now())
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing metrics: %w", err)
+	}
+	return nil
+}
+
+// write writes the metrics in the Prometheus text exposition format. The
+// values are those of this run, so they are gauges rather than counters.
+func (m *runMetrics) write(w io.Writer, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	gauge := func(name, help string) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	}
+	push := 0
+	if m.push {
+		push = 1
+	}
+	gauge("dnscontrol_run_timestamp_seconds", "When the run ended.")
+	fmt.Fprintf(w, "dnscontrol_run_timestamp_seconds %d\n", now.Unix())
+	gauge("dnscontrol_run_duration_seconds", "How long the run took.")
+	fmt.Fprintf(w, "dnscontrol_run_duration_seconds %g\n", now.Sub(m.start).Seconds())
+	gauge("dnscontrol_run_push", "1 if the run was a push, 0 if it was a preview.")
+	fmt.Fprintf(w, "dnscontrol_run_push %d\n", push)
+
+	gauge("dnscontrol_changes", "Changes found (preview) or made (push), by kind and record type.")
+	for _, k := range sortedLabelSets(m.changes) {
+		fmt.Fprintf(w, "dnscontrol_changes{%s} %d\n", labels("domain", k[0], "provider", k[1], "kind", k[2], "rtype", k[3]), m.changes[k])
+	}
+	gauge("dnscontrol_domain_duration_seconds", "How long each domain took.")
+	domains := make([]string, 0, len(m.durations))
+	for d := range m.durations {
+		domains = append(domains, d)
+	}
+	sort.Strings(domains)
+	for _, d := range domains {
+		fmt.Fprintf(w, "dnscontrol_domain_duration_seconds{%s} %g\n", labels("domain", d), m.durations[d].Seconds())
+	}
+	gauge("dnscontrol_provider_api_calls", "Calls of the providers' APIs.")
+	for _, k := range sortedLabelSets(m.apiCalls) {
+		fmt.Fprintf(w, "dnscontrol_provider_api_calls{%s} %d\n", labels("provider", k[0], "call", k[1]), m.apiCalls[k])
+	}
+	gauge("dnscontrol_errors", "Errors, by domain and provider.")
+	for _, k := range sortedLabelSets(m.errors) {
+		fmt.Fprintf(w, "dnscontrol_errors{%s} %d\n", labels("domain", k[0], "provider", k[1]), m.errors[k])
+	}
+}
+
+func sortedLabelSets(m map[labelSet]int) []labelSet {
+	keys := make([]labelSet, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		for n := range keys[i] {
+			if keys[i][n] != keys[j][n] {
+				return keys[i][n] < keys[j][n]
+			}
+		}
+		return false
+	})
+	return keys
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labels formats name/value pairs as Prometheus labels.
+func labels(nameValues ...string) string {
+	var parts []string
+	for i := 0; i < len(nameValues); i += 2 {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, nameValues[i], labelEscaper.Replace(nameValues[i+1])))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_classifyChange(t *testing.T) {
+	tests := []struct{ line, kind, rtype string }{
+		{"+ CREATE www.example.com A 1.2.3.4 ttl=300", "create", "A"},
+		{"- DELETE www.example.com MX 10 mx.example.com. ttl=300", "delete", "MX"},
+		{"± MODIFY www.example.com TXT (\"a\") -> (\"b\")", "modify", "TXT"},
+		{"± MODIFY-TTL www.example.com AAAA 2001:db8::1", "modify", "AAAA"},
+		{"\x1b[32m+ CREATE www.example.com CNAME foo.\x1b[0m", "create", "CNAME"},
+		{"WRITING ZONEFILE: zones/example.com.zone", "other", ""},
+	}
+	for _, tst := range tests {
+		if kind, rtype := classifyChange(tst.line); kind != tst.kind || rtype != tst.rtype {
+			t.Errorf("classifyChange(%q) = %q, %q; want %q, %q", tst.line, kind, rtype, tst.kind, tst.rtype)
+		}
+	}
+}
+
+func Test_runMetrics(t *testing.T) {
+	m := newRunMetrics(MetricsArgs{MetricsFile: "metrics.prom"}, true)
+	m.start = time.Unix(1000, 0)
+	m.addChanges(zoneChanges{dc: testDomain("example.com"), provider: "bind", corrections: []*models.Correction{
+		{Msg: "+ CREATE a.example.com A 1.2.3.4\n+ CREATE b.example.com A 1.2.3.5"},
+		{Msg: "- DELETE c.example.com TXT \"x\""},
+	}})
+	m.apiCall("bind", "GetZoneRecords")
+	m.addError("example.com", `we"ird`, errors.New("boom"))
+	m.domainDone("example.com", 1500*time.Millisecond, true)
+
+	var buf bytes.Buffer
+	m.write(&buf, time.Unix(1003, 0))
+	for _, want := range []string{
+		"dnscontrol_run_timestamp_seconds 1003\n",
+		"dnscontrol_run_duration_seconds 3\n",
+		"dnscontrol_run_push 1\n",
+		`dnscontrol_changes{domain="example.com",provider="bind",kind="create",rtype="A"} 2` + "\n",
+		`dnscontrol_changes{domain="example.com",provider="bind",kind="delete",rtype="TXT"} 1` + "\n",
+		`dnscontrol_domain_duration_seconds{domain="example.com"} 1.5` + "\n",
+		`dnscontrol_provider_api_calls{provider="bind",call="GetZoneRecords"} 1` + "\n",
+		`dnscontrol_errors{domain="example.com",provider="we\"ird"} 1` + "\n",
+		"# TYPE dnscontrol_changes gauge\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, buf.String())
+		}
+	}
+}
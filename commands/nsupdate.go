@@ -0,0 +1,255 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/miekg/dns"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args NsupdateArgs
+	return &cli.Command{
+		Name:  "nsupdate",
+		Usage: "Output the nsupdate (RFC 2136) script that turns the zones of a DNS server into dnsconfig.js",
+		Action: func(c *cli.Context) error {
+			return exit(Nsupdate(args))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol nsupdate [command options]",
+		Description: `Transfer the zones of dnsconfig.js from --server by AXFR, compare them to
+dnsconfig.js, and output the commands of nsupdate that make the changes.
+creds.json is not read and nothing is changed: pipe the script into nsupdate.
+
+Each zone is one update. The RRsets that it changes are prerequisites with
+their current records, so that the update fails, and changes nothing, if
+the zone was changed since the script was made.
+
+--key is the TSIG key, either as "algorithm:name:secret" (Ex:
+hmac-sha256:update:c2VjcmV0) or as "@FILE" to read a BIND key file. It is
+used for the transfer, and written in the script for the update.
+
+EXAMPLES:
+   dnscontrol nsupdate --server ns1.example.com --key @update.key | nsupdate
+   dnscontrol nsupdate --server ns1.example.com:5353 --domains example.com --out update.txt`,
+	}
+}())
+
+// NsupdateArgs encapsulates the flags/arguments for the nsupdate command.
+type NsupdateArgs struct {
+	GetDNSConfigArgs
+	FilterArgs
+	Server     string // host[:port] to transfer the zones from and send the updates to
+	Key        string // TSIG key: "algorithm:name:secret" or "@file"
+	OutputFile string
+}
+
+func (args *NsupdateArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, &cli.StringFlag{
+		Name:        "domains",
+		Destination: &args.Domains,
+		Usage:       `Comma separated list of domain names to include`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "server",
+		Destination: &args.Server,
+		Usage:       `DNS server to transfer the zones from and to update (host or host:port)`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "key",
+		Destination: &args.Key,
+		Usage:       `TSIG key as algorithm:name:secret, or @FILE to read a BIND key file`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "out",
+		Destination: &args.OutputFile,
+		Usage:       `Instead of stdout, write to this file`,
+	})
+	return flags
+}
+
+// nsupdateZoneRecords returns the records of zone at the server of the
+// AXFRDDNS config. Tests replace it.
+var nsupdateZoneRecords = func(config map[string]string, zone string) (models.Records, error) {
+	provider, err := providers.CreateDNSProvider("AXFRDDNS", config, nil)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetZoneRecords(zone, nil)
+}
+
+// Nsupdate implements the nsupdate subcommand.
+func Nsupdate(args NsupdateArgs) error {
+	if args.Server == "" {
+		return fmt.Errorf("nsupdate requires --server")
+	}
+	config, err := axfrProviderConfig(args.Server, args.Key)
+	if err != nil {
+		return err
+	}
+	opts, err := args.GetDNSConfigArgs.normalizeOptions()
+	if err != nil {
+		return err
+	}
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		return err
+	}
+	errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
+	if PrintValidationErrors(errs) {
+		return fmt.Errorf("exiting due to validation errors")
+	}
+	qualifyTargets(cfg)
+
+	w := os.Stdout
+	if args.OutputFile != "" {
+		if w, err = os.Create(args.OutputFile); err != nil {
+			return err
+		}
+		defer w.Close()
+	}
+	fmt.Fprintf(w, "server %s\n", nsupdateServer(args.Server))
+	if key := config["transfer-key"]; key != "" {
+		algo, rest, _ := strings.Cut(key, ":")
+		name, secret, _ := strings.Cut(rest, ":")
+		fmt.Fprintf(w, "key %s:%s %s\n", algo, name, secret)
+	}
+	for _, dc := range cfg.Domains {
+		if !args.shouldRunDomain(dc.GetUniqueName()) {
+			continue
+		}
+		existing, err := nsupdateZoneRecords(config, dc.Name)
+		if err != nil {
+			return fmt.Errorf("transferring %s from %s: %w", dc.Name, args.Server, err)
+		}
+		if err := writeNsupdate(w, dc, existing); err != nil {
+			return fmt.Errorf("%s: %w", dc.GetUniqueName(), err)
+		}
+	}
+	return nil
+}
+
+// nsupdateServer returns the argument of the server command of nsupdate:
+// "host port".
+func nsupdateServer(server string) string {
+	host, port, err := net.SplitHostPort(server)
+	if err != nil {
+		return server
+	}
+	return host + " " + port
+}
+
+// writeNsupdate writes the update that turns the existing records of the
+// zone of dc into its records. The SOA is left to the server, as the
+// AXFRDDNS provider does.
+func writeNsupdate(w io.Writer, dc *models.DomainConfig, existing models.Records) error {
+	var found models.Records
+	for _, rec := range existing {
+		if rec.Type != "SOA" {
+			found = append(found, rec)
+		}
+	}
+	for _, rec := range dc.Records {
+		if _, ok := dns.StringToType[rec.Type]; !ok || rec.Type == "SOA" {
+			return fmt.Errorf("%s %s records can't be written as nsupdate commands", rec.GetLabelFQDN(), rec.Type)
+		}
+	}
+	changes, err := diff2.ByRecord(found, dc, nil)
+	if err != nil {
+		return err
+	}
+
+	// The prerequisites: each RRset that is changed must have the records
+	// it has now, or none.
+	var keys []models.RecordKey
+	touched := map[models.RecordKey]bool{}
+	var reports []string
+	var nsAdds, deletes, adds []*models.RecordConfig
+	apex := dc.Name
+	for _, change := range changes {
+		if change.Type == diff2.REPORT {
+			reports = append(reports, change.Msgs...)
+			continue
+		}
+		for _, recs := range []models.Records{change.Old, change.New} {
+			for _, rec := range recs {
+				if k := rec.Key(); !touched[k] {
+					touched[k] = true
+					keys = append(keys, k)
+				}
+			}
+		}
+		// An update can't delete the last NS record of the zone, so the
+		// new apex NS records are added before the old ones are deleted.
+		// An apex NS record that is added again (e.g. with another TTL)
+		// isn't deleted: the delete would remove the record just added,
+		// and the add alone updates its TTL.
+		readded := map[string]bool{}
+		for _, rec := range change.New {
+			if rec.Type == "NS" && rec.GetLabelFQDN() == apex {
+				nsAdds = append(nsAdds, rec)
+				readded[nsupdateRR(rec, false)] = true
+			} else {
+				adds = append(adds, rec)
+			}
+		}
+		for _, rec := range change.Old {
+			if rec.Type == "NS" && readded[nsupdateRR(rec, false)] {
+				continue
+			}
+			deletes = append(deletes, rec)
+		}
+	}
+
+	for _, msg := range reports {
+		fmt.Fprintf(w, "; %s\n", msg)
+	}
+	n := len(deletes) + len(nsAdds) + len(adds)
+	if n == 0 {
+		fmt.Fprintf(w, "; %s: no changes\n", dc.Name)
+		return nil
+	}
+	fmt.Fprintf(w, "; %s: %d %s\n", dc.Name, n, plural(n, "record to delete or add", "records to delete or add"))
+	fmt.Fprintf(w, "zone %s.\n", dc.Name)
+	current := found.GroupedByKey()
+	for _, k := range keys {
+		if len(current[k]) == 0 {
+			fmt.Fprintf(w, "prereq nxrrset %s. IN %s\n", k.NameFQDN, k.Type)
+			continue
+		}
+		for _, rec := range current[k] {
+			fmt.Fprintf(w, "prereq yxrrset %s\n", nsupdateRR(rec, false))
+		}
+	}
+	for _, rec := range nsAdds {
+		fmt.Fprintf(w, "update add %s\n", nsupdateRR(rec, true))
+	}
+	for _, rec := range deletes {
+		fmt.Fprintf(w, "update delete %s\n", nsupdateRR(rec, false))
+	}
+	for _, rec := range adds {
+		fmt.Fprintf(w, "update add %s\n", nsupdateRR(rec, true))
+	}
+	fmt.Fprintf(w, "send\n")
+	return nil
+}
+
+// nsupdateRR returns rec as the "name [ttl] class type data" of an
+// nsupdate command.
+func nsupdateRR(rec *models.RecordConfig, withTTL bool) string {
+	rr := rec.ToRR()
+	data := strings.TrimPrefix(rr.String(), rr.Header().String())
+	if withTTL {
+		return fmt.Sprintf("%s %d IN %s %s", rr.Header().Name, rr.Header().Ttl, rec.Type, data)
+	}
+	return fmt.Sprintf("%s IN %s %s", rr.Header().Name, rec.Type, data)
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_writeNsupdate(t *testing.T) {
+	existing := models.Records{
+		makeRec("SOA", "@", "ns1.example.com. hostmaster.example.com. 1 3600 600 604800 300"),
+		makeRec("NS", "@", "ns1.example.com."),
+		makeRec("A", "@", "192.0.2.1"),
+		makeRec("A", "www", "192.0.2.1"),
+		makeRec("A", "www", "192.0.2.2"),
+		makeRec("TXT", "old", "gone"),
+	}
+	dc := &models.DomainConfig{Name: "example.com", Records: models.Records{
+		makeRec("NS", "@", "ns2.example.com."),
+		makeRec("A", "@", "192.0.2.1"),
+		makeRec("A", "www", "192.0.2.1"),
+		makeRec("A", "www", "192.0.2.3"),
+		makeRec("MX", "@", "10 mail.example.com."),
+	}}
+	dc.UpdateSplitHorizonNames()
+
+	var b strings.Builder
+	if err := writeNsupdate(&b, dc, existing); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"zone example.com.\n",
+		"prereq yxrrset example.com. IN NS ns1.example.com.\n",
+		"prereq yxrrset www.example.com. IN A 192.0.2.1\nprereq yxrrset www.example.com. IN A 192.0.2.2\n",
+		"prereq nxrrset example.com. IN MX\n",
+		"update delete www.example.com. IN A 192.0.2.2\n",
+		"update add www.example.com. 300 IN A 192.0.2.3\n",
+		"update delete old.example.com. IN TXT \"gone\"\n",
+		"update add example.com. 300 IN MX 10 mail.example.com.\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "SOA") {
+		t.Errorf("the SOA should be left alone:\n%s", got)
+	}
+	// The apex A record and the A record of www that don't change have no
+	// command of their own.
+	if strings.Contains(got, "192.0.2.1\nupdate") || strings.Contains(got, "update delete example.com. IN A") {
+		t.Errorf("unchanged records were updated:\n%s", got)
+	}
+	// New NS records are added before the old ones are deleted.
+	if add, del := strings.Index(got, "update add example.com. 300 IN NS"), strings.Index(got, "update delete example.com. IN NS"); add < 0 || del < add {
+		t.Errorf("the new NS must be added before the old one is deleted:\n%s", got)
+	}
+	if !strings.HasSuffix(got, "send\n") {
+		t.Errorf("missing send:\n%s", got)
+	}
+
+	b.Reset()
+	if err := writeNsupdate(&b, dc, append(dc.Records, existing[0])); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != "; example.com: no changes\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func Test_writeNsupdateApexNSTTL(t *testing.T) {
+	existing := models.Records{
+		makeRec("NS", "@", "ns1.example.com."),
+		makeRec("NS", "@", "ns2.example.com."),
+	}
+	dc := &models.DomainConfig{Name: "example.com", Records: models.Records{
+		makeRec("NS", "@", "ns1.example.com."),
+		makeRec("NS", "@", "ns2.example.com."),
+	}}
+	for _, rec := range dc.Records {
+		rec.TTL = 86400
+	}
+	dc.UpdateSplitHorizonNames()
+
+	var b strings.Builder
+	if err := writeNsupdate(&b, dc, existing); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"update add example.com. 86400 IN NS ns1.example.com.\n",
+		"update add example.com. 86400 IN NS ns2.example.com.\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	// Deleting the records that were just added would leave the zone
+	// without its NS records.
+	if strings.Contains(got, "update delete example.com. IN NS") {
+		t.Errorf("the re-added NS records are deleted:\n%s", got)
+	}
+}
+
+func Test_nsupdateServer(t *testing.T) {
+	for server, want := range map[string]string{
+		"ns1.example.com":      "ns1.example.com",
+		"ns1.example.com:5353": "ns1.example.com 5353",
+		"[2001:db8::1]:53":     "2001:db8::1 53",
+	} {
+		if got := nsupdateServer(server); got != want {
+			t.Errorf("nsupdateServer(%q) = %q, want %q", server, got, want)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catMain, func() *cli.Command {
+	var args PlanArgs
+	return &cli.Command{
+		Name:  "plan",
+		Usage: "preview the changes, and save them in a plan file that apply makes",
+		Action: func(ctx *cli.Context) error {
+			return exit(Plan(args))
+		},
+		Flags: args.flags(),
+		Description: `Like preview, and also save the corrections and the state of each zone in
+a plan file (JSON). "dnscontrol apply" makes exactly these corrections, and
+refuses a zone whose records at the provider, or whose corrections, aren't
+those of the plan.
+
+EXAMPLES:
+   dnscontrol plan --out dns.plan
+   dnscontrol apply dns.plan`,
+	}
+}())
+
+// PlanArgs contains all data/flags needed to run plan, independently of CLI.
+type PlanArgs struct {
+	PreviewArgs
+	PlanFile string
+}
+
+func (args *PlanArgs) flags() []cli.Flag {
+	flags := args.PreviewArgs.flags()
+	flags = append(flags, &cli.StringFlag{
+		Name:        "out",
+		Value:       "dnscontrol.plan",
+		Destination: &args.PlanFile,
+		Usage:       "File to write the plan to",
+	})
+	return flags
+}
+
+var _ = cmd(catMain, func() *cli.Command {
+	var args ApplyArgs
+	return &cli.Command{
+		Name:  "apply",
+		Usage: "make the changes of a plan file made by plan",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return cli.Exit("Arguments should be: planfile (Ex: dnscontrol.plan)", 1)
+			}
+			args.PlanFile = ctx.Args().First()
+			return exit(Apply(args))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol apply [command options] planfile",
+		Description: `Push the corrections that "dnscontrol plan" saved in planfile.
+
+dnsconfig.js is run again, with the same flags as plan, and the corrections
+of each zone are computed again: the closures that make them can't be saved.
+A zone is only changed if its records at the provider are those that plan
+saw (their hash is in the plan) and its corrections are those of the plan.
+Otherwise apply stops, and the plan must be made again. The zones before it
+were changed as planned. Apply also refuses to start if dnsconfig.js (or the
+files it includes) changed since the plan was made.
+
+EXAMPLES:
+   dnscontrol plan --out dns.plan
+   dnscontrol apply dns.plan`,
+	}
+}())
+
+// ApplyArgs contains all data/flags needed to run apply, independently of CLI.
+type ApplyArgs struct {
+	PushArgs
+	PlanFile string
+}
+
+func (args *ApplyArgs) flags() []cli.Flag {
+	return args.PushArgs.flags()
+}
+
+// Plan implements the plan subcommand.
+func Plan(args PlanArgs) error {
+	if args.PlanFile == "" {
+		return fmt.Errorf("--out is required")
+	}
+	p := &savedPlan{Version: planVersion, Created: time.Now().UTC()}
+	if err := run(PushArgs{PreviewArgs: args.PreviewArgs, plan: p}, false, printer.DefaultPrinter); err != nil {
+		return err
+	}
+	if len(p.missing) > 0 {
+		return fmt.Errorf("no plan written: these zones don't exist yet, create them first (e.g. with create-domains): %s", strings.Join(p.missing, ", "))
+	}
+	if err := p.save(args.PlanFile); err != nil {
+		return err
+	}
+	printer.Printf("Plan saved to %s. Run \"dnscontrol apply %s\" to make these changes.\n", args.PlanFile, args.PlanFile)
+	return nil
+}
+
+// Apply implements the apply subcommand.
+func Apply(args ApplyArgs) error {
+	p, err := loadPlan(args.PlanFile)
+	if err != nil {
+		return err
+	}
+	args.plan = p
+	err = Push(args.PushArgs)
+	if p.refused != nil {
+		return fmt.Errorf("%w; run plan again", p.refused)
+	}
+	if err != nil {
+		return err
+	}
+	if missed := p.notApplied(); len(missed) > 0 {
+		return fmt.Errorf("these zones of the plan weren't applied: %s", strings.Join(missed, ", "))
+	}
+	return nil
+}
+
+// The version of the plan file format.
+const planVersion = 1
+
+// savedPlan is the content of a plan file. plan collects it, and apply
+// checks against it; run() gets it in PushArgs. All methods are no-ops on a
+// nil *savedPlan, which run() has for preview and push.
+type savedPlan struct {
+	Version    int        `json:"version"`
+	Created    time.Time  `json:"created"`
+	ConfigHash string     `json:"config_hash"`
+	Zones      []planZone `json:"zones"`
+
+	missing []string        // plan: the zones that don't exist yet.
+	applied map[string]bool // apply: the keys of the zones checked, or nil for plan.
+	refused error           // apply: why the plan was refused.
+}
+
+// planZone is the corrections of a domain at one of its DNS providers, or
+// at its registrar.
+type planZone struct {
+	Domain      string   `json:"domain"`
+	Provider    string   `json:"provider,omitempty"`
+	Registrar   string   `json:"registrar,omitempty"`
+	State       string   `json:"state,omitempty"` // Hash of the records at the provider.
+	Corrections []string `json:"corrections"`
+}
+
+func (z planZone) key() string {
+	if z.Registrar != "" {
+		return z.Domain + " registrar " + z.Registrar
+	}
+	return z.Domain + " provider " + z.Provider
+}
+
+func loadPlan(filename string) (*savedPlan, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading plan: %w", err)
+	}
+	var p savedPlan
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("reading plan %s: %w", filename, err)
+	}
+	if p.Version != planVersion {
+		return nil, fmt.Errorf("plan %s: unsupported version %d (this dnscontrol writes version %d)", filename, p.Version, planVersion)
+	}
+	p.applied = map[string]bool{}
+	return &p, nil
+}
+
+func (p *savedPlan) save(filename string) error {
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing plan: %w", err)
+	}
+	return nil
+}
+
+// setConfig records the hash of the configuration that plan runs, or
+// refuses the plan if apply runs a different one.
+func (p *savedPlan) setConfig(cfg *models.DNSConfig) error {
+	if p == nil {
+		return nil
+	}
+	hash, err := configHash(cfg)
+	if err != nil {
+		return err
+	}
+	if !p.applying() {
+		p.ConfigHash = hash
+		return nil
+	}
+	if hash != p.ConfigHash {
+		p.refused = fmt.Errorf("dnsconfig.js changed since the plan was made")
+		return p.refused
+	}
+	return nil
+}
+
+// covers reports whether the domain is in the plan. Without a plan to
+// apply, every domain is.
+func (p *savedPlan) covers(domain string) bool {
+	if p == nil || !p.applying() {
+		return true
+	}
+	for _, z := range p.Zones {
+		if z.Domain == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// applying reports whether the plan is applied, rather than made. Only
+// loadPlan makes plans to apply.
+func (p *savedPlan) applying() bool {
+	return p.applied != nil
+}
+
+// stopped reports whether apply refused the plan, and makes no more
+// changes.
+func (p *savedPlan) stopped() bool {
+	return p != nil && p.refused != nil
+}
+
+// addMissingZone records that plan found no zone for the domain at the
+// provider, so that there are no corrections to save for it.
+func (p *savedPlan) addMissingZone(domain, provider string) {
+	if p == nil || p.applying() {
+		return
+	}
+	p.missing = append(p.missing, domain+" ("+provider+")")
+}
+
+// check records the corrections of a zone in the plan that plan makes,
+// or, with apply, returns an error if the state of the zone and its
+// corrections aren't those of the plan. state is "" for a registrar.
+func (p *savedPlan) check(z planZone, corrections []*models.Correction) error {
+	if p == nil {
+		return nil
+	}
+	z.Corrections = correctionMessages(corrections)
+	if !p.applying() {
+		p.Zones = append(p.Zones, z)
+		return nil
+	}
+
+	p.applied[z.key()] = true
+	var planned *planZone
+	for i := range p.Zones {
+		if p.Zones[i].key() == z.key() {
+			planned = &p.Zones[i]
+		}
+	}
+	switch {
+	case planned == nil:
+		p.refused = fmt.Errorf("%s: not in the plan", z.key())
+	case planned.State != z.State:
+		p.refused = fmt.Errorf("%s: the records at the provider changed since the plan was made", z.key())
+	case strings.Join(planned.Corrections, "\n") != strings.Join(z.Corrections, "\n"):
+		p.refused = fmt.Errorf("%s: the corrections aren't those of the plan", z.key())
+	}
+	return p.refused
+}
+
+// notApplied returns the zones of the plan with corrections that apply
+// didn't check, for example because of --domains.
+func (p *savedPlan) notApplied() []string {
+	var missed []string
+	for _, z := range p.Zones {
+		if len(z.Corrections) > 0 && !p.applied[z.key()] {
+			missed = append(missed, z.key())
+		}
+	}
+	return missed
+}
+
+// correctionMessages returns the messages of the corrections, without
+// colors.
+func correctionMessages(corrections []*models.Correction) []string {
+	msgs := []string{}
+	for _, c := range corrections {
+		msgs = append(msgs, strings.TrimSpace(colorCodes.ReplaceAllString(c.Msg, "")))
+	}
+	return msgs
+}
+
+// configHash returns a hash of the configuration, as run from dnsconfig.js
+// and before it is normalized.
+func configHash(cfg *models.DNSConfig) (string, error) {
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/zonerecs"
+)
+
+func Test_savedPlan(t *testing.T) {
+	var none *savedPlan
+	if err := none.check(planZone{Domain: "example.com"}, nil); err != nil || !none.covers("example.com") || none.stopped() {
+		t.Errorf("nil plan isn't a no-op")
+	}
+
+	rc := func(name, target string) *models.RecordConfig {
+		r := &models.RecordConfig{Type: "A", TTL: 300}
+		r.SetLabel(name, "example.com")
+		r.SetTarget(target)
+		return r
+	}
+	records := models.Records{rc("www", "192.0.2.1"), rc("@", "192.0.2.2")}
+	state := zonerecs.RecordsHash(records)
+	if got := zonerecs.RecordsHash(models.Records{records[1], records[0]}); got != state {
+		t.Errorf("the state depends on the order of the records")
+	}
+	changes := []*models.Correction{{Msg: "\x1b[32m+ CREATE mail.example.com A 192.0.2.3 ttl=300\x1b[0m"}}
+
+	planned := &savedPlan{Version: planVersion}
+	if err := planned.check(planZone{Domain: "example.com", Provider: "bind", State: state}, changes); err != nil {
+		t.Fatal(err)
+	}
+	planned.check(planZone{Domain: "example.com", Registrar: "none"}, nil)
+	planned.check(planZone{Domain: "example.net", Provider: "bind", State: state}, changes)
+	filename := filepath.Join(t.TempDir(), "dns.plan")
+	if err := planned.save(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := loadPlan(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Zones[0].Corrections; len(got) != 1 || got[0] != "+ CREATE mail.example.com A 192.0.2.3 ttl=300" {
+		t.Errorf("corrections = %q", got)
+	}
+	if p.covers("example.org") || !p.covers("example.net") {
+		t.Errorf("covers is wrong")
+	}
+	if err := p.check(planZone{Domain: "example.com", Provider: "bind", State: state}, changes); err != nil {
+		t.Errorf("the planned zone is refused: %v", err)
+	}
+	if err := p.check(planZone{Domain: "example.com", Registrar: "none"}, nil); err != nil {
+		t.Errorf("the planned registrar is refused: %v", err)
+	}
+	if missed := p.notApplied(); len(missed) != 1 || missed[0] != "example.net provider bind" {
+		t.Errorf("notApplied = %q", missed)
+	}
+
+	drifted := zonerecs.RecordsHash(models.Records{records[0]})
+	err = p.check(planZone{Domain: "example.net", Provider: "bind", State: drifted}, changes)
+	if err == nil || !strings.Contains(err.Error(), "changed since the plan was made") || !p.stopped() {
+		t.Errorf("a zone that changed isn't refused: %v", err)
+	}
+
+	p, _ = loadPlan(filename)
+	err = p.check(planZone{Domain: "example.com", Provider: "bind", State: state}, nil)
+	if err == nil || !strings.Contains(err.Error(), "aren't those of the plan") {
+		t.Errorf("different corrections aren't refused: %v", err)
+	}
+}
@@ -2,15 +2,19 @@ package commands
 
 import (
 	"cmp"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/bindserial"
 	"github.com/StackExchange/dnscontrol/v4/pkg/credsfile"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/StackExchange/dnscontrol/v4/pkg/nameservers"
 	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
 	"github.com/StackExchange/dnscontrol/v4/pkg/notifications"
@@ -34,6 +38,7 @@ var _ = cmd(catMain, func() *cli.Command {
 		Name:  "ppreview",
 		Usage: "read live configuration and identify changes to be made, without applying them",
 		Action: func(ctx *cli.Context) error {
+			enableAnnotations()
 			return exit(PPreview(args))
 		},
 		Flags: args.flags(),
@@ -45,12 +50,17 @@ type PPreviewArgs struct {
 	GetDNSConfigArgs
 	GetCredentialsArgs
 	FilterArgs
-	Notify      bool
-	WarnChanges bool
-	ConcurMode  string
-	NoPopulate  bool
-	DePopulate  bool
-	Full        bool
+	Notify         bool
+	WarnChanges    bool
+	ConcurMode     string
+	NoPopulate     bool
+	DePopulate     bool
+	DelegationOnly bool
+	Full           bool
+	FailOnWarning  bool
+	Audit          bool
+	Timeout        time.Duration
+	RewriteTTLArgs
 }
 
 // ReportItem is a record of corrections for a particular domain/provider/registrar.
@@ -65,6 +75,7 @@ func (args *PPreviewArgs) flags() []cli.Flag {
 	flags := args.GetDNSConfigArgs.flags()
 	flags = append(flags, args.GetCredentialsArgs.flags()...)
 	flags = append(flags, args.FilterArgs.flags()...)
+	flags = append(flags, args.RewriteTTLArgs.flags()...)
 	flags = append(flags, &cli.BoolFlag{
 		Name:        "notify",
 		Destination: &args.Notify,
@@ -97,11 +108,32 @@ func (args *PPreviewArgs) flags() []cli.Flag {
 		Destination: &args.NoPopulate,
 		Usage:       `Delete unknown zones at provider (dangerous!)`,
 	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "delegation-only",
+		Destination: &args.DelegationOnly,
+		Usage:       `Only change NS and SOA records (and the registrar's nameservers); leave all other records as they are`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "explain",
+		Destination: &diff2.Explain,
+		Usage:       `Annotate each modification with the fields that differ and their old and new values`,
+	})
 	flags = append(flags, &cli.BoolFlag{
 		Name:        "full",
 		Destination: &args.Full,
 		Usage:       `Add headings, providers names, notifications of no changes, etc`,
 	})
+	flags = append(flags, failOnWarningFlag(&args.FailOnWarning))
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "audit",
+		Destination: &args.Audit,
+		Usage:       `Read-only audit mode: refuse to run corrections or create zones (preview only)`,
+	})
+	flags = append(flags, &cli.DurationFlag{
+		Name:        "timeout",
+		Destination: &args.Timeout,
+		Usage:       `Stop the run if it takes longer than this, e.g. 10m, canceling the provider API requests in progress`,
+	})
 	flags = append(flags, &cli.IntFlag{
 		Name:   "reportmax",
 		Hidden: true,
@@ -125,6 +157,7 @@ var _ = cmd(catMain, func() *cli.Command {
 		Name:  "ppush",
 		Usage: "identify changes to be made, and perform them",
 		Action: func(ctx *cli.Context) error {
+			enableAnnotations()
 			return exit(PPush(args))
 		},
 		Flags: args.flags(),
@@ -134,12 +167,16 @@ var _ = cmd(catMain, func() *cli.Command {
 // PPushArgs contains all data/flags needed to run push, independently of CLI
 type PPushArgs struct {
 	PPreviewArgs
+	PushHookArgs
+	WindowArgs
 	Interactive bool
 	Report      string
 }
 
 func (args *PPushArgs) flags() []cli.Flag {
-	flags := args.PPreviewArgs.flags()
+	flags := previewOnly(args.PPreviewArgs.flags())
+	flags = append(flags, args.PushHookArgs.flags()...)
+	flags = append(flags, args.WindowArgs.flags()...)
 	flags = append(flags, &cli.BoolFlag{
 		Name:        "i",
 		Destination: &args.Interactive,
@@ -155,28 +192,45 @@ func (args *PPushArgs) flags() []cli.Flag {
 
 // PPreview implements the preview subcommand.
 func PPreview(args PPreviewArgs) error {
-	return prun(args, false, false, printer.DefaultPrinter, "")
+	return prun(PPushArgs{PPreviewArgs: args}, false, printer.DefaultPrinter)
 }
 
 // PPush implements the push subcommand.
 func PPush(args PPushArgs) error {
-	return prun(args.PPreviewArgs, true, args.Interactive, printer.DefaultPrinter, args.Report)
+	if err := args.WindowArgs.check(time.Now()); err != nil {
+		return err
+	}
+	return prun(args, true, printer.DefaultPrinter)
 }
 
 var pobsoleteDiff2FlagUsed = false
 
 // run is the main routine common to preview/push
-func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, report string) error {
+func prun(args PPushArgs, push bool, out printer.CLI) error {
 
 	// This is a hack until we have the new printer replacement.
 	printer.SkinnyReport = !args.Full
+
+	if args.Audit {
+		if push {
+			return fmt.Errorf("--audit can't be used with push")
+		}
+		providers.SetReadOnly(true)
+		out.Printf("Audit mode: only the read APIs of the providers will be called.\n")
+	}
 	fullMode := args.Full
+	ctx, cancel := runContext(args.Timeout)
+	defer cancel()
 
 	if pobsoleteDiff2FlagUsed {
 		printer.Println("WARNING: Please remove obsolete --diff2 flag. This will be an error in v5 or later. See https://github.com/StackExchange/dnscontrol/issues/2262")
 	}
 
 	out.PrintfIf(fullMode, "Reading dnsconfig.js or equiv.\n")
+	opts, err := args.GetDNSConfigArgs.normalizeOptions()
+	if err != nil {
+		return err
+	}
 	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
 	if err != nil {
 		return err
@@ -187,6 +241,9 @@ func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, repor
 	if err != nil {
 		return err
 	}
+	if errs := missingCredsEntries(cfg, providerConfigs, args.CredsFile, true); PrintValidationErrors(errs) {
+		return fmt.Errorf("exiting due to missing creds.json entries")
+	}
 
 	out.PrintfIf(fullMode, "Creating an in-memory model of 'desired'...\n")
 	notifier, err := PInitializeProviders(cfg, providerConfigs, args.Notify)
@@ -195,10 +252,25 @@ func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, repor
 	}
 
 	out.PrintfIf(fullMode, "Normalizing and validating 'desired'..\n")
-	errs := normalize.ValidateAndNormalizeConfig(cfg)
+	errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
 	if PrintValidationErrors(errs) {
 		return fmt.Errorf("exiting due to validation errors")
 	}
+	qualifyTargets(cfg)
+	if n, err := args.RewriteTTLArgs.rewriteTTLs(cfg, &args.FilterArgs); err != nil {
+		return err
+	} else if n > 0 {
+		out.Printf("--rewrite-ttl: %d records have TTL %d for this run only (dnsconfig.js is unchanged).\n", n, args.RewriteTTL)
+	}
+	// TTL_FROM() is read now, not when the IR is made.
+	var ttlErrs []error
+	for _, dc := range whichZonesToProcess(cfg.Domains, args.Domains) {
+		ttlErrs = append(ttlErrs, normalize.ResolveTTLSources(dc, opts)...)
+	}
+	if PrintValidationErrors(ttlErrs) {
+		return fmt.Errorf("exiting due to validation errors")
+	}
+	warnings := countWarnings(errs) + countWarnings(ttlErrs)
 
 	zcache := NewZoneCache()
 
@@ -213,13 +285,13 @@ func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, repor
 		out.PrintfIf(fullMode, "Concurrently gathering: %q\n", zone.Name)
 		go func(zone *models.DomainConfig, args PPreviewArgs, zcache *zoneCache) {
 			defer wg.Done()
-			oneZone(zone, args, zcache)
-		}(zone, args, zcache)
+			oneZone(ctx, zone, args, zcache)
+		}(zone, args.PPreviewArgs, zcache)
 	}
 	out.Printf("SERIALLY gathering %d zone(s)\n", len(zonesSerial))
 	for _, zone := range zonesSerial {
 		out.Printf("Serially Gathering: %q\n", zone.Name)
-		oneZone(zone, args, zcache)
+		oneZone(ctx, zone, args.PPreviewArgs, zcache)
 	}
 	out.PrintfIf(len(zonesConcurrent) > 0, "Waiting for concurrent gathering(s) to complete...")
 	wg.Wait()
@@ -230,7 +302,41 @@ func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, repor
 	var totalCorrections int
 	var reportItems []*ReportItem
 	var anyErrors bool
+	var notRun []string    // the domains skipped because of the timeout
+	var notPushed []string // the domains skipped because the pre-push hook rejected the push
+	var rejected error     // why the pre-push hook rejected the push
+
+	// The pre-push hook gets the corrections of all the zones at once, and
+	// none is run unless it accepts them.
+	var hookZones []hookZone
+	if push && args.PreHook != "" {
+		for _, zone := range zonesToProcess {
+			providersToProcess := whichProvidersToProcess(zone.DNSProviderInstances, args.Providers)
+			for _, provider := range zone.DNSProviderInstances {
+				if !skipProvider(provider.Name, providersToProcess) {
+					hookZones = append(hookZones, newHookZone(zone.Name, provider.Name, zone.GetCorrections(provider.Name)))
+				}
+			}
+			if skipProvider(zone.RegistrarInstance.Name, providersToProcess) {
+				hookZones = append(hookZones, newHookZone(zone.Name, zone.RegistrarInstance.Name, zone.GetCorrections(zone.RegistrarInstance.Name)))
+			}
+		}
+		if output, err := runPushHook(args.PreHook, hookZones); err != nil {
+			out.Errorf("pre-push-hook rejected the corrections: %s\n%s", err, output)
+			rejected = fmt.Errorf("pre-push-hook rejected the corrections: %w", err)
+		}
+		hookZones = nil
+	}
+
 	for _, zone := range zonesToProcess {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			notRun = append(notRun, zone.GetUniqueName())
+			continue
+		}
+		if rejected != nil {
+			notPushed = append(notPushed, zone.GetUniqueName())
+			continue
+		}
 		out.StartDomain(zone.GetUniqueName())
 
 		// Process DNS provider changes:
@@ -244,7 +350,9 @@ func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, repor
 				totalCorrections += numActions
 				out.EndProvider2(provider.Name, numActions)
 				reportItems = append(reportItems, genReportItem(zone.Name, corrections, provider.Name))
-				anyErrors = cmp.Or(anyErrors, pprintOrRunCorrections(zone.Name, provider.Name, corrections, out, push, interactive, notifier, report))
+				errCount := pprintOrRunCorrections(ctx, zone.Name, provider.Name, corrections, out, push, args.Interactive, notifier, args.Report)
+				anyErrors = cmp.Or(anyErrors, errCount > 0)
+				hookZones = appendPushed(hookZones, zone.Name, provider.Name, corrections, errCount)
 			}
 		}
 
@@ -257,10 +365,17 @@ func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, repor
 			out.EndProvider2(zone.RegistrarName, numActions)
 			totalCorrections += numActions
 			reportItems = append(reportItems, genReportItem(zone.Name, corrections, zone.RegistrarName))
-			anyErrors = cmp.Or(anyErrors, pprintOrRunCorrections(zone.Name, zone.RegistrarInstance.Name, corrections, out, push, interactive, notifier, report))
+			errCount := pprintOrRunCorrections(ctx, zone.Name, zone.RegistrarInstance.Name, corrections, out, push, args.Interactive, notifier, args.Report)
+			anyErrors = cmp.Or(anyErrors, errCount > 0)
+			hookZones = appendPushed(hookZones, zone.Name, zone.RegistrarInstance.Name, corrections, errCount)
 		}
 
 	}
+	if push {
+		if output, err := runPushHook(args.PostHook, hookZones); err != nil {
+			out.Warnf("post-push-hook: %s\n%s", err, output)
+		}
+	}
 
 	if os.Getenv("TEAMCITY_VERSION") != "" {
 		fmt.Fprintf(os.Stderr, "##teamcity[buildStatus status='SUCCESS' text='%d corrections']", totalCorrections)
@@ -268,16 +383,31 @@ func prun(args PPreviewArgs, push bool, interactive bool, out printer.CLI, repor
 	rfc4183.PrintWarning()
 	notifier.Done()
 	out.Printf("Done. %d corrections.\n", totalCorrections)
-	err = writeReport(report, reportItems)
+	err = writeReport(args.Report, reportItems)
 	if err != nil {
 		return fmt.Errorf("could not write report")
 	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if len(notRun) > 0 {
+			out.Errorf("%d domains weren't started before the timeout: %s\n", len(notRun), strings.Join(notRun, ", "))
+		}
+		return fmt.Errorf("timed out after %s (--timeout); the results above are incomplete", args.Timeout)
+	}
+	if rejected != nil {
+		if len(notPushed) > 0 {
+			out.Errorf("%d domains weren't pushed: %s\n", len(notPushed), strings.Join(notPushed, ", "))
+		}
+		return fmt.Errorf("push aborted: %w", rejected)
+	}
 	if anyErrors {
 		return fmt.Errorf("completed with errors")
 	}
 	if totalCorrections != 0 && args.WarnChanges {
 		return fmt.Errorf("there are pending changes")
 	}
+	if args.FailOnWarning && warnings > 0 {
+		return warningsError(warnings)
+	}
 	return nil
 }
 
@@ -361,7 +491,7 @@ func optimizeOrder(zones []*models.DomainConfig) []*models.DomainConfig {
 	return zones
 }
 
-func oneZone(zone *models.DomainConfig, args PPreviewArgs, zc *zoneCache) {
+func oneZone(ctx context.Context, zone *models.DomainConfig, args PPreviewArgs, zc *zoneCache) {
 	// Fix the parent zone's delegation: (if able/needed)
 	//zone.NameserversMutex.Lock()
 	delegationCorrections := generateDelegationCorrections(zone, zone.DNSProviderInstances, zone.RegistrarInstance)
@@ -378,7 +508,7 @@ func oneZone(zone *models.DomainConfig, args PPreviewArgs, zc *zoneCache) {
 		}
 
 		// Update the zone's records at the provider:
-		zoneCor, rep := generateZoneCorrections(zone, provider)
+		zoneCor, rep := generateZoneCorrections(ctx, zone, provider, args.DelegationOnly)
 		zone.StoreCorrections(provider.Name, rep)
 		zone.StoreCorrections(provider.Name, zoneCor)
 	}
@@ -441,11 +571,11 @@ func genReportItem(zname string, corrections []*models.Correction, pname string)
 	return &r
 }
 
-func pprintOrRunCorrections(zoneName string, providerName string, corrections []*models.Correction, out printer.CLI, push bool, interactive bool, notifier notifications.Notifier, report string) bool {
+func pprintOrRunCorrections(ctx context.Context, zoneName string, providerName string, corrections []*models.Correction, out printer.CLI, push bool, interactive bool, notifier notifications.Notifier, report string) (errCount int) {
 	if len(corrections) == 0 {
-		return false
+		return 0
 	}
-	var anyErrors bool
+
 	cc := 0
 	cn := 0
 	for _, correction := range corrections {
@@ -470,17 +600,17 @@ func pprintOrRunCorrections(zoneName string, providerName string, corrections []
 			// If it is an action (not an informational message), notify and execute.
 			if correction.F != nil {
 				notifier.Notify(zoneName, providerName, correction.Msg, err, false)
-				err = correction.F()
+				err = runCorrection(ctx, correction)
 				out.EndCorrection(err)
 				if err != nil {
-					anyErrors = true
+					errCount++
 				}
 			}
 		}
 	}
 
 	_ = report // File name to write report to. (obsolete)
-	return anyErrors
+	return errCount
 }
 
 func writeReport(report string, reportItems []*ReportItem) error {
@@ -533,10 +663,14 @@ func generatePopulateCorrections(provider *models.DNSProviderInstance, zoneName
 	}}
 }
 
-func generateZoneCorrections(zone *models.DomainConfig, provider *models.DNSProviderInstance) ([]*models.Correction, []*models.Correction) {
-	reports, zoneCorrections, err := zonerecs.CorrectZoneRecords(provider.Driver, zone)
+func generateZoneCorrections(ctx context.Context, zone *models.DomainConfig, provider *models.DNSProviderInstance, delegationOnly bool) ([]*models.Correction, []*models.Correction) {
+	correctZone := zonerecs.CorrectZoneRecords
+	if delegationOnly {
+		correctZone = zonerecs.CorrectDelegationRecords
+	}
+	reports, zoneCorrections, _, err := correctZone(ctx, provider.Driver, zone)
 	if err != nil {
-		return []*models.Correction{{Msg: fmt.Sprintf("Domain %q provider %s Error: %s", zone.Name, provider.Name, err)}}, nil
+		return []*models.Correction{{Msg: fmt.Sprintf("Domain %q provider %s Error: %s", zone.Name, provider.Name, providers.WithHint(err))}}, nil
 	}
 	return zoneCorrections, reports
 }
@@ -548,6 +682,10 @@ func generateDelegationCorrections(zone *models.DomainConfig, providers []*model
 		return msg(fmt.Sprintf("DtermineNS: zone %q; Error: %s", zone.Name, err))
 	}
 	zone.Nameservers = nsList
+	var warnings []*models.Correction
+	for _, w := range nameservers.CheckApexNS(zone) {
+		warnings = append(warnings, &models.Correction{Msg: "WARNING: " + w})
+	}
 	nameservers.AddNSRecords(zone)
 
 	if len(zone.Nameservers) == 0 && zone.Metadata["no_ns"] != "true" {
@@ -558,7 +696,7 @@ func generateDelegationCorrections(zone *models.DomainConfig, providers []*model
 	if err != nil {
 		return msg(fmt.Sprintf("zone %q; Rprovider %q; Error: %s", zone.Name, zone.RegistrarInstance.Name, err))
 	}
-	return corrections
+	return append(warnings, corrections...)
 }
 
 func msg(s string) []*models.Correction {
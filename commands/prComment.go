@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// prSummary is the changes of a run, by domain, rendered as Markdown for
+// a pull request comment. It is the runReporter of --pr-comment.
+type prSummary struct {
+	nopReporter
+	file string
+	push bool
+
+	mu      sync.Mutex
+	domains []string              // in the order of dnsconfig.js
+	changes map[string][]prChange // domain -> changes
+	failed  map[string]bool       // domains whose changes couldn't be computed
+}
+
+type prChange struct {
+	Provider string
+	Change   string
+}
+
+// prCollapseAfter is the number of changes above which the changes of a
+// domain are in a collapsed <details> section.
+const prCollapseAfter = 10
+
+func newPRSummary(file string, push bool) *prSummary {
+	return &prSummary{file: file, push: push, changes: map[string][]prChange{}, failed: map[string]bool{}}
+}
+
+// startDomain records that the domain was previewed, so that a domain
+// without changes still counts.
+func (s *prSummary) startDomain(domain string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.changes[domain]; !ok {
+		s.domains = append(s.domains, domain)
+		s.changes[domain] = nil
+	}
+}
+
+// addChanges records the corrections of the domain at the provider, one
+// change per line of their messages, without their colors.
+func (s *prSummary) addChanges(z zoneChanges) {
+	domain := z.domain()
+	s.startDomain(domain)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range z.corrections {
+		for _, line := range strings.Split(c.Msg, "\n") {
+			if line = strings.TrimSpace(colorCodes.ReplaceAllString(line, "")); line != "" {
+				s.changes[domain] = append(s.changes[domain], prChange{z.provider, line})
+			}
+		}
+	}
+}
+
+// domainDone records that the changes of the domain are incomplete, if
+// it failed.
+func (s *prSummary) domainDone(domain string, elapsed time.Duration, failed bool) {
+	if !failed {
+		return
+	}
+	s.startDomain(domain)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed[domain] = true
+}
+
+// finish writes the comment to the file.
+func (s *prSummary) finish(out printer.CLI) error {
+	f, err := os.Create(s.file)
+	if err != nil {
+		return fmt.Errorf("writing the PR comment: %w", err)
+	}
+	s.write(f)
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing the PR comment: %w", err)
+	}
+	return nil
+}
+
+// write writes the comment as Markdown: a table of the changes of each
+// domain that has any, collapsed if it is long.
+func (s *prSummary) write(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	verb := "pending"
+	if s.push {
+		verb = "made"
+	}
+	total, changed := 0, 0
+	for _, d := range s.domains {
+		if n := len(s.changes[d]); n > 0 {
+			total += n
+			changed++
+		}
+	}
+	fmt.Fprintf(w, "## DNS changes\n\n")
+	switch {
+	case total == 0 && len(s.failed) == 0:
+		fmt.Fprintf(w, "No DNS changes (%d domains checked).\n", len(s.domains))
+		return
+	case total > 0:
+		fmt.Fprintf(w, "%d %s %s in %d of %d domains.\n", total, plural(total, "change", "changes"), verb, changed, len(s.domains))
+	}
+	for _, d := range s.domains {
+		if s.failed[d] {
+			fmt.Fprintf(w, "\n:warning: **%s**: errors occurred; the changes below may be incomplete. See the CI log.\n", d)
+		}
+	}
+
+	for _, d := range s.domains {
+		changes := s.changes[d]
+		if len(changes) == 0 {
+			continue
+		}
+		collapse := len(changes) > prCollapseAfter
+		if collapse {
+			fmt.Fprintf(w, "\n<details>\n<summary><b>%s</b>: %d changes</summary>\n\n", d, len(changes))
+		} else {
+			fmt.Fprintf(w, "\n### %s\n\n", d)
+		}
+		fmt.Fprintf(w, "| Provider | Change |\n| --- | --- |\n")
+		for _, c := range changes {
+			fmt.Fprintf(w, "| %s | %s |\n", markdownCell(c.Provider), markdownCode(c.Change))
+		}
+		if collapse {
+			fmt.Fprintf(w, "\n</details>\n")
+		}
+	}
+}
+
+// colorCodes matches the ANSI escape sequences that color the messages
+// of the corrections.
+var colorCodes = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// markdownCell escapes the pipes of a table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// markdownCode formats s as inline code in a table cell, using a fence
+// of more backticks than s contains.
+func markdownCode(s string) string {
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + markdownCell(s) + fence
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_prSummary(t *testing.T) {
+	s := newPRSummary("comment.md", false)
+	s.startDomain("quiet.example")
+	s.addChanges(zoneChanges{dc: testDomain("example.com"), provider: "bind", corrections: []*models.Correction{
+		{Msg: "\x1b[32m+ CREATE www.example.com A 1.2.3.4 ttl=300\x1b[0m"},
+		{Msg: "± MODIFY txt.example.com TXT (\"a|b\") -> (\"`c`\")"},
+	}})
+	var big []*models.Correction
+	for i := 0; i < prCollapseAfter+1; i++ {
+		big = append(big, &models.Correction{Msg: fmt.Sprintf("+ CREATE h%d.example.net A 192.0.2.%d", i, i)})
+	}
+	s.addChanges(zoneChanges{dc: testDomain("example.net"), provider: "r53", corrections: big})
+	s.domainDone("broken.example", time.Second, true)
+	s.domainDone("example.com", time.Second, false)
+
+	var buf bytes.Buffer
+	s.write(&buf)
+	got := buf.String()
+	for _, want := range []string{
+		"13 changes pending in 2 of 4 domains.\n",
+		":warning: **broken.example**: errors occurred",
+		"### example.com\n\n| Provider | Change |\n| --- | --- |\n",
+		"| bind | `+ CREATE www.example.com A 1.2.3.4 ttl=300` |\n",
+		"| bind | ``± MODIFY txt.example.com TXT (\"a\\|b\") -> (\"`c`\")`` |\n",
+		"<details>\n<summary><b>example.net</b>: 11 changes</summary>\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "quiet.example") {
+		t.Errorf("a domain without changes is listed:\n%s", got)
+	}
+
+	buf.Reset()
+	empty := newPRSummary("comment.md", true)
+	empty.startDomain("example.com")
+	empty.write(&buf)
+	if want := "## DNS changes\n\nNo DNS changes (1 domains checked).\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
@@ -1,22 +1,26 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
-	"sync"
+	"time"
 
 	"golang.org/x/net/idna"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/bindserial"
 	"github.com/StackExchange/dnscontrol/v4/pkg/credsfile"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/StackExchange/dnscontrol/v4/pkg/nameservers"
 	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
 	"github.com/StackExchange/dnscontrol/v4/pkg/notifications"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 	"github.com/StackExchange/dnscontrol/v4/pkg/rfc4183"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/StackExchange/dnscontrol/v4/pkg/zonerecs"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/urfave/cli/v2"
@@ -29,6 +33,7 @@ var _ = cmd(catMain, func() *cli.Command {
 		Name:  "preview",
 		Usage: "read live configuration and identify changes to be made, without applying them",
 		Action: func(ctx *cli.Context) error {
+			enableAnnotations()
 			return exit(Preview(args))
 		},
 		Flags: args.flags(),
@@ -40,10 +45,28 @@ type PreviewArgs struct {
 	GetDNSConfigArgs
 	GetCredentialsArgs
 	FilterArgs
-	Notify      bool
-	WarnChanges bool
-	NoPopulate  bool
-	Full        bool
+	Notify         bool
+	WarnChanges    bool
+	NoPopulate     bool
+	DelegationOnly bool
+	Full           bool
+	FailOnWarning  bool
+	Audit          bool
+	PRCommentFile  string
+	JUnitFile      string
+	Changeset      string
+	ChangesetFile  string
+	Timeout        time.Duration
+	SortByImpact   bool
+	DiffContext    int
+	Table          bool
+	Quiet          bool
+	Shuffle        bool
+	ShuffleSeed    int64
+	MetricsArgs
+	RewriteTTLArgs
+	VerifyDSArgs
+	CostArgs
 }
 
 // ReportItem is a record of corrections for a particular domain/provider/registrar.
@@ -58,6 +81,10 @@ func (args *PreviewArgs) flags() []cli.Flag {
 	flags := args.GetDNSConfigArgs.flags()
 	flags = append(flags, args.GetCredentialsArgs.flags()...)
 	flags = append(flags, args.FilterArgs.flags()...)
+	flags = append(flags, args.RewriteTTLArgs.flags()...)
+	flags = append(flags, args.MetricsArgs.flags()...)
+	flags = append(flags, args.VerifyDSArgs.flags()...)
+	flags = append(flags, args.CostArgs.flags()...)
 	flags = append(flags, &cli.BoolFlag{
 		Name:        "notify",
 		Destination: &args.Notify,
@@ -73,11 +100,84 @@ func (args *PreviewArgs) flags() []cli.Flag {
 		Destination: &args.NoPopulate,
 		Usage:       `Use this flag to not auto-create non-existing zones at the provider`,
 	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "delegation-only",
+		Destination: &args.DelegationOnly,
+		Usage:       `Only change NS and SOA records (and the registrar's nameservers); leave all other records as they are`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "explain",
+		Destination: &diff2.Explain,
+		Usage:       `Annotate each modification with the fields that differ and their old and new values`,
+	})
 	flags = append(flags, &cli.BoolFlag{
 		Name:        "full",
+		Aliases:     []string{"verbose"},
 		Destination: &args.Full,
 		Usage:       `Add headings, providers names, notifications of no changes, etc`,
 	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "quiet",
+		Destination: &args.Quiet,
+		Usage:       `Only show the domains with changes, warnings or errors, and how many domains have changes (--full or --verbose overrides it)`,
+	})
+	flags = append(flags, failOnWarningFlag(&args.FailOnWarning))
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "audit",
+		Destination: &args.Audit,
+		Usage:       `Read-only audit mode: refuse to run corrections or create zones (preview only)`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "pr-comment",
+		Destination: &args.PRCommentFile,
+		Usage:       `Write a Markdown summary of the changes to this file, for posting as a pull request comment`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "junit",
+		Destination: &args.JUnitFile,
+		Usage:       `Write the result of each domain to this file as a JUnit XML test case, for the test dashboards of CI`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "changeset",
+		Destination: &args.Changeset,
+		Usage:       `Write the changes as the provider's API requests, for applying them manually (preview only). Formats: route53`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "changeset-file",
+		Destination: &args.ChangesetFile,
+		Value:       "changeset.json",
+		Usage:       `The file that --changeset writes`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "sort-by-impact",
+		Destination: &args.SortByImpact,
+		Usage:       `List the production domains first, and the deletions, then the modifications, then the creations of each (preview only)`,
+	})
+	flags = append(flags, &cli.IntFlag{
+		Name:        "diff-context",
+		Destination: &args.DiffContext,
+		Usage:       `Summarize large batches of creations and deletions after N records, and show only the changed fields of modifications with N fields around them (preview only; --full shows everything)`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "table",
+		Destination: &args.Table,
+		Usage:       `Show the changes as one table, with the long values truncated to fit the terminal (preview only; --full shows them whole)`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "shuffle",
+		Destination: &args.Shuffle,
+		Usage:       `Process the domains in a random order, to spread the load on the providers' rate limits`,
+	})
+	flags = append(flags, &cli.Int64Flag{
+		Name:        "shuffle-seed",
+		Destination: &args.ShuffleSeed,
+		Usage:       `Shuffle the domains in the order that this seed gives, e.g. to repeat the order of an earlier run (implies --shuffle)`,
+	})
+	flags = append(flags, &cli.DurationFlag{
+		Name:        "timeout",
+		Destination: &args.Timeout,
+		Usage:       `Stop the run if it takes longer than this, e.g. 10m, canceling the provider API requests in progress`,
+	})
 	flags = append(flags, &cli.IntFlag{
 		Name:   "reportmax",
 		Hidden: true,
@@ -101,6 +201,7 @@ var _ = cmd(catMain, func() *cli.Command {
 		Name:  "push",
 		Usage: "identify changes to be made, and perform them",
 		Action: func(ctx *cli.Context) error {
+			enableAnnotations()
 			return exit(Push(args))
 		},
 		Flags: args.flags(),
@@ -110,12 +211,22 @@ var _ = cmd(catMain, func() *cli.Command {
 // PushArgs contains all data/flags needed to run push, independently of CLI
 type PushArgs struct {
 	PreviewArgs
+	PushHookArgs
+	WindowArgs
+	CheckpointArgs
+	WaitArgs
 	Interactive bool
 	Report      string
+
+	plan *savedPlan // The plan that plan makes, or that apply applies.
 }
 
 func (args *PushArgs) flags() []cli.Flag {
-	flags := args.PreviewArgs.flags()
+	flags := previewOnly(args.PreviewArgs.flags())
+	flags = append(flags, args.PushHookArgs.flags()...)
+	flags = append(flags, args.WindowArgs.flags()...)
+	flags = append(flags, args.CheckpointArgs.flags()...)
+	flags = append(flags, args.WaitArgs.flags()...)
 	flags = append(flags, &cli.BoolFlag{
 		Name:        "i",
 		Destination: &args.Interactive,
@@ -129,182 +240,471 @@ func (args *PushArgs) flags() []cli.Flag {
 	return flags
 }
 
+// previewOnly removes the flags of PreviewArgs that push doesn't take.
+// --fail-on-warning is one: push validates dnsconfig.js before it changes
+// anything, so a push that should stop on warnings must be preceded by a
+// preview (or check) with the flag, not exit 2 after the changes are made.
+func previewOnly(flags []cli.Flag) []cli.Flag {
+	return slices.DeleteFunc(flags, func(f cli.Flag) bool {
+		return slices.Contains(f.Names(), "fail-on-warning")
+	})
+}
+
 // Preview implements the preview subcommand.
 func Preview(args PreviewArgs) error {
-	return run(args, false, false, printer.DefaultPrinter, nil)
+	return run(PushArgs{PreviewArgs: args}, false, printer.DefaultPrinter)
 }
 
 // Push implements the push subcommand.
 func Push(args PushArgs) error {
-	return run(args.PreviewArgs, true, args.Interactive, printer.DefaultPrinter, &args.Report)
+	if err := args.WindowArgs.check(time.Now()); err != nil {
+		return err
+	}
+	return run(args, true, printer.DefaultPrinter)
 }
 
 var obsoleteDiff2FlagUsed = false
 
-// run is the main routine common to preview/push
-func run(args PreviewArgs, push bool, interactive bool, out printer.CLI, report *string) error {
+// run is the main routine common to preview/push. The flags of PushArgs
+// that aren't in PreviewArgs are only used if push is true.
+func run(args PushArgs, push bool, out printer.CLI) error {
 	// TODO: make truly CLI independent. Perhaps return results on a channel as they occur
 
 	// This is a hack until we have the new printer replacement.
 	printer.SkinnyReport = !args.Full
 
+	if args.Audit {
+		if push {
+			return fmt.Errorf("--audit can't be used with push")
+		}
+		providers.SetReadOnly(true)
+		out.Printf("Audit mode: only the read APIs of the providers will be called.\n")
+	}
+
+	if args.SortByImpact && push {
+		return fmt.Errorf("--sort-by-impact can't be used with push")
+	}
+	if (args.Shuffle || args.ShuffleSeed != 0) && args.SortByImpact {
+		return fmt.Errorf("--shuffle can't be used with --sort-by-impact")
+	}
+	if args.CostFile != "" && push {
+		return fmt.Errorf("--cost can't be used with push")
+	}
+	if args.DiffContext > 0 && push {
+		return fmt.Errorf("--diff-context can't be used with push")
+	}
+	if args.Table && push {
+		return fmt.Errorf("--table can't be used with push")
+	}
+	condense := args.DiffContext > 0 && !args.Full
+	var quiet *quietPrinter
+	if args.Quiet && !args.Full {
+		quiet = newQuietPrinter(out)
+		out = quiet
+	}
+	if args.Table {
+		out = tablePrinter{CLI: out}
+	}
+	ctx, cancel := runContext(args.Timeout)
+	defer cancel()
+
+	cs, err := newChangeset(args.Changeset, args.ChangesetFile, push)
+	if err != nil {
+		return err
+	}
+
 	if obsoleteDiff2FlagUsed {
 		printer.Println("WARNING: Please remove obsolete --diff2 flag. This will be an error in v5 or later. See https://github.com/StackExchange/dnscontrol/issues/2262")
 	}
 
+	opts, err := args.GetDNSConfigArgs.normalizeOptions()
+	if err != nil {
+		return err
+	}
 	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
 	if err != nil {
 		return err
 	}
+	saved := args.plan
+	if err := saved.setConfig(cfg); err != nil {
+		return err
+	}
+	var ckpt *checkpoint
+	if push {
+		if ckpt, err = openCheckpoint(args.CheckpointArgs, cfg); err != nil {
+			return err
+		}
+	}
 	providerConfigs, err := credsfile.LoadProviderConfigs(args.CredsFile)
 	if err != nil {
 		return err
 	}
+	if errs := missingCredsEntries(cfg, providerConfigs, args.CredsFile, true); PrintValidationErrors(errs) {
+		return fmt.Errorf("exiting due to missing creds.json entries")
+	}
+	var prices map[string]costModel
+	if args.CostFile != "" {
+		if prices, err = loadCostModels(args.CostFile, providerConfigs); err != nil {
+			return err
+		}
+	}
 	notifier, err := InitializeProviders(cfg, providerConfigs, args.Notify)
 	if err != nil {
 		return err
 	}
 
-	errs := normalize.ValidateAndNormalizeConfig(cfg)
+	errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
 	if PrintValidationErrors(errs) {
 		return fmt.Errorf("exiting due to validation errors")
 	}
+	qualifyTargets(cfg)
+	if n, err := args.RewriteTTLArgs.rewriteTTLs(cfg, &args.FilterArgs); err != nil {
+		return err
+	} else if n > 0 {
+		out.Printf("--rewrite-ttl: %d records have TTL %d for this run only (dnsconfig.js is unchanged).\n", n, args.RewriteTTL)
+	}
+	// TTL_FROM() is read now, not when the IR is made.
+	var ttlErrs []error
+	for _, dc := range cfg.Domains {
+		if args.shouldRunDomain(dc.GetUniqueName()) {
+			ttlErrs = append(ttlErrs, normalize.ResolveTTLSources(dc, opts)...)
+		}
+	}
+	if PrintValidationErrors(ttlErrs) {
+		return fmt.Errorf("exiting due to validation errors")
+	}
+	if errs := args.VerifyDSArgs.verifyDS(cfg, &args.FilterArgs); PrintValidationErrors(errs) {
+		return fmt.Errorf("exiting due to DS records that don't match the DNSKEY records of their zone (--verify-ds)")
+	}
+	warnings := countWarnings(errs) + countWarnings(ttlErrs)
 	anyErrors := false
 	totalCorrections := 0
+	var reps runReporters
+	if args.MetricsFile != "" {
+		reps = append(reps, newRunMetrics(args.MetricsArgs, push))
+	}
+	if args.PRCommentFile != "" {
+		reps = append(reps, newPRSummary(args.PRCommentFile, push))
+	}
+	if args.JUnitFile != "" {
+		reps = append(reps, newJUnitReport(args.JUnitFile, push))
+	}
+	if prices != nil {
+		reps = append(reps, newCostEstimate(prices))
+	}
+	if cs != nil {
+		reps = append(reps, cs)
+	}
+	if args.Table {
+		reps = append(reps, newChangeTable(args.Full))
+	}
 
-	// create a WaitGroup with the length of domains for the anonymous functions (later goroutines) to wait for
-	var wg sync.WaitGroup
-	wg.Add(len(cfg.Domains))
 	var reportItems []ReportItem
-	// For each domain in dnsconfig.js...
-	for _, domain := range cfg.Domains {
-		// Run preview or push operations per domain as anonymous function, in preparation for the later use of goroutines.
-		// For now running this code is still sequential.
-		// Please note that at the end of this anonymous function there is a } (domain) which executes this function actually
-		func(domain *models.DomainConfig) {
-			defer wg.Done() // defer notify WaitGroup this anonymous function has finished
-
-			uniquename := domain.GetUniqueName()
-			if !args.shouldRunDomain(uniquename) {
-				return
-			}
+	var notRun []string    // the domains skipped because of the timeout
+	var notPushed []string // the domains skipped because the pre-push hook rejected the push
+	var hookZones []hookZone
+	var rejected error // why the pre-push hook rejected the push
+	checkedDomains, changedDomains := 0, 0
+	domains := cfg.Domains
+	if args.SortByImpact {
+		domains = sortDomainsByImpact(domains)
+	}
+	if args.Shuffle || args.ShuffleSeed != 0 {
+		var seed int64
+		domains, seed = shuffleDomains(domains, args.ShuffleSeed)
+		out.Printf("Domains in random order (--shuffle-seed %d repeats it).\n", seed)
+	}
 
-			err = domain.Punycode()
-			if err != nil {
-				return
-			}
+	// readDomain reads the zones of the domain and computes their
+	// corrections. It returns nil if the domain isn't run.
+	readDomain := func(domain *models.DomainConfig) *domainRun {
+		uniquename := domain.GetUniqueName()
+		if !args.shouldRunDomain(uniquename) || !saved.covers(uniquename) || saved.stopped() {
+			return nil
+		}
+		if ckpt.isDone(uniquename) {
+			out.Printf("%s: already pushed according to the checkpoint; skipping\n", uniquename)
+			return nil
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			notRun = append(notRun, uniquename)
+			reps.skipDomain(uniquename, "not started before the timeout (--timeout)")
+			return nil
+		}
+
+		d := &domainRun{dc: domain, uniquename: uniquename, start: time.Now()}
+		checkedDomains++
+		if err := domain.Punycode(); err != nil {
+			return d
+		}
 
-			// Correct the domain...
-
-			out.StartDomain(uniquename)
-			var providersWithExistingZone []*models.DNSProviderInstance
-			/// For each DSP...
-			for _, provider := range domain.DNSProviderInstances {
-				if !args.NoPopulate {
-					// preview run: check if zone is already there, if not print a warning
-					if lister, ok := provider.Driver.(providers.ZoneLister); ok && !push {
-						zones, err := lister.ListZones()
-						if err != nil {
-							out.Errorf("ERROR: %s\n", err.Error())
-							return
-						}
-						aceZoneName, _ := idna.ToASCII(domain.Name)
-
-						if !slices.Contains(zones, aceZoneName) {
-							//out.Warnf("DEBUG: zones: %v\n", zones)
-							//out.Warnf("DEBUG: Name: %v\n", domain.Name)
-
-							out.Warnf("Zone '%s' does not exist in the '%s' profile and will be added automatically.\n", domain.Name, provider.Name)
-							continue // continue with next provider, as we can not determine corrections without an existing zone
-						}
-					} else if creator, ok := provider.Driver.(providers.ZoneCreator); ok && push {
-						// this is the actual push, ensure domain exists at DSP
-						if err := creator.EnsureZoneExists(domain.Name); err != nil {
-							out.Warnf("Error creating domain: %s\n", err)
-							anyErrors = true
-							continue // continue with next provider, as we couldn't create this one
-						}
+		// Correct the domain...
+
+		out.StartDomain(uniquename)
+		reps.startDomain(uniquename)
+		var providersWithExistingZone []*models.DNSProviderInstance
+		/// For each DSP...
+		for _, provider := range domain.DNSProviderInstances {
+			if !args.NoPopulate {
+				// preview run: check if zone is already there, if not print a warning
+				if lister, ok := provider.Driver.(providers.ZoneLister); ok && !push {
+					reps.apiCall(provider.Name, "ListZones")
+					zones, err := lister.ListZones()
+					if err != nil {
+						reps.addError(uniquename, provider.Name, err)
+						out.Errorf("ERROR: %s\n", err.Error())
+						return d
 					}
-				}
-				providersWithExistingZone = append(providersWithExistingZone, provider)
-			}
+					aceZoneName, _ := idna.ToASCII(domain.Name)
 
-			// Correct the registrar...
+					if !slices.Contains(zones, aceZoneName) {
+						//out.Warnf("DEBUG: zones: %v\n", zones)
+						//out.Warnf("DEBUG: Name: %v\n", domain.Name)
 
-			nsList, err := nameservers.DetermineNameserversForProviders(domain, providersWithExistingZone, false)
-			if err != nil {
-				out.Errorf("ERROR: %s\n", err.Error())
-				return
+						out.Warnf("Zone '%s' does not exist in the '%s' profile and will be added automatically.\n", domain.Name, provider.Name)
+						reps.addMissingZone(domain, provider.Name)
+						saved.addMissingZone(uniquename, provider.Name)
+						d.changed = true
+						continue // continue with next provider, as we can not determine corrections without an existing zone
+					}
+				} else if creator, ok := provider.Driver.(providers.ZoneCreator); ok && push {
+					// this is the actual push, ensure domain exists at DSP
+					if err := providers.CheckWrite("create zone " + domain.Name); err != nil {
+						out.Warnf("Error creating domain: %s\n", err)
+						d.failed = true
+						continue
+					}
+					reps.apiCall(provider.Name, "EnsureZoneExists")
+					if err := creator.EnsureZoneExists(domain.Name); err != nil {
+						reps.addError(uniquename, provider.Name, err)
+						out.Warnf("Error creating domain: %s\n", err)
+						d.failed = true
+						continue // continue with next provider, as we couldn't create this one
+					}
+				}
 			}
-			domain.Nameservers = nsList
-			nameservers.AddNSRecords(domain)
+			providersWithExistingZone = append(providersWithExistingZone, provider)
+		}
 
-			for _, provider := range providersWithExistingZone {
+		// Correct the registrar...
 
-				shouldrun := args.shouldRunProvider(provider.Name, domain)
-				out.StartDNSProvider(provider.Name, !shouldrun)
-				if !shouldrun {
-					continue
-				}
+		nsList, err := nameservers.DetermineNameserversForProviders(domain, providersWithExistingZone, false)
+		if err != nil {
+			out.Errorf("ERROR: %s\n", err.Error())
+			return d
+		}
+		domain.Nameservers = nsList
+		for _, w := range nameservers.CheckApexNS(domain) {
+			out.Warnf("%s\n", w)
+		}
+		nameservers.AddNSRecords(domain)
 
-				reports, corrections, err := zonerecs.CorrectZoneRecords(provider.Driver, domain)
-				out.EndProvider(provider.Name, len(corrections), err)
-				if err != nil {
-					anyErrors = true
-					return
-				}
-				totalCorrections += len(corrections)
-				printReports(domain.Name, provider.Name, reports, out, push, notifier)
-				reportItems = append(reportItems, ReportItem{
-					Domain:      domain.Name,
-					Corrections: len(corrections),
-					Provider:    provider.Name,
-				})
-				anyErrors = printOrRunCorrections(domain.Name, provider.Name, corrections, out, push, interactive, notifier) || anyErrors
-			}
+		for _, provider := range providersWithExistingZone {
 
-			//
-			run := args.shouldRunProvider(domain.RegistrarName, domain)
-			out.StartRegistrar(domain.RegistrarName, !run)
-			if !run {
-				return
-			}
-			if len(domain.Nameservers) == 0 && domain.Metadata["no_ns"] != "true" {
-				out.Warnf("No nameservers declared; skipping registrar. Add {no_ns:'true'} to force.\n")
-				return
+			shouldrun := args.shouldRunProvider(provider.Name, domain)
+			out.StartDNSProvider(provider.Name, !shouldrun)
+			if !shouldrun {
+				continue
 			}
 
-			corrections, err := domain.RegistrarInstance.Driver.GetRegistrarCorrections(domain)
-			out.EndProvider(domain.RegistrarName, len(corrections), err)
+			correctZone := zonerecs.CorrectZoneRecords
+			if args.DelegationOnly {
+				correctZone = zonerecs.CorrectDelegationRecords
+			}
+			reps.apiCall(provider.Name, "GetZoneRecords")
+			reps.apiCall(provider.Name, "GetZoneRecordsCorrections")
+			reports, corrections, state, err := correctZone(ctx, provider.Driver, domain)
+			out.EndProvider(provider.Name, len(corrections), providers.WithHint(err))
 			if err != nil {
-				anyErrors = true
-				return
+				reps.addError(uniquename, provider.Name, err)
+				d.failed = true
+				return d
+			}
+			if err := saved.check(planZone{Domain: uniquename, Provider: provider.Name, State: state}, corrections); err != nil {
+				out.Errorf("ERROR: %s\n", err)
+				reps.addError(uniquename, provider.Name, err)
+				d.failed = true
+				return d
 			}
 			totalCorrections += len(corrections)
+			d.changed = d.changed || len(corrections) > 0
+			reps.addChanges(zoneChanges{dc: domain, provider: provider.Name, providerType: provider.ProviderType, corrections: corrections})
+			printReports(domain.Name, provider.Name, reports, out, push, notifier)
 			reportItems = append(reportItems, ReportItem{
 				Domain:      domain.Name,
 				Corrections: len(corrections),
-				Registrar:   domain.RegistrarName,
+				Provider:    provider.Name,
 			})
-			anyErrors = printOrRunCorrections(domain.Name, domain.RegistrarName, corrections, out, push, interactive, notifier) || anyErrors
-		}(domain)
+			if args.SortByImpact {
+				corrections = sortByImpact(domain.Name, corrections)
+			}
+			if condense {
+				corrections = condenseCorrections(corrections, args.DiffContext)
+			}
+			d.zones = append(d.zones, zoneRun{provider: provider.Name, driver: provider.Driver, corrections: corrections})
+		}
+
+		//
+		run := args.shouldRunProvider(domain.RegistrarName, domain)
+		out.StartRegistrar(domain.RegistrarName, !run)
+		if !run {
+			d.completed = true
+			return d
+		}
+		if len(domain.Nameservers) == 0 && domain.Metadata["no_ns"] != "true" {
+			out.Warnf("No nameservers declared; skipping registrar. Add {no_ns:'true'} to force.\n")
+			d.completed = true
+			return d
+		}
+
+		reps.apiCall(domain.RegistrarName, "GetRegistrarCorrections")
+		corrections, err := domain.RegistrarInstance.Driver.GetRegistrarCorrections(domain)
+		out.EndProvider(domain.RegistrarName, len(corrections), err)
+		if err != nil {
+			reps.addError(uniquename, domain.RegistrarName, err)
+			d.failed = true
+			return d
+		}
+		if err := saved.check(planZone{Domain: uniquename, Registrar: domain.RegistrarName}, corrections); err != nil {
+			out.Errorf("ERROR: %s\n", err)
+			reps.addError(uniquename, domain.RegistrarName, err)
+			d.failed = true
+			return d
+		}
+		totalCorrections += len(corrections)
+		d.changed = d.changed || len(corrections) > 0
+		reps.addChanges(zoneChanges{dc: domain, provider: domain.RegistrarName, corrections: corrections})
+		reportItems = append(reportItems, ReportItem{
+			Domain:      domain.Name,
+			Corrections: len(corrections),
+			Registrar:   domain.RegistrarName,
+		})
+		if args.SortByImpact {
+			corrections = sortByImpact(domain.Name, corrections)
+		}
+		if condense {
+			corrections = condenseCorrections(corrections, args.DiffContext)
+		}
+		d.zones = append(d.zones, zoneRun{provider: domain.RegistrarName, corrections: corrections})
+		d.completed = true
+		return d
+	}
+
+	// runDomain prints or runs the corrections of the domain. The domain
+	// is recorded in the checkpoint only if it was completed without
+	// errors.
+	runDomain := func(d *domainRun) {
+		for _, z := range d.zones {
+			errCount := printOrRunCorrections(ctx, d.dc.Name, z.provider, z.corrections, out, push, args.Interactive, notifier, reps)
+			d.failed = d.failed || errCount > 0
+			if push {
+				hookZones = appendPushed(hookZones, d.dc.Name, z.provider, z.corrections, errCount)
+			}
+			if push && len(z.corrections) > 0 && z.driver != nil {
+				if err := args.WaitArgs.waitForChanges(ctx, z.provider, z.driver, out); err != nil {
+					reps.addError(d.uniquename, z.provider, err)
+					out.Errorf("%s\n", err)
+					d.failed = true
+				}
+			}
+		}
+		if d.changed {
+			changedDomains++
+		}
+		reps.domainDone(d.uniquename, time.Since(d.start), d.failed)
+		if d.failed {
+			anyErrors = true
+		} else if d.completed {
+			if err := ckpt.markDone(d.uniquename); err != nil {
+				out.Errorf("ERROR: %s\n", err)
+				anyErrors = true
+			}
+		}
+	}
+
+	// With a pre-push hook, the corrections of all the domains are read
+	// first, and none is run unless the hook accepts them all.
+	if push && args.PreHook != "" {
+		var read []*domainRun
+		for _, domain := range domains {
+			if d := readDomain(domain); d != nil {
+				read = append(read, d)
+				for _, z := range d.zones {
+					hookZones = append(hookZones, newHookZone(d.dc.Name, z.provider, z.corrections))
+				}
+			}
+		}
+		if output, err := runPushHook(args.PreHook, hookZones); err != nil {
+			out.Errorf("pre-push-hook rejected the corrections: %s\n%s", err, output)
+			rejected = fmt.Errorf("pre-push-hook rejected the corrections: %w", err)
+			for _, d := range read {
+				notPushed = append(notPushed, d.uniquename)
+				reps.skipDomain(d.uniquename, "the pre-push-hook rejected the push")
+			}
+			read = nil
+		}
+		hookZones = nil
+		for _, d := range read {
+			out.StartDomain(d.uniquename)
+			runDomain(d)
+		}
+	} else {
+		// For each domain in dnsconfig.js...
+		for _, domain := range domains {
+			if d := readDomain(domain); d != nil {
+				runDomain(d)
+			}
+		}
+	}
+	if push {
+		if output, err := runPushHook(args.PostHook, hookZones); err != nil {
+			out.Warnf("post-push-hook: %s\n%s", err, output)
+		}
+	}
+	if quiet != nil {
+		quiet.done()
+		out.Printf("%d of %d %s %s.\n", changedDomains, checkedDomains, plural(checkedDomains, "domain", "domains"), plural(changedDomains, "has changes", "have changes"))
 	}
-	wg.Wait() // wait for all anonymous functions to finish
 
 	if os.Getenv("TEAMCITY_VERSION") != "" {
 		fmt.Fprintf(os.Stderr, "##teamcity[buildStatus status='SUCCESS' text='%d corrections']", totalCorrections)
 	}
 	rfc4183.PrintWarning()
 	notifier.Done()
+	if err := reps.finish(out); err != nil {
+		out.Errorf("ERROR: %s\n", err)
+		anyErrors = true
+	}
 	out.Printf("Done. %d corrections.\n", totalCorrections)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		if len(notRun) > 0 {
+			out.Errorf("%d domains weren't started before the timeout: %s\n", len(notRun), strings.Join(notRun, ", "))
+		}
+		if ckpt != nil {
+			out.Printf("Run again with --resume to skip the domains that were pushed.\n")
+		}
+		return fmt.Errorf("timed out after %s (--timeout); the results above are incomplete", args.Timeout)
+	}
+	if rejected != nil {
+		if len(notPushed) > 0 {
+			out.Errorf("%d domains weren't pushed: %s\n", len(notPushed), strings.Join(notPushed, ", "))
+		}
+		return fmt.Errorf("push aborted: %w", rejected)
+	}
 	if anyErrors {
+		if ckpt != nil {
+			out.Printf("Run again with --resume to skip the domains that were pushed.\n")
+		}
 		return fmt.Errorf("completed with errors")
 	}
+	if err := ckpt.remove(); err != nil {
+		return err
+	}
 	if totalCorrections != 0 && args.WarnChanges {
 		return fmt.Errorf("there are pending changes")
 	}
-	if report != nil && *report != "" {
-		f, err := os.OpenFile(*report, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if args.Report != "" {
+		f, err := os.OpenFile(args.Report, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 		if err != nil {
 			return err
 		}
@@ -317,9 +717,33 @@ func run(args PreviewArgs, push bool, interactive bool, out printer.CLI, report
 			return err
 		}
 	}
+	if args.FailOnWarning && warnings > 0 {
+		return warningsError(warnings)
+	}
 	return nil
 }
 
+// domainRun is a domain of a preview or push: the corrections of its
+// zones, which run reads before it prints or runs them, and how it went.
+type domainRun struct {
+	dc         *models.DomainConfig
+	uniquename string
+	start      time.Time
+	zones      []zoneRun
+
+	completed bool // All its zones were read.
+	failed    bool
+	changed   bool
+}
+
+// zoneRun is the corrections of a domain at one of its DNS providers, or
+// at its registrar.
+type zoneRun struct {
+	provider    string
+	driver      any // The DNS provider, whose changes --wait waits for; nil for the registrar.
+	corrections []*models.Correction
+}
+
 // InitializeProviders takes (fully processed) configuration and instantiates all providers and returns them.
 func InitializeProviders(cfg *models.DNSConfig, providerConfigs map[string]map[string]string, notifyFlag bool) (notify notifications.Notifier, err error) {
 	var notificationCfg map[string]string
@@ -570,11 +994,9 @@ func refineProviderType(credEntryName string, t string, credFields map[string]st
 
 }
 
-func printOrRunCorrections(domain string, provider string, corrections []*models.Correction, out printer.CLI, push bool, interactive bool, notifier notifications.Notifier) (anyErrors bool) {
-	anyErrors = false
-	if len(corrections) == 0 {
-		return false
-	}
+// printOrRunCorrections prints the corrections, and runs them if push is
+// true. It returns the number of corrections that failed.
+func printOrRunCorrections(ctx context.Context, domain string, provider string, corrections []*models.Correction, out printer.CLI, push bool, interactive bool, notifier notifications.Notifier, rep runReporter) (errCount int) {
 	for i, correction := range corrections {
 		out.PrintCorrection(i, correction)
 		var err error
@@ -583,16 +1005,51 @@ func printOrRunCorrections(domain string, provider string, corrections []*models
 				continue
 			}
 			if correction.F != nil {
-				err = correction.F()
+				rep.apiCall(provider, "correction")
+				err = runCorrection(ctx, correction)
 				out.EndCorrection(err)
 				if err != nil {
-					anyErrors = true
+					rep.addError(domain, provider, err)
+					errCount++
 				}
 			}
 		}
 		notifier.Notify(domain, provider, correction.Msg, err, !push)
 	}
-	return anyErrors
+	return errCount
+}
+
+// runContext returns the context of a preview or push, which is canceled
+// after timeout (never if it is 0), and makes it runctx.Context() for the
+// providers until cancel is called.
+func runContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	restore := runctx.Set(ctx)
+	return ctx, func() {
+		cancel()
+		restore()
+	}
+}
+
+// runCorrection runs the correction. It isn't retried: a correction is
+// often several API calls, some of which may have succeeded, and the
+// requests that were rate limited are already retried by the ratelimit
+// transport. The error returned includes advice for the user, if there is
+// any. The correction isn't run once ctx is done.
+func runCorrection(ctx context.Context, correction *models.Correction) error {
+	if err := providers.CheckWrite("run correction: " + correction.Msg); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := correction.F(); err != nil {
+		return providers.WithHint(err)
+	}
+	return nil
 }
 
 func printReports(domain string, provider string, reports []*models.Correction, out printer.CLI, push bool, notifier notifications.Notifier) (anyErrors bool) {
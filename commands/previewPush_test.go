@@ -1,8 +1,17 @@
 package commands
 
 import (
+	"context"
+	"errors"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/urfave/cli/v2"
 )
 
 func Test_refineProviderType(t *testing.T) {
@@ -56,3 +65,197 @@ func Test_refineProviderType(t *testing.T) {
 		})
 	}
 }
+
+func Test_runCorrection(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{"success", nil, false},
+		{"rate limited", providers.WrapStatus(429, errors.New("429")), true},
+		{"transient", providers.WrapError(providers.ErrTransient, errors.New("503")), true},
+		{"unclassified", errors.New("boom"), true},
+	}
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			calls := 0
+			c := &models.Correction{F: func() error {
+				calls++
+				return tst.err
+			}}
+			err := runCorrection(context.Background(), c)
+			if calls != 1 {
+				t.Errorf("F called %d times, want 1", calls)
+			}
+			if (err != nil) != tst.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tst.wantErr)
+			}
+		})
+	}
+}
+
+func Test_runCorrectionReadOnly(t *testing.T) {
+	providers.SetReadOnly(true)
+	defer providers.SetReadOnly(false)
+
+	called := false
+	err := runCorrection(context.Background(), &models.Correction{Msg: "+ CREATE www A 1.2.3.4", F: func() error {
+		called = true
+		return nil
+	}})
+	if called {
+		t.Error("F was called in read-only mode")
+	}
+	if !errors.Is(err, providers.ErrReadOnly) {
+		t.Errorf("err = %v, want ErrReadOnly", err)
+	}
+}
+
+func Test_runCorrectionCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	err := runCorrection(ctx, &models.Correction{Msg: "+ CREATE www A 1.2.3.4", F: func() error {
+		called = true
+		return nil
+	}})
+	if called {
+		t.Error("F was called after the run was canceled")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want %v", err, context.Canceled)
+	}
+}
+
+func Test_runContext(t *testing.T) {
+	for _, timeout := range []time.Duration{0, time.Hour} {
+		ctx, cancel := runContext(timeout)
+		if runctx.Context() != ctx {
+			t.Errorf("timeout %s: the providers don't get the context of the run", timeout)
+		}
+		if _, ok := ctx.Deadline(); ok != (timeout > 0) {
+			t.Errorf("timeout %s: deadline set = %v", timeout, ok)
+		}
+		cancel()
+		if timeout > 0 && (ctx.Err() == nil || runctx.Context() == ctx) {
+			t.Errorf("timeout %s: the context of the run isn't canceled and restored", timeout)
+		}
+	}
+}
+
+func Test_missingCredsEntries(t *testing.T) {
+	base := func(name, typ string) models.ProviderBase {
+		return models.ProviderBase{Name: name, ProviderType: typ}
+	}
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{
+		{
+			Name:              "example.com",
+			RegistrarInstance: &models.RegistrarInstance{ProviderBase: base("reg", "-")},
+			DNSProviderInstances: []*models.DNSProviderInstance{
+				{ProviderBase: base("dns", "")},
+				{ProviderBase: base("typed", "BIND")},
+			},
+		},
+		{
+			Name:              "example.net",
+			RegistrarInstance: &models.RegistrarInstance{ProviderBase: base("none", "NONE")},
+			DNSProviderInstances: []*models.DNSProviderInstance{
+				{ProviderBase: base("dns", "")},
+			},
+		},
+	}}
+	providerConfigs := map[string]map[string]string{
+		"reg":  {"TYPE": "GANDI_V5"},
+		"none": {"TYPE": "NONE"},
+	}
+
+	var got []string
+	for _, err := range missingCredsEntries(cfg, providerConfigs, "creds.json", false) {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		`creds.json has no entry for DNS provider "dns", which is used by example.com, example.net (See ` + url + `#missing)`,
+		`creds.json has no entry for DNS provider "typed", which is used by example.com (See ` + url + `#missing)`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Providers with a type in dnsconfig.js work without an entry.
+	if errs := missingCredsEntries(cfg, providerConfigs, "creds.json", true); len(errs) != 1 {
+		t.Errorf("untypedOnly: got %d errors, want 1: %v", len(errs), errs)
+	}
+}
+
+func Test_rewriteTTLs(t *testing.T) {
+	rec := func(typ string, ttl uint32, meta map[string]string) *models.RecordConfig {
+		return &models.RecordConfig{Type: typ, TTL: ttl, Metadata: meta}
+	}
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{
+		{Name: "example.com", Records: models.Records{
+			rec("A", 300, nil),
+			rec("MX", 300, nil),
+			rec("A", 60, nil),
+			rec("TXT", 300, map[string]string{"ttl_from": "env:TTL"}),
+		}},
+		{Name: "example.net", Records: models.Records{rec("A", 300, nil)}},
+	}}
+	for _, dc := range cfg.Domains {
+		dc.UpdateSplitHorizonNames()
+	}
+
+	args := RewriteTTLArgs{RewriteTTL: 60, RewriteTTLTypes: "a, txt"}
+	n, err := args.rewriteTTLs(cfg, &FilterArgs{Domains: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("rewrote %d records, want 2", n)
+	}
+	var got []uint32
+	for _, dc := range cfg.Domains {
+		for _, r := range dc.Records {
+			got = append(got, r.TTL)
+		}
+	}
+	if want := []uint32{60, 300, 60, 60, 300}; !slices.Equal(got, want) {
+		t.Errorf("TTLs = %v, want %v", got, want)
+	}
+	if _, ok := cfg.Domains[0].Records[3].Metadata["ttl_from"]; ok {
+		t.Errorf("ttl_from was not removed")
+	}
+
+	if _, err := (&RewriteTTLArgs{RewriteTTL: 1 << 31}).rewriteTTLs(cfg, &FilterArgs{}); err == nil {
+		t.Errorf("expected an error for a TTL over the maximum")
+	}
+
+	// A TTL that a DNS provider of a selected domain refuses rewrites nothing.
+	providers.RegisterDomainServiceProviderType("REWRITE-TTL-TEST", providers.DspFuncs{}, providers.TTLRange{Min: 120, Max: 86400})
+	cfg.Domains[1].DNSProviderInstances = []*models.DNSProviderInstance{
+		{ProviderBase: models.ProviderBase{Name: "slow", ProviderType: "REWRITE-TTL-TEST"}},
+	}
+	_, err = (&RewriteTTLArgs{RewriteTTL: 30}).rewriteTTLs(cfg, &FilterArgs{})
+	if err == nil || !strings.Contains(err.Error(), "outside the TTL range of provider slow (min 120, max 86400) of domain example.net") {
+		t.Errorf("expected an error for a TTL under the provider's minimum, got %v", err)
+	}
+	if cfg.Domains[0].Records[1].TTL != 300 {
+		t.Errorf("the TTLs were rewritten despite the error")
+	}
+	if _, err := (&RewriteTTLArgs{RewriteTTL: 600}).rewriteTTLs(cfg, &FilterArgs{}); err != nil {
+		t.Errorf("a TTL in the range is refused: %v", err)
+	}
+}
+
+func Test_failOnWarningPreviewOnly(t *testing.T) {
+	has := func(flags []cli.Flag) bool {
+		return slices.ContainsFunc(flags, func(f cli.Flag) bool { return slices.Contains(f.Names(), "fail-on-warning") })
+	}
+	if !has((&PreviewArgs{}).flags()) || !has((&PPreviewArgs{}).flags()) {
+		t.Errorf("preview doesn't take --fail-on-warning")
+	}
+	if has((&PushArgs{}).flags()) || has((&PPushArgs{}).flags()) {
+		t.Errorf("push takes --fail-on-warning")
+	}
+}
@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/mattn/go-isatty"
+)
+
+// changeTable is the changes of a preview, one row per change. It is the
+// runReporter of --table, which prints them as one table at the end
+// instead of one by one.
+type changeTable struct {
+	nopReporter
+	full bool // Don't truncate the cells.
+
+	mu   sync.Mutex
+	rows [][]string
+}
+
+var changeTableHeader = []string{"DOMAIN", "ACTION", "TYPE", "NAME", "OLD", "NEW", "PROVIDER"}
+
+// The columns of changeTable whose cells are truncated to fit: NAME, OLD
+// and NEW.
+var changeTableTruncated = []int{3, 4, 5}
+
+const (
+	// Width of a truncated column when the output isn't a terminal.
+	changeTableCellWidth = 40
+	// Narrowest a truncated column is made to fit the terminal.
+	changeTableMinCellWidth = 10
+	// Spaces between the columns.
+	changeTablePadding = 2
+)
+
+func newChangeTable(full bool) *changeTable {
+	return &changeTable{full: full}
+}
+
+// addChanges adds the corrections of the zone, one row per line of their
+// messages.
+func (t *changeTable) addChanges(z zoneChanges) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, correction := range z.corrections {
+		for _, line := range strings.Split(correction.Msg, "\n") {
+			if line = strings.TrimSpace(colorCodes.ReplaceAllString(line, "")); line != "" {
+				t.rows = append(t.rows, changeTableRow(z.dc.Name, z.provider, line))
+			}
+		}
+	}
+}
+
+// finish prints the table, if there are any changes.
+func (t *changeTable) finish(out printer.CLI) error {
+	var table strings.Builder
+	t.print(&table, stdoutWidth())
+	out.Printf("%s", table.String())
+	return nil
+}
+
+// tablePrinter is the printer of --table: the corrections are in the
+// table, so they aren't printed one by one.
+type tablePrinter struct {
+	printer.CLI
+}
+
+// PrintCorrection is called to print/format each correction.
+func (tablePrinter) PrintCorrection(n int, c *models.Correction) {}
+
+// changeTableRow splits a line of the message of a correction into the
+// cells of a row. A line that isn't a change of a record, such as a
+// change of the nameservers at a registrar, is all in the NEW column.
+func changeTableRow(domain, provider, line string) []string {
+	ch, ok := parseDiffChange(line)
+	if !ok {
+		return []string{domain, "CHANGE", "", "", "", line, provider}
+	}
+	var old, new string
+	switch ch.verb {
+	case "CREATE":
+		new = ch.rest
+	case "DELETE":
+		old = ch.rest
+	case "MODIFY":
+		// "(old) -> (new)", and the differences if --explain is given.
+		old, new, _ = strings.Cut(ch.rest, ") -> (")
+		old = strings.TrimPrefix(old, "(")
+		if strings.HasSuffix(new, ")") {
+			new = strings.TrimSuffix(new, ")")
+		} else if i := strings.LastIndex(new, "): "); i >= 0 {
+			new = new[:i] + new[i+1:]
+		}
+	case "MODIFY-TTL":
+		// "target ttl=(old->new)", and the differences if --explain is given.
+		target, ttls, _ := strings.Cut(ch.rest, " ttl=(")
+		ttls, explained, _ := strings.Cut(ttls, ")")
+		oldTTL, newTTL, _ := strings.Cut(ttls, "->")
+		old = target + " ttl=" + oldTTL
+		new = target + " ttl=" + newTTL + explained
+	}
+	return []string{domain, ch.verb, ch.rtype, ch.name, old, new, provider}
+}
+
+// print writes the table to w, if there are any changes. width is the
+// width of the terminal, or 0 if the output isn't one.
+func (t *changeTable) print(w io.Writer, width int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.rows) == 0 {
+		return
+	}
+	rows := append([][]string{changeTableHeader}, t.rows...)
+	if !t.full {
+		widths := changeTableWidths(rows, width)
+		for r, row := range rows[1:] {
+			truncated := make([]string, len(row))
+			for i, cell := range row {
+				truncated[i] = truncateCell(cell, widths[i])
+			}
+			rows[r+1] = truncated
+		}
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, changeTablePadding, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+}
+
+// changeTableWidths returns the widths to truncate the columns of rows to
+// (0 for none). Only NAME, OLD and NEW are truncated: to share what the
+// other columns leave of width, or to changeTableCellWidth if width is 0.
+func changeTableWidths(rows [][]string, width int) []int {
+	natural := make([]int, len(changeTableHeader))
+	for _, row := range rows {
+		for i, cell := range row {
+			natural[i] = max(natural[i], utf8.RuneCountInString(cell))
+		}
+	}
+	widths := make([]int, len(natural))
+	if width <= 0 {
+		for _, i := range changeTableTruncated {
+			widths[i] = changeTableCellWidth
+		}
+		return widths
+	}
+
+	// What is left after the other columns and the padding, shared out
+	// from the narrowest column up, so that a narrow column leaves the
+	// rest of its share to the wider ones.
+	left := width - changeTablePadding*(len(natural)-1)
+	for i, n := range natural {
+		if !slices.Contains(changeTableTruncated, i) {
+			left -= n
+		}
+	}
+	todo := append([]int(nil), changeTableTruncated...)
+	for len(todo) > 0 {
+		narrowest := 0
+		for j, i := range todo {
+			if natural[i] < natural[todo[narrowest]] {
+				narrowest = j
+			}
+		}
+		i := todo[narrowest]
+		widths[i] = min(natural[i], max(changeTableMinCellWidth, left/len(todo)))
+		left -= widths[i]
+		todo = append(todo[:narrowest], todo[narrowest+1:]...)
+	}
+	return widths
+}
+
+// truncateCell returns cell cut to width runes, ending with an ellipsis
+// if it was cut. A width of 0 is no limit.
+func truncateCell(cell string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(cell) <= width {
+		return cell
+	}
+	runes := []rune(cell)
+	return string(runes[:width-1]) + "…"
+}
+
+// stdoutWidth returns the width of the terminal of stdout, or 0 if stdout
+// isn't a terminal.
+func stdoutWidth() int {
+	if !isatty.IsTerminal(os.Stdout.Fd()) {
+		return 0
+	}
+	return terminalWidth(os.Stdout)
+}
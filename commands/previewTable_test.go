@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_changeTableRow(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"+ CREATE www.example.com A 192.0.2.1 ttl=300",
+			[]string{"example.com", "CREATE", "A", "www.example.com", "", "192.0.2.1 ttl=300", "bind"}},
+		{"- DELETE old.example.com CNAME x.example.net. ttl=300",
+			[]string{"example.com", "DELETE", "CNAME", "old.example.com", "x.example.net. ttl=300", "", "bind"}},
+		{"± MODIFY example.com MX (10 mx1.example.com. ttl=300) -> (20 mx1.example.com. ttl=300)",
+			[]string{"example.com", "MODIFY", "MX", "example.com", "10 mx1.example.com. ttl=300", "20 mx1.example.com. ttl=300", "bind"}},
+		{"± MODIFY example.com MX (10 mx1.example.com. ttl=300) -> (20 mx1.example.com. ttl=300): mx_preference 10->20",
+			[]string{"example.com", "MODIFY", "MX", "example.com", "10 mx1.example.com. ttl=300", "20 mx1.example.com. ttl=300: mx_preference 10->20", "bind"}},
+		{"± MODIFY-TTL www.example.com A 192.0.2.1 ttl=(300->600)",
+			[]string{"example.com", "MODIFY-TTL", "A", "www.example.com", "192.0.2.1 ttl=300", "192.0.2.1 ttl=600", "bind"}},
+		{"Update nameservers ns1.example.net -> ns2.example.net",
+			[]string{"example.com", "CHANGE", "", "", "", "Update nameservers ns1.example.net -> ns2.example.net", "bind"}},
+	}
+	for _, tt := range tests {
+		if got := changeTableRow("example.com", "bind", tt.line); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("changeTableRow(%q):\n got %q\nwant %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func Test_changeTable(t *testing.T) {
+	long := strings.Repeat("x", 60)
+	newTable := func(full bool) *changeTable {
+		table := newChangeTable(full)
+		table.addChanges(zoneChanges{dc: testDomain("example.com"), provider: "bind", providerType: "BIND", corrections: []*models.Correction{{
+			Msg: "\x1b[32m+ CREATE www.example.com A 192.0.2.1 ttl=300\x1b[0m\n\x1b[32m+ CREATE txt.example.com TXT \"" + long + "\" ttl=300\x1b[0m",
+		}}})
+		return table
+	}
+
+	var buf bytes.Buffer
+	newTable(false).print(&buf, 0)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("want a header and 2 rows, got:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "DOMAIN       ACTION  TYPE  NAME             OLD  NEW") {
+		t.Errorf("header isn't aligned: %q", lines[0])
+	}
+	if want := "\"" + strings.Repeat("x", changeTableCellWidth-2) + "…  bind"; !strings.HasSuffix(lines[2], want) {
+		t.Errorf("long value isn't truncated to %d: %q", changeTableCellWidth, lines[2])
+	}
+
+	buf.Reset()
+	newTable(false).print(&buf, 80)
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if n := len([]rune(line)); n > 80 {
+			t.Errorf("line is %d wide for a terminal of 80: %q", n, line)
+		}
+	}
+
+	buf.Reset()
+	newTable(true).print(&buf, 80)
+	if !strings.Contains(buf.String(), long+"\" ttl=300") {
+		t.Errorf("--full truncated:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	newChangeTable(false).print(&buf, 80)
+	if buf.Len() != 0 {
+		t.Errorf("table without changes: %q", buf.String())
+	}
+}
+
+func Test_changeTableWidths(t *testing.T) {
+	rows := [][]string{changeTableHeader, {"example.com", "CREATE", "A", "www.example.com", "", strings.Repeat("x", 100), "bind"}}
+	got := changeTableWidths(rows, 100)
+	// The other columns and the padding take 11+6+4+8+2*6 = 41; NAME and
+	// OLD need 15 and 3, which leaves 41 for NEW.
+	if got[3] != 15 || got[4] != 3 || got[5] != 41 {
+		t.Errorf("changeTableWidths() = %v", got)
+	}
+	if got[0] != 0 || got[6] != 0 {
+		t.Errorf("changeTableWidths() truncates DOMAIN or PROVIDER: %v", got)
+	}
+}
@@ -2,16 +2,21 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/credsfile"
 	"github.com/StackExchange/dnscontrol/v4/pkg/js"
 	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
 	"github.com/StackExchange/dnscontrol/v4/pkg/rfc4183"
 	"github.com/StackExchange/dnscontrol/v4/pkg/rtypes"
+	"github.com/StackExchange/dnscontrol/v4/pkg/spflib"
+	"github.com/miekg/dns/dnsutil"
 	"github.com/urfave/cli/v2"
 )
 
@@ -30,6 +35,45 @@ var _ = cmd(catDebug, func() *cli.Command {
 // CheckArgs encapsulates the flags/arguments for the check command.
 type CheckArgs struct {
 	GetDNSConfigArgs
+	CheckTargetsArgs
+	ResolveSPF       bool
+	ReportCAA        bool
+	Suggest          bool
+	IdenticalDomains bool
+	FailOnWarning    bool
+	CredsFile        string
+}
+
+func (args *CheckArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "resolve-spf",
+		Usage:       "Resolve the includes of SPF records (live DNS) and check the total number of DNS lookups",
+		Destination: &args.ResolveSPF,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "report-caa",
+		Usage:       "Report the CAs authorized by the CAA records at the apex of each domain, and the domains without any (warnings)",
+		Destination: &args.ReportCAA,
+	})
+	flags = append(flags, args.CheckTargetsArgs.flags()...)
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "suggest",
+		Usage:       "Suggest simplifications, such as labels with identical A/AAAA records that could be CNAMEs",
+		Destination: &args.Suggest,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "identical-domains",
+		Usage:       "Report the domains whose records are identical apart from the domain name, often a copy that wasn't changed (warnings)",
+		Destination: &args.IdenticalDomains,
+	})
+	flags = append(flags, failOnWarningFlag(&args.FailOnWarning))
+	flags = append(flags, &cli.StringFlag{
+		Name:        "creds",
+		Usage:       "Also check that every provider used in dnsconfig.js has an entry in this creds.json file",
+		Destination: &args.CredsFile,
+	})
+	return flags
 }
 
 var _ = cmd(catDebug, func() *cli.Command {
@@ -49,8 +93,26 @@ var _ = cmd(catDebug, func() *cli.Command {
 			// Copy these verbatim:
 			pargs.JSFile = args.JSFile
 			pargs.JSONFile = args.JSONFile
+			pargs.DisableChecks = args.DisableChecks
+			pargs.SunsetDays = args.SunsetDays
+			pargs.SunsetErrors = args.SunsetErrors
+			pargs.RelativeTargets = args.RelativeTargets
+			pargs.LowTTL = args.LowTTL
+			pargs.LowTTLTypes = args.LowTTLTypes
+			pargs.MergeSPF = args.MergeSPF
+			pargs.DropUnsupported = args.DropUnsupported
+			pargs.TypePolicy = args.TypePolicy
+			pargs.RecordPolicy = args.RecordPolicy
+			pargs.Strict = args.Strict
 			pargs.DevMode = args.DevMode
 			pargs.Variable = args.Variable
+			pargs.ResolveSPF = args.ResolveSPF
+			pargs.ReportCAA = args.ReportCAA
+			pargs.CheckTargetsArgs = args.CheckTargetsArgs
+			pargs.Suggest = args.Suggest
+			pargs.IdenticalDomains = args.IdenticalDomains
+			pargs.FailOnWarning = args.FailOnWarning
+			pargs.CredsFile = args.CredsFile
 			// Force these settings:
 			pargs.Pretty = false
 			pargs.Output = os.DevNull
@@ -59,6 +121,7 @@ var _ = cmd(catDebug, func() *cli.Command {
 			cli.ErrWriter = os.Stdout
 			log.SetOutput(os.Stdout)
 
+			enableAnnotations()
 			err := exit(PrintIR(pargs))
 			rfc4183.PrintWarning()
 			if err == nil {
@@ -74,7 +137,18 @@ var _ = cmd(catDebug, func() *cli.Command {
 type PrintIRArgs struct {
 	GetDNSConfigArgs
 	PrintJSONArgs
-	Raw bool
+	CheckTargetsArgs // Set by "check --check-targets".
+	Raw              bool
+	IncludeComputed  bool
+	GroupBy          string // "domain" or "provider"
+	FQDN             bool
+	IRTransform      string
+	ResolveSPF       bool   // Set by "check --resolve-spf".
+	ReportCAA        bool   // Set by "check --report-caa".
+	Suggest          bool   // Set by "check --suggest".
+	IdenticalDomains bool   // Set by "check --identical-domains".
+	FailOnWarning    bool   // Set by "check --fail-on-warning".
+	CredsFile        string // Set by "check --creds".
 }
 
 func (args *PrintIRArgs) flags() []cli.Flag {
@@ -84,22 +158,256 @@ func (args *PrintIRArgs) flags() []cli.Flag {
 		Usage:       "Skip validation and normalization. Just print js result.",
 		Destination: &args.Raw,
 	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "include-computed",
+		Usage:       "Annotate records with metadata explaining which values were derived during normalization",
+		Destination: &args.IncludeComputed,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "group-by",
+		Value:       "domain",
+		Usage:       `Group the output by "domain" or by "provider" (provider -> domains -> records)`,
+		Destination: &args.GroupBy,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "fqdn",
+		Usage:       `Write the label of each record as a FQDN with a trailing dot ("www.example.com."); --ir reads it back`,
+		Destination: &args.FQDN,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "ir-transform",
+		Usage:       "Output the IR as reshaped by this registered IR transform (see RegisterIRTransform)",
+		Destination: &args.IRTransform,
+	})
 	return flags
 }
 
 // PrintIR implements the print-ir subcommand.
 func PrintIR(args PrintIRArgs) error {
+	if args.GroupBy != "" && args.GroupBy != "domain" && args.GroupBy != "provider" {
+		return fmt.Errorf(`invalid value for --group-by: %q (must be "domain" or "provider")`, args.GroupBy)
+	}
+	if args.IRTransform != "" && args.GroupBy == "provider" {
+		return fmt.Errorf("--ir-transform can't be used with --group-by=provider")
+	}
+	opts, err := args.GetDNSConfigArgs.normalizeOptions()
+	if err != nil {
+		return err
+	}
 	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
 	if err != nil {
 		return err
 	}
+	if args.CredsFile != "" {
+		providerConfigs, err := credsfile.LoadProviderConfigs(args.CredsFile)
+		if err != nil {
+			return err
+		}
+		if errs := missingCredsEntries(cfg, providerConfigs, args.CredsFile, false); PrintValidationErrors(errs) {
+			return fmt.Errorf("exiting due to missing creds.json entries")
+		}
+	}
+	warnings := 0
 	if !args.Raw {
-		errs := normalize.ValidateAndNormalizeConfig(cfg)
+		var before map[*models.RecordConfig]recordSnapshot
+		if args.IncludeComputed {
+			before = snapshotRecords(cfg)
+		}
+		errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
 		if PrintValidationErrors(errs) {
 			return fmt.Errorf("exiting due to validation errors")
 		}
+		warnings = countWarnings(errs)
+		if args.IncludeComputed {
+			annotateComputed(cfg, before)
+		}
+		if args.ResolveSPF {
+			if err := reportSPFLookups(os.Stdout, cfg, spflib.NewMemoResolver(spflib.LiveResolver{})); err != nil {
+				return err
+			}
+		}
+		if args.ReportCAA {
+			warnings += reportCAA(os.Stdout, cfg)
+		}
+		if args.CheckTargets {
+			resolver, err := resolverAddress(args.CheckTargetsResolver, "check-targets")
+			if err != nil {
+				return err
+			}
+			warnings += reportTargets(os.Stdout, cfg, resolver, args.IncludeComputed)
+		}
+		if args.Suggest {
+			for _, dc := range cfg.Domains {
+				normalize.WriteCNAMESuggestions(os.Stdout, dc.Name, normalize.SuggestCNAMEs(dc))
+			}
+		}
+		if args.IdenticalDomains {
+			errs := normalize.CheckIdenticalDomains(cfg.Domains)
+			PrintValidationErrors(errs)
+			warnings += countWarnings(errs)
+		}
+	}
+	if args.FQDN {
+		setFQDNLabels(cfg)
 	}
-	return PrintJSON(args.PrintJSONArgs, cfg)
+	var out any = cfg
+	if args.GroupBy == "provider" {
+		out = groupByProvider(cfg)
+	}
+	if args.IRTransform != "" {
+		if out, err = applyIRTransform(args.IRTransform, cfg); err != nil {
+			return err
+		}
+	}
+	if err := PrintJSON(args.PrintJSONArgs, out); err != nil {
+		return err
+	}
+	if args.FailOnWarning && warnings > 0 {
+		return warningsError(warnings)
+	}
+	return nil
+}
+
+// providerIR is the IR of the domains of one DNS provider, as output by
+// print-ir --group-by=provider.
+type providerIR struct {
+	Name    string             `json:"name"`
+	Type    string             `json:"type,omitempty"`
+	Domains []providerDomainIR `json:"domains"`
+}
+
+type providerDomainIR struct {
+	Name    string         `json:"name"`
+	Records models.Records `json:"records"`
+}
+
+// groupByProvider reorganizes cfg as provider -> domains -> records. A
+// domain with several DNS providers appears under each of them. Providers
+// are in the order they are declared, domains in the order of dnsconfig.js.
+func groupByProvider(cfg *models.DNSConfig) []providerIR {
+	var result []providerIR
+	index := map[string]int{}
+	add := func(name, typ string) int {
+		if i, ok := index[name]; ok {
+			return i
+		}
+		index[name] = len(result)
+		result = append(result, providerIR{Name: name, Type: typ, Domains: []providerDomainIR{}})
+		return index[name]
+	}
+	for _, p := range cfg.DNSProviders {
+		add(p.Name, p.Type)
+	}
+	for _, dc := range cfg.Domains {
+		names := make([]string, 0, len(dc.DNSProviderNames))
+		for name := range dc.DNSProviderNames {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			i := add(name, "")
+			result[i].Domains = append(result[i].Domains, providerDomainIR{Name: dc.Name, Records: dc.Records})
+		}
+	}
+	return result
+}
+
+// setFQDNLabels replaces the label of each record of cfg by its FQDN, with
+// a trailing dot. The records are changed in place: print-ir outputs cfg
+// and exits.
+func setFQDNLabels(cfg *models.DNSConfig) {
+	for _, dc := range cfg.Domains {
+		for _, recs := range []models.Records{dc.Records, dc.EnsureAbsent} {
+			for _, rc := range recs {
+				rc.Name = dnsutil.AddOrigin(rc.Name, dc.Name) + "."
+			}
+		}
+	}
+}
+
+// relativeLabels turns the FQDN labels that print-ir --fqdn writes back
+// into labels relative to their domain. A label is a FQDN if it ends with a
+// dot, which a relative label can't.
+func relativeLabels(cfg *models.DNSConfig) error {
+	for _, dc := range cfg.Domains {
+		for _, recs := range []models.Records{dc.Records, dc.EnsureAbsent} {
+			for _, rc := range recs {
+				if !strings.HasSuffix(rc.Name, ".") {
+					continue
+				}
+				fqdn := strings.ToLower(strings.TrimSuffix(rc.Name, "."))
+				switch domain := strings.ToLower(dc.Name); {
+				case fqdn == domain:
+					rc.Name = "@"
+				case strings.HasSuffix(fqdn, "."+domain):
+					rc.Name = rc.Name[:len(rc.Name)-len(domain)-2]
+				default:
+					return fmt.Errorf("%s: the label %q of a %s record isn't in the domain", dc.Name, rc.Name, rc.Type)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// recordSnapshot stores the author-specified values of a record, as
+// they were before normalization.
+type recordSnapshot struct {
+	ttl    uint32
+	target string
+}
+
+func snapshotRecords(cfg *models.DNSConfig) map[*models.RecordConfig]recordSnapshot {
+	snap := map[*models.RecordConfig]recordSnapshot{}
+	for _, dc := range cfg.Domains {
+		for _, rc := range dc.Records {
+			snap[rc] = recordSnapshot{ttl: rc.TTL, target: rc.GetTargetField()}
+		}
+	}
+	return snap
+}
+
+// annotateComputed adds "computed_*" metadata to every record whose
+// values were derived during normalization rather than written in
+// dnsconfig.js. The metadata is informational only.
+func annotateComputed(cfg *models.DNSConfig, before map[*models.RecordConfig]recordSnapshot) {
+	for _, dc := range cfg.Domains {
+		for _, rc := range dc.Records {
+			orig, ok := before[rc]
+			if !ok {
+				setComputed(rc, "computed_record", "generated during normalization")
+				continue
+			}
+			switch {
+			case orig.ttl == 0:
+				setComputed(rc, "computed_ttl", fmt.Sprintf("from built-in default (%d)", models.DefaultTTL))
+			case rc.TTLOrigin != "":
+				setComputed(rc, "computed_ttl", fmt.Sprintf("from %s (%d)", rc.TTLOrigin, rc.TTL))
+			}
+			if rc.Type == "ALIAS" {
+				// reportTargets replaces this with the addresses, with
+				// --check-targets.
+				setComputed(rc, "computed_alias", fmt.Sprintf("resolved by the DNS provider to the addresses of %s", rc.GetTargetField()))
+			}
+			// Dropping the trailing dot is how the IR stores a FQDN, not a
+			// change of the target.
+			if orig.target != rc.GetTargetField() && orig.target != rc.GetTargetField()+"." {
+				switch {
+				case rc.Metadata["flatten"] != "" || rc.Metadata["split"] != "":
+					setComputed(rc, "computed_target", "SPF-flattened")
+				default:
+					setComputed(rc, "computed_target", fmt.Sprintf("canonicalized from %q", orig.target))
+				}
+			}
+		}
+	}
+}
+
+func setComputed(rc *models.RecordConfig, key, value string) {
+	if rc.Metadata == nil {
+		rc.Metadata = map[string]string{}
+	}
+	rc.Metadata[key] = value
 }
 
 // PrintValidationErrors formats and prints the validation errors and warnings.
@@ -111,14 +419,47 @@ func PrintValidationErrors(errs []error) (fatal bool) {
 	for _, err := range errs {
 		if _, ok := err.(normalize.Warning); ok {
 			log.Printf("WARNING: %s\n", err)
+			annotate("warning", err)
 		} else {
 			fatal = true
 			log.Printf("ERROR: %s\n", err)
+			annotate("error", err)
 		}
 	}
 	return
 }
 
+// countWarnings returns the number of errs that are warnings.
+func countWarnings(errs []error) int {
+	n := 0
+	for _, err := range errs {
+		if _, ok := err.(normalize.Warning); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// exitCodeWarnings is the exit code when --fail-on-warning is set and
+// there were warnings but no errors. Errors exit with 1.
+const exitCodeWarnings = 2
+
+// warningsError is returned when --fail-on-warning is set and validation
+// found this many warnings.
+type warningsError int
+
+func (n warningsError) Error() string {
+	return fmt.Sprintf("exiting due to %d validation warning(s) (--fail-on-warning)", int(n))
+}
+
+func failOnWarningFlag(dest *bool) cli.Flag {
+	return &cli.BoolFlag{
+		Name:        "fail-on-warning",
+		Destination: dest,
+		Usage:       fmt.Sprintf("Exit with code %d if validation finds warnings", exitCodeWarnings),
+	}
+}
+
 // ExecuteDSL executes the dnsconfig.js contents.
 func ExecuteDSL(args ExecuteDSLArgs) (*models.DNSConfig, error) {
 	if args.JSFile == "" {
@@ -139,7 +480,7 @@ func ExecuteDSL(args ExecuteDSLArgs) (*models.DNSConfig, error) {
 }
 
 // PrintJSON outputs/prettyprints the IR data.
-func PrintJSON(args PrintJSONArgs, config *models.DNSConfig) (err error) {
+func PrintJSON(args PrintJSONArgs, config any) (err error) {
 	var dat []byte
 	if args.Pretty {
 		dat, err = json.MarshalIndent(config, "", "  ")
@@ -166,6 +507,10 @@ func exit(err error) error {
 	if err == nil {
 		return nil
 	}
+	var warnings warningsError
+	if errors.As(err, &warnings) {
+		return cli.Exit(err, exitCodeWarnings)
+	}
 	return cli.Exit(err, 1)
 }
 
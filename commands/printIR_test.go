@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_annotateComputed(t *testing.T) {
+	makeRC := func(typ, label, target string, ttl uint32) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: typ, TTL: ttl, Metadata: map[string]string{}}
+		rc.SetLabel(label, "example.com")
+		rc.SetTarget(target)
+		return rc
+	}
+	plain := makeRC("A", "www", "1.2.3.4", 600)
+	noTTL := makeRC("A", "ttl", "1.2.3.4", 0)
+	domainTTL := makeRC("A", "default", "1.2.3.4", 3600)
+	domainTTL.TTLOrigin = "domain default"
+	short := makeRC("CNAME", "cname", "www", 600)
+	alias := makeRC("ALIAS", "@", "lb.example.net.", 600)
+	dc := &models.DomainConfig{Name: "example.com", Records: models.Records{plain, noTTL, domainTTL, short, alias}}
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{dc}}
+
+	before := snapshotRecords(cfg)
+	// Simulate what normalization does.
+	noTTL.TTL = models.DefaultTTL
+	short.SetTarget("www.example.com.")
+	added := makeRC("TXT", "_spf", "v=spf1 -all", 600)
+	dc.Records = append(dc.Records, added)
+
+	annotateComputed(cfg, before)
+
+	if len(plain.Metadata) != 0 {
+		t.Errorf("unexpected annotations on unchanged record: %v", plain.Metadata)
+	}
+	if got := noTTL.Metadata["computed_ttl"]; got != "from built-in default (300)" {
+		t.Errorf("computed_ttl = %q", got)
+	}
+	if got := domainTTL.Metadata["computed_ttl"]; got != "from domain default (3600)" {
+		t.Errorf("computed_ttl = %q", got)
+	}
+	if got := alias.Metadata["computed_alias"]; got != "resolved by the DNS provider to the addresses of lb.example.net." {
+		t.Errorf("computed_alias = %q", got)
+	}
+	if got := short.Metadata["computed_target"]; got != `canonicalized from "www"` {
+		t.Errorf("computed_target = %q", got)
+	}
+	if got := added.Metadata["computed_record"]; got != "generated during normalization" {
+		t.Errorf("computed_record = %q", got)
+	}
+}
+
+func Test_groupByProvider(t *testing.T) {
+	a := &models.DomainConfig{Name: "a.com", DNSProviderNames: map[string]int{"p2": -1, "p1": -1}}
+	b := &models.DomainConfig{Name: "b.com", DNSProviderNames: map[string]int{"p1": -1}}
+	c := &models.DomainConfig{Name: "c.com", DNSProviderNames: map[string]int{"undeclared": -1}}
+	cfg := &models.DNSConfig{
+		DNSProviders: []*models.DNSProviderConfig{{Name: "p2", Type: "BIND"}, {Name: "p1", Type: "ROUTE53"}, {Name: "unused", Type: "BIND"}},
+		Domains:      []*models.DomainConfig{a, b, c},
+	}
+
+	got := groupByProvider(cfg)
+	var summary []string
+	for _, p := range got {
+		s := p.Name + "(" + p.Type + "):"
+		for _, d := range p.Domains {
+			s += " " + d.Name
+		}
+		summary = append(summary, s)
+	}
+	want := []string{"p2(BIND): a.com", "p1(ROUTE53): a.com b.com", "unused(BIND):", "undeclared(): c.com"}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("groupByProvider() = %q, want %q", summary, want)
+	}
+}
+
+func Test_fqdnLabels(t *testing.T) {
+	rec := func(label string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: "A"}
+		rc.SetLabel(label, "example.com")
+		rc.SetTarget("192.0.2.1")
+		return rc
+	}
+	dc := &models.DomainConfig{
+		Name:         "example.com",
+		Records:      models.Records{rec("@"), rec("www"), rec("a.b")},
+		EnsureAbsent: models.Records{rec("old")},
+	}
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{dc}}
+
+	setFQDNLabels(cfg)
+	var got []string
+	for _, rc := range append(dc.Records, dc.EnsureAbsent...) {
+		got = append(got, rc.Name)
+	}
+	if want := []string{"example.com.", "www.example.com.", "a.b.example.com.", "old.example.com."}; !reflect.DeepEqual(got, want) {
+		t.Errorf("setFQDNLabels: got %q, want %q", got, want)
+	}
+
+	// A relative label is left as is.
+	dc.Records = append(dc.Records, rec("rel"))
+	dc.Records[1].Name = "WWW.Example.COM."
+	if err := relativeLabels(cfg); err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	for _, rc := range append(dc.Records, dc.EnsureAbsent...) {
+		got = append(got, rc.Name)
+	}
+	if want := []string{"@", "WWW", "a.b", "rel", "old"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("relativeLabels: got %q, want %q", got, want)
+	}
+
+	dc.Records[0].Name = "www.example.net."
+	if err := relativeLabels(cfg); err == nil {
+		t.Error("expected an error for a label outside of the domain")
+	}
+}
+
+func Test_exitCodes(t *testing.T) {
+	errs := []error{normalize.Warning{}, errors.New("an error"), normalize.Warning{}}
+	if n := countWarnings(errs); n != 2 {
+		t.Fatalf("countWarnings: got %d, want 2", n)
+	}
+	for _, tst := range []struct {
+		err  error
+		code int
+	}{
+		{warningsError(2), exitCodeWarnings},
+		{fmt.Errorf("wrapped: %w", warningsError(1)), exitCodeWarnings},
+		{errors.New("exiting due to validation errors"), 1},
+	} {
+		var coder cli.ExitCoder
+		if !errors.As(exit(tst.err), &coder) || coder.ExitCode() != tst.code {
+			t.Errorf("exit(%v): want exit code %d", tst.err, tst.code)
+		}
+	}
+	if exit(nil) != nil {
+		t.Errorf("exit(nil) should be nil")
+	}
+}
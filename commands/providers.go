@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args ProvidersArgs
+	return &cli.Command{
+		Name:  "providers",
+		Usage: "List the provider types and their capabilities",
+		Action: func(ctx *cli.Context) error {
+			return exit(Providers(args))
+		},
+		Flags: args.flags(),
+		Description: `List the provider types that this build of DNSControl supports, whether
+each is a DNS provider and/or a registrar, and the capabilities it declares.
+
+With --json, each provider has a map of every capability name to true or
+false, suitable for picking providers programmatically:
+
+   dnscontrol providers --json | jq -r '.[] | select(.capabilities.CanAutoDNSSEC) | .name'`,
+	}
+}())
+
+// ProvidersArgs encapsulates the flags/arguments for the providers command.
+type ProvidersArgs struct {
+	JSON bool
+}
+
+func (args *ProvidersArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:        "json",
+			Destination: &args.JSON,
+			Usage:       "Output JSON instead of a table",
+		},
+	}
+}
+
+// providerInfo describes a provider type, as output by the providers
+// command.
+type providerInfo struct {
+	Name         string          `json:"name"`
+	DNSProvider  bool            `json:"dns_provider"`
+	Registrar    bool            `json:"registrar"`
+	Capabilities map[string]bool `json:"capabilities"`
+}
+
+// Providers implements the providers subcommand.
+func Providers(args ProvidersArgs) error {
+	infos := providerInfos()
+	if args.JSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(infos)
+	}
+	writeProviderTable(os.Stdout, infos)
+	return nil
+}
+
+// providerInfos returns the registered provider types, sorted by name.
+func providerInfos() []providerInfo {
+	names := map[string]bool{}
+	for name := range providers.DNSProviderTypes {
+		names[name] = true
+	}
+	for name := range providers.RegistrarTypes {
+		names[name] = true
+	}
+
+	var infos []providerInfo
+	for name := range names {
+		_, isDNS := providers.DNSProviderTypes[name]
+		_, isReg := providers.RegistrarTypes[name]
+		info := providerInfo{Name: name, DNSProvider: isDNS, Registrar: isReg, Capabilities: map[string]bool{}}
+		for _, c := range providers.AllCapabilities() {
+			info.Capabilities[c.String()] = providers.ProviderHasCapability(name, c)
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// writeProviderTable writes one line per provider: its name, kinds and
+// the capabilities it has.
+func writeProviderTable(w io.Writer, infos []providerInfo) {
+	for _, info := range infos {
+		var kinds []string
+		if info.DNSProvider {
+			kinds = append(kinds, "dns")
+		}
+		if info.Registrar {
+			kinds = append(kinds, "registrar")
+		}
+		var caps []string
+		for _, c := range providers.AllCapabilities() {
+			if info.Capabilities[c.String()] {
+				caps = append(caps, c.String())
+			}
+		}
+		fmt.Fprintf(w, "%-22s %-13s %s\n", info.Name, strings.Join(kinds, ","), strings.Join(caps, " "))
+	}
+}
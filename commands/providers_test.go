@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+func TestProviderInfos(t *testing.T) {
+	providers.RegisterDomainServiceProviderType("PROVIDERSTEST", providers.DspFuncs{}, providers.CanUseCAA)
+
+	var got *providerInfo
+	for _, info := range providerInfos() {
+		if info.Name == "PROVIDERSTEST" {
+			got = &info
+		}
+	}
+	if got == nil {
+		t.Fatal("PROVIDERSTEST not listed")
+	}
+	if !got.DNSProvider || got.Registrar {
+		t.Errorf("kinds: got dns=%v registrar=%v, want dns only", got.DNSProvider, got.Registrar)
+	}
+	if len(got.Capabilities) != len(providers.AllCapabilities()) {
+		t.Errorf("got %d capabilities, want all %d", len(got.Capabilities), len(providers.AllCapabilities()))
+	}
+	if !got.Capabilities["CanUseCAA"] || got.Capabilities["CanUseSRV"] {
+		t.Errorf("capabilities: got CanUseCAA=%v CanUseSRV=%v", got.Capabilities["CanUseCAA"], got.Capabilities["CanUseSRV"])
+	}
+
+	var buf bytes.Buffer
+	writeProviderTable(&buf, []providerInfo{*got})
+	if line := buf.String(); !strings.HasPrefix(line, "PROVIDERSTEST") || !strings.Contains(line, " dns ") || !strings.Contains(line, "CanUseCAA") {
+		t.Errorf("table: got %q", line)
+	}
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// ptrPattern is a --pattern of generate-reverse: the PTRs of all the
+// addresses of a subnet, named from a template.
+type ptrPattern struct {
+	spec     string // As given, for the warnings.
+	prefix   netip.Prefix
+	template string // FQDN with a trailing dot.
+}
+
+// Largest subnet of a --pattern, in host bits.
+const maxPatternHostBits = 16
+
+var ptrPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// parsePTRPattern parses a SUBNET=TEMPLATE --pattern. The template has
+// placeholders for the parts of each address:
+//
+//	{ip}             the address, with "-" instead of "." or ":"
+//	{host}           the host part of the address (not in the subnet); the
+//	                 octets of IPv4, or the nibbles of IPv6 in groups
+//	{octet1}..{octet4}, {last-octet}  an octet of an IPv4 address
+func parsePTRPattern(spec string) (*ptrPattern, error) {
+	subnet, template, ok := strings.Cut(spec, "=")
+	if !ok || template == "" {
+		return nil, fmt.Errorf("--pattern %q must be SUBNET=TEMPLATE", spec)
+	}
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(subnet))
+	if err != nil {
+		return nil, fmt.Errorf("--pattern %q: %w", spec, err)
+	}
+	prefix = prefix.Masked()
+	if hostBits := prefix.Addr().BitLen() - prefix.Bits(); hostBits > maxPatternHostBits {
+		return nil, fmt.Errorf("--pattern %q: %s has 2^%d addresses; the largest subnet is a /%d",
+			spec, prefix, hostBits, prefix.Addr().BitLen()-maxPatternHostBits)
+	}
+	placeholders := ptrPlaceholder.FindAllString(template, -1)
+	if len(placeholders) == 0 {
+		return nil, fmt.Errorf("--pattern %q: the template has no placeholder, so all the addresses would have the same name", spec)
+	}
+	for _, ph := range placeholders {
+		switch ph {
+		case "{ip}", "{host}":
+		case "{octet1}", "{octet2}", "{octet3}", "{octet4}", "{last-octet}":
+			if !prefix.Addr().Is4() {
+				return nil, fmt.Errorf("--pattern %q: %s is only for IPv4 subnets", spec, ph)
+			}
+		default:
+			return nil, fmt.Errorf("--pattern %q: unknown placeholder %s", spec, ph)
+		}
+	}
+	return &ptrPattern{spec: spec, prefix: prefix, template: dns.Fqdn(strings.ToLower(template))}, nil
+}
+
+// addrs returns all the addresses of the subnet of p, in order.
+func (p *ptrPattern) addrs() []netip.Addr {
+	var addrs []netip.Addr
+	for ip := p.prefix.Addr(); ip.IsValid() && p.prefix.Contains(ip); ip = ip.Next() {
+		addrs = append(addrs, ip)
+	}
+	return addrs
+}
+
+// name returns the name of ip from the template of p. The nibbles of the
+// host part of an IPv6 address are in groups of nibbleGroup, from the
+// right, separated by "-".
+func (p *ptrPattern) name(ip netip.Addr, nibbleGroup int) string {
+	return ptrPlaceholder.ReplaceAllStringFunc(p.template, func(ph string) string {
+		switch ph {
+		case "{ip}":
+			return strings.NewReplacer(".", "-", ":", "-").Replace(ip.String())
+		case "{host}":
+			return p.hostPart(ip, nibbleGroup)
+		case "{last-octet}":
+			return strconv.Itoa(int(ip.As4()[3]))
+		}
+		n, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(ph, "{octet"), "}"))
+		return strconv.Itoa(int(ip.As4()[n-1]))
+	})
+}
+
+// hostPart returns the octets (IPv4) or nibbles (IPv6) of ip that are not
+// in the subnet of p, at least one.
+func (p *ptrPattern) hostPart(ip netip.Addr, nibbleGroup int) string {
+	hostBits := ip.BitLen() - p.prefix.Bits()
+	if ip.Is4() {
+		b := ip.As4()
+		var octets []string
+		for _, o := range b[4-max(1, (hostBits+7)/8):] {
+			octets = append(octets, strconv.Itoa(int(o)))
+		}
+		return strings.Join(octets, "-")
+	}
+	b := ip.As16()
+	hex := fmt.Sprintf("%x", b[:])
+	nibbles := hex[len(hex)-max(1, (hostBits+3)/4):]
+	if nibbleGroup < 1 {
+		nibbleGroup = len(nibbles)
+	}
+	var groups []string
+	for len(nibbles) > nibbleGroup {
+		groups = append([]string{nibbles[len(nibbles)-nibbleGroup:]}, groups...)
+		nibbles = nibbles[:len(nibbles)-nibbleGroup]
+	}
+	return strings.Join(append([]string{nibbles}, groups...), "-")
+}
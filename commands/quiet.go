@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// quietPrinter is the printer of --quiet: it holds back the output of each
+// domain until the domain has a change, a warning or an error, and drops
+// it if it has none. The output before the first domain, and after done
+// is called, is printed as usual.
+type quietPrinter struct {
+	printer.CLI
+
+	inDomain bool     // Between StartDomain and done.
+	shown    bool     // The output of the current domain is printed.
+	held     []func() // The output of the current domain, if not shown.
+}
+
+func newQuietPrinter(out printer.CLI) *quietPrinter {
+	return &quietPrinter{CLI: out}
+}
+
+// hold prints the output f now, or holds it back if the current domain
+// doesn't show yet.
+func (q *quietPrinter) hold(f func()) {
+	if q.inDomain && !q.shown {
+		q.held = append(q.held, f)
+		return
+	}
+	f()
+}
+
+// show prints the output of the current domain, which has something to
+// show, and then the output f.
+func (q *quietPrinter) show(f func()) {
+	if q.inDomain && !q.shown {
+		q.shown = true
+		for _, h := range q.held {
+			h()
+		}
+		q.held = nil
+	}
+	f()
+}
+
+// done drops the output of the last domain, if it had nothing to show.
+// The output after it is printed.
+func (q *quietPrinter) done() {
+	q.inDomain, q.shown, q.held = false, false, nil
+}
+
+// StartDomain is called at the start of each domain.
+func (q *quietPrinter) StartDomain(domain string) {
+	q.done()
+	q.inDomain = true
+	q.hold(func() { q.CLI.StartDomain(domain) })
+}
+
+// StartDNSProvider is called at the start of each new provider.
+func (q *quietPrinter) StartDNSProvider(name string, skip bool) {
+	q.hold(func() { q.CLI.StartDNSProvider(name, skip) })
+}
+
+// StartRegistrar is called at the start of each new registrar.
+func (q *quietPrinter) StartRegistrar(name string, skip bool) {
+	q.hold(func() { q.CLI.StartRegistrar(name, skip) })
+}
+
+// EndProvider is called at the end of each provider.
+func (q *quietPrinter) EndProvider(name string, numCorrections int, err error) {
+	f := func() { q.CLI.EndProvider(name, numCorrections, err) }
+	if numCorrections > 0 || err != nil {
+		q.show(f)
+	} else {
+		q.hold(f)
+	}
+}
+
+// EndProvider2 is called at the end of each provider.
+func (q *quietPrinter) EndProvider2(name string, numCorrections int) {
+	f := func() { q.CLI.EndProvider2(name, numCorrections) }
+	if numCorrections > 0 {
+		q.show(f)
+	} else {
+		q.hold(f)
+	}
+}
+
+// PrintCorrection is called to print/format each correction.
+func (q *quietPrinter) PrintCorrection(n int, c *models.Correction) {
+	q.show(func() { q.CLI.PrintCorrection(n, c) })
+}
+
+// PrintReport is called to print/format each non-mutating correction.
+func (q *quietPrinter) PrintReport(n int, c *models.Correction) {
+	q.show(func() { q.CLI.PrintReport(n, c) })
+}
+
+// EndCorrection is called at the end of each correction.
+func (q *quietPrinter) EndCorrection(err error) {
+	q.show(func() { q.CLI.EndCorrection(err) })
+}
+
+// PromptToRun prompts the user to see if they want to execute a correction.
+func (q *quietPrinter) PromptToRun() bool {
+	q.show(func() {})
+	return q.CLI.PromptToRun()
+}
+
+// Debugf is called to print/format debug information.
+func (q *quietPrinter) Debugf(format string, args ...interface{}) {
+	q.hold(func() { q.CLI.Debugf(format, args...) })
+}
+
+// Printf is called to print/format information.
+func (q *quietPrinter) Printf(format string, args ...interface{}) {
+	q.hold(func() { q.CLI.Printf(format, args...) })
+}
+
+// Println is called to print/format information.
+func (q *quietPrinter) Println(lines ...string) {
+	q.hold(func() { q.CLI.Println(lines...) })
+}
+
+// PrintfIf is called to optionally print/format a message.
+func (q *quietPrinter) PrintfIf(print bool, format string, args ...interface{}) {
+	q.hold(func() { q.CLI.PrintfIf(print, format, args...) })
+}
+
+// Warnf is called to print/format a warning.
+func (q *quietPrinter) Warnf(format string, args ...interface{}) {
+	q.show(func() { q.CLI.Warnf(format, args...) })
+}
+
+// Errorf is called to print/format an error.
+func (q *quietPrinter) Errorf(format string, args ...interface{}) {
+	q.show(func() { q.CLI.Errorf(format, args...) })
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+func Test_quietPrinter(t *testing.T) {
+	var buf bytes.Buffer
+	q := newQuietPrinter(printer.ConsolePrinter{Writer: &buf})
+
+	q.Printf("before\n")
+	q.StartDomain("unchanged.example")
+	q.Printf("detail\n")
+	q.EndProvider("bind", 0, nil)
+	q.StartDomain("changed.example")
+	q.Printf("detail\n")
+	q.EndProvider("bind", 1, nil)
+	q.PrintCorrection(0, &models.Correction{Msg: "+ CREATE www.changed.example A 192.0.2.1"})
+	q.StartDomain("warned.example")
+	q.Warnf("zone will be created\n")
+	q.StartDomain("failed.example")
+	q.EndProvider("bind", 0, fmt.Errorf("boom"))
+	q.StartDomain("last.example")
+	q.Printf("detail\n")
+	q.done()
+	q.Printf("Done.\n")
+
+	want := `before
+******************** Domain: changed.example
+detail
+1 correction (bind)
+#1: + CREATE www.changed.example A 192.0.2.1
+******************** Domain: warned.example
+WARNING: zone will be created
+******************** Domain: failed.example
+ERROR
+Error getting corrections (bind): boom
+Done.
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
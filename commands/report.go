@@ -0,0 +1,260 @@
+package commands
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args ReportArgs
+	return &cli.Command{
+		Name:  "report",
+		Usage: "Output a table (or CSV) of the records of all domains that match all the criteria, for audits",
+		Action: func(c *cli.Context) error {
+			return exit(Report(args, os.Stdout, os.Stderr))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol report [command options]",
+		Description: `Output the (normalized) records of dnsconfig.js that match all the criteria
+given, as a table or as CSV for a spreadsheet.  Providers are not accessed.
+
+--label, --type and --target select the records as with "find".  The other
+criteria are:
+   --contains TEXT     the target and its arguments contain TEXT (case insensitive)
+   --in-range CIDR     the target has an address in CIDR: the address of an A or
+                       AAAA record, or an address (or range) in the text of a TXT
+                       record, such as the ip4: of an SPF record
+   --ttl-above N       the TTL is more than N seconds
+   --ttl-below N       the TTL is less than N seconds
+
+The number of records that match is written on stderr.
+
+EXAMPLES:
+   dnscontrol report --type TXT --in-range 192.0.2.0/24
+   dnscontrol report --label '*' --ttl-above 86400 --format csv > long-ttls.csv
+   dnscontrol report --type MX,CNAME --contains old-provider.example --ttl-below 300`,
+	}
+}())
+
+// ReportArgs encapsulates the flags/arguments for the report command.
+type ReportArgs struct {
+	GetDNSConfigArgs
+	FilterArgs
+	Label    string
+	Type     string
+	Target   string
+	Regex    bool
+	Contains string
+	InRange  string
+	TTLAbove uint
+	TTLBelow uint
+	Format   string
+}
+
+func (args *ReportArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, &cli.StringFlag{
+		Name:        "domains",
+		Destination: &args.Domains,
+		Usage:       `Comma separated list of domain names to include`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "label",
+		Destination: &args.Label,
+		Usage:       "Only records whose label (short or FQDN) matches this pattern",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "type",
+		Destination: &args.Type,
+		Usage:       "Only records of this type (comma separated list)",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "target",
+		Destination: &args.Target,
+		Usage:       "Only records whose target matches this pattern",
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "regex",
+		Destination: &args.Regex,
+		Usage:       "Patterns are regular expressions instead of globs",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "contains",
+		Destination: &args.Contains,
+		Usage:       "Only records whose target and arguments contain this text (case insensitive)",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "in-range",
+		Destination: &args.InRange,
+		Usage:       "Only records with an address in this range (CIDR), as the target or in the text of a TXT record",
+	})
+	flags = append(flags, &cli.UintFlag{
+		Name:        "ttl-above",
+		Destination: &args.TTLAbove,
+		Usage:       "Only records whose TTL is more than this (0 for any)",
+	})
+	flags = append(flags, &cli.UintFlag{
+		Name:        "ttl-below",
+		Destination: &args.TTLBelow,
+		Usage:       "Only records whose TTL is less than this (0 for any)",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "format",
+		Destination: &args.Format,
+		Value:       "table",
+		Usage:       `Output format: table or csv`,
+	})
+	return flags
+}
+
+// reportCriteria are the criteria of the report command, besides those of
+// find.
+type reportCriteria struct {
+	contains string // lowercase; "" for any
+	inRange  netip.Prefix
+	ttlAbove uint32 // 0 for any
+	ttlBelow uint32 // 0 for any
+}
+
+// newReportCriteria parses the criteria of the report flags.
+func newReportCriteria(args ReportArgs) (*reportCriteria, error) {
+	c := &reportCriteria{contains: strings.ToLower(args.Contains)}
+	if args.InRange != "" {
+		if ip, err := netip.ParseAddr(args.InRange); err == nil {
+			return nil, fmt.Errorf("--in-range %q must be a range, such as %q", args.InRange, netip.PrefixFrom(ip, ip.BitLen()))
+		}
+		p, err := netip.ParsePrefix(args.InRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --in-range: %w", err)
+		}
+		c.inRange = p.Masked()
+	}
+	if args.TTLAbove > 1<<31-1 || args.TTLBelow > 1<<31-1 {
+		return nil, fmt.Errorf("--ttl-above and --ttl-below must be less than 2^31")
+	}
+	c.ttlAbove, c.ttlBelow = uint32(args.TTLAbove), uint32(args.TTLBelow)
+	return c, nil
+}
+
+// matches returns true if rec matches all the criteria.
+func (c *reportCriteria) matches(rec *models.RecordConfig) bool {
+	if c.ttlAbove != 0 && rec.TTL <= c.ttlAbove {
+		return false
+	}
+	if c.ttlBelow != 0 && rec.TTL >= c.ttlBelow {
+		return false
+	}
+	if c.contains != "" && !strings.Contains(strings.ToLower(rec.GetTargetCombinedFunc(nil)), c.contains) {
+		return false
+	}
+	if c.inRange.IsValid() && !rangeInText(c.inRange, rec.GetTargetCombinedFunc(nil)) {
+		return false
+	}
+	return true
+}
+
+// rangeInText returns true if one of the addresses or ranges in text (such
+// as "192.0.2.1" or the "ip4:192.0.2.0/24" of an SPF record) overlaps r.
+func rangeInText(r netip.Prefix, text string) bool {
+	words := strings.FieldsFunc(text, func(c rune) bool {
+		return c == ' ' || c == '\t' || c == '"' || c == ',' || c == ';' || c == '='
+	})
+	for _, word := range words {
+		// "ip4:", "ip6:", "a:" and "mx:" in SPF records.
+		for _, s := range []string{word, word[strings.IndexByte(word, ':')+1:]} {
+			if p, err := netip.ParsePrefix(s); err == nil && p.Overlaps(r) {
+				return true
+			}
+			if ip, err := netip.ParseAddr(s); err == nil && r.Contains(ip.Unmap()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Report implements the report subcommand. The number of records is
+// written to log.
+func Report(args ReportArgs, w, log io.Writer) error {
+	if args.Label == "" && args.Type == "" && args.Target == "" && args.Contains == "" &&
+		args.InRange == "" && args.TTLAbove == 0 && args.TTLBelow == 0 {
+		return fmt.Errorf("at least one criterion is required (use --label '*' for all the records)")
+	}
+	if args.Format != "table" && args.Format != "csv" {
+		return fmt.Errorf("unknown --format %q (want table or csv)", args.Format)
+	}
+	criteria, err := newReportCriteria(args)
+	if err != nil {
+		return err
+	}
+	labelMatch, err := newMatcher(args.Label, args.Regex)
+	if err != nil {
+		return fmt.Errorf("invalid --label: %w", err)
+	}
+	targetMatch, err := newMatcher(args.Target, args.Regex)
+	if err != nil {
+		return fmt.Errorf("invalid --target: %w", err)
+	}
+	types := map[string]bool{}
+	for _, t := range strings.Split(args.Type, ",") {
+		if t != "" {
+			types[strings.ToUpper(t)] = true
+		}
+	}
+
+	opts, err := args.GetDNSConfigArgs.normalizeOptions()
+	if err != nil {
+		return err
+	}
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		return err
+	}
+	errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
+	if PrintValidationErrors(errs) {
+		return fmt.Errorf("exiting due to validation errors")
+	}
+	qualifyTargets(cfg)
+
+	rows := [][]string{{"domain", "name", "type", "ttl", "target"}}
+	for _, dc := range cfg.Domains {
+		if !args.shouldRunDomain(dc.GetUniqueName()) {
+			continue
+		}
+		for _, rec := range findRecords(dc.Records, labelMatch, types, targetMatch) {
+			if criteria.matches(rec) {
+				rows = append(rows, []string{dc.GetUniqueName(), rec.GetLabelFQDN(), rec.Type,
+					strconv.FormatUint(uint64(rec.TTL), 10), rec.GetTargetCombinedFunc(nil)})
+			}
+		}
+	}
+
+	if args.Format == "csv" {
+		cw := csv.NewWriter(w)
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+	} else {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for i, row := range rows {
+			if i == 0 {
+				row = []string{"DOMAIN", "NAME", "TYPE", "TTL", "TARGET"}
+			}
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		tw.Flush()
+	}
+	n := len(rows) - 1
+	fmt.Fprintf(log, "%d %s.\n", n, plural(n, "record matches", "records match"))
+	return nil
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// reportCAA writes a table of the CAs that the CAA records at the apex of
+// each domain authorize, and returns the number of domains that have no
+// CAA records there, which lets any CA issue certificates for them.
+func reportCAA(w io.Writer, cfg *models.DNSConfig) int {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DOMAIN\tISSUE\tISSUEWILD\tIODEF")
+	missing := 0
+	for _, dc := range cfg.Domains {
+		var issue, issuewild, iodef []string
+		found := false
+		for _, rc := range dc.Records {
+			if rc.Type != "CAA" || rc.GetLabel() != "@" {
+				continue
+			}
+			found = true
+			switch strings.ToLower(rc.CaaTag) {
+			case "issue":
+				issue = append(issue, caaIssuer(rc.GetTargetField()))
+			case "issuewild":
+				issuewild = append(issuewild, caaIssuer(rc.GetTargetField()))
+			case "iodef":
+				iodef = append(iodef, rc.GetTargetField())
+			}
+		}
+		if !found {
+			missing++
+			fmt.Fprintf(tw, "%s\t-\t-\t-\tNO CAA: any CA may issue\n", dc.GetUniqueName())
+			continue
+		}
+		wild := caaList(issuewild, "(as issue)")
+		if len(issue) == 0 && len(issuewild) == 0 {
+			wild = "any CA"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", dc.GetUniqueName(), caaList(issue, "any CA"), wild, caaList(iodef, "-"))
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "%d of %d domains have CAA records at the apex.\n", len(cfg.Domains)-missing, len(cfg.Domains))
+	return missing
+}
+
+// caaIssuer returns the CA of the value of an issue or issuewild property,
+// without its parameters. An empty one (";") authorizes no CA.
+func caaIssuer(value string) string {
+	ca, _, _ := strings.Cut(value, ";")
+	if ca = strings.TrimSpace(ca); ca == "" {
+		return "(none)"
+	}
+	return ca
+}
+
+// caaList joins the values of a column, or returns ifEmpty.
+func caaList(values []string, ifEmpty string) string {
+	if len(values) == 0 {
+		return ifEmpty
+	}
+	return strings.Join(values, ", ")
+}
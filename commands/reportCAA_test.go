@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_reportCAA(t *testing.T) {
+	caa := func(label, tag, value string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: "CAA"}
+		rc.SetLabel(label, "example.com")
+		rc.SetTargetCAA(0, tag, value)
+		return rc
+	}
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{
+		{Name: "example.com", Records: models.Records{
+			caa("@", "issue", "letsencrypt.org"),
+			caa("@", "issue", "sectigo.com; account=123"),
+			caa("@", "issuewild", ";"),
+			caa("@", "iodef", "mailto:security@example.com"),
+			caa("www", "issue", "digicert.com"),
+		}},
+		{Name: "example.net"},
+		{Name: "example.org", Records: models.Records{
+			caa("@", "iodef", "mailto:security@example.org"),
+		}},
+	}}
+	for _, dc := range cfg.Domains {
+		dc.UpdateSplitHorizonNames()
+	}
+
+	var buf bytes.Buffer
+	if missing := reportCAA(&buf, cfg); missing != 1 {
+		t.Errorf("reportCAA() = %d, want 1", missing)
+	}
+	want := `DOMAIN       ISSUE                         ISSUEWILD  IODEF
+example.com  letsencrypt.org, sectigo.com  (none)     mailto:security@example.com
+example.net  -                             -          -  NO CAA: any CA may issue
+example.org  any CA                        any CA     mailto:security@example.org
+2 of 3 domains have CAA records at the apex.
+`
+	if got := buf.String(); got != want {
+		t.Errorf("reportCAA() output:\n%s\nwant:\n%s", got, want)
+	}
+}
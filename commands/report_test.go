@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_reportCriteria(t *testing.T) {
+	rec := func(typ, target string, ttl uint32) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: typ, TTL: ttl}
+		rc.SetLabel("@", "example.com")
+		if err := rc.PopulateFromString(typ, target, "example.com"); err != nil {
+			t.Fatal(err)
+		}
+		return rc
+	}
+
+	tests := []struct {
+		name string
+		args ReportArgs
+		rec  *models.RecordConfig
+		want bool
+	}{
+		{"a in range", ReportArgs{InRange: "192.0.2.0/24"}, rec("A", "192.0.2.7", 300), true},
+		{"a out of range", ReportArgs{InRange: "192.0.2.0/24"}, rec("A", "198.51.100.7", 300), false},
+		{"spf ip4", ReportArgs{InRange: "192.0.2.0/24"}, rec("TXT", "v=spf1 ip4:192.0.2.0/25 -all", 300), true},
+		{"spf larger range", ReportArgs{InRange: "192.0.2.0/28"}, rec("TXT", "v=spf1 ip4:192.0.0.0/16 -all", 300), true},
+		{"spf ip6", ReportArgs{InRange: "2001:db8::/32"}, rec("TXT", "v=spf1 ip6:2001:db8:1::/48 ~all", 300), true},
+		{"spf other", ReportArgs{InRange: "192.0.2.0/24"}, rec("TXT", "v=spf1 include:_spf.example.net -all", 300), false},
+		{"ttl above", ReportArgs{TTLAbove: 86400}, rec("A", "192.0.2.7", 86401), true},
+		{"ttl not above", ReportArgs{TTLAbove: 86400}, rec("A", "192.0.2.7", 86400), false},
+		{"ttl below", ReportArgs{TTLBelow: 300}, rec("A", "192.0.2.7", 60), true},
+		{"ttl not below", ReportArgs{TTLBelow: 300}, rec("A", "192.0.2.7", 300), false},
+		{"contains", ReportArgs{Contains: "Old-Mail"}, rec("MX", "10 old-mail.example.net.", 300), true},
+		{"contains priority", ReportArgs{Contains: "10 old"}, rec("MX", "10 old-mail.example.net.", 300), true},
+		{"all", ReportArgs{Contains: "old", TTLAbove: 3600}, rec("MX", "10 old-mail.example.net.", 300), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newReportCriteria(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := c.matches(tt.rec); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := newReportCriteria(ReportArgs{InRange: "192.0.2.1"}); err == nil {
+		t.Error("expected an error for an address instead of a range")
+	}
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// runReporter is told what a preview or push does, as it does it, and
+// reports it at the end. The reports that the flags of run() request are
+// runReporters, which run() makes and which live as long as the run.
+type runReporter interface {
+	// startDomain is called before the zones of the domain are read.
+	startDomain(domain string)
+	// apiCall is called before each call of a provider's API, e.g.
+	// "GetZoneRecords".
+	apiCall(provider, call string)
+	// skipDomain is called for each domain that isn't run, with why.
+	skipDomain(domain, reason string)
+	// addMissingZone is called for each zone that preview found missing
+	// at the provider, and that push would create.
+	addMissingZone(dc *models.DomainConfig, provider string)
+	// addChanges is called with the corrections of each zone, when they
+	// are read.
+	addChanges(z zoneChanges)
+	// addError is called for each error of the domain at the provider.
+	addError(domain, provider string, err error)
+	// domainDone is called when the corrections of the domain were
+	// printed or run.
+	domainDone(domain string, elapsed time.Duration, failed bool)
+	// finish writes or prints the report.
+	finish(out printer.CLI) error
+}
+
+// zoneChanges is the corrections of a domain at one of its DNS providers,
+// or at its registrar.
+type zoneChanges struct {
+	dc           *models.DomainConfig
+	provider     string
+	providerType string // "" for the registrar.
+	corrections  []*models.Correction
+}
+
+// domain returns the unique name of the domain.
+func (z zoneChanges) domain() string {
+	return z.dc.GetUniqueName()
+}
+
+// nopReporter implements runReporter with methods that do nothing. A
+// reporter embeds it, and implements the methods it needs.
+type nopReporter struct{}
+
+func (nopReporter) startDomain(domain string)                                    {}
+func (nopReporter) apiCall(provider, call string)                                {}
+func (nopReporter) skipDomain(domain, reason string)                             {}
+func (nopReporter) addMissingZone(dc *models.DomainConfig, provider string)      {}
+func (nopReporter) addChanges(z zoneChanges)                                     {}
+func (nopReporter) addError(domain, provider string, err error)                  {}
+func (nopReporter) domainDone(domain string, elapsed time.Duration, failed bool) {}
+func (nopReporter) finish(out printer.CLI) error                                 { return nil }
+
+// runReporters is the reporters of a run, which are all told everything.
+type runReporters []runReporter
+
+func (rs runReporters) startDomain(domain string) {
+	for _, r := range rs {
+		r.startDomain(domain)
+	}
+}
+
+func (rs runReporters) apiCall(provider, call string) {
+	for _, r := range rs {
+		r.apiCall(provider, call)
+	}
+}
+
+func (rs runReporters) skipDomain(domain, reason string) {
+	for _, r := range rs {
+		r.skipDomain(domain, reason)
+	}
+}
+
+func (rs runReporters) addMissingZone(dc *models.DomainConfig, provider string) {
+	for _, r := range rs {
+		r.addMissingZone(dc, provider)
+	}
+}
+
+func (rs runReporters) addChanges(z zoneChanges) {
+	for _, r := range rs {
+		r.addChanges(z)
+	}
+}
+
+func (rs runReporters) addError(domain, provider string, err error) {
+	for _, r := range rs {
+		r.addError(domain, provider, err)
+	}
+}
+
+func (rs runReporters) domainDone(domain string, elapsed time.Duration, failed bool) {
+	for _, r := range rs {
+		r.domainDone(domain, elapsed, failed)
+	}
+}
+
+// finish finishes all the reports, even if some fail.
+func (rs runReporters) finish(out printer.CLI) error {
+	var errs []error
+	for _, r := range rs {
+		errs = append(errs, r.finish(out))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// testDomain returns a domain without records.
+func testDomain(name string) *models.DomainConfig {
+	dc := &models.DomainConfig{Name: name}
+	dc.UpdateSplitHorizonNames()
+	return dc
+}
+
+// failingReporter counts the API calls, and fails to finish.
+type failingReporter struct {
+	nopReporter
+	calls int
+}
+
+func (r *failingReporter) apiCall(provider, call string) { r.calls++ }
+
+func (r *failingReporter) finish(out printer.CLI) error { return errors.New("can't write") }
+
+func Test_runReporters(t *testing.T) {
+	a, b := &failingReporter{}, &failingReporter{}
+	reps := runReporters{a, b}
+	reps.apiCall("bind", "GetZoneRecords")
+	if a.calls != 1 || b.calls != 1 {
+		t.Errorf("the reporters got %d and %d calls, want 1 each", a.calls, b.calls)
+	}
+	reps.domainDone("example.com", time.Second, false)
+
+	err := reps.finish(printer.ConsolePrinter{Writer: &strings.Builder{}})
+	if err == nil || strings.Count(err.Error(), "can't write") != 2 {
+		t.Errorf("got %v, want the errors of both reporters", err)
+	}
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/spflib"
+)
+
+// reportSPFLookups resolves the includes of each SPF record in cfg and
+// writes the tree of DNS lookups it needs. It returns an error if a record
+// needs more than spflib.MaxLookups lookups or can't be resolved.
+func reportSPFLookups(w io.Writer, cfg *models.DNSConfig, dnsres spflib.Resolver) error {
+	var problems []string
+	for _, dc := range cfg.Domains {
+		for _, rc := range dc.Records {
+			if rc.Type != "TXT" {
+				continue
+			}
+			txt := rc.GetTargetTXTJoined()
+			if !strings.HasPrefix(txt, "v=spf1 ") {
+				continue
+			}
+			name := rc.GetLabelFQDN()
+			tree, err := spflib.ResolveLookups(txt, dnsres)
+			if err != nil {
+				fmt.Fprintf(w, "%s: ERROR: %s\n", name, err)
+				problems = append(problems, fmt.Sprintf("%s: %s", name, err))
+				continue
+			}
+			verdict := "OK"
+			if tree.Lookups > spflib.MaxLookups {
+				verdict = "TOO MANY"
+				problems = append(problems, fmt.Sprintf("%s: SPF needs %d DNS lookups (limit %d)", name, tree.Lookups, spflib.MaxLookups))
+			}
+			fmt.Fprintf(w, "%s: %d DNS lookups (limit %d) %s\n", name, tree.Lookups, spflib.MaxLookups, verdict)
+			tree.Write(w, "  ")
+		}
+	}
+	if len(problems) != 0 {
+		return fmt.Errorf("SPF lookup check failed:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+type spfMap map[string]string
+
+func (m spfMap) GetSPF(name string) (string, error) {
+	if v, ok := m[name]; ok {
+		return v, nil
+	}
+	return "", fmt.Errorf("%s has no SPF record", name)
+}
+
+func Test_reportSPFLookups(t *testing.T) {
+	txt := func(label, target string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: "TXT"}
+		rc.SetLabel(label, "example.com")
+		rc.SetTargetTXT(target)
+		return rc
+	}
+	res := spfMap{"_spf.example.net": "v=spf1 a mx -all"}
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{{Name: "example.com", Records: models.Records{
+		txt("@", "v=spf1 include:_spf.example.net -all"),
+		txt("@", "not spf"),
+	}}}}
+
+	var buf bytes.Buffer
+	if err := reportSPFLookups(&buf, cfg, res); err != nil {
+		t.Fatal(err)
+	}
+	want := `example.com: 3 DNS lookups (limit 10) OK
+  include:_spf.example.net (3)
+    a (1)
+    mx (1)
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	// 9 + 3 lookups.
+	cfg.Domains[0].Records = models.Records{txt("@", "v=spf1 "+strings.Repeat("a ", 9)+"include:_spf.example.net -all")}
+	buf.Reset()
+	if err := reportSPFLookups(&buf, cfg, res); err == nil || !strings.Contains(err.Error(), "12 DNS lookups") {
+		t.Errorf("expected an error for 12 lookups, got %v\n%s", err, buf.String())
+	}
+
+	cfg.Domains[0].Records = models.Records{txt("@", "v=spf1 include:missing.example -all")}
+	if err := reportSPFLookups(&buf, cfg, res); err == nil {
+		t.Error("expected an error for an unresolvable include")
+	}
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/ttlsource"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/urfave/cli/v2"
+)
+
+// RewriteTTLArgs encapsulates the flags that override the TTLs of the
+// records for one preview or push.
+type RewriteTTLArgs struct {
+	RewriteTTL      uint
+	RewriteTTLTypes string
+}
+
+func (args *RewriteTTLArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.UintFlag{
+			Name:        "rewrite-ttl",
+			Destination: &args.RewriteTTL,
+			Usage:       `Use this TTL (in seconds) for all records of the selected domains, for this run only. dnsconfig.js is unchanged`,
+		},
+		&cli.StringFlag{
+			Name:        "rewrite-ttl-types",
+			Destination: &args.RewriteTTLTypes,
+			Usage:       `Comma-separated list of the record types that --rewrite-ttl applies to (default all)`,
+		},
+	}
+}
+
+// rewriteTTLs sets the TTL of the records of the domains that filter
+// selects, and returns how many records were changed. Records that get
+// their TTL with TTL_FROM() are rewritten too: the override wins. Only the
+// in-memory config is changed. The validation checked the TTLs of
+// dnsconfig.js, so the new TTL is checked against the TTL ranges of the
+// DNS providers here, and nothing is rewritten if one refuses it.
+func (args *RewriteTTLArgs) rewriteTTLs(cfg *models.DNSConfig, filter *FilterArgs) (int, error) {
+	if args.RewriteTTL == 0 {
+		return 0, nil
+	}
+	if args.RewriteTTL > maxTTL {
+		return 0, fmt.Errorf("--rewrite-ttl: %d is more than the maximum TTL, %d", args.RewriteTTL, maxTTL)
+	}
+	types := map[string]bool{}
+	for _, t := range strings.Split(args.RewriteTTLTypes, ",") {
+		if t = strings.ToUpper(strings.TrimSpace(t)); t != "" {
+			types[t] = true
+		}
+	}
+	selected := func(rec *models.RecordConfig) bool {
+		return len(types) == 0 || types[rec.Type]
+	}
+	var domains []*models.DomainConfig
+	for _, dc := range cfg.Domains {
+		if !filter.shouldRunDomain(dc.GetUniqueName()) || !slices.ContainsFunc(dc.Records, selected) {
+			continue
+		}
+		if err := checkRewriteTTLRange(dc, uint32(args.RewriteTTL)); err != nil {
+			return 0, err
+		}
+		domains = append(domains, dc)
+	}
+	n := 0
+	for _, dc := range domains {
+		for _, rec := range dc.Records {
+			if !selected(rec) {
+				continue
+			}
+			delete(rec.Metadata, ttlsource.MetaKey)
+			if rec.TTL != uint32(args.RewriteTTL) {
+				rec.TTL = uint32(args.RewriteTTL)
+				n++
+			}
+		}
+	}
+	return n, nil
+}
+
+// checkRewriteTTLRange returns an error if a DNS provider of the domain
+// doesn't accept the TTL.
+func checkRewriteTTLRange(dc *models.DomainConfig, ttl uint32) error {
+	for _, p := range dc.DNSProviderInstances {
+		limits, ok := providers.ProviderTTLRange(p.ProviderType)
+		if !ok || (ttl >= limits.Min && (limits.Max == 0 || ttl <= limits.Max)) {
+			continue
+		}
+		limit := fmt.Sprintf("min %d", limits.Min)
+		if limits.Max != 0 {
+			limit += fmt.Sprintf(", max %d", limits.Max)
+		}
+		return fmt.Errorf("--rewrite-ttl: %d is outside the TTL range of provider %s (%s) of domain %s", ttl, p.Name, limit, dc.GetUniqueName())
+	}
+	return nil
+}
+
+// maxTTL is the largest TTL allowed by RFC 2181, section 8.
+const maxTTL = 1<<31 - 1
@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// shuffleDomains returns the domains in a random order that depends only on
+// seed. If seed is 0, a seed is picked (and returned, so that the order can
+// be repeated).
+func shuffleDomains(domains []*models.DomainConfig, seed int64) ([]*models.DomainConfig, int64) {
+	for seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	shuffled := append([]*models.DomainConfig(nil), domains...)
+	rand.New(rand.NewSource(seed)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled, seed
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_shuffleDomains(t *testing.T) {
+	var domains []*models.DomainConfig
+	for i := 0; i < 20; i++ {
+		domains = append(domains, &models.DomainConfig{Name: fmt.Sprintf("d%02d.example", i)})
+	}
+	names := func(dcs []*models.DomainConfig) string {
+		var s []string
+		for _, dc := range dcs {
+			s = append(s, dc.Name)
+		}
+		return strings.Join(s, " ")
+	}
+	original := names(domains)
+
+	first, seed := shuffleDomains(domains, 0)
+	if seed == 0 {
+		t.Fatalf("no seed was picked")
+	}
+	if names(first) == original {
+		t.Errorf("the order didn't change")
+	}
+	if names(domains) != original {
+		t.Errorf("the domains given were reordered")
+	}
+	again, _ := shuffleDomains(domains, seed)
+	if names(again) != names(first) {
+		t.Errorf("the same seed gave another order:\n%s\n%s", names(first), names(again))
+	}
+	if other, _ := shuffleDomains(domains, seed+1); names(other) == names(first) {
+		t.Errorf("another seed gave the same order")
+	}
+}
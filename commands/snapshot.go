@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catMain, func() *cli.Command {
+	var args RestoreArgs
+	return &cli.Command{
+		Name:  "restore",
+		Usage: "preview (or, with --confirm, push) a snapshot made by get-zones --snapshot",
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return cli.Exit("Arguments should be: snapshotfile (Ex: snapshot-r53-20240102T030405Z.json)", 1)
+			}
+			args.SnapshotFile = ctx.Args().First()
+			return exit(Restore(args))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol restore [command options] snapshotfile",
+		Description: `Roll zones back to the state saved by "get-zones --snapshot".
+
+The snapshot is used as the desired state in place of dnsconfig.js. Like
+preview, no changes are made unless --confirm is given.
+
+EXAMPLES:
+   dnscontrol get-zones --snapshot r53 - example.com
+   dnscontrol restore snapshot-r53-20240102T030405Z.json
+   dnscontrol restore --confirm snapshot-r53-20240102T030405Z.json`,
+	}
+}())
+
+// RestoreArgs contains all data/flags needed to run restore, independently of CLI.
+type RestoreArgs struct {
+	GetCredentialsArgs
+	FilterArgs
+	PushHookArgs
+	WindowArgs
+	SnapshotFile string
+	Confirm      bool
+	Full         bool
+}
+
+func (args *RestoreArgs) flags() []cli.Flag {
+	flags := args.GetCredentialsArgs.flags()
+	flags = append(flags, args.FilterArgs.flags()...)
+	flags = append(flags, args.PushHookArgs.flags()...)
+	flags = append(flags, args.WindowArgs.flags()...)
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "confirm",
+		Destination: &args.Confirm,
+		Usage:       `Apply the changes (without this, restore only previews them)`,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "full",
+		Destination: &args.Full,
+		Usage:       `Add headings, providers names, notifications of no changes, etc`,
+	})
+	return flags
+}
+
+// Restore implements the restore subcommand.
+func Restore(args RestoreArgs) error {
+	if args.Confirm {
+		if err := args.WindowArgs.check(time.Now()); err != nil {
+			return err
+		}
+	}
+	pargs := PreviewArgs{
+		GetDNSConfigArgs:   GetDNSConfigArgs{JSONFile: args.SnapshotFile},
+		GetCredentialsArgs: args.GetCredentialsArgs,
+		FilterArgs:         args.FilterArgs,
+		Full:               args.Full,
+	}
+	return run(PushArgs{PreviewArgs: pargs, PushHookArgs: args.PushHookArgs}, args.Confirm, printer.DefaultPrinter)
+}
+
+// snapshotZones downloads zones and writes them as IR, so that restore
+// can use them as the desired state.
+func snapshotZones(args GetZoneArgs, provider providers.DNSServiceProvider, config map[string]string, zones []string) error {
+	providerType := args.ProviderName
+	if providerType == "" || providerType == "-" {
+		providerType = config["TYPE"]
+	}
+	canSOA := providers.ProviderHasCapability(providerType, providers.CanUseSOA)
+
+	zoneRecs := make([]models.Records, len(zones))
+	for i, zone := range zones {
+		recs, err := provider.GetZoneRecords(zone, nil)
+		if err != nil {
+			return fmt.Errorf("failed GetZone gzr: %w", err)
+		}
+		zoneRecs[i] = recs
+	}
+	cfg := makeSnapshot(args.CredName, args.ProviderName, zones, zoneRecs, canSOA)
+
+	fname := args.OutputFile
+	if fname == "" {
+		fname = fmt.Sprintf("snapshot-%s-%s.json", args.CredName, time.Now().UTC().Format("20060102T150405Z"))
+	}
+	f, err := os.Create(fname)
+	if err != nil {
+		return fmt.Errorf("failed GetZone Create(%q): %w", fname, err)
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(cfg); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Snapshot of %d zone(s) written to %s\n", len(zones), fname)
+	return nil
+}
+
+// makeSnapshot builds the IR for a snapshot.  The apex NS records become
+// NAMESERVER()s and the provider's own nameservers are not added, so that
+// restoring puts back exactly what was there. SOA records are only kept for
+// providers that let us manage them, and without their serial.
+func makeSnapshot(credName, providerName string, zones []string, zoneRecs []models.Records, canSOA bool) *models.DNSConfig {
+	if providerName == "" {
+		providerName = "-"
+	}
+	cfg := &models.DNSConfig{
+		Registrars:   []*models.RegistrarConfig{{Name: "none", Type: "-"}},
+		DNSProviders: []*models.DNSProviderConfig{{Name: credName, Type: providerName}},
+	}
+	for i, zone := range zones {
+		dc := &models.DomainConfig{
+			Name:             zone,
+			RegistrarName:    "none",
+			DNSProviderNames: map[string]int{credName: 0},
+			Metadata:         map[string]string{},
+			Records:          models.Records{},
+		}
+		for _, rec := range zoneRecs[i] {
+			switch {
+			case rec.Type == "NS" && rec.GetLabel() == "@":
+				name := rec.GetTargetField()
+				if !strings.HasSuffix(name, ".") {
+					name += "."
+				}
+				dc.Nameservers = append(dc.Nameservers, &models.Nameserver{Name: name})
+				dc.Metadata["ns_ttl"] = fmt.Sprint(rec.TTL)
+			case rec.Type == "SOA" && !canSOA:
+				continue
+			case rec.Type == "SOA":
+				// Restoring the old serial would move it backwards.
+				// Leave it to the provider to pick the next one.
+				soa := *rec
+				soa.SoaSerial = 0
+				dc.Records = append(dc.Records, &soa)
+			default:
+				dc.Records = append(dc.Records, rec)
+			}
+		}
+		cfg.Domains = append(cfg.Domains, dc)
+	}
+	return cfg
+}
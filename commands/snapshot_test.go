@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_makeSnapshot(t *testing.T) {
+	rec := func(typ, label, target string, ttl uint32) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: typ, TTL: ttl}
+		rc.SetLabel(label, "example.com")
+		rc.SetTarget(target)
+		return rc
+	}
+	soa := rec("SOA", "@", "ns1.example.net.", 300)
+	soa.SoaSerial = 2024010101
+	recs := models.Records{
+		soa,
+		rec("NS", "@", "ns1.example.net.", 3600),
+		rec("NS", "@", "ns2.example.net.", 3600),
+		rec("NS", "sub", "ns1.example.org.", 300),
+		rec("A", "www", "1.2.3.4", 300),
+	}
+
+	for _, canSOA := range []bool{false, true} {
+		cfg := makeSnapshot("r53", "", []string{"example.com"}, []models.Records{recs}, canSOA)
+		if len(cfg.Domains) != 1 {
+			t.Fatalf("expected 1 domain, got %d", len(cfg.Domains))
+		}
+		dc := cfg.Domains[0]
+		if dc.DNSProviderNames["r53"] != 0 || cfg.DNSProviders[0].Type != "-" {
+			t.Errorf("unexpected provider config %v %v", dc.DNSProviderNames, cfg.DNSProviders[0])
+		}
+		if len(dc.Nameservers) != 2 || dc.Nameservers[0].Name != "ns1.example.net." || dc.Metadata["ns_ttl"] != "3600" {
+			t.Errorf("apex NS not converted to nameservers: %v %v", dc.Nameservers, dc.Metadata)
+		}
+
+		var types []string
+		for _, r := range dc.Records {
+			types = append(types, r.Type)
+			if r.Type == "SOA" && r.SoaSerial != 0 {
+				t.Errorf("SOA serial was kept: %d", r.SoaSerial)
+			}
+		}
+		want := 2 // NS sub, A www
+		if canSOA {
+			want++
+		}
+		if len(dc.Records) != want {
+			t.Errorf("canSOA=%v: got records %v", canSOA, types)
+		}
+	}
+	if soa.SoaSerial != 2024010101 {
+		t.Errorf("the downloaded SOA was modified")
+	}
+}
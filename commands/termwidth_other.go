@@ -0,0 +1,15 @@
+//go:build !unix
+
+package commands
+
+import (
+	"os"
+	"strconv"
+)
+
+// terminalWidth returns the number of columns of the terminal f, or 0 if
+// it isn't known. Only $COLUMNS is used on this platform.
+func terminalWidth(f *os.File) int {
+	n, _ := strconv.Atoi(os.Getenv("COLUMNS"))
+	return n
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/miekg/dns"
+)
+
+// writeTinydns writes the records in the format of the tinydns "data" file
+// (https://cr.yp.to/djbdns/tinydns-data.html). A, CNAME, MX, NS, PTR, SOA
+// and TXT have their own line type; the other types that DNS knows are
+// written as generic (":") lines, and those it doesn't as comments. The
+// timestamp and location fields are left empty.
+func writeTinydns(w io.Writer, recs models.Records) {
+	for _, rec := range recs {
+		fqdn := tinydnsName(rec.NameFQDN)
+		target := tinydnsName(rec.GetTargetField())
+		switch rec.Type { // #rtype_variations
+		case "SOA":
+			fmt.Fprintf(w, "Z%s:%s:%s:%d:%d:%d:%d:%d:%d\n", fqdn, target, tinydnsName(rec.SoaMbox),
+				rec.SoaSerial, rec.SoaRefresh, rec.SoaRetry, rec.SoaExpire, rec.SoaMinttl, rec.TTL)
+		case "NS":
+			fmt.Fprintf(w, "&%s::%s:%d\n", fqdn, target, rec.TTL)
+		case "A":
+			fmt.Fprintf(w, "+%s:%s:%d\n", fqdn, rec.GetTargetField(), rec.TTL)
+		case "CNAME":
+			fmt.Fprintf(w, "C%s:%s:%d\n", fqdn, target, rec.TTL)
+		case "MX":
+			fmt.Fprintf(w, "@%s::%s:%d:%d\n", fqdn, target, rec.MxPreference, rec.TTL)
+		case "PTR":
+			fmt.Fprintf(w, "^%s:%s:%d\n", fqdn, target, rec.TTL)
+		case "TXT":
+			// tinydns-data splits the text into strings itself, so a
+			// record of several strings must be written as rdata.
+			if txts := rec.GetTargetTXTSegmented(); len(txts) == 1 {
+				fmt.Fprintf(w, "'%s:%s:%d\n", fqdn, tinydnsEscape(txts[0]), rec.TTL)
+				break
+			}
+			fallthrough
+		default:
+			rdata, err := tinydnsRdata(rec)
+			if err != nil {
+				fmt.Fprintf(w, "# %s %s %s: %s\n", rec.NameFQDN, rec.Type, rec.GetTargetCombined(), err)
+				continue
+			}
+			fmt.Fprintf(w, ":%s:%d:%s:%d\n", fqdn, dns.StringToType[rec.Type], tinydnsEscape(rdata), rec.TTL)
+		}
+	}
+}
+
+// tinydnsName returns the domain name without the trailing dot, escaped.
+func tinydnsName(name string) string {
+	return tinydnsEscape(strings.TrimSuffix(name, "."))
+}
+
+// tinydnsEscape writes the bytes that can't appear in a field (the field
+// separator, the backslash, and the non-printable ones) as \ooo octal.
+func tinydnsEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < ' ' || c > '~' || c == ':' || c == '\\' {
+			fmt.Fprintf(&b, "\\%03o", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// tinydnsRdata returns the wire format of the rdata of rec.
+func tinydnsRdata(rec *models.RecordConfig) (string, error) {
+	if _, ok := dns.StringToType[rec.Type]; !ok {
+		return "", fmt.Errorf("not a DNS record type; not supported by tinydns")
+	}
+	rr := rec.ToRR()
+	buf := make([]byte, dns.MaxMsgSize)
+	nameEnd, err := dns.PackDomainName(rr.Header().Name, buf, 0, nil, false)
+	if err != nil {
+		return "", err
+	}
+	end, err := dns.PackRR(rr, buf, 0, nil, false)
+	if err != nil {
+		return "", err
+	}
+	// The header is the name, then type, class, TTL and rdlength (10 bytes).
+	return string(buf[nameEnd+10 : end]), nil
+}
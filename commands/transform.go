@@ -0,0 +1,223 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args TransformArgs
+	return &cli.Command{
+		Name:  "transform",
+		Usage: "Change the matching records of all domains, and output the result as IR for preview/push --ir",
+		Action: func(c *cli.Context) error {
+			return exit(Transform(args, os.Stderr))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol transform [command options]",
+		Description: `Change the (normalized) records of dnsconfig.js that match --label, --type and
+--target (as with "find"), and output the IR (as with "print-ir").  Providers are
+not accessed, and dnsconfig.js isn't changed.
+
+The records that are changed are listed on stderr.  Preview the result with
+"dnscontrol preview --ir", then push it, or edit dnsconfig.js to match.
+
+EXAMPLES:
+   dnscontrol transform --type MX --target 'old-mail.*' --replace old-mail=new-mail --out new.json
+   dnscontrol transform --regex --type A --target '^192\.0\.2\.' --replace '^192\.0\.2\.=198.51.100.' --expect 12 --out new.json
+   dnscontrol transform --type NS --set-ttl 86400 --out new.json
+   dnscontrol preview --ir new.json`,
+	}
+}())
+
+// TransformArgs encapsulates the flags/arguments for the transform command.
+type TransformArgs struct {
+	GetDNSConfigArgs
+	PrintJSONArgs
+	FilterArgs
+	Label     string
+	Type      string
+	Target    string
+	Regex     bool
+	SetTarget string
+	Replace   string
+	SetTTL    uint
+	Expect    int
+}
+
+func (args *TransformArgs) flags() []cli.Flag {
+	flags := append(args.GetDNSConfigArgs.flags(), args.PrintJSONArgs.flags()...)
+	flags = append(flags, &cli.StringFlag{
+		Name:        "domains",
+		Destination: &args.Domains,
+		Usage:       `Comma separated list of domain names to include`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "label",
+		Destination: &args.Label,
+		Usage:       "Only records whose label (short or FQDN) matches this pattern",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "type",
+		Destination: &args.Type,
+		Usage:       "Only records of this type (comma separated list)",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "target",
+		Destination: &args.Target,
+		Usage:       "Only records whose target matches this pattern",
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "regex",
+		Destination: &args.Regex,
+		Usage:       "Patterns (and the OLD of --replace) are regular expressions instead of globs",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "set-target",
+		Destination: &args.SetTarget,
+		Usage:       "Set the target of the records to this value",
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "replace",
+		Destination: &args.Replace,
+		Usage:       "OLD=NEW: replace OLD by NEW in the target of the records (with --regex, NEW can use $1, etc.)",
+	})
+	flags = append(flags, &cli.UintFlag{
+		Name:        "set-ttl",
+		Destination: &args.SetTTL,
+		Usage:       "Set the TTL of the records to this value",
+	})
+	flags = append(flags, &cli.IntFlag{
+		Name:        "expect",
+		Destination: &args.Expect,
+		Value:       -1,
+		Usage:       "Fail, without output, unless exactly this many records are changed (-1 for any number)",
+	})
+	return flags
+}
+
+// recordTransform is the change that transform makes to each record.
+type recordTransform struct {
+	setTarget string
+	old       *regexp.Regexp // Of --replace; nil for none.
+	new       string
+	ttl       uint32 // 0 to leave the TTL as is.
+}
+
+// newRecordTransform parses the change of the transform flags.
+func newRecordTransform(args TransformArgs) (*recordTransform, error) {
+	if args.SetTarget != "" && args.Replace != "" {
+		return nil, fmt.Errorf("--set-target and --replace can't be used together")
+	}
+	if args.SetTTL > 1<<31-1 {
+		return nil, fmt.Errorf("--set-ttl %d is too large", args.SetTTL)
+	}
+	t := &recordTransform{setTarget: args.SetTarget, ttl: uint32(args.SetTTL)}
+	if args.Replace != "" {
+		old, repl, ok := strings.Cut(args.Replace, "=")
+		if !ok || old == "" {
+			return nil, fmt.Errorf("--replace %q must be OLD=NEW", args.Replace)
+		}
+		if !args.Regex {
+			old, repl = regexp.QuoteMeta(old), strings.ReplaceAll(repl, "$", "$$")
+		}
+		re, err := regexp.Compile(old)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --replace: %w", err)
+		}
+		t.old, t.new = re, repl
+	}
+	if t.setTarget == "" && t.old == nil && t.ttl == 0 {
+		return nil, fmt.Errorf("at least one of --set-target, --replace or --set-ttl is required")
+	}
+	return t, nil
+}
+
+// apply changes rec, and returns true if it was changed.
+func (t *recordTransform) apply(rec *models.RecordConfig) bool {
+	changed := false
+	target := rec.GetTargetField()
+	if t.setTarget != "" {
+		target = t.setTarget
+	}
+	if t.old != nil {
+		target = t.old.ReplaceAllString(target, t.new)
+	}
+	if target != rec.GetTargetField() {
+		rec.SetTarget(target)
+		changed = true
+	}
+	if t.ttl != 0 && rec.TTL != t.ttl {
+		rec.TTL = t.ttl
+		changed = true
+	}
+	return changed
+}
+
+// Transform implements the transform subcommand. The changes are listed
+// on log.
+func Transform(args TransformArgs, log io.Writer) error {
+	if args.Label == "" && args.Type == "" && args.Target == "" {
+		return fmt.Errorf("at least one of --label, --type or --target is required (use --label '*' for all the records)")
+	}
+	t, err := newRecordTransform(args)
+	if err != nil {
+		return err
+	}
+	labelMatch, err := newMatcher(args.Label, args.Regex)
+	if err != nil {
+		return fmt.Errorf("invalid --label: %w", err)
+	}
+	targetMatch, err := newMatcher(args.Target, args.Regex)
+	if err != nil {
+		return fmt.Errorf("invalid --target: %w", err)
+	}
+	types := map[string]bool{}
+	for _, typ := range strings.Split(args.Type, ",") {
+		if typ != "" {
+			types[strings.ToUpper(typ)] = true
+		}
+	}
+
+	opts, err := args.GetDNSConfigArgs.normalizeOptions()
+	if err != nil {
+		return err
+	}
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		return err
+	}
+	errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
+	if PrintValidationErrors(errs) {
+		return fmt.Errorf("exiting due to validation errors")
+	}
+	qualifyTargets(cfg)
+
+	changed := 0
+	for _, dc := range cfg.Domains {
+		if !args.shouldRunDomain(dc.GetUniqueName()) {
+			continue
+		}
+		for _, rec := range findRecords(dc.Records, labelMatch, types, targetMatch) {
+			before := fmt.Sprintf("%s ttl=%d", rec.GetTargetCombinedFunc(nil), rec.TTL)
+			if !t.apply(rec) {
+				continue
+			}
+			changed++
+			fmt.Fprintf(log, "%s: %s %s: %s -> %s ttl=%d\n", dc.GetUniqueName(), rec.GetLabelFQDN(), rec.Type,
+				before, rec.GetTargetCombinedFunc(nil), rec.TTL)
+		}
+	}
+	fmt.Fprintf(log, "%d %s changed.\n", changed, plural(changed, "record", "records"))
+	if args.Expect >= 0 && changed != args.Expect {
+		return fmt.Errorf("--expect %d: %d %s changed; nothing was output", args.Expect, changed, plural(changed, "record was", "records were"))
+	}
+	return PrintJSON(args.PrintJSONArgs, cfg)
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_recordTransform(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    TransformArgs
+		target  string
+		want    string
+		wantTTL uint32
+		changed bool
+	}{
+		{"replace", TransformArgs{Replace: "old-mail=new-mail"}, "old-mail.example.net.", "new-mail.example.net.", 300, true},
+		{"replace literal", TransformArgs{Replace: "a.b=$1"}, "a.b.axb.", "$1.axb.", 300, true},
+		{"replace regex", TransformArgs{Regex: true, Replace: `^192\.0\.2\.(\d+)$=198.51.100.$1`}, "192.0.2.7", "198.51.100.7", 300, true},
+		{"no match", TransformArgs{Replace: "old=new"}, "mail.example.net.", "mail.example.net.", 300, false},
+		{"set target", TransformArgs{SetTarget: "mx.example.com."}, "old.example.net.", "mx.example.com.", 300, true},
+		{"set ttl", TransformArgs{SetTTL: 86400}, "ns1.example.net.", "ns1.example.net.", 86400, true},
+		{"same ttl", TransformArgs{SetTTL: 300}, "ns1.example.net.", "ns1.example.net.", 300, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := newRecordTransform(tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rec := &models.RecordConfig{Type: "MX", TTL: 300}
+			rec.SetLabel("@", "example.com")
+			rec.SetTarget(tt.target)
+			if changed := tr.apply(rec); changed != tt.changed {
+				t.Errorf("got changed=%v, want %v", changed, tt.changed)
+			}
+			if got := rec.GetTargetField(); got != tt.want {
+				t.Errorf("got target %q, want %q", got, tt.want)
+			}
+			if rec.TTL != tt.wantTTL {
+				t.Errorf("got TTL %d, want %d", rec.TTL, tt.wantTTL)
+			}
+		})
+	}
+
+	for _, args := range []TransformArgs{
+		{},
+		{Replace: "old"},
+		{Replace: "=new"},
+		{SetTarget: "a.", Replace: "b=c"},
+		{Regex: true, Replace: "(=x"},
+	} {
+		if _, err := newRecordTransform(args); err == nil {
+			t.Errorf("%+v: expected an error", args)
+		}
+	}
+}
@@ -220,6 +220,29 @@ declare function A(name: string, address: string | number, ...modifiers: RecordM
  */
 declare function AAAA(name: string, address: string, ...modifiers: RecordModifier[]): DomainModifier;
 
+/**
+ * `AFSDB` adds an `AFSDB` record (RFC 1183) to a domain. It names a server of
+ * the AFS cell, or of the DCE cell, that has the name of the record.
+ *
+ * * `subtype` is 1 for an AFS cell database server, or 2 for a DCE
+ *   authenticated name server.
+ * * `hostname` is the name of the server. As with `MX`, a single label is
+ *   relative to the domain; a name with dots must end with a `.`.
+ *
+ * The record type is obsolete (AFS clients now use `SRV` records, RFC 5864),
+ * but it can still be found in old zones.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   AFSDB("@", 1, "afsdb1"), // afsdb1.example.com
+ *   AFSDB("@", 2, "dce.example.net."),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/afsdb
+ */
+declare function AFSDB(name: string, subtype: number, hostname: string, ...modifiers: RecordModifier[]): DomainModifier;
+
 /**
  * AKAMAICDN is a proprietary record type that is used to configure [Zone Apex Mapping](https://blogs.akamai.com/2019/08/fast-dns-zone-apex-mapping-dnssec.html).
  * The AKAMAICDN target must be preconfigured in the Akamai network.
@@ -233,6 +256,11 @@ declare function AKAMAICDN(name: string, target: string, ...modifiers: RecordMod
  *
  * Different providers handle ALIAS records differently, and many do not support it at all. Attempting to use ALIAS records with a DNS provider type that does not support them will result in an error.
  *
+ * An ALIAS can't have the same name as a CNAME; this is an error. An ALIAS with
+ * the same name as an A or AAAA record is a warning: the ALIAS is served as A
+ * and AAAA records, so, depending on the provider, the zone is rejected or one
+ * of them is ignored. Other types (MX, TXT, etc.) can share the name.
+ *
  * The name should be the relative label for the domain.
  *
  * Target should be a string representing the target. If it is a single label we will assume it is a relative name on the current domain. If it contains *any* dots, it should be a fully qualified domain name, ending with a `.`.
@@ -349,6 +377,14 @@ declare function AZURE_ALIAS(name: string, type: "A" | "AAAA" | "CNAME", target:
  *
  * Value is a string. The format of the contents is different depending on the tag. DNSControl will handle any escaping or quoting required, similar to TXT records. For example use `CAA("@", "issue", "letsencrypt.org")` rather than `CAA("@", "issue", "\"letsencrypt.org\"")`.
  *
+ * The value of an `"iodef"` record is the URL that CAs report to: `mailto:` (one
+ * or more addresses, separated by commas), `http:` or `https:`. It is checked: a
+ * bare address such as `security@example.com` is an error (write
+ * `mailto:security@example.com`), and so is an address that is malformed. A URL
+ * can't contain non-ASCII characters, so an internationalized address must be
+ * percent-encoded before the `@`, and its domain written in punycode (`xn--...`);
+ * otherwise there is a warning.
+ *
  * Flags are controlled by modifier:
  * - `CAA_CRITICAL`: Issuer critical flag. CA that does not understand this tag will refuse to issue certificate for this domain.
  *
@@ -366,6 +402,30 @@ declare function AZURE_ALIAS(name: string, type: "A" | "AAAA" | "CNAME", target:
  *
  * DNSControl contains a [`CAA_BUILDER`](CAA_BUILDER.md) which can be used to simply create `CAA()` records for your domains. Instead of creating each CAA record individually, you can simply configure your report mail address, the authorized certificate authorities and the builder cares about the rest.
  *
+ * ## Auditing CAA coverage
+ *
+ * To check which CAs may issue certificates for each domain, run:
+ *
+ * ```shell
+ * dnscontrol check --report-caa
+ * ```
+ *
+ * It prints the CAs authorized by the CAA records at the apex of each domain,
+ * and flags the domains that have none:
+ *
+ * ```text
+ * DOMAIN       ISSUE                         ISSUEWILD   IODEF
+ * example.com  letsencrypt.org, sectigo.com  (none)      mailto:security@example.com
+ * example.net  -                             -           -  NO CAA: any CA may issue
+ * example.org  letsencrypt.org               (as issue)  -
+ * 2 of 3 domains have CAA records at the apex.
+ * ```
+ *
+ * `(none)` means that no CA is authorized (`";"`), and `(as issue)` that
+ * wildcard certificates follow the `issue` records. Each domain without CAA
+ * records counts as a warning, so `check --report-caa --fail-on-warning`
+ * fails if any domain is missing them.
+ *
  * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/caa
  */
 declare function CAA(name: string, tag: "issue" | "issuewild" | "iodef", value: string, ...modifiers: RecordModifier[]): DomainModifier;
@@ -471,6 +531,30 @@ declare function CAA(name: string, tag: "issue" | "issuewild" | "iodef", value:
  */
 declare function CAA_BUILDER(opts: { label?: string; iodef: string; iodef_critical?: boolean; issue: string[]; issue_critical?: boolean; issuewild: string[]; issuewild_critical?: boolean; ttl?: Duration }): DomainModifier;
 
+/**
+ * `CERT` adds a `CERT` record (RFC 4398) to a domain. It publishes a
+ * certificate, such as an X.509 certificate or an OpenPGP key.
+ *
+ * * `type` is the certificate type, either as a number or as one of the
+ *   mnemonics `PKIX` (1, X.509), `SPKI` (2), `PGP` (3), `IPKIX` (4), `ISPKI` (5),
+ *   `IPGP` (6), `ACPKIX` (7), `IACPKIX` (8), `URI` (253) or `OID` (254).
+ *   Numbers 65280-65534 (experimental) are also accepted.
+ * * `keytag` is the key tag of the key, or 0.
+ * * `algorithm` is the DNSSEC algorithm number of the key (for example 8 for
+ *   RSA/SHA-256), or 0 if unknown.
+ * * `certificate` is the certificate, base64 encoded.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   CERT("alice", "PGP", 0, 0, "mQINBFkzs/0BEAC..."),
+ *   CERT("www", 1, 12345, 8, "MIIDdzCCAl+gAwIBAgIJ..."),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/cert
+ */
+declare function CERT(name: string, type: number | string, keytag: number, algorithm: number, certificate: string, ...modifiers: RecordModifier[]): DomainModifier;
+
 /**
  * WARNING: Cloudflare is removing this feature and replacing it with a new
  * feature called "Dynamic Single Redirect". DNSControl will automatically
@@ -613,6 +697,31 @@ declare function CLOUDNS_WR(name: string, target: string, ...modifiers: RecordMo
  * END);
  * ```
  *
+ * ## Finding labels that could be CNAMEs
+ *
+ * When several labels have the same `A` and `AAAA` records, each change of the
+ * addresses has to be made to all of them. `dnscontrol check --suggest` lists
+ * such labels, grouped by the records they share, and the CNAMEs that could
+ * replace them:
+ *
+ * ```text
+ * example.com: 4 labels have the same records (A 192.0.2.1, A 192.0.2.2):
+ *     @ www api mail
+ *   Consider replacing them with CNAMEs to "@":
+ *     CNAME("www", "@"),
+ *     CNAME("api", "@"),
+ *   (mail keeps its records: it has other records or is the apex.)
+ * ```
+ *
+ * Nothing is changed. A label that has other records (such as `MX`), and the
+ * apex, can't be a CNAME, so they are only suggested as the target.
+ *
+ * ## Checking the targets
+ *
+ * `dnscontrol check --check-targets` resolves the targets of the CNAMEs that
+ * point outside of `dnsconfig.js`, and reports those that don't exist, which
+ * may be open to a subdomain takeover. See [`RESOLVES_TO()`](../record-modifiers/RESOLVES_TO.md).
+ *
  * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/cname
  */
 declare function CNAME(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
@@ -689,6 +798,14 @@ declare function CNAME(name: string, target: string, ...modifiers: RecordModifie
  * may have noticed this mistake, but will your coworkers?  Will you in
  * six months? You get the idea.
  *
+ * Each view is validated, and its corrections computed, as a separate domain.
+ * The views are usually at different DNS providers. If several views are at
+ * the same DNS provider, the provider must keep them apart (as BIND does with
+ * its default `filenameformat`, and ROUTE53 does with
+ * [`R53_ZONE()`](../record-modifiers/R53_ZONE.md)). Otherwise the views are the
+ * same zone at the provider, and pushing one undoes the other; `check` and
+ * `preview` warn about this.
+ *
  * DNSControl command line flag `--domains` matches the full name (with the "!").  If you
  * define domains `example.com!george` and `example.com!john` then:
  *
@@ -725,6 +842,11 @@ declare function D(name: string, registrar: string, ...modifiers: DomainModifier
  * END);
  * ```
  *
+ * Because the defaults come first, a domain can still override them. For the
+ * TTL, the order of precedence is: `TTL()` on the record, then a `DefaultTTL()`
+ * in the `D()` (for the records that follow it), then a `DefaultTTL()` in
+ * `DEFAULTS()`, then the internal default of 300 seconds.
+ *
  * If you want to clear the defaults, you can do the following.
  * The domain `example2.com` will **not** have the defaults set.
  *
@@ -741,13 +863,47 @@ declare function D(name: string, registrar: string, ...modifiers: DomainModifier
 declare function DEFAULTS(...modifiers: DomainModifier[]): void;
 
 /**
- * DHCID adds a DHCID record to the domain.
+ * `DELEGATE()` delegates a child zone to other nameservers. It creates one
+ * [`NS()`](NS.md) record at `name` for each item in `nameservers`.
  *
- * Digest should be a string.
+ * The name may not be `@` (the bare domain). The nameservers of the zone itself
+ * are controlled via [`NAMESERVER()`](NAMESERVER.md). Using `DELEGATE()` makes it
+ * obvious which NS records are downward delegations and which are the zone's own
+ * nameservers.
+ *
+ * Record modifiers such as [`TTL()`](../record-modifiers/TTL.md) are applied to
+ * every NS record that is created.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   // Delegate "sub.example.com" to another DNS service:
+ *   DELEGATE("sub", ["ns1.example2.com.", "ns2.example2.com."]),
+ *   // Same, with a TTL:
+ *   DELEGATE("lab", ["ns1.example2.com.", "ns2.example2.com."], TTL("1h")),
+ * END);
+ * ```
+ *
+ * During `preview` and `push`, DNSControl warns if the NS records declared at the
+ * apex (with `NS("@", ...)`) do not match the nameservers reported by the DNS
+ * providers and listed with [`NAMESERVER()`](NAMESERVER.md). Such a mismatch
+ * usually means the delegation has drifted.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/delegate
+ */
+declare function DELEGATE(name: string, nameservers: string[], ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * DHCID adds a DHCID record (RFC 4701) to the domain. DHCP servers that
+ * update DNS publish them to record which client owns a name.
+ *
+ * The digest is the base64 RDATA of the record, as in a zone file: a 2-octet
+ * identifier type, a 1-octet digest type and the digest. It is validated: it
+ * must be base64 and at least 4 octets long, and a SHA-256 digest (digest
+ * type 1) must be 32 octets.
  *
  * ```javascript
  * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
- *   DHCID("example.com", "ABCDEFG"),
+ *   DHCID("client", "AAIBY2/AuCccgoJbsaxcQc9TUapptP69lOjxfNuVAA2kjEA="),
  * END);
  * ```
  *
@@ -755,6 +911,36 @@ declare function DEFAULTS(...modifiers: DomainModifier[]): void;
  */
 declare function DHCID(name: string, digest: string, ...modifiers: RecordModifier[]): DomainModifier;
 
+/**
+ * `DISABLED()` keeps a record in `dnsconfig.js` without pushing it. The
+ * record is validated like any other and appears in the IR (`print-ir`), but
+ * `preview` and `push` leave it out of the corrections, as if it were
+ * commented out. This is better than commenting the record out, which hides
+ * mistakes until the record is enabled.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   A("www", "192.0.2.10"),
+ *   A("beta", "192.0.2.20", DISABLED()), // not launched yet
+ * END);
+ * ```
+ *
+ * `preview` notes each disabled record:
+ *
+ * ```text
+ * Note: beta.example.com A 192.0.2.20 is DISABLED(); not created at bind
+ * ```
+ *
+ * Like a commented-out record, a disabled record that already exists at the
+ * provider is deleted (unless [`NO_PURGE`](../domain-modifiers/NO_PURGE.md)
+ * or an `IGNORE` keeps it).
+ *
+ * To enable the record, remove `DISABLED()`.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/record-modifiers/disabled
+ */
+declare function DISABLED(): RecordModifier;
+
 /**
  * `DISABLE_IGNORE_SAFETY_CHECK()` disables the safety check. Normally it is an
  * error to insert records that match an `IGNORE()` pattern. This disables that
@@ -780,6 +966,51 @@ declare function DHCID(name: string, digest: string, ...modifiers: RecordModifie
  */
 declare const DISABLE_IGNORE_SAFETY_CHECK: DomainModifier;
 
+/**
+ * `DKIM_BUILDER` creates the DKIM TXT record for a selector, with the public key
+ * read from a file. This saves pasting the long base64 key into `dnsconfig.js`,
+ * and makes key rotation a matter of adding a selector that points to the new
+ * key file.
+ *
+ * The key file is read when `dnsconfig.js` is run. It can be PEM
+ * (`-----BEGIN PUBLIC KEY-----`) or DER, and the key can be RSA or Ed25519.
+ * A missing or unreadable file is an error.
+ *
+ * ## Example
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   // The key being retired, and the new one.
+ *   DKIM_BUILDER({selector: "2024", keyfile: "./dkim/2024.pem"}),
+ *   DKIM_BUILDER({selector: "2025", keyfile: "./dkim/2025.pem", flags: ["y"]}),
+ * END);
+ * ```
+ *
+ * This yields the following records:
+ *
+ * ```text
+ * 2024._domainkey   IN  TXT "v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA..."
+ * 2025._domainkey   IN  TXT "v=DKIM1; t=y; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA..."
+ * ```
+ *
+ * ### Parameters
+ *
+ * * `selector:` The DKIM selector (the record is `selector._domainkey`)
+ * * `keyfile:` The file with the public key. As with `require()`, a name that starts with `.` is relative to the file that calls `DKIM_BUILDER`, and other names are relative to the current directory.
+ * * `label:` The DNS label the selector is under (default: `"@"`)
+ * * `flags:` Array of flags (`t=`), such as `"y"` (testing) and `"s"` (optional)
+ * * `hashAlgorithms:` Array of acceptable hash algorithms (`h=`), such as `"sha256"` (optional)
+ * * `ttl:` Input for `TTL` method (optional)
+ *
+ * ### Caveats
+ *
+ * * Only the public key is accepted. A private key file is rejected, so that it is not published by mistake.
+ * * TXT records longer than 255 octets are split into several strings automatically.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/dkim_builder
+ */
+declare function DKIM_BUILDER(opts: { selector: string; keyfile: string; label?: string; flags?: string[]; hashAlgorithms?: string[]; ttl?: Duration }): DomainModifier;
+
 /**
  * DNSControl contains a `DMARC_BUILDER` which can be used to simply create
  * DMARC policies for your domains.
@@ -887,6 +1118,15 @@ declare function DMARC_BUILDER(opts: { label?: string; version?: string; policy:
  * END);
  * ```
  *
+ * A DNAME redirects all the names below its name, but not the name itself: in
+ * this example, `www.sub.example.com` is `www.example.net`, and `sub.example.com`
+ * is still looked up in `example.com`. A record at a name below a DNAME, such
+ * as `CNAME("www.sub", ...)`, is never returned. DNSControl warns about each
+ * one, with the DNAME that hides it, and reports more than one DNAME at a name
+ * as an error.
+ *
+ * Turn the warnings off with `--disable-check dname`.
+ *
  * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/dname
  */
 declare function DNAME(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
@@ -912,6 +1152,29 @@ declare function DNAME(name: string, target: string, ...modifiers: RecordModifie
  */
 declare function DNSKEY(name: string, flags: number, protocol: number, algorithm: number, publicKey: string, ...modifiers: RecordModifier[]): DomainModifier;
 
+/**
+ * `DOMAIN_CLASS` gives the domain one or more classes. The
+ * [`DOMAIN_TEMPLATE()`](../top-level-functions/DOMAIN_TEMPLATE.md) of each
+ * class is merged into the domain.
+ *
+ * The classes are stored in the `domain_class` metadata of the domain, as a
+ * comma-separated list: `DOMAIN_CLASS("corporate", "marketing")` is the same as
+ * `{domain_class: "corporate,marketing"}`.
+ *
+ * ```javascript
+ * DOMAIN_TEMPLATE("corporate", CAA("@", "issue", "letsencrypt.org"), END);
+ * DOMAIN_TEMPLATE("marketing", TXT("@", "google-site-verification=abc"), END);
+ *
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   DOMAIN_CLASS("corporate", "marketing"),
+ *   A("@", "192.0.2.1"),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/domain_class
+ */
+declare function DOMAIN_CLASS(...classes: string[]): DomainModifier;
+
 /**
  * `DOMAIN_ELSEWHERE()` is a helper macro that lets you easily indicate that
  * a domain's zones are managed elsewhere. That is, it permits you easily delegate
@@ -979,6 +1242,45 @@ declare function DOMAIN_ELSEWHERE(name: string, registrar: string, nameserver_na
  */
 declare function DOMAIN_ELSEWHERE_AUTO(name: string, domain: string, registrar: string, dnsProvider: string): void;
 
+/**
+ * `DOMAIN_TEMPLATE` defines the baseline of a class of domains: records (and
+ * other modifiers) that are merged into every domain of the class. A domain
+ * gets a class with [`DOMAIN_CLASS()`](../domain-modifiers/DOMAIN_CLASS.md).
+ * The baseline is defined once, instead of being copied into each `D()`.
+ *
+ * ```javascript
+ * DOMAIN_TEMPLATE("corporate",
+ *   CAA("@", "issue", "letsencrypt.org"),
+ *   CAA("@", "iodef", "mailto:security@example.net"),
+ *   CNAME("security", "security.example.net."), // security.txt
+ *   CNAME("mta-sts", "mta-sts.example.net."),
+ * END);
+ *
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   DOMAIN_CLASS("corporate"),
+ *   A("@", "192.0.2.1"),
+ * END);
+ * ```
+ *
+ * The template is merged when the `D()` ends, so the templates must be defined
+ * before the domains that use them. A class without a template is an error.
+ *
+ * * The domain's own records win: a record of the template whose label and type
+ *   the domain already has is left out. In a domain of the example above,
+ *   `CNAME("security", "security.example.com.")` replaces the baseline one.
+ * * The domain's own metadata wins too. The `IGNORE*()` rules, nameservers and
+ *   DNS providers of the template are added to the domain's.
+ * * The records get the domain's [`DefaultTTL()`](../domain-modifiers/DefaultTTL.md)
+ *   unless they have a [`TTL()`](../record-modifiers/TTL.md).
+ *
+ * The merged records are ordinary records of the domain: `print-ir` shows them,
+ * and `preview` and `push` treat them like the others. A domain of several
+ * classes gets all their templates.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/top-level-functions/domain_template
+ */
+declare function DOMAIN_TEMPLATE(className: string, ...modifiers: DomainModifier[]): void;
+
 /**
  * DS adds a DS record to the domain.
  *
@@ -996,6 +1298,9 @@ declare function DOMAIN_ELSEWHERE_AUTO(name: string, domain: string, registrar:
  * END);
  * ```
  *
+ * `preview --verify-ds` and `push --verify-ds` check that each `DS` matches a
+ * DNSKEY that the child zone publishes. See [preview/push](../../preview-push.md).
+ *
  * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/ds
  */
 declare function DS(name: string, keytag: number, algorithm: number, digesttype: number, digest: string, ...modifiers: RecordModifier[]): DomainModifier;
@@ -1129,6 +1434,42 @@ declare function DefaultTTL(ttl: Duration): DomainModifier;
  */
 declare function DnsProvider(name: string, nsCount?: number): DomainModifier;
 
+/**
+ * EUI48 adds an EUI48 record (RFC 7043) to the domain. It holds a 48-bit
+ * MAC address, such as the one of a network interface.
+ *
+ * The address is written as 6 pairs of hex digits separated by dashes, as in
+ * `00-00-5e-00-53-2a`. Upper and lower case are accepted. For 64-bit
+ * addresses, use [`EUI64`](EUI64.md).
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   EUI48("host", "00-00-5e-00-53-2a"),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/eui48
+ */
+declare function EUI48(name: string, address: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * EUI64 adds an EUI64 record (RFC 7043) to the domain. It holds a 64-bit
+ * MAC address, such as the one of a network interface.
+ *
+ * The address is written as 8 pairs of hex digits separated by dashes, as in
+ * `00-00-5e-ef-10-00-00-2a`. Upper and lower case are accepted. For 48-bit
+ * addresses, use [`EUI48`](EUI48.md).
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   EUI64("host", "00-00-5e-ef-10-00-00-2a"),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/eui64
+ */
+declare function EUI64(name: string, address: string, ...modifiers: RecordModifier[]): DomainModifier;
+
 /**
  * Documentation needed.
  *
@@ -1136,6 +1477,49 @@ declare function DnsProvider(name: string, nsCount?: number): DomainModifier;
  */
 declare function FRAME(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
 
+/**
+ * `FROM_CSV` generates records from the rows of a CSV file, such as an
+ * inventory exported from a spreadsheet. The file is read when `dnsconfig.js`
+ * is executed. `fn` is called for each row and returns the records for it (or
+ * any other domain modifiers, or an array of them). If it returns nothing,
+ * the row is skipped.
+ *
+ * The first row of the file is the names of the columns. `fn` receives the
+ * row as an object keyed by those names, and the line number the row is on.
+ * Cells that are integers (without leading zeros) are numbers; the others are
+ * strings, and empty cells are `""`.
+ *
+ * ```text
+ * name,ip,ttl
+ * www,192.0.2.10,600
+ * mail,192.0.2.20,
+ * ```
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   FROM_CSV("hosts.csv", function (row, line) {
+ *     if (row.ttl === "") {
+ *       return A(row.name, row.ip);
+ *     }
+ *     return A(row.name, row.ip, TTL(row.ttl));
+ *   }),
+ * END);
+ * ```
+ *
+ * The filename is relative to the file that calls `FROM_CSV`. Files whose
+ * name ends in `.tsv` are read as tab-separated; for other delimiters, pass
+ * the `delimiter` argument (e.g. `";"`).
+ *
+ * Malformed files are an error that gives the line number:
+ *
+ * ```text
+ * FROM_CSV: hosts.csv: record on line 3: wrong number of fields
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/from_csv
+ */
+declare function FROM_CSV(filename: string, fn: (row: Record<string, string | number>, line: number) => DomainModifier | void, delimiter?: string): DomainModifier;
+
 /**
  * `HASH` hashes `value` using the hashing algorithm given in `algorithm`
  * (accepted values `SHA1`, `SHA256`, and `SHA512`) and returns the hex encoded
@@ -1165,6 +1549,35 @@ declare function FRAME(name: string, target: string, ...modifiers: RecordModifie
  */
 declare function HASH(algorithm: "SHA1" | "SHA256" | "SHA512", value: string): string;
 
+/**
+ * `HIP` adds a `HIP` record (RFC 8005) to a domain. It publishes the Host
+ * Identity of a host that uses the Host Identity Protocol, and the rendezvous
+ * servers through which it can be reached.
+ *
+ * * `algorithm` is the PK algorithm of the public key, either as a number or
+ *   as one of the mnemonics `DSA` (1), `RSA` (2), `ECDSA` (3) or `EdDSA` (4).
+ * * `hit` is the Host Identity Tag (HIT), hex encoded.
+ * * `publickey` is the public key of the Host Identity, base64 encoded.
+ * * `servers` is the list of the hostnames of the rendezvous servers, in order
+ *   of preference, or `[]` for none. A single server can be given as a
+ *   string.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   HIP("www", "RSA", "200100107B1A74DF365639CC39F1D578",
+ *     "AwEAAbdxyhNuSutc5EMzxTs9LBPCIkOFH8cIvM4p9+LrV4e19WzK00...",
+ *     ["rvs1.example.com.", "rvs2"]),
+ *   HIP("host", "ECDSA", "2001001F4DB1C16E6F3A3E28ACB4A811", "BD2RBLbv...", "rvs.example.net."),
+ * END);
+ * ```
+ *
+ * The HIT is stored in uppercase. The lengths of the HIT and of the public
+ * key, which are in the record on the wire, are computed from them.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/hip
+ */
+declare function HIP(name: string, algorithm: number | string, hit: string, publickey: string, servers: string | string[], ...modifiers: RecordModifier[]): DomainModifier;
+
 /**
  * HTTPS adds an HTTPS record to a domain. The name should be the relative label for the record. Use `@` for the domain apex. The HTTPS record is a special form of the SVCB resource record.
  *
@@ -1256,7 +1669,8 @@ declare function HTTPS(name: string, priority: number, target: string, params: s
  * END);
  * ```
  *
- * Ignore Let's Encrypt (ACME) validation records:
+ * Ignore Let's Encrypt (ACME) validation records (or use
+ * [`IGNORE_ACME()`](IGNORE_ACME.md), which does the same):
  *
  * ```javascript
  * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
@@ -1498,6 +1912,36 @@ declare function HTTPS(name: string, priority: number, target: string, params: s
  */
 declare function IGNORE(labelSpec: string, typeSpec?: string, targetSpec?: string): DomainModifier;
 
+/**
+ * `IGNORE_ACME()` ignores the TXT records of ACME DNS-01 challenges, which
+ * ACME clients such as certbot, lego or cert-manager create and delete
+ * themselves to get certificates from Let's Encrypt and other ACME CAs.
+ *
+ * It is the same as:
+ *
+ * ```javascript
+ *   IGNORE("_acme-challenge", "TXT"),    // the challenges of the domain
+ *   IGNORE("_acme-challenge.**", "TXT"), // and of all its subdomains
+ * ```
+ *
+ * Only TXT records are ignored: a `CNAME` that delegates the challenges of a
+ * name to another zone (e.g. for [acme-dns](https://github.com/joohoi/acme-dns))
+ * can still be managed by DNSControl.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   IGNORE_ACME(),
+ *   A("www", "192.0.2.1"),
+ *   CNAME("_acme-challenge.shop", "d420c923-bbd7-4056-ab64-c3ca54c9b3cf.auth.acme-dns.example.net."),
+ * END);
+ * ```
+ *
+ * See [`IGNORE()`](IGNORE.md) for how ignored records are handled.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/ignore_acme
+ */
+declare function IGNORE_ACME(): DomainModifier;
+
 /**
  * `IGNORE_NAME(a)` is the same as `IGNORE(a, "*", "*")`.
  *
@@ -1507,6 +1951,42 @@ declare function IGNORE(labelSpec: string, typeSpec?: string, targetSpec?: strin
  */
 declare function IGNORE_NAME(pattern: string, rTypes?: string): DomainModifier;
 
+/**
+ * `IGNORE_RECENT(age, labelSpec, typeSpec, targetSpec)` is
+ * [`IGNORE()`](IGNORE.md) for the records that were changed at the provider
+ * less than `age` ago. The older records that match are not ignored: they are
+ * deleted, like any other record that isn't in `dnsconfig.js`.
+ *
+ * This is for records that an external system creates and deletes (session
+ * tokens, challenges of a verification, etc.), when it sometimes fails to
+ * delete them: DNSControl leaves the fresh ones alone, and cleans up the stale
+ * ones.
+ *
+ * The age is a number of seconds, or a string with a unit: `"30m"`, `"6h"`,
+ * `"2d"`, `"1w"` (as for [`TTL`](../record-modifiers/TTL.md)). The patterns are
+ * those of `IGNORE()`, and default to `"*"`.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   A("www", "192.0.2.1"),
+ *   // The challenges of the last day are being used; older ones are leftovers.
+ *   IGNORE_RECENT("1d", "_challenge.**", "TXT"),
+ * END);
+ * ```
+ *
+ * The age of a record is known only if the provider reports when it was last
+ * changed. `CLOUDFLAREAPI` and `DESEC` do. With the other providers, and for a
+ * record whose time isn't reported, `IGNORE_RECENT()` is the same as `IGNORE()`:
+ * the records that match are always ignored, since deleting them would be the
+ * unsafe choice.
+ *
+ * The old records are deleted when `push` runs; run it regularly (e.g. daily)
+ * to keep the zone clean.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/ignore_recent
+ */
+declare function IGNORE_RECENT(age: Duration, labelSpec?: string, typeSpec?: string, targetSpec?: string): DomainModifier;
+
 /**
  * `IGNORE_TARGET_NAME(target)` is the same as `IGNORE("*", "*", target)`.
  *
@@ -1558,6 +2038,43 @@ declare function INCLUDE(domain: string): DomainModifier;
  */
 declare function IP(ip: string): number;
 
+/**
+ * L32 adds an L32 record (RFC 6742) to the domain. It holds a 32-bit Locator
+ * of an ILNPv4 host: the IPv4 address of the subnet the host is attached to.
+ *
+ * The preference is a number from 0 to 65535; lower values are preferred. The
+ * locator is written as an IPv4 address, as in `10.1.2.0`. See
+ * [`NID`](NID.md) for an example.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   L32("host", 10, "10.1.2.0"),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/l32
+ */
+declare function L32(name: string, preference: number, locator: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * L64 adds an L64 record (RFC 6742) to the domain. It holds a 64-bit Locator
+ * of an ILNPv6 host: the IPv6 routing prefix of the subnet the host is
+ * attached to.
+ *
+ * The preference is a number from 0 to 65535; lower values are preferred. The
+ * locator is written like the node identifier of a [`NID`](NID.md) record:
+ * 4 groups of hex digits separated by colons, as in `2001:0db8:1140:1000`.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   L64("host", 10, "2001:0db8:1140:1000"),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/l64
+ */
+declare function L64(name: string, preference: number, locator: string, ...modifiers: RecordModifier[]): DomainModifier;
+
 /**
  * The parameter number types are as follows:
  *
@@ -1837,6 +2354,27 @@ declare function LOC_BUILDER_DMS_STR(opts: { label?: string; str: string; alt?:
  */
 declare function LOC_BUILDER_STR(opts: { label?: string; str: string; alt?: number; ttl?: Duration }): DomainModifier;
 
+/**
+ * LP adds an LP record (RFC 6742) to the domain. It points an ILNP host, such
+ * as a mobile node, at another name that holds its [`L32`](L32.md) or
+ * [`L64`](L64.md) records, so that the locators of many hosts can be changed
+ * in one place.
+ *
+ * The preference is a number from 0 to 65535; lower values are preferred. The
+ * target is a hostname, and must not be the name of the LP record itself.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   NID("mobile", 10, "0014:4fff:ff20:ee64"),
+ *   LP("mobile", 10, "l64-subnet1.example.com."),
+ *   L64("l64-subnet1", 10, "2001:0db8:1140:1000"),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/lp
+ */
+declare function LP(name: string, preference: number, fqdn: string, ...modifiers: RecordModifier[]): DomainModifier;
+
 /**
  * DNSControl offers a `M365_BUILDER` which can be used to simply set up Microsoft 365 for a domain in an opinionated way.
  *
@@ -1890,6 +2428,53 @@ declare function LOC_BUILDER_STR(opts: { label?: string; str: string; alt?: numb
  */
 declare function M365_BUILDER(opts: { label?: string; mx?: boolean; autodiscover?: boolean; dkim?: boolean; skypeForBusiness?: boolean; mdm?: boolean; domainGUID?: string; initialDomain?: string }): DomainModifier;
 
+/**
+ * `MTA_STS_BUILDER` creates the `_mta-sts` TXT record that announces an
+ * [MTA-STS](https://www.rfc-editor.org/rfc/rfc8461) policy: the senders that
+ * find it fetch the policy from `https://mta-sts.example.com/.well-known/mta-sts.txt`
+ * and only deliver the mail of the domain over TLS, to the MX hosts of the
+ * policy.
+ *
+ * DNSControl doesn't serve the policy file. Point `mta-sts` at the web server
+ * that does (with an `A` or `CNAME` record), and change the `id` each time the
+ * file changes: the senders keep the policy they have until the `id` changes.
+ *
+ * Use [`TLSRPT_BUILDER`](TLSRPT_BUILDER.md) to get reports of the failures.
+ *
+ * ## Example
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   MTA_STS_BUILDER({id: "20240131"}),
+ *   TLSRPT_BUILDER({rua: ["mailto:tlsrpt@example.com"]}),
+ *   CNAME("mta-sts", "policy-server.example.net."),
+ * END);
+ * ```
+ *
+ * This yields the following records:
+ *
+ * ```text
+ * _mta-sts     IN  TXT    "v=STSv1; id=20240131"
+ * _smtp._tls   IN  TXT    "v=TLSRPTv1; rua=mailto:tlsrpt@example.com"
+ * mta-sts      IN  CNAME  policy-server.example.net.
+ * ```
+ *
+ * ### Parameters
+ *
+ * * `id:` The ID of the policy: 1 to 32 letters and digits, such as the date of the policy file
+ * * `label:` The DNS label the policy is for (default: `"@"`)
+ * * `ttl:` Input for `TTL` method (optional)
+ *
+ * ### Validation
+ *
+ * An `id` that isn't 1 to 32 letters and digits is an error. The same check is
+ * made on the `v=STSv1` TXT records at `_mta-sts` that are written without
+ * `MTA_STS_BUILDER`.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/mta_sts_builder
+ */
+declare function MTA_STS_BUILDER(opts: { id: string; label?: string; ttl?: Duration }): DomainModifier;
+
 /**
  * MX adds an MX record to the domain.
  *
@@ -1904,6 +2489,22 @@ declare function M365_BUILDER(opts: { label?: string; mx?: boolean; autodiscover
  * END);
  * ```
  *
+ * DNSControl warns (but doesn't fail) about two common mistakes:
+ *
+ * * Several MX records of a name have the same priority. Mail is then balanced
+ *   between them, which is fine if that's intended, but usually one of them was
+ *   meant as a backup.
+ * * The only MX at priority 0 has a backup at a higher priority that points at
+ *   the same host, which backs up nothing.
+ *
+ * Turn these warnings off with `--disable-check mx-preferences`.
+ *
+ * DNSControl also warns about an MX whose target is a name inside the domain
+ * without an A or AAAA record (or that is a CNAME, which an MX may not point
+ * at), since mail to it can't be delivered. Targets outside the domain, or in
+ * a subdomain delegated with `NS()`, aren't checked. Turn this warning off
+ * with `--disable-check in-zone-targets`.
+ *
  * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/mx
  */
 declare function MX(name: string, priority: number, target: string, ...modifiers: RecordModifier[]): DomainModifier;
@@ -1917,7 +2518,7 @@ declare function MX(name: string, priority: number, target: string, ...modifiers
  *
  * This is different than the [`NS()`](NS.md) function, which inserts NS records
  * in the current zone and accepts a label. [`NS()`](NS.md) is useful for downward
- * delegations. `NAMESERVER()` is for informing upstream delegations.
+ * delegations (see also [`DELEGATE()`](DELEGATE.md)). `NAMESERVER()` is for informing upstream delegations.
  *
  * For more information, refer to [this page](../../nameservers.md).
  *
@@ -2212,6 +2813,30 @@ declare function NAMESERVER_TTL(ttl: Duration): DomainModifier;
  */
 declare function NAPTR(subdomain: string, order: number, preference: number, terminalflag: string, service: string, regexp: string, target: string): DomainModifier;
 
+/**
+ * NID adds a NID record (RFC 6742) to the domain. It holds the 64-bit Node
+ * Identifier of a host that uses ILNP, the Identifier-Locator Network
+ * Protocol.
+ *
+ * The preference is a number from 0 to 65535; lower values are preferred. The
+ * node identifier is written as 4 groups of hex digits separated by colons,
+ * as in `0014:4fff:ff20:ee64`.
+ *
+ * The locators of the host are in [`L32`](L32.md) and [`L64`](L64.md)
+ * records, or are found through an [`LP`](LP.md) record.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   NID("host", 10, "0014:4fff:ff20:ee64"),
+ *   L64("host", 10, "2001:0db8:1140:1000"),
+ *   L32("host", 20, "10.1.2.0"),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/nid
+ */
+declare function NID(name: string, preference: number, nodeid: string, ...modifiers: RecordModifier[]): DomainModifier;
+
 /**
  * `NO_PURGE` indicates that existing records should not be deleted from a domain.
  * Records will be added and updated, but not removed.
@@ -2283,6 +2908,20 @@ declare const NO_PURGE: DomainModifier;
  * END);
  * ```
  *
+ * If a nameserver is inside the domain, such as `ns1.foo.example.com` for
+ * `NS("foo", "ns1.foo.example.com.")`, the parent zone must have its address
+ * as glue: add an `A` or `AAAA` record for it. DNSControl warns about an
+ * in-zone nameserver without one, whether it is the target of an `NS()` or a
+ * [`NAMESERVER()`](NAMESERVER.md). Use `--disable-check glue` to turn the
+ * warning off.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   NS("foo", "ns1.foo.example.com."),
+ *   A("ns1.foo", "10.10.10.10"), // Glue for ns1.foo.example.com
+ * END);
+ * ```
+ *
  * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/ns
  */
 declare function NS(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
@@ -2391,6 +3030,100 @@ declare function NewDnsProvider(name: string, type?: string, meta?: object): str
  */
 declare function NewRegistrar(name: string, type?: string, meta?: object): string;
 
+/**
+ * `ONLY_PROVIDERS` limits a record to some of the DNS providers of its domain.
+ * The record is only created at those providers. At the domain's other
+ * providers it is unmanaged: it is not added, and if a provider already has
+ * the same record, it is left as it is.
+ *
+ * This is useful when a zone is served by several providers but a record, such
+ * as a health-check record, only makes sense at one of them.
+ *
+ * ```javascript
+ * var DSP_A = NewDnsProvider("dsp_a");
+ * var DSP_B = NewDnsProvider("dsp_b");
+ *
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_A), DnsProvider(DSP_B),
+ *   A("www", "192.0.2.10"),
+ *   TXT("_healthcheck", "dsp-a-only", ONLY_PROVIDERS(DSP_A)),
+ * END);
+ * ```
+ *
+ * The names are those given to [`NewDnsProvider`](../top-level-functions/NewDnsProvider.md), and
+ * each must be a DNS provider of the domain. `preview` and `push` list the
+ * corrections of a limited record under the provider it is for, and print a
+ * note for each of the other providers:
+ *
+ * ```text
+ * Note: _healthcheck.example.com TXT "dsp-a-only" is only for dsp_a; not managed at dsp_b
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/record-modifiers/only_providers
+ */
+declare function ONLY_PROVIDERS(...names: string[]): RecordModifier;
+
+/**
+ * `OPENPGPKEY` adds an OPENPGPKEY record (RFC 7929) to the domain. These
+ * records publish an OpenPGP public key so that mail clients can discover it
+ * via DNS.
+ *
+ * The public key is the binary OpenPGP transferable public key, base64
+ * encoded, without the ASCII armor (`-----BEGIN PGP PUBLIC KEY BLOCK-----`,
+ * the checksum line, etc.).  `gpg --export USER | base64 -w0` produces the
+ * correct value.  DNSControl rejects keys that are not valid base64.
+ *
+ * The owner name of an OPENPGPKEY record is derived from the local-part of
+ * the email address: the SHA-256 hash of the local-part, truncated to 28
+ * octets and hex-encoded, followed by `._openpgpkey`.  If `name` contains an
+ * `@`, DNSControl computes this for you, using the domain part of the address
+ * to place the record.  Otherwise `name` is used as-is, as with any other
+ * record.  See [`OPENPGPKEY_LABEL()`](../top-level-functions/OPENPGPKEY_LABEL.md)
+ * to compute the name yourself.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   // These two records are equivalent:
+ *   OPENPGPKEY("hugh@example.com", "mQENBFV...base64...AAAA=="),
+ *   OPENPGPKEY("c93f1e400f26708f98cb19d936620da35eec8f72e57f9eec01c1afd6._openpgpkey", "mQENBFV...base64...AAAA=="),
+ * END);
+ * ```
+ *
+ * The domain part of the email address must be the domain (or a subdomain of
+ * the domain) that the record is added to.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/openpgpkey
+ */
+declare function OPENPGPKEY(name: string, publickey: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * `OPENPGPKEY_LABEL` returns the RFC 7929 owner name of the
+ * [`OPENPGPKEY`](../domain-modifiers/OPENPGPKEY.md) record for an email
+ * address.  The local-part is hashed with SHA-256, truncated to 28 octets and
+ * hex-encoded, then `._openpgpkey` is appended.
+ *
+ * If the address has a domain part, the result is a fully qualified name with
+ * a trailing dot.  If there is no `@`, the whole string is treated as the
+ * local-part and a relative label is returned.
+ *
+ * The local-part is hashed exactly as given. RFC 7929 does not case-fold it,
+ * so `Hugh` and `hugh` produce different labels.
+ *
+ * ```javascript
+ * OPENPGPKEY_LABEL("hugh@example.com");
+ * // c93f1e400f26708f98cb19d936620da35eec8f72e57f9eec01c1afd6._openpgpkey.example.com.
+ *
+ * OPENPGPKEY_LABEL("hugh");
+ * // c93f1e400f26708f98cb19d936620da35eec8f72e57f9eec01c1afd6._openpgpkey
+ *
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   OPENPGPKEY(OPENPGPKEY_LABEL("hugh"), "mQENBFV...base64...AAAA=="),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/top-level-functions/openpgpkey_label
+ */
+declare function OPENPGPKEY_LABEL(email: string): string;
+
 /**
  * `PANIC` terminates the script and therefore DNSControl with an exit code of 1. This should be used if your script cannot gather enough information to generate records, for example when a HTTP request failed.
  *
@@ -2423,6 +3156,52 @@ declare function PANIC(message: string): never;
  */
 declare function PORKBUN_URLFWD(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
 
+/**
+ * `PROXY_OFF()` asks the DNS provider not to proxy an `A`, `AAAA`, `CNAME` or
+ * `ALIAS` record, whatever the provider's default is. It is the
+ * provider-neutral form of provider-specific settings such as Cloudflare's
+ * `CF_PROXY_OFF`.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   A("origin", "192.0.2.11", PROXY_OFF()),
+ * END);
+ * ```
+ *
+ * Like [`PROXY_ON`](PROXY_ON.md), it requires DNS providers that support
+ * proxying.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/record-modifiers/proxy_off
+ */
+declare function PROXY_OFF(): RecordModifier;
+
+/**
+ * `PROXY_ON()` asks the DNS provider to proxy the traffic of an `A`, `AAAA`,
+ * `CNAME` or `ALIAS` record through its CDN. It is the provider-neutral form of
+ * provider-specific settings such as Cloudflare's `CF_PROXY_ON`.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   A("www", "192.0.2.10", PROXY_ON()),
+ *   A("origin", "192.0.2.11", PROXY_OFF()),
+ * END);
+ * ```
+ *
+ * The proxy setting is part of the record, so `preview` shows turning the
+ * proxy on or off as a correction.
+ *
+ * Every DNS provider that the record is created at must support proxying;
+ * otherwise `check`, `preview` and `push` fail. Use
+ * [`ONLY_PROVIDERS`](ONLY_PROVIDERS.md) to limit a proxied record to the
+ * providers that can proxy it. `PROXY_ON()` can't be used on other record
+ * types.
+ *
+ * See also [`PROXY_OFF`](PROXY_OFF.md).
+ *
+ * @see https://docs.dnscontrol.org/language-reference/record-modifiers/proxy_on
+ */
+declare function PROXY_ON(): RecordModifier;
+
 /**
  * PTR adds a PTR record to the domain.
  *
@@ -2632,6 +3411,53 @@ declare function R53_EVALUATE_TARGET_HEALTH(enabled: boolean): RecordModifier;
  */
 declare function R53_ZONE(zone_id: string): DomainModifier & RecordModifier;
 
+/**
+ * `RESOLVES_TO(patterns...)` declares what the target of a `CNAME` or `ALIAS`
+ * record is expected to resolve to, for `dnscontrol check --check-targets`. A
+ * pattern is either a name (a glob, such as `"*.cloudfront.net."`) or a range
+ * of addresses in CIDR notation (`"192.0.2.0/24"`, `"2001:db8::/32"`).
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   CNAME("www", "d111111abcdef8.cloudfront.net.", RESOLVES_TO("*.cloudfront.net.")),
+ *   CNAME("docs", "example.github.io.", RESOLVES_TO("185.199.108.0/22", "2606:50c0:8000::/46")),
+ *   CNAME("shop", "example.myshopify.com."),
+ * END);
+ * ```
+ *
+ * `RESOLVES_TO()` changes nothing at the providers.
+ *
+ * ## Checking the targets
+ *
+ * A `CNAME` to a resource that was deleted (a CDN distribution, an app of a
+ * cloud provider, a bucket) is a risk: whoever creates a resource with the same
+ * name takes over the name that points at it (a "subdomain takeover").
+ * `dnscontrol check --check-targets` resolves, with live DNS, the target of each
+ * `CNAME` and `ALIAS` record that isn't in one of the domains of
+ * `dnsconfig.js`, and reports:
+ *
+ * * `NXDOMAIN`: the target doesn't exist.
+ * * `NO ADDRESS`: the target exists, but has no `A` or `AAAA` records.
+ * * `UNEXPECTED`: the target doesn't resolve to what `RESOLVES_TO()` expects.
+ *   It does if a name of its `CNAME` chain (the target included) matches one of
+ *   the names, or if all its addresses are in the ranges.
+ * * `ERROR`: the lookup failed, or a pattern of `RESOLVES_TO()` is invalid.
+ *
+ * ```text
+ * www.example.com CNAME d111111abcdef8.cloudfront.net.: OK (192.0.2.10 192.0.2.11)
+ * shop.example.com CNAME example.myshopify.com.: NXDOMAIN: the target doesn't exist; if it was a resource that was deleted, whoever creates it again takes over shop.example.com
+ * 1 of 2 CNAME and ALIAS targets outside of dnsconfig.js resolve as expected.
+ * ```
+ *
+ * Each problem is a warning, so `check --check-targets --fail-on-warning` fails
+ * (for a CI job) if there are any. The resolver is the first nameserver of
+ * `/etc/resolv.conf`, or that of `--check-targets-resolver`. Without
+ * `RESOLVES_TO()`, a target is only checked for existence.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/record-modifiers/resolves_to
+ */
+declare function RESOLVES_TO(...patterns: string[]): RecordModifier;
+
 /**
  * `REV` returns the reverse lookup domain for an IP network. For
  * example `REV("1.2.3.0/24")` returns `3.2.1.in-addr.arpa.` and
@@ -2745,6 +3571,32 @@ declare function REV(address: string): string;
  */
 declare function REVCOMPAT(rfc: string): string;
 
+/**
+ * `RP` adds an `RP` (Responsible Person) record (RFC 1183) to a domain. It
+ * says who is responsible for the name of the record.
+ *
+ * * `mbox` is the email address of the person, written as a domain name as in
+ *   the [`SOA`](SOA.md) record: the `@` becomes a `.` (and the dots before
+ *   it are escaped), so `first.last@example.com` is
+ *   `first\\.last.example.com.` (in a JavaScript string).
+ * * `txt` is the name of a `TXT` record with more information, such as a
+ *   phone number.
+ *
+ * Either can be `"."` for none. As with `MX`, a name with a single label
+ * is relative to the domain; a name with dots must end with a `.`.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   RP("@", "hostmaster.example.com.", "contact"), // contact.example.com
+ *   TXT("contact", "NOC: +1 555 0100"),
+ *   RP("www", "webmaster.example.com.", "."),
+ * END);
+ * ```
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/rp
+ */
+declare function RP(name: string, mbox: string, txt: string, ...modifiers: RecordModifier[]): DomainModifier;
+
 /**
  * `SOA` adds an `SOA` record to a domain. The name should be `@`.  ns and mbox are strings. The other fields are unsigned 32-bit ints.
  *
@@ -2754,13 +3606,19 @@ declare function REVCOMPAT(rfc: string): string;
  * END);
  * ```
  *
- * If you accidentally include an `@` in the email field DNSControl will quietly
- * change it to a `.`. This way you can specify a human-readable email address
- * when you are making it easier for spammers how to find you.
+ * The mbox is an email address written as a domain name (RFC 1035): the `@`
+ * becomes a `.`, and the dots before the `@` are escaped with a backslash.
+ * `hostmaster@example.com` is `hostmaster.example.com.`, and
+ * `first.last@example.com` is `first\.last.example.com.` (in a JavaScript
+ * string, the backslash itself is escaped: `"first\\.last.example.com."`).
+ * An mbox with an `@`, or that starts with `mailto:`, is an error, and the
+ * message shows how to write it. An mbox with non-ASCII characters is a
+ * warning: write its domain in punycode (`xn--...`).
  *
  * ## Notes
- * * The serial number is managed automatically.  It isn't even a field in `SOA()`.
- * * Most providers automatically generate SOA records.  They will ignore any `SOA()` statements.
+ * * The serial number is managed automatically.  It isn't even a field in `SOA()`. The BIND provider's `soa_serial` setting controls how it changes.
+ * * Most providers automatically generate SOA records.  They ignore any `SOA()` statements, and a warning says so. Only the providers with the `SOA` capability in the [provider list](../../providers.md) use it.
+ * * The timers are checked: a warning is printed if the retry isn't less than the refresh (a secondary would not retry a failed refresh before the next one), or if the expire isn't more than the refresh. `--disable-check soa-timers` turns this off.
  * * The mbox field should not be set to a real email address unless you love spam and hate your privacy.
  *
  * There is more info about `SOA` in the documentation for the [BIND provider](../../provider/bind.md).
@@ -2993,6 +3851,33 @@ declare function SOA(name: string, ns: string, mbox: string, refresh: number, re
  * handle the case where redirect is the last item in the SPF record.
  * In which case, it is equivalent to `include:`.
  *
+ * ## Checking the number of lookups
+ *
+ * An SPF record may cause at most 10 DNS lookups when it is evaluated; more
+ * than that and it fails. Counting the `include:` terms of a record isn't
+ * enough, because the included records have lookups of their own. To resolve
+ * the includes and redirects (using live DNS) and count the real total, run:
+ *
+ * ```shell
+ * dnscontrol check --resolve-spf
+ * ```
+ *
+ * The tree of lookups of each SPF record is printed, and `check` fails if a
+ * record needs more than 10 lookups or an include can't be resolved:
+ *
+ * ```text
+ * example.com: 12 DNS lookups (limit 10) TOO MANY
+ *   include:_spf.google.com (4)
+ *     include:_netblocks.google.com (1)
+ *     include:_netblocks2.google.com (1)
+ *     include:_netblocks3.google.com (1)
+ *   include:mailgun.org (3)
+ *   ...
+ * ```
+ *
+ * Each include is resolved once per run. The records are checked after
+ * flattening, so a flattened `SPF_BUILDER()` is counted as it will be published.
+ *
  * ## Advanced Technique: Interactive SPF Debugger
  *
  * DNSControl includes an experimental system for viewing
@@ -3068,10 +3953,48 @@ declare function SPF_BUILDER(opts: { label?: string; overflow?: string; overhead
  * END);
  * ```
  *
+ * DNSControl warns about an SRV whose target is a name inside the domain
+ * without an A or AAAA record (or that is a CNAME, which an SRV may not point
+ * at), since the service can't be reached there. Targets outside the domain,
+ * or in a subdomain delegated with `NS()`, aren't checked, and neither is the
+ * target `"."` (the service isn't available). Turn this warning off with
+ * `--disable-check in-zone-targets`.
+ *
  * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/srv
  */
 declare function SRV(name: string, priority: number, weight: number, port: number, target: string, ...modifiers: RecordModifier[]): DomainModifier;
 
+/**
+ * `SRV_SVC` adds a [`SRV`](SRV.md) record for a service, building its
+ * `_service._protocol` label: `SRV_SVC("sip", "tcp", ...)` is the same as
+ * `SRV("_sip._tcp", ...)`. The label can't be mistyped, e.g. as `_sip_tcp`
+ * or `_tcp._sip`.
+ *
+ * * `service` is the service name, such as `sip`, `xmpp-server` or `ldap`.
+ *   It is made of letters, digits and hyphens; a leading `_` is dropped.
+ * * `protocol` is one of `tcp`, `udp`, `tls`, `sctp` or `dccp`.
+ * * `priority`, `weight` and `port` are integers from 0 to 65535.
+ *
+ * The record is an ordinary `SRV` record: the providers, `preview` and
+ * `get-zones` see no difference.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   //      service        proto  pr  w   port  target
+ *   SRV_SVC("sip",         "tcp", 10, 60, 5060, "bigbox.example.com."),
+ *   SRV_SVC("sip",         "tcp", 10, 20, 5060, "smallbox1.example.com."),
+ *   SRV_SVC("xmpp-server", "tcp", 5,  0,  5269, "xmpp.example.com.", TTL(600)),
+ * END);
+ * ```
+ *
+ * The records of a subdomain are made with [`D_EXTEND`](../top-level-functions/D_EXTEND.md):
+ * `SRV_SVC("sip", "tcp", ...)` in `D_EXTEND("eu.example.com", ...)` is
+ * `_sip._tcp.eu.example.com`.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/srv_svc
+ */
+declare function SRV_SVC(service: string, protocol: string, priority: number, weight: number, port: number, target: string, ...modifiers: RecordModifier[]): DomainModifier;
+
 /**
  * `SSHFP` contains a fingerprint of a SSH server which can be validated before SSH clients are establishing the connection.
  *
@@ -3105,6 +4028,34 @@ declare function SRV(name: string, priority: number, weight: number, port: numbe
  */
 declare function SSHFP(name: string, algorithm: 0 | 1 | 2 | 3 | 4, type: 0 | 1 | 2, value: string, ...modifiers: RecordModifier[]): DomainModifier;
 
+/**
+ * `SUNSET(date)` marks a record as temporary: `check`, `preview` and `push`
+ * warn when the record is near or past `date`. Use it for the records that
+ * tend to linger after they are needed, such as CNAMEs kept during a
+ * migration or the TXT records of a domain verification.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   CNAME("old-app", "app.example.com.", SUNSET("2025-06-30")),
+ *   TXT("@", "google-site-verification=abc123", SUNSET("2025-06-30T17:00:00-07:00")),
+ * END);
+ * ```
+ *
+ * `date` is either a date (`2025-06-30`), meaning the start of that day in
+ * UTC, or an [RFC 3339](https://www.rfc-editor.org/rfc/rfc3339) timestamp
+ * with its own time zone (`2025-06-30T17:00:00-07:00`).
+ *
+ * The warning starts 30 days before the sunset date; change that with
+ * `--sunset-warn-days`. With `--sunset-errors`, a record past its sunset date
+ * is an error instead of a warning, which stops `preview` and `push` until
+ * it is removed. `--disable-check=sunset` turns the check off.
+ *
+ * The record itself is unchanged: `SUNSET()` never removes it.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/record-modifiers/sunset
+ */
+declare function SUNSET(date: string): RecordModifier;
+
 /**
  * SVCB adds an SVCB record to a domain. The name should be the relative label for the record. Use `@` for the domain apex.
  *
@@ -3142,6 +4093,47 @@ declare function SVCB(name: string, priority: number, target: string, params: st
  */
 declare function TLSA(name: string, usage: number, selector: number, type: number, certificate: string, ...modifiers: RecordModifier[]): DomainModifier;
 
+/**
+ * `TLSRPT_BUILDER` creates the `_smtp._tls` TXT record that asks the senders
+ * for reports of the failures to deliver mail over TLS
+ * ([SMTP TLS Reporting](https://www.rfc-editor.org/rfc/rfc8460)). It is
+ * usually set up with [`MTA_STS_BUILDER`](MTA_STS_BUILDER.md).
+ *
+ * ## Example
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   TLSRPT_BUILDER({
+ *     rua: [
+ *       "mailto:tlsrpt@example.com",
+ *       "https://reports.example.com/tlsrpt",
+ *     ],
+ *   }),
+ * END);
+ * ```
+ *
+ * This yields the following record:
+ *
+ * ```text
+ * _smtp._tls   IN  TXT  "v=TLSRPTv1; rua=mailto:tlsrpt@example.com,https://reports.example.com/tlsrpt"
+ * ```
+ *
+ * ### Parameters
+ *
+ * * `rua:` Array of report targets: `mailto:` or `https:` URLs
+ * * `label:` The DNS label the reports are for (default: `"@"`)
+ * * `ttl:` Input for `TTL` method (optional)
+ *
+ * ### Validation
+ *
+ * A target that isn't a `mailto:` URL with valid email addresses, or an
+ * `https:` URL, is an error. The same check is made on the `v=TLSRPTv1` TXT
+ * records at `_smtp._tls` that are written without `TLSRPT_BUILDER`.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/domain-modifiers/tlsrpt_builder
+ */
+declare function TLSRPT_BUILDER(opts: { rua: string[]; label?: string; ttl?: Duration }): DomainModifier;
+
 /**
  * TTL sets the TTL for a single record only. This will take precedence
  * over the domain's [DefaultTTL](../domain-modifiers/DefaultTTL.md) if supplied.
@@ -3171,10 +4163,60 @@ declare function TLSA(name: string, usage: number, selector: number, type: numbe
  * END);
  * ```
  *
+ * Some providers only accept TTLs within a certain range. Out-of-range TTLs
+ * generate a warning that names the provider's limits, since the provider would
+ * adjust them and `preview` would report the same change forever. To have
+ * DNSControl clamp such TTLs to the nearest limit instead, add the `clamp_ttl`
+ * metadata to the domain:
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_PORKBUN),
+ *   {clamp_ttl: "true"},
+ *   A("foo", "2.3.4.5", TTL(60)), // Porkbun's minimum is 600. Clamped to 600.
+ * END);
+ * ```
+ *
  * @see https://docs.dnscontrol.org/language-reference/record-modifiers/ttl
  */
 declare function TTL(ttl: Duration): RecordModifier;
 
+/**
+ * `TTL_FROM` takes the TTL of a record from an environment variable or a file
+ * that is read when `preview` or `push` runs, instead of from `dnsconfig.js`.
+ * This lets another system set the TTL, for example to lower it during a
+ * migration, without editing `dnsconfig.js`.
+ *
+ * The source is one of:
+ *
+ * * `"env:NAME"`: the environment variable `NAME`.
+ * * `"file:PATH"`: the contents of the file `PATH`, relative to the current directory.
+ *
+ * The value is a TTL in the same format as [`TTL`](TTL.md): a number of seconds,
+ * or a number with a unit (`"5m"`). Surrounding white space is ignored.
+ *
+ * If the environment variable isn't set (or the file is empty), the record's
+ * normal TTL is used: the one from `TTL()` or [`DefaultTTL`](../domain-modifiers/DefaultTTL.md).
+ * A file that doesn't exist, or a value that isn't a TTL, is an error.
+ *
+ * ```javascript
+ * D("example.com", REG_MY_PROVIDER, DnsProvider(DSP_MY_PROVIDER),
+ *   A("www", "192.0.2.1", TTL("1h"), TTL_FROM("env:WWW_TTL")),
+ * END);
+ * ```
+ *
+ * ```shell
+ * WWW_TTL=60 dnscontrol push   # www gets a TTL of 60
+ * dnscontrol push              # www gets a TTL of 3600
+ * ```
+ *
+ * The source is not read by `print-ir` or `check`, so the IR has the normal TTL
+ * and the `ttl_from` metadata. The TTL that is read is not adjusted to fit the
+ * provider's TTL range; it is sent as is.
+ *
+ * @see https://docs.dnscontrol.org/language-reference/record-modifiers/ttl_from
+ */
+declare function TTL_FROM(source: string): RecordModifier;
+
 /**
  * `TXT` adds an `TXT` record To a domain. The name should be the relative
  * label for the record. Use `@` for the domain apex.
@@ -3244,6 +4286,15 @@ declare function TTL(ttl: Duration): RecordModifier;
  * Regardless of the quantity and length of strings, some providers ban
  * double quotes, back-ticks, or other chars.
  *
+ * Before any provider is involved, `check`, `preview` and `push` reject
+ * strings that contain control characters (a newline, a tab, or any other
+ * character below 0x20, and DEL), naming the record, the character and its
+ * offset. They are almost always copy-paste artifacts, and most providers
+ * reject them with an unhelpful error. Curly quotes (`“ ” ‘ ’`) and
+ * backslash-escaped double quotes (`\"`) only produce a warning: they are
+ * valid, but usually not intended. DNSControl quotes and escapes the strings
+ * itself, so `TXT("@", 'say "hi"')` needs no backslashes.
+ *
  * ### Testing the support of a provider
  *
  * #### How can you tell if a provider will support a particular `TXT()` record?
@@ -0,0 +1,403 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
+	"github.com/miekg/dns"
+	"github.com/urfave/cli/v2"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args VerifyArgs
+	return &cli.Command{
+		Name:  "verify",
+		Usage: "Query the authoritative nameservers and check that they serve the records of dnsconfig.js",
+		Action: func(c *cli.Context) error {
+			return exit(Verify(args, os.Stdout))
+		},
+		Flags:     args.flags(),
+		UsageText: "dnscontrol verify [command options]",
+		Description: `Query the authoritative nameservers of each domain for each record set
+in dnsconfig.js, and report the ones whose answer isn't the expected value.
+Providers are not accessed: run it after a push.
+
+A record set that some nameservers serve and others don't yet is "not yet
+propagated"; one that no nameserver serves is "wrong". With --retry-for,
+the record sets that don't match are queried again until they do or the
+time is up.
+
+EXAMPLES:
+   dnscontrol verify
+   dnscontrol push && dnscontrol verify --retry-for 5m
+   dnscontrol verify --domains example.com --server ns1.example.net`,
+	}
+}())
+
+// VerifyArgs encapsulates the flags/arguments for the verify command.
+type VerifyArgs struct {
+	GetDNSConfigArgs
+	FilterArgs
+	Servers       string
+	RetryFor      time.Duration
+	RetryInterval time.Duration
+}
+
+func (args *VerifyArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, &cli.StringFlag{
+		Name:        "domains",
+		Destination: &args.Domains,
+		Usage:       `Comma separated list of domain names to include`,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "server",
+		Destination: &args.Servers,
+		Usage:       `Query these nameservers (comma separated host or host:port) instead of those of each domain`,
+	})
+	flags = append(flags, &cli.DurationFlag{
+		Name:        "retry-for",
+		Destination: &args.RetryFor,
+		Usage:       `Query the record sets that don't match again until they do, for up to this long, e.g. 5m`,
+	})
+	flags = append(flags, &cli.DurationFlag{
+		Name:        "retry-interval",
+		Destination: &args.RetryInterval,
+		Value:       15 * time.Second,
+		Usage:       `How long --retry-for waits between the queries`,
+	})
+	return flags
+}
+
+// dnsExchange sends m to server (host:port), over TCP if the UDP answer is
+// truncated.
+var dnsExchange = func(server string, m *dns.Msg) (*dns.Msg, error) {
+	c := new(dns.Client)
+	r, _, err := c.ExchangeContext(runctx.Context(), m, server)
+	if err == nil && r.Truncated {
+		c.Net = "tcp"
+		r, _, err = c.ExchangeContext(runctx.Context(), m, server)
+	}
+	return r, err
+}
+
+// verifySleep is replaced in tests.
+var verifySleep = time.Sleep
+
+// verifySet is a record set of dnsconfig.js and what the nameservers
+// answered for it.
+type verifySet struct {
+	name, rtype string
+	qtype       uint16
+	want        []dns.RR
+	origin      string                          // The domain, to convert the extra records.
+	unmanaged   func(*models.RecordConfig) bool // IGNORE*() of the domain
+	answers     map[string][]dns.RR             // nameserver -> answer
+	failures    map[string]error                // nameserver -> why there is no answer
+}
+
+// containsRR reports whether rrs contains rr.
+func containsRR(rrs []dns.RR, rr dns.RR) bool {
+	for _, r := range rrs {
+		if dns.IsDuplicate(r, rr) {
+			return true
+		}
+	}
+	return false
+}
+
+// extra returns the records of the answer that aren't in the set and that
+// no IGNORE*() of the domain matches, e.g. a record deleted from
+// dnsconfig.js that a nameserver still serves.
+func (s *verifySet) extra(answer []dns.RR) []dns.RR {
+	var extra []dns.RR
+	for _, a := range answer {
+		if containsRR(s.want, a) {
+			continue
+		}
+		if s.unmanaged != nil {
+			if rc, err := models.RRtoRC(a, s.origin); err == nil && s.unmanaged(&rc) {
+				continue
+			}
+		}
+		extra = append(extra, a)
+	}
+	return extra
+}
+
+// matches reports whether the answer is the set: it contains all the
+// records of the set, and no others except those that IGNORE*() matches.
+func (s *verifySet) matches(answer []dns.RR) bool {
+	for _, w := range s.want {
+		if !containsRR(answer, w) {
+			return false
+		}
+	}
+	return len(s.extra(answer)) == 0
+}
+
+// The results of verifying a record set.
+const (
+	verifyOK = iota
+	verifyNotPropagated
+	verifyWrong
+)
+
+// result returns whether all the nameservers serve the set, only some do,
+// or none do.
+func (s *verifySet) result() int {
+	good := 0
+	for _, answer := range s.answers {
+		if s.matches(answer) {
+			good++
+		}
+	}
+	switch {
+	case good == len(s.answers) && len(s.failures) == 0:
+		return verifyOK
+	case good > 0:
+		return verifyNotPropagated
+	}
+	return verifyWrong
+}
+
+// Verify implements the verify subcommand.
+func Verify(args VerifyArgs, w io.Writer) error {
+	opts, err := args.GetDNSConfigArgs.normalizeOptions()
+	if err != nil {
+		return err
+	}
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		return err
+	}
+	errs := normalize.ValidateAndNormalizeConfig(cfg, opts)
+	if PrintValidationErrors(errs) {
+		return fmt.Errorf("exiting due to validation errors")
+	}
+	qualifyTargets(cfg)
+
+	bad := 0
+	for _, dc := range cfg.Domains {
+		if !args.shouldRunDomain(dc.GetUniqueName()) {
+			continue
+		}
+		servers, err := args.nameservers(dc)
+		if err != nil {
+			fmt.Fprintf(w, "%s: %s\n", dc.Name, err)
+			bad++
+			continue
+		}
+		bad += verifyDomain(w, &args, dc, servers)
+	}
+	if bad > 0 {
+		return fmt.Errorf("%d record %s served as expected", bad, plural(bad, "set isn't", "sets aren't"))
+	}
+	return nil
+}
+
+// verifyDomain queries the servers for the record sets of the domain,
+// again for those that don't match until --retry-for is up, and reports
+// the result. It returns the number of record sets that don't match.
+func verifyDomain(w io.Writer, args *VerifyArgs, dc *models.DomainConfig, servers []string) int {
+	sets, skipped, err := verifySets(dc)
+	if err != nil {
+		fmt.Fprintf(w, "%s: %s\n", dc.Name, err)
+		return 1
+	}
+	pending := sets
+	deadline := time.Now().Add(args.RetryFor)
+	for {
+		for _, s := range pending {
+			queryVerifySet(s, servers)
+		}
+		var still []*verifySet
+		for _, s := range pending {
+			if s.result() != verifyOK {
+				still = append(still, s)
+			}
+		}
+		pending = still
+		if len(pending) == 0 || !time.Now().Add(args.RetryInterval).Before(deadline) {
+			break
+		}
+		verifySleep(args.RetryInterval)
+	}
+	return printVerifyResults(w, dc.Name, servers, sets, skipped)
+}
+
+// nameservers returns the addresses (host:port) to query for the domain:
+// those of --server, or else the nameservers of the domain in dnsconfig.js
+// (NAMESERVER()), or else those that DNS delegates the domain to.
+func (args *VerifyArgs) nameservers(dc *models.DomainConfig) ([]string, error) {
+	var hosts []string
+	switch {
+	case args.Servers != "":
+		hosts = strings.Split(args.Servers, ",")
+	case len(dc.Nameservers) > 0:
+		for _, ns := range dc.Nameservers {
+			hosts = append(hosts, ns.Name)
+		}
+	default:
+		nss, err := net.DefaultResolver.LookupNS(runctx.Context(), dc.Name)
+		if err != nil {
+			return nil, fmt.Errorf("looking up the nameservers: %w", err)
+		}
+		for _, ns := range nss {
+			hosts = append(hosts, ns.Host)
+		}
+	}
+	var servers []string
+	for _, h := range hosts {
+		h = strings.TrimSpace(h)
+		if _, _, err := net.SplitHostPort(h); err != nil {
+			h = net.JoinHostPort(strings.TrimSuffix(h, "."), "53")
+		}
+		servers = append(servers, h)
+	}
+	sort.Strings(servers)
+	return servers, nil
+}
+
+// verifySets groups the records of the domain into record sets. It
+// returns the number of records that can't be verified: those of the
+// types only DNSControl knows (ALIAS, etc.), the SOA, the records that a
+// proxy answers for, and those below a delegation.
+func verifySets(dc *models.DomainConfig) (sets []*verifySet, skipped int, err error) {
+	unmanaged, err := diff2.UnmanagedMatcher(dc.Unmanaged)
+	if err != nil {
+		return nil, 0, err
+	}
+	delegated := map[string]bool{}
+	for _, rec := range dc.Records {
+		if rec.Type == "NS" && rec.GetLabel() != "@" {
+			delegated[strings.ToLower(rec.GetLabelFQDN())] = true
+		}
+	}
+	belowDelegation := func(name string) bool {
+		for d := range delegated {
+			if strings.HasSuffix(name, "."+d) {
+				return true
+			}
+		}
+		return false
+	}
+
+	byKey := map[string]*verifySet{}
+	for _, rec := range dc.Records {
+		name := strings.ToLower(rec.GetLabelFQDN())
+		qtype, ok := dns.StringToType[rec.Type]
+		if !ok || rec.Type == "SOA" || rec.Proxy() == "on" || belowDelegation(name) {
+			skipped++
+			continue
+		}
+		key := name + " " + rec.Type
+		s := byKey[key]
+		if s == nil {
+			s = &verifySet{name: name, rtype: rec.Type, qtype: qtype, origin: dc.Name, unmanaged: unmanaged}
+			byKey[key] = s
+			sets = append(sets, s)
+		}
+		s.want = append(s.want, rec.ToRR())
+	}
+	sort.SliceStable(sets, func(i, j int) bool {
+		if sets[i].name != sets[j].name {
+			return sets[i].name < sets[j].name
+		}
+		return sets[i].rtype < sets[j].rtype
+	})
+	return sets, skipped, nil
+}
+
+// queryVerifySet asks each server for the record set. The NS records of a
+// delegation are in the authority section of the (non-authoritative)
+// referral.
+func queryVerifySet(s *verifySet, servers []string) {
+	s.answers = map[string][]dns.RR{}
+	s.failures = map[string]error{}
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(s.name), s.qtype)
+	m.RecursionDesired = false
+	m.SetEdns0(4096, false)
+	for _, server := range servers {
+		r, err := dnsExchange(server, m)
+		if err != nil {
+			s.failures[server] = err
+			continue
+		}
+		if r.Rcode != dns.RcodeSuccess && r.Rcode != dns.RcodeNameError {
+			s.failures[server] = fmt.Errorf("answered %s", dns.RcodeToString[r.Rcode])
+			continue
+		}
+		var answer []dns.RR
+		for _, rr := range append(r.Answer, r.Ns...) {
+			if rr.Header().Rrtype == s.qtype && strings.EqualFold(rr.Header().Name, dns.Fqdn(s.name)) {
+				answer = append(answer, rr)
+			}
+		}
+		s.answers[server] = answer
+	}
+}
+
+// rdatas formats the rdata of the records, for the report.
+func rdatas(rrs []dns.RR) string {
+	if len(rrs) == 0 {
+		return "nothing"
+	}
+	var values []string
+	for _, rr := range rrs {
+		values = append(values, strings.TrimPrefix(rr.String(), rr.Header().String()))
+	}
+	sort.Strings(values)
+	return strings.Join(values, ", ")
+}
+
+// printVerifyResults reports the record sets of a domain that didn't
+// match, the wrong ones first, and returns how many there are.
+func printVerifyResults(w io.Writer, domain string, servers []string, sets []*verifySet, skipped int) int {
+	var wrong, notPropagated []*verifySet
+	for _, s := range sets {
+		switch s.result() {
+		case verifyWrong:
+			wrong = append(wrong, s)
+		case verifyNotPropagated:
+			notPropagated = append(notPropagated, s)
+		}
+	}
+	fmt.Fprintf(w, "%s: %d record sets checked on %s: %d OK, %d not yet propagated, %d wrong",
+		domain, len(sets), strings.Join(servers, ", "), len(sets)-len(wrong)-len(notPropagated), len(notPropagated), len(wrong))
+	if skipped > 0 {
+		fmt.Fprintf(w, " (%d %s can't be verified)", skipped, plural(skipped, "record", "records"))
+	}
+	fmt.Fprintln(w)
+	report := func(label string, s *verifySet) {
+		fmt.Fprintf(w, "  %s %s %s: expected %s\n", label, s.name, s.rtype, rdatas(s.want))
+		for _, server := range servers {
+			if err, ok := s.failures[server]; ok {
+				fmt.Fprintf(w, "    %s: %s\n", server, err)
+			} else if !s.matches(s.answers[server]) {
+				fmt.Fprintf(w, "    %s answered %s", server, rdatas(s.answers[server]))
+				if extra := s.extra(s.answers[server]); len(extra) > 0 {
+					fmt.Fprintf(w, " (not in dnsconfig.js: %s)", rdatas(extra))
+				}
+				fmt.Fprintln(w)
+			}
+		}
+	}
+	for _, s := range wrong {
+		report("WRONG", s)
+	}
+	for _, s := range notPropagated {
+		report("NOT PROPAGATED", s)
+	}
+	return len(wrong) + len(notPropagated)
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/miekg/dns"
+	"github.com/urfave/cli/v2"
+)
+
+// VerifyDSArgs encapsulates the flags that check the DS records against
+// the DNSKEY records that the child zones publish.
+type VerifyDSArgs struct {
+	VerifyDS         bool
+	VerifyDSResolver string
+}
+
+func (args *VerifyDSArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:        "verify-ds",
+			Destination: &args.VerifyDS,
+			Usage:       `Look up the DNSKEY records of the child zone of each DS record, and fail if the DS matches none of them`,
+		},
+		&cli.StringFlag{
+			Name:        "verify-ds-resolver",
+			Destination: &args.VerifyDSResolver,
+			Usage:       `The resolver that --verify-ds queries, as host or host:port (default the first nameserver of /etc/resolv.conf)`,
+		},
+	}
+}
+
+// lookupDNSKEY returns the DNSKEY records of zone, asking resolver. Tests
+// replace it.
+var lookupDNSKEY = func(resolver, zone string) ([]*dns.DNSKEY, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(zone), dns.TypeDNSKEY)
+	m.SetEdns0(4096, false)
+	r, err := dnsExchange(resolver, m)
+	if err != nil {
+		return nil, err
+	}
+	if r.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("the resolver %s answered %s", resolver, dns.RcodeToString[r.Rcode])
+	}
+	var keys []*dns.DNSKEY
+	for _, rr := range r.Answer {
+		if k, ok := rr.(*dns.DNSKEY); ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+// resolver returns the address of the resolver to query.
+func (args *VerifyDSArgs) resolver() (string, error) {
+	return resolverAddress(args.VerifyDSResolver, "verify-ds")
+}
+
+// resolverAddress returns the host:port of the resolver that the --flag
+// option queries: value (from --flag-resolver), or the first nameserver
+// of /etc/resolv.conf.
+func resolverAddress(value, flag string) (string, error) {
+	if value != "" {
+		if _, _, err := net.SplitHostPort(value); err != nil {
+			return net.JoinHostPort(value, "53"), nil
+		}
+		return value, nil
+	}
+	conf, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+	if err != nil || len(conf.Servers) == 0 {
+		return "", fmt.Errorf("--%s: no resolver in /etc/resolv.conf; use --%s-resolver", flag, flag)
+	}
+	return net.JoinHostPort(conf.Servers[0], conf.Port), nil
+}
+
+// verifyDS checks that each DS record of the domains that filter selects
+// matches a DNSKEY that its child zone publishes now. The DNSKEY records
+// of each child zone are looked up once.
+func (args *VerifyDSArgs) verifyDS(cfg *models.DNSConfig, filter *FilterArgs) []error {
+	if !args.VerifyDS {
+		return nil
+	}
+	resolver, err := args.resolver()
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	zones := map[string][]*dns.DNSKEY{}
+	for _, dc := range cfg.Domains {
+		if !filter.shouldRunDomain(dc.GetUniqueName()) {
+			continue
+		}
+		for _, rec := range dc.Records {
+			if rec.Type != "DS" {
+				continue
+			}
+			child := rec.GetLabelFQDN()
+			keys, ok := zones[child]
+			if !ok {
+				if keys, err = lookupDNSKEY(resolver, child); err != nil {
+					errs = append(errs, fmt.Errorf("%s: DS %s: looking up the DNSKEY records of %s: %w", dc.Name, rec.GetTargetCombined(), child, err))
+					continue
+				}
+				zones[child] = keys
+			}
+			if err := matchDS(rec, child, keys); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", dc.Name, err))
+			}
+		}
+	}
+	return errs
+}
+
+// matchDS returns an error unless the DS record rec is the digest of one
+// of the zone keys of the child zone.
+func matchDS(rec *models.RecordConfig, child string, keys []*dns.DNSKEY) error {
+	ds := fmt.Sprintf("DS %s %s", rec.GetLabelFQDN(), rec.GetTargetCombined())
+	var published []string
+	for _, k := range keys {
+		if k.Flags&dns.ZONE == 0 {
+			continue
+		}
+		published = append(published, fmt.Sprintf("%d/%d", k.KeyTag(), k.Algorithm))
+		want := k.ToDS(rec.DsDigestType)
+		if want == nil {
+			return fmt.Errorf("%s: digest type %d isn't supported", ds, rec.DsDigestType)
+		}
+		if want.KeyTag == rec.DsKeyTag && want.Algorithm == rec.DsAlgorithm && strings.EqualFold(want.Digest, rec.DsDigest) {
+			return nil
+		}
+	}
+	if len(published) == 0 {
+		return fmt.Errorf("%s: %s publishes no DNSKEY records; resolvers that validate DNSSEC would fail to resolve it", ds, child)
+	}
+	return fmt.Errorf("%s: matches none of the DNSKEY records of %s (key tag/algorithm: %s); resolvers that validate DNSSEC would fail to resolve it", ds, child, strings.Join(published, ", "))
+}
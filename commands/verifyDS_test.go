@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/miekg/dns"
+)
+
+func testDNSKEY(t *testing.T, zone string, flags uint16) *dns.DNSKEY {
+	t.Helper()
+	k := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: dns.Fqdn(zone), Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 3600},
+		Flags:     flags,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}
+	if _, err := k.Generate(256); err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func testDS(t *testing.T, label, origin string, ds *dns.DS) *models.RecordConfig {
+	t.Helper()
+	rec := &models.RecordConfig{Type: "DS"}
+	rec.SetLabel(label, origin)
+	if err := rec.SetTargetDS(ds.KeyTag, ds.Algorithm, ds.DigestType, ds.Digest); err != nil {
+		t.Fatal(err)
+	}
+	return rec
+}
+
+func Test_matchDS(t *testing.T) {
+	ksk := testDNSKEY(t, "sub.example.com", dns.ZONE|dns.SEP)
+	zsk := testDNSKEY(t, "sub.example.com", dns.ZONE)
+	other := testDNSKEY(t, "sub.example.com", dns.ZONE|dns.SEP)
+	revoked := testDNSKEY(t, "sub.example.com", dns.SEP) // not a zone key
+
+	tests := []struct {
+		name    string
+		ds      *dns.DS
+		keys    []*dns.DNSKEY
+		wantErr string
+	}{
+		{"sha256", ksk.ToDS(dns.SHA256), []*dns.DNSKEY{zsk, ksk}, ""},
+		{"sha384", ksk.ToDS(dns.SHA384), []*dns.DNSKEY{zsk, ksk}, ""},
+		{"lowercase", &dns.DS{KeyTag: ksk.KeyTag(), Algorithm: ksk.Algorithm, DigestType: dns.SHA256, Digest: strings.ToLower(ksk.ToDS(dns.SHA256).Digest)}, []*dns.DNSKEY{ksk}, ""},
+		{"rolled", other.ToDS(dns.SHA256), []*dns.DNSKEY{zsk, ksk}, "matches none of the DNSKEY records of sub.example.com"},
+		{"unsigned", ksk.ToDS(dns.SHA256), nil, "sub.example.com publishes no DNSKEY records"},
+		{"not a zone key", revoked.ToDS(dns.SHA256), []*dns.DNSKEY{revoked}, "publishes no DNSKEY records"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := matchDS(testDS(t, "sub", "example.com", tt.ds), "sub.example.com", tt.keys)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_verifyDS(t *testing.T) {
+	key := testDNSKEY(t, "sub.example.com", dns.ZONE|dns.SEP)
+
+	// A resolver that answers the DNSKEY query for sub.example.com.
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var queries atomic.Int32
+	srv := &dns.Server{PacketConn: pc, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		queries.Add(1)
+		m := new(dns.Msg)
+		m.SetReply(r)
+		if r.Question[0].Name == "sub.example.com." && r.Question[0].Qtype == dns.TypeDNSKEY {
+			m.Answer = append(m.Answer, key)
+		} else {
+			m.Rcode = dns.RcodeNameError
+		}
+		w.WriteMsg(m)
+	})}
+	go srv.ActivateAndServe()
+	defer srv.Shutdown()
+
+	good := testDS(t, "sub", "example.com", key.ToDS(dns.SHA256))
+	bad := testDS(t, "sub", "example.com", &dns.DS{KeyTag: 1, Algorithm: 13, DigestType: 2, Digest: strings.Repeat("AB", 32)})
+	missing := testDS(t, "nx", "example.com", key.ToDS(dns.SHA256))
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{
+		{Name: "example.com", Records: models.Records{good, bad, missing}},
+		{Name: "skipped.com", Records: models.Records{bad}},
+	}}
+	for _, dc := range cfg.Domains {
+		dc.UpdateSplitHorizonNames()
+	}
+	args := VerifyDSArgs{VerifyDS: true, VerifyDSResolver: pc.LocalAddr().String()}
+	errs := args.verifyDS(cfg, &FilterArgs{Domains: "example.com"})
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2: %v", len(errs), errs)
+	}
+	if !strings.Contains(errs[0].Error(), "DS sub.example.com 1 13 2") {
+		t.Errorf("unexpected first error: %s", errs[0])
+	}
+	if !strings.Contains(errs[1].Error(), "answered NXDOMAIN") {
+		t.Errorf("unexpected second error: %s", errs[1])
+	}
+	if n := queries.Load(); n != 2 {
+		t.Errorf("the resolver got %d queries, want 2 (one per child zone)", n)
+	}
+}
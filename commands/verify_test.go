@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/miekg/dns"
+)
+
+// testNameserver serves the records, which can be changed while it runs.
+type testNameserver struct {
+	mu   sync.Mutex
+	rrs  []string
+	addr string
+}
+
+func startTestNameserver(t *testing.T, rrs ...string) *testNameserver {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ns := &testNameserver{rrs: rrs, addr: pc.LocalAddr().String()}
+	srv := &dns.Server{PacketConn: pc, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetReply(r)
+		m.Authoritative = true
+		q := r.Question[0]
+		ns.mu.Lock()
+		for _, s := range ns.rrs {
+			rr, err := dns.NewRR(s)
+			if err != nil {
+				t.Error(err)
+				continue
+			}
+			if strings.EqualFold(rr.Header().Name, q.Name) && rr.Header().Rrtype == q.Qtype {
+				m.Answer = append(m.Answer, rr)
+			}
+		}
+		ns.mu.Unlock()
+		w.WriteMsg(m)
+	})}
+	go srv.ActivateAndServe()
+	t.Cleanup(func() { srv.Shutdown() })
+	return ns
+}
+
+func (ns *testNameserver) set(rrs ...string) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.rrs = rrs
+}
+
+func testVerifyDomain() *models.DomainConfig {
+	dc := &models.DomainConfig{Name: "example.com", Records: models.Records{
+		makeRec("A", "www", "192.0.2.1"),
+		makeRec("A", "www", "192.0.2.2"),
+		makeRec("MX", "@", "10 mx.example.com."),
+		makeRec("TXT", "@", "v=spf1 -all"),
+		makeRec("NS", "sub", "ns1.example.net."),
+		makeRec("A", "host.sub", "192.0.2.9"), // below the delegation
+		makeRec("ALIAS", "@", "lb.example.net."),
+	}}
+	dc.UpdateSplitHorizonNames()
+	return dc
+}
+
+func Test_verifySets(t *testing.T) {
+	sets, skipped, err := verifySets(testVerifyDomain())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range sets {
+		got = append(got, s.name+" "+s.rtype)
+	}
+	want := "example.com MX,example.com TXT,sub.example.com NS,www.example.com A"
+	if strings.Join(got, ",") != want {
+		t.Errorf("got %v, want %s", got, want)
+	}
+	if skipped != 2 {
+		t.Errorf("got %d skipped, want 2 (ALIAS and the record below the delegation)", skipped)
+	}
+}
+
+func Test_verify(t *testing.T) {
+	good := []string{
+		"www.example.com. 300 IN A 192.0.2.2",
+		"www.example.com. 300 IN A 192.0.2.1",
+		"example.com. 300 IN MX 10 MX.example.com.",
+		`example.com. 300 IN TXT "v=spf1 -all"`,
+		`example.com. 300 IN TXT "unmanaged"`,
+		"sub.example.com. 300 IN NS ns1.example.net.",
+	}
+	ns1 := startTestNameserver(t, good...)
+	ns2 := startTestNameserver(t,
+		"www.example.com. 300 IN A 192.0.2.1", // not yet propagated
+		"example.com. 300 IN MX 10 mx.example.com.",
+		`example.com. 300 IN TXT "v=spf1 ~all"`, // wrong on both
+		"sub.example.com. 300 IN NS ns1.example.net.",
+	)
+	ns1.set(append(good[:3:3], `example.com. 300 IN TXT "v=spf1 ~all"`, good[5])...)
+
+	dc := testVerifyDomain()
+	dc.Unmanaged = []*models.UnmanagedConfig{{LabelPattern: "@", RTypePattern: "TXT", TargetPattern: "unmanaged"}}
+	servers := []string{ns1.addr, ns2.addr}
+	sets, skipped, err := verifySets(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range sets {
+		queryVerifySet(s, servers)
+	}
+	var buf bytes.Buffer
+	if n := printVerifyResults(&buf, dc.Name, servers, sets, skipped); n != 2 {
+		t.Errorf("got %d bad record sets, want 2:\n%s", n, buf.String())
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"4 record sets checked on " + ns1.addr + ", " + ns2.addr + ": 2 OK, 1 not yet propagated, 1 wrong (2 records can't be verified)",
+		"  WRONG example.com TXT: expected \"v=spf1 -all\"\n",
+		"  NOT PROPAGATED www.example.com A: expected 192.0.2.1, 192.0.2.2\n    " + ns2.addr + " answered 192.0.2.1\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("the report doesn't contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "WRONG") > strings.Index(out, "NOT PROPAGATED") {
+		t.Errorf("the wrong record sets should be listed first:\n%s", out)
+	}
+
+	// Once ns1 has the TXT record and ns2 catches up, everything matches.
+	ns1.set(good...)
+	ns2.set(good...)
+	for _, s := range sets {
+		queryVerifySet(s, servers)
+	}
+	buf.Reset()
+	if n := printVerifyResults(&buf, dc.Name, servers, sets, skipped); n != 0 {
+		t.Errorf("got %d bad record sets, want 0:\n%s", n, buf.String())
+	}
+}
+
+func Test_verifyStale(t *testing.T) {
+	// The nameserver still serves a record that was deleted from dnsconfig.js.
+	ns := startTestNameserver(t,
+		"www.example.com. 300 IN A 192.0.2.1",
+		"www.example.com. 300 IN A 192.0.2.2",
+		"www.example.com. 300 IN A 192.0.2.3",
+		"example.com. 300 IN MX 10 mx.example.com.",
+		`example.com. 300 IN TXT "v=spf1 -all"`,
+		"sub.example.com. 300 IN NS ns1.example.net.",
+	)
+	dc := testVerifyDomain()
+	servers := []string{ns.addr}
+	sets, skipped, err := verifySets(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range sets {
+		queryVerifySet(s, servers)
+	}
+	var buf bytes.Buffer
+	if n := printVerifyResults(&buf, dc.Name, servers, sets, skipped); n != 1 {
+		t.Errorf("got %d bad record sets, want 1:\n%s", n, buf.String())
+	}
+	want := "  WRONG www.example.com A: expected 192.0.2.1, 192.0.2.2\n    " + ns.addr +
+		" answered 192.0.2.1, 192.0.2.2, 192.0.2.3 (not in dnsconfig.js: 192.0.2.3)\n"
+	if out := buf.String(); !strings.Contains(out, want) {
+		t.Errorf("the report doesn't contain %q:\n%s", want, out)
+	}
+}
+
+func Test_verifyRetry(t *testing.T) {
+	ns := startTestNameserver(t)
+	sleeps := 0
+	defer func(f func(time.Duration)) { verifySleep = f }(verifySleep)
+	verifySleep = func(time.Duration) {
+		// The records are pushed after the first query.
+		sleeps++
+		ns.set(
+			"www.example.com. 300 IN A 192.0.2.1",
+			"www.example.com. 300 IN A 192.0.2.2",
+			"example.com. 300 IN MX 10 mx.example.com.",
+			`example.com. 300 IN TXT "v=spf1 -all"`,
+			"sub.example.com. 300 IN NS ns1.example.net.",
+		)
+	}
+	dc := testVerifyDomain()
+	args := VerifyArgs{Servers: ns.addr, RetryFor: time.Hour, RetryInterval: time.Second}
+	servers, err := args.nameservers(dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if n := verifyDomain(&buf, &args, dc, servers); n != 0 || sleeps != 1 {
+		t.Errorf("got %d bad record sets after %d retries, want 0 after 1:\n%s", n, sleeps, buf.String())
+	}
+}
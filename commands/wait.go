@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+	"github.com/urfave/cli/v2"
+)
+
+// WaitArgs encapsulates the flags that make push wait for the changes of
+// the providers that apply them asynchronously.
+type WaitArgs struct {
+	Wait        bool
+	WaitTimeout time.Duration
+}
+
+func (args *WaitArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:        "wait",
+			Destination: &args.Wait,
+			Usage:       `After the changes of a provider that applies them asynchronously (ROUTE53), wait until they are live`,
+		},
+		&cli.DurationFlag{
+			Name:        "wait-timeout",
+			Destination: &args.WaitTimeout,
+			Value:       10 * time.Minute,
+			Usage:       `How long --wait waits for the changes of a provider`,
+		},
+	}
+}
+
+// waitForChanges waits, if --wait was given, for the changes just made at
+// the provider to be live. Providers that don't implement
+// providers.ChangeWaiter apply their changes synchronously: there is
+// nothing to wait for.
+func (args WaitArgs) waitForChanges(run context.Context, provider string, driver any, out printer.CLI) error {
+	waiter, ok := driver.(providers.ChangeWaiter)
+	if !args.Wait || !ok {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(run, args.WaitTimeout)
+	defer cancel()
+	out.Printf("Waiting for the changes at %s to be live...\n", provider)
+	start := time.Now()
+	if err := waiter.WaitForChanges(ctx); err != nil {
+		if ctx.Err() == context.DeadlineExceeded && run.Err() == nil {
+			return fmt.Errorf("%s: the changes weren't live after %s (--wait-timeout): %w", provider, args.WaitTimeout, err)
+		}
+		return fmt.Errorf("%s: %w", provider, err)
+	}
+	out.Printf("The changes at %s are live (after %s).\n", provider, time.Since(start).Round(time.Second))
+	return nil
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+)
+
+// slowWaiter is a provider whose changes are live after delay.
+type slowWaiter struct {
+	delay time.Duration
+	calls int
+}
+
+func (w *slowWaiter) WaitForChanges(ctx context.Context) error {
+	w.calls++
+	select {
+	case <-time.After(w.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func Test_waitForChanges(t *testing.T) {
+	out := printer.ConsolePrinter{Writer: &strings.Builder{}}
+
+	w := &slowWaiter{}
+	args := WaitArgs{Wait: false, WaitTimeout: time.Minute}
+	if err := args.waitForChanges(context.Background(), "r53", w, out); err != nil || w.calls != 0 {
+		t.Errorf("without --wait: err=%v, calls=%d", err, w.calls)
+	}
+
+	args.Wait = true
+	if err := args.waitForChanges(context.Background(), "bind", struct{}{}, out); err != nil {
+		t.Errorf("synchronous provider: %v", err)
+	}
+	if err := args.waitForChanges(context.Background(), "r53", w, out); err != nil || w.calls != 1 {
+		t.Errorf("with --wait: err=%v, calls=%d", err, w.calls)
+	}
+
+	args.WaitTimeout = 10 * time.Millisecond
+	w.delay = time.Minute
+	err := args.waitForChanges(context.Background(), "r53", w, out)
+	if err == nil || !strings.Contains(err.Error(), "weren't live after 10ms (--wait-timeout)") {
+		t.Errorf("got %v, want a --wait-timeout error", err)
+	}
+}
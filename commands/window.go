@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/urfave/cli/v2"
+)
+
+// WindowArgs encapsulates the flags that restrict pushes to maintenance
+// windows.
+type WindowArgs struct {
+	AllowWindows   cli.StringSlice
+	OverrideWindow bool
+}
+
+func (args *WindowArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:        "allow-window",
+			Destination: &args.AllowWindows,
+			Usage:       `Only push during this maintenance window, e.g. "Mon-Fri 22:00-04:00 America/New_York" (repeatable)`,
+		},
+		&cli.BoolFlag{
+			Name:        "override-window",
+			Destination: &args.OverrideWindow,
+			Usage:       `Push even if outside the --allow-window maintenance windows`,
+		},
+	}
+}
+
+// check returns an error if windows are configured and now is outside
+// all of them.
+func (args *WindowArgs) check(now time.Time) error {
+	specs := args.AllowWindows.Value()
+	if len(specs) == 0 {
+		return nil
+	}
+	for _, spec := range specs {
+		w, err := parseWindow(spec)
+		if err != nil {
+			return fmt.Errorf("--allow-window: %w", err)
+		}
+		if w.contains(now) {
+			return nil
+		}
+	}
+	if args.OverrideWindow {
+		printer.Printf("WARNING: outside the maintenance window (%s); continuing because of --override-window\n", strings.Join(specs, "; "))
+		return nil
+	}
+	return fmt.Errorf("refusing to push outside the maintenance window (%s); it is now %s. Use --override-window to push anyway",
+		strings.Join(specs, "; "), now.Format("Mon 2006-01-02 15:04 MST"))
+}
+
+// window is a maintenance window: the days it starts on, its start and
+// end as minutes after midnight, and the time zone they are in. A window
+// whose end is not after its start runs past midnight into the next day.
+type window struct {
+	days       [7]bool // indexed by time.Weekday
+	start, end int
+	loc        *time.Location
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// parseWindow parses "DAYS HH:MM-HH:MM [ZONE]". DAYS is a comma-separated
+// list of days or day ranges ("Mon-Fri,Sun"), or "*" for every day. ZONE
+// is an IANA time zone name; the default is the local time zone.
+func parseWindow(spec string) (window, error) {
+	var w window
+	fields := strings.Fields(spec)
+	if len(fields) < 2 || len(fields) > 3 {
+		return w, fmt.Errorf(`%q: expected "DAYS HH:MM-HH:MM [ZONE]"`, spec)
+	}
+
+	if fields[0] == "*" {
+		for i := range w.days {
+			w.days[i] = true
+		}
+	} else {
+		for _, part := range strings.Split(fields[0], ",") {
+			from, to, isRange := strings.Cut(part, "-")
+			first, ok1 := weekdays[strings.ToLower(from)]
+			last, ok2 := first, true
+			if isRange {
+				last, ok2 = weekdays[strings.ToLower(to)]
+			}
+			if !ok1 || !ok2 {
+				return w, fmt.Errorf("%q: invalid day %q", spec, part)
+			}
+			for d := first; ; d = (d + 1) % 7 {
+				w.days[d] = true
+				if d == last {
+					break
+				}
+			}
+		}
+	}
+
+	from, to, ok := strings.Cut(fields[1], "-")
+	if !ok {
+		return w, fmt.Errorf("%q: invalid time range %q", spec, fields[1])
+	}
+	var err error
+	if w.start, err = parseClock(from); err != nil {
+		return w, fmt.Errorf("%q: %w", spec, err)
+	}
+	if w.end, err = parseClock(to); err != nil {
+		return w, fmt.Errorf("%q: %w", spec, err)
+	}
+
+	w.loc = time.Local
+	if len(fields) == 3 {
+		if w.loc, err = time.LoadLocation(fields[2]); err != nil {
+			return w, fmt.Errorf("%q: %w", spec, err)
+		}
+	}
+	return w, nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (use HH:MM)", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// contains reports whether t is in the window. The start and end are wall
+// clock times in the window's zone, so a window keeps its local hours
+// across DST changes.
+func (w window) contains(t time.Time) bool {
+	t = t.In(w.loc)
+	// A window that runs past midnight may have started yesterday.
+	for _, daysAgo := range []int{0, 1} {
+		y, m, d := t.AddDate(0, 0, -daysAgo).Date()
+		start := time.Date(y, m, d, w.start/60, w.start%60, 0, 0, w.loc)
+		if !w.days[start.Weekday()] {
+			continue
+		}
+		endDay := d
+		if w.end <= w.start {
+			endDay++
+		}
+		end := time.Date(y, m, endDay, w.end/60, w.end%60, 0, 0, w.loc)
+		if !t.Before(start) && t.Before(end) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+func Test_windowContains(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	at := func(s string) time.Time {
+		tm, err := time.ParseInLocation("2006-01-02 15:04", s, ny)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+	tests := []struct {
+		spec string
+		when time.Time
+		want bool
+	}{
+		// 2024-03-08 is a Friday.
+		{"Mon-Fri 22:00-04:00 America/New_York", at("2024-03-08 23:00"), true},
+		{"Mon-Fri 22:00-04:00 America/New_York", at("2024-03-09 03:59"), true}, // Friday's window, on Saturday
+		{"Mon-Fri 22:00-04:00 America/New_York", at("2024-03-09 04:00"), false},
+		{"Mon-Fri 22:00-04:00 America/New_York", at("2024-03-09 23:00"), false}, // Saturday
+		{"Mon-Fri 22:00-04:00 America/New_York", at("2024-03-11 02:00"), false}, // Sunday's window
+		{"Mon-Fri 22:00-04:00 America/New_York", at("2024-03-08 21:59"), false},
+		// DST starts on Sunday 2024-03-10: the window is in local hours.
+		{"Sun 22:00-23:00 America/New_York", at("2024-03-10 22:30"), true},
+		{"* 09:00-17:00 America/New_York", at("2024-03-10 08:30"), false},
+		{"* 09:00-17:00 America/New_York", at("2024-03-10 09:30"), true},
+		// The same instant, expressed in UTC.
+		{"* 09:00-17:00 America/New_York", time.Date(2024, 3, 10, 13, 30, 0, 0, time.UTC), true},
+		{"Sat,Sun 00:00-00:00 Europe/Paris", time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC), true},
+		{"Fri-Mon 10:00-11:00 UTC", time.Date(2024, 3, 10, 10, 30, 0, 0, time.UTC), true},
+		{"Fri-Mon 10:00-11:00 UTC", time.Date(2024, 3, 13, 10, 30, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		w, err := parseWindow(tt.spec)
+		if err != nil {
+			t.Fatalf("parseWindow(%q): %v", tt.spec, err)
+		}
+		if got := w.contains(tt.when); got != tt.want {
+			t.Errorf("%q contains %s = %v, want %v", tt.spec, tt.when, got, tt.want)
+		}
+	}
+}
+
+func Test_parseWindowErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"Mon-Fri",
+		"Mon-Fro 22:00-04:00",
+		"Mon 22:00",
+		"Mon 25:00-04:00",
+		"Mon 22:00-04:00 Nowhere/Land",
+	} {
+		if _, err := parseWindow(spec); err == nil {
+			t.Errorf("parseWindow(%q): expected an error", spec)
+		}
+	}
+}
+
+func TestWindowArgs_check(t *testing.T) {
+	now := time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC) // Saturday
+	args := WindowArgs{AllowWindows: *cli.NewStringSlice("Mon-Fri 22:00-04:00 UTC", "Sat 11:00-13:00 UTC")}
+	if err := args.check(now); err != nil {
+		t.Errorf("in the second window: %v", err)
+	}
+	args = WindowArgs{AllowWindows: *cli.NewStringSlice("Mon-Fri 22:00-04:00 UTC")}
+	if err := args.check(now); err == nil {
+		t.Error("outside the window: expected an error")
+	}
+	args.OverrideWindow = true
+	if err := args.check(now); err != nil {
+		t.Errorf("with --override-window: %v", err)
+	}
+	if err := (&WindowArgs{}).check(now); err != nil {
+		t.Errorf("no windows: %v", err)
+	}
+}
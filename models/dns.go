@@ -17,6 +17,10 @@ type DNSConfig struct {
 	RegistrarsByName   map[string]*RegistrarConfig   `json:"-"`
 	DNSProvidersByName map[string]*DNSProviderConfig `json:"-"`
 	SkipRecordAudit    bool                          `json:"skiprecordaudit,omitempty"`
+	// Normalized is set by normalize.ValidateAndNormalizeConfig. The
+	// hostname targets are then FQDNs without the trailing dot (see
+	// UnqualifyTargets), rather than as written in dnsconfig.js.
+	Normalized bool `json:"normalized,omitempty"`
 }
 
 // FindDomain returns the *DomainConfig for domain query in config.
@@ -102,6 +106,11 @@ func NameserversToStrings(nss []*Nameserver) (s []string) {
 type Correction struct {
 	F   func() error `json:"-"`
 	Msg string
+
+	// Changeset is the API request that F sends, in a form that can be
+	// marshaled to JSON, for "preview --changeset". It is nil if the
+	// provider doesn't support that.
+	Changeset any `json:"-"`
 }
 
 // DomainContainingFQDN finds the best domain from the dns config for the given record fqdn.
@@ -42,6 +42,8 @@ func helperRRtoRC(rr dns.RR, origin string, fixBug bool) (RecordConfig, error) {
 		err = rc.SetTarget(v.A.String())
 	case *dns.AAAA:
 		err = rc.SetTarget(v.AAAA.String())
+	case *dns.AFSDB:
+		err = rc.SetTargetAFSDB(v.Subtype, v.Hostname)
 	case *dns.CAA:
 		err = rc.SetTargetCAA(v.Flag, v.Tag, v.Value)
 	case *dns.CNAME:
@@ -52,20 +54,40 @@ func helperRRtoRC(rr dns.RR, origin string, fixBug bool) (RecordConfig, error) {
 		err = rc.SetTarget(v.Target)
 	case *dns.DS:
 		err = rc.SetTargetDS(v.KeyTag, v.Algorithm, v.DigestType, v.Digest)
+	case *dns.CERT:
+		err = rc.SetTargetCERT(v.Type, v.KeyTag, v.Algorithm, v.Certificate)
 	case *dns.DNSKEY:
 		err = rc.SetTargetDNSKEY(v.Flags, v.Protocol, v.Algorithm, v.PublicKey)
+	case *dns.EUI48:
+		err = rc.SetTargetEUI(FormatEUI("EUI48", v.Address))
+	case *dns.EUI64:
+		err = rc.SetTargetEUI(FormatEUI("EUI64", v.Address))
+	case *dns.HIP:
+		err = rc.SetTargetHIP(v.PublicKeyAlgorithm, v.Hit, v.PublicKey, v.RendezvousServers)
 	case *dns.HTTPS:
 		err = rc.SetTargetSVCB(v.Priority, v.Target, v.Value)
+	case *dns.L32:
+		err = rc.SetTargetILNP(v.Preference, v.Locator32.String())
+	case *dns.L64:
+		err = rc.SetTargetILNP(v.Preference, FormatILNP64(v.Locator64))
 	case *dns.LOC:
 		err = rc.SetTargetLOC(v.Version, v.Latitude, v.Longitude, v.Altitude, v.Size, v.HorizPre, v.VertPre)
+	case *dns.LP:
+		err = rc.SetTargetILNP(v.Preference, v.Fqdn)
 	case *dns.MX:
 		err = rc.SetTargetMX(v.Preference, v.Mx)
 	case *dns.NAPTR:
 		err = rc.SetTargetNAPTR(v.Order, v.Preference, v.Flags, v.Service, v.Regexp, v.Replacement)
+	case *dns.NID:
+		err = rc.SetTargetILNP(v.Preference, FormatILNP64(v.NodeID))
 	case *dns.NS:
 		err = rc.SetTarget(v.Ns)
+	case *dns.OPENPGPKEY:
+		err = rc.SetTargetOPENPGPKEY(v.PublicKey)
 	case *dns.PTR:
 		err = rc.SetTarget(v.Ptr)
+	case *dns.RP:
+		err = rc.SetTargetRP(v.Mbox, v.Txt)
 	case *dns.SOA:
 		err = rc.SetTargetSOA(v.Ns, v.Mbox, v.Serial, v.Refresh, v.Retry, v.Expire, v.Minttl)
 	case *dns.SRV:
@@ -123,16 +123,36 @@ func (dc *DomainConfig) Punycode() error {
 
 		// Set the target:
 		switch rec.Type { // #rtype_variations
-		case "ALIAS", "MX", "NS", "CNAME", "DNAME", "PTR", "SRV", "URL", "URL301", "FRAME", "R53_ALIAS", "NS1_URLFWD", "AKAMAICDN", "CLOUDNS_WR", "PORKBUN_URLFWD":
+		case "AFSDB", "ALIAS", "LP", "MX", "NS", "CNAME", "DNAME", "PTR", "SRV", "URL", "URL301", "FRAME", "R53_ALIAS", "NS1_URLFWD", "AKAMAICDN", "CLOUDNS_WR", "PORKBUN_URLFWD":
 			// These rtypes are hostnames, therefore need to be converted (unlike, for example, an AAAA record)
 			t, err := idna.ToASCII(rec.GetTargetField())
 			if err != nil {
 				return err
 			}
 			rec.SetTarget(t)
+		case "RP":
+			// The mailbox and the name of the TXT record are domain names.
+			t, err := idna.ToASCII(rec.GetTargetField())
+			if err != nil {
+				return err
+			}
+			txt, err := idna.ToASCII(rec.RpTxt)
+			if err != nil {
+				return err
+			}
+			rec.SetTargetRP(t, txt)
+		case "HIP":
+			// The rendezvous servers are hostnames.
+			for i, s := range rec.HipServers {
+				t, err := idna.ToASCII(s)
+				if err != nil {
+					return err
+				}
+				rec.HipServers[i] = t
+			}
 		case "CLOUDFLAREAPI_SINGLE_REDIRECT", "CF_REDIRECT", "CF_TEMP_REDIRECT", "CF_WORKER_ROUTE":
 			rec.SetTarget(rec.GetTargetField())
-		case "A", "AAAA", "CAA", "DHCID", "DNSKEY", "DS", "HTTPS", "LOC", "NAPTR", "SOA", "SSHFP", "SVCB", "TXT", "TLSA", "AZURE_ALIAS":
+		case "A", "AAAA", "CAA", "CERT", "DHCID", "DNSKEY", "DS", "EUI48", "EUI64", "HTTPS", "L32", "L64", "LOC", "NAPTR", "NID", "OPENPGPKEY", "SOA", "SSHFP", "SVCB", "TXT", "TLSA", "AZURE_ALIAS":
 			// Nothing to do.
 		default:
 			return fmt.Errorf("Punycode rtype %v unimplemented", rec.Type)
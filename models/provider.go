@@ -1,13 +1,16 @@
 package models
 
 // DNSProvider is an interface for DNS Provider plug-ins.
+// Its methods only read from the provider; the writes are done by the F of
+// the corrections it returns.
 type DNSProvider interface {
 	GetNameservers(domain string) ([]*Nameserver, error)
 	GetZoneRecords(domain string, meta map[string]string) (Records, error)
 	GetZoneRecordsCorrections(dc *DomainConfig, existing Records) ([]*Correction, error)
 }
 
-// Registrar is an interface for Registrar plug-ins.
+// Registrar is an interface for Registrar plug-ins. Like DNSProvider, it
+// only reads; the corrections write.
 type Registrar interface {
 	GetRegistrarCorrections(dc *DomainConfig) ([]*Correction, error)
 }
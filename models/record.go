@@ -1,10 +1,13 @@
 package models
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"strings"
+	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/pkg/txtutil"
 	"github.com/jinzhu/copier"
@@ -99,13 +102,30 @@ type RecordConfig struct {
 	Metadata  map[string]string `json:"meta,omitempty"`
 	Original  interface{}       `json:"-"` // Store pointer to provider-specific record object. Used in diffing.
 
+	// ModifiedAt is when the record was last changed at the provider, if
+	// the provider reports it (or zero). Used by IGNORE_RECENT().
+	ModifiedAt time.Time `json:"-"`
+
+	// TTLOrigin is where the TTL came from if dnsconfig.js didn't set it
+	// with TTL(): "domain default" for DefaultTTL(). It is only read from
+	// dnsconfig.js, for print-ir --include-computed, and isn't in the IR.
+	TTLOrigin string `json:"-"`
+
 	// If you add a field to this struct, also add it to the list in the UnmarshalJSON function.
 	MxPreference     uint16            `json:"mxpreference,omitempty"`
+	AfsdbSubtype     uint16            `json:"afsdbsubtype,omitempty"`
 	SrvPriority      uint16            `json:"srvpriority,omitempty"`
 	SrvWeight        uint16            `json:"srvweight,omitempty"`
 	SrvPort          uint16            `json:"srvport,omitempty"`
 	CaaTag           string            `json:"caatag,omitempty"`
 	CaaFlag          uint8             `json:"caaflag,omitempty"`
+	CertType         uint16            `json:"certtype,omitempty"`
+	CertKeyTag       uint16            `json:"certkeytag,omitempty"`
+	CertAlgorithm    uint8             `json:"certalgorithm,omitempty"`
+	HipPkAlgorithm   uint8             `json:"hippkalgorithm,omitempty"`
+	HipPublicKey     string            `json:"hippublickey,omitempty"`
+	HipServers       []string          `json:"hiprendezvousservers,omitempty"`
+	IlnpPreference   uint16            `json:"ilnppreference,omitempty"`
 	DsKeyTag         uint16            `json:"dskeytag,omitempty"`
 	DsAlgorithm      uint8             `json:"dsalgorithm,omitempty"`
 	DsDigestType     uint8             `json:"dsdigesttype,omitempty"`
@@ -126,6 +146,7 @@ type RecordConfig struct {
 	NaptrFlags       string            `json:"naptrflags,omitempty"`
 	NaptrService     string            `json:"naptrservice,omitempty"`
 	NaptrRegexp      string            `json:"naptrregexp,omitempty"`
+	RpTxt            string            `json:"rptxt,omitempty"`
 	SshfpAlgorithm   uint8             `json:"sshfpalgorithm,omitempty"`
 	SshfpFingerprint uint8             `json:"sshfpfingerprint,omitempty"`
 	SoaMbox          string            `json:"soambox,omitempty"`
@@ -201,11 +222,19 @@ func (rc *RecordConfig) UnmarshalJSON(b []byte) error {
 		Args      []any             `json:"args,omitempty"`
 
 		MxPreference     uint16            `json:"mxpreference,omitempty"`
+		AfsdbSubtype     uint16            `json:"afsdbsubtype,omitempty"`
 		SrvPriority      uint16            `json:"srvpriority,omitempty"`
 		SrvWeight        uint16            `json:"srvweight,omitempty"`
 		SrvPort          uint16            `json:"srvport,omitempty"`
 		CaaTag           string            `json:"caatag,omitempty"`
 		CaaFlag          uint8             `json:"caaflag,omitempty"`
+		CertType         uint16            `json:"certtype,omitempty"`
+		CertKeyTag       uint16            `json:"certkeytag,omitempty"`
+		CertAlgorithm    uint8             `json:"certalgorithm,omitempty"`
+		HipPkAlgorithm   uint8             `json:"hippkalgorithm,omitempty"`
+		HipPublicKey     string            `json:"hippublickey,omitempty"`
+		HipServers       []string          `json:"hiprendezvousservers,omitempty"`
+		IlnpPreference   uint16            `json:"ilnppreference,omitempty"`
 		DsKeyTag         uint16            `json:"dskeytag,omitempty"`
 		DsAlgorithm      uint8             `json:"dsalgorithm,omitempty"`
 		DsDigestType     uint8             `json:"dsdigesttype,omitempty"`
@@ -226,6 +255,7 @@ func (rc *RecordConfig) UnmarshalJSON(b []byte) error {
 		NaptrFlags       string            `json:"naptrflags,omitempty"`
 		NaptrService     string            `json:"naptrservice,omitempty"`
 		NaptrRegexp      string            `json:"naptrregexp,omitempty"`
+		RpTxt            string            `json:"rptxt,omitempty"`
 		SshfpAlgorithm   uint8             `json:"sshfpalgorithm,omitempty"`
 		SshfpFingerprint uint8             `json:"sshfpfingerprint,omitempty"`
 		SoaMbox          string            `json:"soambox,omitempty"`
@@ -245,6 +275,8 @@ func (rc *RecordConfig) UnmarshalJSON(b []byte) error {
 
 		EnsureAbsent bool `json:"ensure_absent,omitempty"` // Override NO_PURGE and delete this record
 
+		TTLOrigin string `json:"ttl_origin,omitempty"`
+
 		// NB(tlim): If anyone can figure out how to do this without listing all
 		// the fields, please let us know!
 	}{}
@@ -397,6 +429,9 @@ func (rc *RecordConfig) ToRR() dns.RR {
 		rr.(*dns.A).A = rc.GetTargetIP()
 	case dns.TypeAAAA:
 		rr.(*dns.AAAA).AAAA = rc.GetTargetIP()
+	case dns.TypeAFSDB:
+		rr.(*dns.AFSDB).Subtype = rc.AfsdbSubtype
+		rr.(*dns.AFSDB).Hostname = rc.GetTargetField()
 	case dns.TypeCAA:
 		rr.(*dns.CAA).Flag = rc.CaaFlag
 		rr.(*dns.CAA).Tag = rc.CaaTag
@@ -412,15 +447,32 @@ func (rc *RecordConfig) ToRR() dns.RR {
 		rr.(*dns.DS).DigestType = rc.DsDigestType
 		rr.(*dns.DS).Digest = rc.DsDigest
 		rr.(*dns.DS).KeyTag = rc.DsKeyTag
+	case dns.TypeCERT:
+		rr.(*dns.CERT).Type = rc.CertType
+		rr.(*dns.CERT).KeyTag = rc.CertKeyTag
+		rr.(*dns.CERT).Algorithm = rc.CertAlgorithm
+		rr.(*dns.CERT).Certificate = rc.GetTargetField()
 	case dns.TypeDNSKEY:
 		rr.(*dns.DNSKEY).Flags = rc.DnskeyFlags
 		rr.(*dns.DNSKEY).Protocol = rc.DnskeyProtocol
 		rr.(*dns.DNSKEY).Algorithm = rc.DnskeyAlgorithm
 		rr.(*dns.DNSKEY).PublicKey = rc.DnskeyPublicKey
+	case dns.TypeEUI48:
+		// The address was checked by normalize.
+		rr.(*dns.EUI48).Address, _ = ParseEUI(rc.Type, rc.GetTargetField())
+	case dns.TypeEUI64:
+		rr.(*dns.EUI64).Address, _ = ParseEUI(rc.Type, rc.GetTargetField())
 	case dns.TypeHTTPS:
 		rr.(*dns.HTTPS).Priority = rc.SvcPriority
 		rr.(*dns.HTTPS).Target = rc.GetTargetField()
 		rr.(*dns.HTTPS).Value = rc.GetSVCBValue()
+	case dns.TypeL32:
+		rr.(*dns.L32).Preference = rc.IlnpPreference
+		rr.(*dns.L32).Locator32 = net.ParseIP(rc.GetTargetField())
+	case dns.TypeL64:
+		// The locator was checked by normalize.
+		rr.(*dns.L64).Preference = rc.IlnpPreference
+		rr.(*dns.L64).Locator64, _ = ParseILNP64(rc.GetTargetField())
 	case dns.TypeLOC:
 		// fmt.Printf("ToRR long: %d, lat:%d, sz: %d, hz:%d, vt:%d\n", rc.LocLongitude, rc.LocLatitude, rc.LocSize, rc.LocHorizPre, rc.LocVertPre)
 		// fmt.Printf("ToRR rc: %+v\n", *rc)
@@ -431,6 +483,17 @@ func (rc *RecordConfig) ToRR() dns.RR {
 		rr.(*dns.LOC).Size = rc.LocSize
 		rr.(*dns.LOC).HorizPre = rc.LocHorizPre
 		rr.(*dns.LOC).VertPre = rc.LocVertPre
+	case dns.TypeHIP:
+		rr.(*dns.HIP).PublicKeyAlgorithm = rc.HipPkAlgorithm
+		rr.(*dns.HIP).Hit = rc.GetTargetField()
+		rr.(*dns.HIP).HitLength = uint8(len(rc.GetTargetField()) / 2)
+		rr.(*dns.HIP).PublicKey = rc.HipPublicKey
+		pk, _ := base64.StdEncoding.DecodeString(rc.HipPublicKey)
+		rr.(*dns.HIP).PublicKeyLength = uint16(len(pk))
+		rr.(*dns.HIP).RendezvousServers = rc.HipServers
+	case dns.TypeLP:
+		rr.(*dns.LP).Preference = rc.IlnpPreference
+		rr.(*dns.LP).Fqdn = rc.GetTargetField()
 	case dns.TypeMX:
 		rr.(*dns.MX).Preference = rc.MxPreference
 		rr.(*dns.MX).Mx = rc.GetTargetField()
@@ -441,10 +504,18 @@ func (rc *RecordConfig) ToRR() dns.RR {
 		rr.(*dns.NAPTR).Service = rc.NaptrService
 		rr.(*dns.NAPTR).Regexp = rc.NaptrRegexp
 		rr.(*dns.NAPTR).Replacement = rc.GetTargetField()
+	case dns.TypeNID:
+		rr.(*dns.NID).Preference = rc.IlnpPreference
+		rr.(*dns.NID).NodeID, _ = ParseILNP64(rc.GetTargetField())
 	case dns.TypeNS:
 		rr.(*dns.NS).Ns = rc.GetTargetField()
+	case dns.TypeOPENPGPKEY:
+		rr.(*dns.OPENPGPKEY).PublicKey = rc.GetTargetField()
 	case dns.TypePTR:
 		rr.(*dns.PTR).Ptr = rc.GetTargetField()
+	case dns.TypeRP:
+		rr.(*dns.RP).Mbox = rc.GetTargetField()
+		rr.(*dns.RP).Txt = rc.RpTxt
 	case dns.TypeSOA:
 		rr.(*dns.SOA).Ns = rc.GetTargetField()
 		rr.(*dns.SOA).Mbox = rc.SoaMbox
@@ -498,6 +569,39 @@ func (rc *RecordConfig) GetDependencies() []string {
 	return []string{}
 }
 
+// OnlyProviders returns the names of the DNS providers that
+// ONLY_PROVIDERS() limits the record to, or nil if it is for all the
+// providers of its domain.
+func (rc *RecordConfig) OnlyProviders() []string {
+	v := rc.Metadata["only_providers"]
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+// ResolvesTo returns the names and address ranges that RESOLVES_TO() expects
+// the target of the record to resolve to, or nil.
+func (rc *RecordConfig) ResolvesTo() []string {
+	v := rc.Metadata["resolves_to"]
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+// Proxy returns "on" or "off" as set by PROXY_ON() or PROXY_OFF(), or ""
+// if the record leaves the proxy setting to the provider's default.
+func (rc *RecordConfig) Proxy() string {
+	return rc.Metadata["proxy"]
+}
+
+// Disabled returns true if the record is marked DISABLED(): it is
+// validated, but not pushed.
+func (rc *RecordConfig) Disabled() bool {
+	return rc.Metadata["disabled"] == "true"
+}
+
 // RecordKey represents a resource record in a format used by some systems.
 type RecordKey struct {
 	NameFQDN string
@@ -611,12 +715,21 @@ func Downcase(recs []*RecordConfig) {
 		r.Name = strings.ToLower(r.Name)
 		r.NameFQDN = strings.ToLower(r.NameFQDN)
 		switch r.Type { // #rtype_variations
-		case "AKAMAICDN", "ALIAS", "AAAA", "ANAME", "CNAME", "DNAME", "DS", "DNSKEY", "MX", "NS", "NAPTR", "PTR", "SRV", "TLSA":
+		case "AFSDB", "AKAMAICDN", "ALIAS", "AAAA", "ANAME", "CNAME", "DNAME", "DS", "DNSKEY", "EUI48", "EUI64", "L64", "LP", "MX", "NID", "NS", "NAPTR", "PTR", "SRV", "TLSA":
 			// Target is case insensitive. Downcase it.
 			r.target = strings.ToLower(r.target)
 			// BUGFIX(tlim): isn't ALIAS in the wrong case statement?
-		case "A", "CAA", "CLOUDFLAREAPI_SINGLE_REDIRECT", "CF_REDIRECT", "CF_TEMP_REDIRECT", "CF_WORKER_ROUTE", "DHCID", "IMPORT_TRANSFORM", "LOC", "SSHFP", "TXT":
+		case "A", "CAA", "CLOUDFLAREAPI_SINGLE_REDIRECT", "CF_REDIRECT", "CF_TEMP_REDIRECT", "CF_WORKER_ROUTE", "DHCID", "IMPORT_TRANSFORM", "L32", "LOC", "OPENPGPKEY", "SSHFP", "TXT":
 			// Do nothing. (IP address or case sensitive target)
+		case "HIP":
+			// The HIT was uppercased by SetTargetHIP and the public key is
+			// case sensitive. The rendezvous servers are hostnames.
+			for i, s := range r.HipServers {
+				r.HipServers[i] = strings.ToLower(s)
+			}
+		case "RP":
+			r.target = strings.ToLower(r.target) // .target stores the Mbox
+			r.RpTxt = strings.ToLower(r.RpTxt)
 		case "SOA":
 			if r.target != "DEFAULT_NOT_SET." {
 				r.target = strings.ToLower(r.target) // .target stores the Ns
@@ -636,11 +749,18 @@ func CanonicalizeTargets(recs []*RecordConfig, origin string) {
 
 	for _, r := range recs {
 		switch r.Type { // #rtype_variations
-		case "ALIAS", "ANAME", "CNAME", "DNAME", "DS", "DNSKEY", "MX", "NS", "NAPTR", "PTR", "SRV":
+		case "AFSDB", "ALIAS", "ANAME", "CNAME", "DNAME", "DS", "DNSKEY", "LP", "MX", "NS", "NAPTR", "PTR", "SRV":
 			// Target is a hostname that might be a shortname. Turn it into a FQDN.
 			r.target = dnsutil.AddOrigin(r.target, originFQDN)
-		case "A", "AKAMAICDN", "CAA", "DHCID", "CLOUDFLAREAPI_SINGLE_REDIRECT", "CF_REDIRECT", "CF_TEMP_REDIRECT", "CF_WORKER_ROUTE", "HTTPS", "IMPORT_TRANSFORM", "LOC", "SSHFP", "SVCB", "TLSA", "TXT":
+		case "A", "AKAMAICDN", "CAA", "CERT", "DHCID", "CLOUDFLAREAPI_SINGLE_REDIRECT", "CF_REDIRECT", "CF_TEMP_REDIRECT", "CF_WORKER_ROUTE", "EUI48", "EUI64", "HTTPS", "IMPORT_TRANSFORM", "L32", "L64", "LOC", "NID", "OPENPGPKEY", "SSHFP", "SVCB", "TLSA", "TXT":
 			// Do nothing.
+		case "HIP":
+			for i, s := range r.HipServers {
+				r.HipServers[i] = dnsutil.AddOrigin(s, originFQDN)
+			}
+		case "RP":
+			r.target = dnsutil.AddOrigin(r.target, originFQDN) // .target stores the Mbox
+			r.RpTxt = dnsutil.AddOrigin(r.RpTxt, originFQDN)
 		case "SOA":
 			if r.target != "DEFAULT_NOT_SET." {
 				r.target = dnsutil.AddOrigin(r.target, originFQDN) // .target stores the Ns
@@ -653,3 +773,61 @@ func CanonicalizeTargets(recs []*RecordConfig, origin string) {
 		}
 	}
 }
+
+// HasHostnameTarget returns true for the record types whose target is a
+// hostname that normalization canonicalizes: see QualifyTargets.
+func HasHostnameTarget(rtype string) bool {
+	switch rtype { // #rtype_variations
+	case "AFSDB", "ALIAS", "CNAME", "DNAME", "LP", "MX", "NS", "PTR", "RP", "SRV":
+		return true
+	}
+	return false
+}
+
+// UnqualifyTargets removes the trailing dot from the hostname targets of
+// recs, which must be FQDNs. This is how the IR stores them:
+// "foo.example.com", not "foo.example.com." or "foo". The root (".", as
+// in a null MX) is left as it is.
+func UnqualifyTargets(recs []*RecordConfig) {
+	trim := func(s string) string {
+		if s == "." {
+			return s
+		}
+		return strings.TrimSuffix(s, ".")
+	}
+	for _, r := range recs {
+		if HasHostnameTarget(r.Type) {
+			r.target = trim(r.target)
+		}
+		switch r.Type {
+		case "HIP":
+			for i, s := range r.HipServers {
+				r.HipServers[i] = trim(s)
+			}
+		case "RP":
+			r.RpTxt = trim(r.RpTxt)
+		}
+	}
+}
+
+// QualifyTargets adds the trailing dot back to the hostname targets of
+// recs, which are in the IR (see UnqualifyTargets). Anything that works
+// with DNS names, such as the providers, diff2 and the zone file writers,
+// expects it. Targets that already have the dot are left as they are.
+func QualifyTargets(recs []*RecordConfig) {
+	for _, r := range recs {
+		if HasHostnameTarget(r.Type) && r.target != "" {
+			r.target = dns.Fqdn(r.target)
+		}
+		switch r.Type {
+		case "HIP":
+			for i, s := range r.HipServers {
+				r.HipServers[i] = dns.Fqdn(s)
+			}
+		case "RP":
+			if r.RpTxt != "" {
+				r.RpTxt = dns.Fqdn(r.RpTxt)
+			}
+		}
+	}
+}
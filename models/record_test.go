@@ -229,3 +229,30 @@ func TestRecordConfig_Copy(t *testing.T) {
 		})
 	}
 }
+
+func TestQualifyTargets(t *testing.T) {
+	cname := &RecordConfig{Type: "CNAME"}
+	cname.SetTarget("foo.example.com.")
+	nullMX := &RecordConfig{Type: "MX"}
+	nullMX.SetTarget(".")
+	rp := &RecordConfig{Type: "RP"}
+	rp.SetTargetRP("hostmaster.example.com.", "txt.example.com.")
+	txt := &RecordConfig{Type: "TXT"}
+	txt.SetTargetTXT("v=spf1 -all.")
+	recs := []*RecordConfig{cname, nullMX, rp, txt}
+
+	UnqualifyTargets(recs)
+	if cname.GetTargetField() != "foo.example.com" || nullMX.GetTargetField() != "." ||
+		rp.GetTargetField() != "hostmaster.example.com" || rp.RpTxt != "txt.example.com" {
+		t.Errorf("UnqualifyTargets() = %q %q %q %q", cname.GetTargetField(), nullMX.GetTargetField(), rp.GetTargetField(), rp.RpTxt)
+	}
+	QualifyTargets(recs)
+	QualifyTargets(recs) // Already qualified targets are left as they are.
+	if cname.GetTargetField() != "foo.example.com." || nullMX.GetTargetField() != "." ||
+		rp.GetTargetField() != "hostmaster.example.com." || rp.RpTxt != "txt.example.com." {
+		t.Errorf("QualifyTargets() = %q %q %q %q", cname.GetTargetField(), nullMX.GetTargetField(), rp.GetTargetField(), rp.RpTxt)
+	}
+	if txt.GetTargetField() != "v=spf1 -all." {
+		t.Errorf("the TXT record was changed: %q", txt.GetTargetField())
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SetTargetAFSDB sets the AFSDB fields. The hostname is stored in the
+// target.
+func (rc *RecordConfig) SetTargetAFSDB(subtype uint16, hostname string) error {
+	rc.AfsdbSubtype = subtype
+	rc.SetTarget(hostname)
+	if rc.Type == "" {
+		rc.Type = "AFSDB"
+	}
+	if rc.Type != "AFSDB" {
+		panic("assertion failed: SetTargetAFSDB called when .Type is not AFSDB")
+	}
+	return nil
+}
+
+// SetTargetAFSDBStrings is like SetTargetAFSDB but accepts strings.
+func (rc *RecordConfig) SetTargetAFSDBStrings(subtype, hostname string) error {
+	u64subtype, err := strconv.ParseUint(subtype, 10, 16)
+	if err != nil {
+		return fmt.Errorf("can't parse AFSDB data: %w", err)
+	}
+	return rc.SetTargetAFSDB(uint16(u64subtype), hostname)
+}
+
+// SetTargetAFSDBString is like SetTargetAFSDB but accepts one big string.
+func (rc *RecordConfig) SetTargetAFSDBString(s string) error {
+	part := strings.Fields(s)
+	if len(part) != 2 {
+		return fmt.Errorf("AFSDB value does not contain 2 fields: (%#v)", s)
+	}
+	return rc.SetTargetAFSDBStrings(part[0], part[1])
+}
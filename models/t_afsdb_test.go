@@ -0,0 +1,37 @@
+package models
+
+import "testing"
+
+func TestAFSDBRoundTrip(t *testing.T) {
+	rc := &RecordConfig{Type: "AFSDB", Name: "@", NameFQDN: "example.com", TTL: 300}
+	if err := rc.SetTargetAFSDBString("1 afsdb1.example.com."); err != nil {
+		t.Fatal(err)
+	}
+	if rc.AfsdbSubtype != 1 || rc.GetTargetField() != "afsdb1.example.com." {
+		t.Fatalf("SetTargetAFSDBString() = %d %q", rc.AfsdbSubtype, rc.GetTargetField())
+	}
+	rr := rc.ToRR()
+	if got, want := rr.String(), "example.com.\t300\tIN\tAFSDB\t1 afsdb1.example.com."; got != want {
+		t.Errorf("ToRR() = %q, want %q", got, want)
+	}
+	back, err := RRtoRC(rr, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.GetTargetCombined() != rc.GetTargetCombined() {
+		t.Errorf("RRtoRC() = %q, want %q", back.GetTargetCombined(), rc.GetTargetCombined())
+	}
+}
+
+func TestSetTargetAFSDBStringErrors(t *testing.T) {
+	for _, s := range []string{
+		"1",
+		"70000 afsdb1.example.com.",
+		"x afsdb1.example.com.",
+	} {
+		rc := &RecordConfig{Type: "AFSDB"}
+		if err := rc.SetTargetAFSDBString(s); err == nil {
+			t.Errorf("SetTargetAFSDBString(%q): expected an error", s)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// SetTargetCERT sets the CERT fields. The certificate (base64) is stored
+// in the target.
+func (rc *RecordConfig) SetTargetCERT(certtype, keytag uint16, algorithm uint8, certificate string) error {
+	rc.CertType = certtype
+	rc.CertKeyTag = keytag
+	rc.CertAlgorithm = algorithm
+	rc.SetTarget(certificate)
+	if rc.Type == "" {
+		rc.Type = "CERT"
+	}
+	if rc.Type != "CERT" {
+		panic("assertion failed: SetTargetCERT called when .Type is not CERT")
+	}
+	return nil
+}
+
+// SetTargetCERTStrings is like SetTargetCERT but accepts strings. The type
+// and algorithm may be numbers or mnemonics ("PKIX", "RSASHA256").
+func (rc *RecordConfig) SetTargetCERTStrings(certtype, keytag, algorithm, certificate string) error {
+	t, ok := dns.StringToCertType[strings.ToUpper(certtype)]
+	if !ok {
+		u, err := strconv.ParseUint(certtype, 10, 16)
+		if err != nil {
+			return fmt.Errorf("CERT type %q is not a number or a known mnemonic", certtype)
+		}
+		t = uint16(u)
+	}
+	k, err := strconv.ParseUint(keytag, 10, 16)
+	if err != nil {
+		return fmt.Errorf("CERT key tag can't fit in 16 bits: %w", err)
+	}
+	a, ok := dns.StringToAlgorithm[strings.ToUpper(algorithm)]
+	if !ok {
+		u, err := strconv.ParseUint(algorithm, 10, 8)
+		if err != nil {
+			return fmt.Errorf("CERT algorithm %q is not a number or a known mnemonic", algorithm)
+		}
+		a = uint8(u)
+	}
+	return rc.SetTargetCERT(t, uint16(k), a, certificate)
+}
+
+// SetTargetCERTString is like SetTargetCERT but accepts one big string.
+// The certificate may be split into several space-separated chunks, as in
+// zone files.
+func (rc *RecordConfig) SetTargetCERTString(s string) error {
+	part := strings.Fields(s)
+	if len(part) < 4 {
+		return fmt.Errorf("CERT value does not contain 4 fields: (%#v)", s)
+	}
+	return rc.SetTargetCERTStrings(part[0], part[1], part[2], strings.Join(part[3:], ""))
+}
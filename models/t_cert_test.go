@@ -0,0 +1,38 @@
+package models
+
+import "testing"
+
+func TestCERTRoundTrip(t *testing.T) {
+	rc := &RecordConfig{Type: "CERT", Name: "x509", NameFQDN: "x509.example.com", TTL: 300}
+	if err := rc.SetTargetCERTString("PKIX 12345 RSASHA256 MIIDdzCC Al+gAwIB"); err != nil {
+		t.Fatal(err)
+	}
+	if rc.CertType != 1 || rc.CertKeyTag != 12345 || rc.CertAlgorithm != 8 || rc.GetTargetField() != "MIIDdzCCAl+gAwIB" {
+		t.Fatalf("SetTargetCERTString() = %d %d %d %q", rc.CertType, rc.CertKeyTag, rc.CertAlgorithm, rc.GetTargetField())
+	}
+	rr := rc.ToRR()
+	if got, want := rr.String(), "x509.example.com.\t300\tIN\tCERT\tPKIX 12345 RSASHA256 MIIDdzCCAl+gAwIB"; got != want {
+		t.Errorf("ToRR() = %q, want %q", got, want)
+	}
+	back, err := RRtoRC(rr, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.GetTargetCombined() != rc.GetTargetCombined() {
+		t.Errorf("RRtoRC() = %q, want %q", back.GetTargetCombined(), rc.GetTargetCombined())
+	}
+}
+
+func TestSetTargetCERTStringErrors(t *testing.T) {
+	for _, s := range []string{
+		"PKIX 1 8",
+		"X509 1 8 AAAA",
+		"1 70000 8 AAAA",
+		"1 1 NOSUCHALG AAAA",
+	} {
+		rc := &RecordConfig{Type: "CERT"}
+		if err := rc.SetTargetCERTString(s); err == nil {
+			t.Errorf("SetTargetCERTString(%q): expected an error", s)
+		}
+	}
+}
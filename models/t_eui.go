@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// euiLen returns the number of octets in an EUI48 or EUI64 address.
+func euiLen(rtype string) int {
+	if rtype == "EUI64" {
+		return 8
+	}
+	return 6
+}
+
+// ParseEUI parses an EUI48 or EUI64 address written as hex octets
+// separated by dashes (RFC 7043 section 3.2), e.g. "00-00-5e-00-53-2a".
+func ParseEUI(rtype, s string) (uint64, error) {
+	octets := strings.Split(s, "-")
+	if len(octets) != euiLen(rtype) {
+		return 0, errors.Errorf("%s address %q must be %d hex octets separated by dashes", rtype, s, euiLen(rtype))
+	}
+	var addr uint64
+	for _, o := range octets {
+		if len(o) != 2 {
+			return 0, errors.Errorf("%s address %q: octet %q is not 2 hex digits", rtype, s, o)
+		}
+		v, err := strconv.ParseUint(o, 16, 8)
+		if err != nil {
+			return 0, errors.Errorf("%s address %q: octet %q is not hex", rtype, s, o)
+		}
+		addr = addr<<8 | v
+	}
+	return addr, nil
+}
+
+// FormatEUI formats addr as an EUI48 or EUI64 address.
+func FormatEUI(rtype string, addr uint64) string {
+	n := euiLen(rtype)
+	octets := make([]string, n)
+	for i := n - 1; i >= 0; i-- {
+		octets[i] = fmt.Sprintf("%02x", addr&0xff)
+		addr >>= 8
+	}
+	return strings.Join(octets, "-")
+}
+
+// SetTargetEUI sets the target of an EUI48 or EUI64 record. The address
+// is stored in lower case, which is how zone files print it.
+func (rc *RecordConfig) SetTargetEUI(s string) error {
+	if rc.Type != "EUI48" && rc.Type != "EUI64" {
+		panic("assertion failed: SetTargetEUI called when .Type is not EUI48 or EUI64")
+	}
+	return rc.SetTarget(strings.ToLower(s))
+}
@@ -0,0 +1,55 @@
+package models
+
+import "testing"
+
+func TestParseEUI(t *testing.T) {
+	tests := []struct {
+		rtype, addr string
+		want        uint64
+		wantErr     bool
+	}{
+		// Examples from RFC 7043.
+		{"EUI48", "00-00-5e-00-53-2a", 0x00005e00532a, false},
+		{"EUI64", "00-00-5e-ef-10-00-00-2a", 0x00005eef1000002a, false},
+		{"EUI48", "00-00-5E-00-53-2A", 0x00005e00532a, false},
+		{"EUI48", "00-00-5e-00-53-2a-00-00", 0, true},
+		{"EUI64", "00-00-5e-00-53-2a", 0, true},
+		{"EUI48", "00:00:5e:00:53:2a", 0, true},
+		{"EUI48", "00-00-5e-00-53-2", 0, true},
+		{"EUI48", "00-00-5e-00-53-zz", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rtype+" "+tt.addr, func(t *testing.T) {
+			got, err := ParseEUI(tt.rtype, tt.addr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEUI(%q, %q) error = %v, wantErr %v", tt.rtype, tt.addr, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseEUI(%q, %q) = %#x, want %#x", tt.rtype, tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEUIRoundTrip(t *testing.T) {
+	for _, tt := range []struct{ rtype, addr string }{
+		{"EUI48", "00-00-5e-00-53-2a"},
+		{"EUI64", "00-00-5e-ef-10-00-00-2a"},
+	} {
+		rc := &RecordConfig{Type: tt.rtype, Name: "host", NameFQDN: "host.example.com", TTL: 300}
+		if err := rc.SetTargetEUI(tt.addr); err != nil {
+			t.Fatal(err)
+		}
+		rr := rc.ToRR()
+		if got, want := rr.String(), "host.example.com.\t300\tIN\t"+tt.rtype+"\t"+tt.addr; got != want {
+			t.Errorf("ToRR() = %q, want %q", got, want)
+		}
+		back, err := RRtoRC(rr, "example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back.GetTargetField() != tt.addr {
+			t.Errorf("RRtoRC() target = %q, want %q", back.GetTargetField(), tt.addr)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SetTargetHIP sets the HIP fields. The HIT (hex) is stored in the target,
+// in uppercase as in RFC 8005, so that HITs read from a zone file and from
+// the wire compare equal.
+func (rc *RecordConfig) SetTargetHIP(algorithm uint8, hit, publickey string, rendezvousServers []string) error {
+	rc.HipPkAlgorithm = algorithm
+	rc.HipPublicKey = publickey
+	rc.HipServers = rendezvousServers
+	rc.SetTarget(strings.ToUpper(hit))
+	if rc.Type == "" {
+		rc.Type = "HIP"
+	}
+	if rc.Type != "HIP" {
+		panic("assertion failed: SetTargetHIP called when .Type is not HIP")
+	}
+	return nil
+}
+
+// SetTargetHIPString is like SetTargetHIP but accepts one big string: the
+// PK algorithm, the HIT, the public key and the rendezvous servers, if
+// any, as in zone files.
+func (rc *RecordConfig) SetTargetHIPString(s string) error {
+	part := strings.Fields(s)
+	if len(part) < 3 {
+		return fmt.Errorf("HIP value does not contain at least 3 fields: (%#v)", s)
+	}
+	algorithm, err := strconv.ParseUint(part[0], 10, 8)
+	if err != nil {
+		return fmt.Errorf("HIP PK algorithm can't fit in 8 bits: %w", err)
+	}
+	var servers []string
+	if len(part) > 3 {
+		servers = part[3:]
+	}
+	return rc.SetTargetHIP(uint8(algorithm), part[1], part[2], servers)
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+// From RFC 8005 section 6.
+const (
+	testHIT = "200100107B1A74DF365639CC39F1D578"
+	testHPK = "AwEAAbdxyhNuSutc5EMzxTs9LBPCIkOFH8cIvM4p9+LrV4e19WzK00+CI6zBCQTdtWsuxKbWIy87UOoJTwkUs7lBu+Upr1gsNrut79ryra+bSRGQb1slImA8YVJyuIDsj7kwzG7jnERNqnWxZ48AWkskmdHaVDP4BcelrTI3rMXdXF5D"
+)
+
+func TestHIPRoundTrip(t *testing.T) {
+	rc := &RecordConfig{Type: "HIP", Name: "www", NameFQDN: "www.example.com", TTL: 300}
+	if err := rc.SetTargetHIPString("2 200100107b1a74df365639cc39f1d578 " + testHPK + " rvs1.example.com. rvs2.example.com."); err != nil {
+		t.Fatal(err)
+	}
+	if rc.HipPkAlgorithm != 2 || rc.GetTargetField() != testHIT || rc.HipPublicKey != testHPK || len(rc.HipServers) != 2 {
+		t.Fatalf("SetTargetHIPString() = %d %q %q %v", rc.HipPkAlgorithm, rc.GetTargetField(), rc.HipPublicKey, rc.HipServers)
+	}
+	rr := rc.ToRR()
+	if got, want := rr.String(), "www.example.com.\t300\tIN\tHIP\t2 "+testHIT+" "+testHPK+" rvs1.example.com. rvs2.example.com."; got != want {
+		t.Errorf("ToRR() = %q, want %q", got, want)
+	}
+
+	// Through the wire format, which has the lengths of the HIT and the
+	// public key, and a lowercase HIT.
+	msg := make([]byte, 512)
+	n, err := dns.PackRR(rr, msg, 0, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unpacked, _, err := dns.UnpackRR(msg[:n], 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := RRtoRC(unpacked, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.GetTargetCombined() != rc.GetTargetCombined() {
+		t.Errorf("RRtoRC() = %q, want %q", back.GetTargetCombined(), rc.GetTargetCombined())
+	}
+}
+
+func TestSetTargetHIPStringErrors(t *testing.T) {
+	for _, s := range []string{
+		"2 " + testHIT,
+		"300 " + testHIT + " " + testHPK,
+		"RSA " + testHIT + " " + testHPK,
+	} {
+		rc := &RecordConfig{Type: "HIP"}
+		if err := rc.SetTargetHIPString(s); err == nil {
+			t.Errorf("SetTargetHIPString(%q): expected an error", s)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseILNP64 parses the Node Identifier of a NID record or the Locator
+// of an L64 record: 64 bits written as four groups of up to 4 hex digits
+// separated by colons (RFC 6742 section 2.3), e.g. "0014:4fff:ff20:ee64".
+func ParseILNP64(s string) (uint64, error) {
+	groups := strings.Split(s, ":")
+	if len(groups) != 4 {
+		return 0, fmt.Errorf("%q must be 4 groups of hex digits separated by colons", s)
+	}
+	var v uint64
+	for _, g := range groups {
+		if len(g) == 0 || len(g) > 4 {
+			return 0, fmt.Errorf("%q: group %q is not 1 to 4 hex digits", s, g)
+		}
+		u, err := strconv.ParseUint(g, 16, 16)
+		if err != nil {
+			return 0, fmt.Errorf("%q: group %q is not hex", s, g)
+		}
+		v = v<<16 | u
+	}
+	return v, nil
+}
+
+// FormatILNP64 formats v as the Node Identifier of a NID record or the
+// Locator of an L64 record.
+func FormatILNP64(v uint64) string {
+	return fmt.Sprintf("%04x:%04x:%04x:%04x", v>>48, v>>32&0xffff, v>>16&0xffff, v&0xffff)
+}
+
+// SetTargetILNP sets the fields of a NID, L32, L64 or LP record (RFC
+// 6742). The node identifier, locator or FQDN is stored in the target. A
+// valid node identifier or 64-bit locator is stored in its canonical
+// form.
+func (rc *RecordConfig) SetTargetILNP(pref uint16, target string) error {
+	switch rc.Type {
+	case "NID", "L64":
+		if v, err := ParseILNP64(target); err == nil {
+			target = FormatILNP64(v)
+		}
+	case "L32", "LP":
+	default:
+		panic("assertion failed: SetTargetILNP called when .Type is not NID, L32, L64 or LP")
+	}
+	rc.IlnpPreference = pref
+	return rc.SetTarget(target)
+}
+
+// SetTargetILNPStrings is like SetTargetILNP but accepts strings.
+func (rc *RecordConfig) SetTargetILNPStrings(pref, target string) error {
+	u64pref, err := strconv.ParseUint(pref, 10, 16)
+	if err != nil {
+		return fmt.Errorf("can't parse %s preference: %w", rc.Type, err)
+	}
+	return rc.SetTargetILNP(uint16(u64pref), target)
+}
+
+// SetTargetILNPString is like SetTargetILNP but accepts one big string.
+func (rc *RecordConfig) SetTargetILNPString(s string) error {
+	part := strings.Fields(s)
+	if len(part) != 2 {
+		return fmt.Errorf("%s value does not contain 2 fields: (%#v)", rc.Type, s)
+	}
+	return rc.SetTargetILNPStrings(part[0], part[1])
+}
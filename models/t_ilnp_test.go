@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseILNP64(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    uint64
+		wantErr bool
+	}{
+		// Examples from RFC 6742.
+		{"0014:4fff:ff20:ee64", 0x00144fffff20ee64, false},
+		{"2001:0DB8:1140:1000", 0x20010db811401000, false},
+		{"14:4fff:ff20:ee64", 0x00144fffff20ee64, false},
+		{"0014:4fff:ff20", 0, true},
+		{"0014:4fff:ff20:ee64:0000", 0, true},
+		{"0014:4fff::ee64", 0, true},
+		{"00014:4fff:ff20:ee64", 0, true},
+		{"0014:4fff:ff20:zz64", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseILNP64(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseILNP64(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseILNP64(%q) = %#x, want %#x", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestILNPRoundTrip(t *testing.T) {
+	for _, tt := range []struct{ rtype, contents, want string }{
+		{"NID", "10 14:4fff:ff20:ee64", "10 0014:4fff:ff20:ee64"},
+		{"L32", "10 10.1.2.0", "10 10.1.2.0"},
+		{"L64", "20 2001:0DB8:1140:1000", "20 2001:0DB8:1140:1000"},
+		{"LP", "10 l64-subnet1.example.com.", "10 l64-subnet1.example.com."},
+	} {
+		t.Run(tt.rtype, func(t *testing.T) {
+			rc := &RecordConfig{Name: "host", NameFQDN: "host.example.com", TTL: 300}
+			if err := rc.PopulateFromString(tt.rtype, tt.contents, "example.com"); err != nil {
+				t.Fatal(err)
+			}
+			if got := rc.GetTargetCombined(); got != tt.want {
+				t.Errorf("GetTargetCombined() = %q, want %q", got, tt.want)
+			}
+
+			// The zone file form, as used by providers.
+			back, err := RRtoRC(rc.ToRR(), "example.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := back.GetTargetCombined(); got != tt.want || back.IlnpPreference != rc.IlnpPreference {
+				t.Errorf("RRtoRC() = %d %q, want %q", back.IlnpPreference, got, tt.want)
+			}
+
+			// The JSON form, as used by print-ir.
+			b, err := json.Marshal(rc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fromJSON RecordConfig
+			if err := json.Unmarshal(b, &fromJSON); err != nil {
+				t.Fatal(err)
+			}
+			if got := fromJSON.GetTargetCombined(); got != tt.want {
+				t.Errorf("JSON round trip = %q, want %q (JSON %s)", got, tt.want, b)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// OpenPGPKeyLabel returns the owner name of the OPENPGPKEY record for
+// an email address, as described in RFC 7929 section 3: the SHA-256
+// hash of the local-part, truncated to 28 octets and hex-encoded,
+// followed by "._openpgpkey".
+//
+// If email includes a domain part, the result is an FQDN with a
+// trailing dot (e.g. "<hash>._openpgpkey.example.com.").  Otherwise
+// email is taken to be just the local-part and the label is relative.
+func OpenPGPKeyLabel(email string) (string, error) {
+	localpart, domain := email, ""
+	if i := strings.LastIndex(email, "@"); i != -1 {
+		localpart, domain = email[:i], strings.TrimSuffix(email[i+1:], ".")
+		if domain == "" {
+			return "", errors.Errorf("OPENPGPKEY email address %q has an empty domain", email)
+		}
+	}
+	if localpart == "" {
+		return "", errors.Errorf("OPENPGPKEY email address %q has an empty local-part", email)
+	}
+
+	sum := sha256.Sum256([]byte(localpart))
+	label := hex.EncodeToString(sum[:28]) + "._openpgpkey"
+	if domain != "" {
+		label += "." + domain + "."
+	}
+	return label, nil
+}
+
+// SetTargetOPENPGPKEY sets the OPENPGPKEY fields.  Whitespace in
+// publicKey is removed, since zone files often split long keys.
+func (rc *RecordConfig) SetTargetOPENPGPKEY(publicKey string) error {
+	if rc.Type == "" {
+		rc.Type = "OPENPGPKEY"
+	}
+	if rc.Type != "OPENPGPKEY" {
+		panic("assertion failed: SetTargetOPENPGPKEY called when .Type is not OPENPGPKEY")
+	}
+
+	return rc.SetTarget(strings.Join(strings.Fields(publicKey), ""))
+}
@@ -0,0 +1,44 @@
+package models
+
+import "testing"
+
+func TestOpenPGPKeyLabel(t *testing.T) {
+	// Example from RFC 7929 section 3.
+	const hugh = "c93f1e400f26708f98cb19d936620da35eec8f72e57f9eec01c1afd6._openpgpkey"
+
+	tests := []struct {
+		email   string
+		want    string
+		wantErr bool
+	}{
+		{email: "hugh@example.com", want: hugh + ".example.com."},
+		{email: "hugh@example.com.", want: hugh + ".example.com."},
+		{email: "hugh", want: hugh},
+		{email: "@example.com", wantErr: true},
+		{email: "hugh@", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			got, err := OpenPGPKeyLabel(tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("OpenPGPKeyLabel(%q) error = %v, wantErr %v", tt.email, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("OpenPGPKeyLabel(%q) = %q, want %q", tt.email, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenPGPKeyToRR(t *testing.T) {
+	rc := &RecordConfig{Type: "OPENPGPKEY", Name: "x", NameFQDN: "x.example.com", TTL: 300}
+	if err := rc.SetTargetOPENPGPKEY("AQID BAU="); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := rc.GetTargetField(), "AQIDBAU="; got != want {
+		t.Errorf("target = %q, want %q", got, want)
+	}
+	if got, want := rc.ToRR().String(), "x.example.com.\t300\tIN\tOPENPGPKEY\tAQIDBAU="; got != want {
+		t.Errorf("ToRR() = %q, want %q", got, want)
+	}
+}
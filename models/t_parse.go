@@ -74,22 +74,36 @@ func (rc *RecordConfig) PopulateFromStringFunc(rtype, contents, origin string, t
 		return rc.SetTargetIP(ip) // Reformat to canonical form.
 	case "AKAMAICDN", "ALIAS", "ANAME", "CNAME", "NS", "PTR":
 		return rc.SetTarget(contents)
+	case "AFSDB":
+		return rc.SetTargetAFSDBString(contents)
 	case "CAA":
 		return rc.SetTargetCAAString(contents)
 	case "DS":
 		return rc.SetTargetDSString(contents)
+	case "CERT":
+		return rc.SetTargetCERTString(contents)
 	case "DNSKEY":
 		return rc.SetTargetDNSKEYString(contents)
 	case "DHCID":
 		return rc.SetTarget(contents)
 	case "DNAME":
 		return rc.SetTarget(contents)
+	case "EUI48", "EUI64":
+		return rc.SetTargetEUI(contents)
+	case "L32", "L64", "LP", "NID":
+		return rc.SetTargetILNPString(contents)
 	case "LOC":
 		return rc.SetTargetLOCString(origin, contents)
 	case "MX":
 		return rc.SetTargetMXString(contents)
 	case "NAPTR":
 		return rc.SetTargetNAPTRString(contents)
+	case "HIP":
+		return rc.SetTargetHIPString(contents)
+	case "OPENPGPKEY":
+		return rc.SetTargetOPENPGPKEY(contents)
+	case "RP":
+		return rc.SetTargetRPString(contents)
 	case "SOA":
 		return rc.SetTargetSOAString(contents)
 	case "SPF", "TXT":
@@ -164,22 +178,36 @@ func (rc *RecordConfig) PopulateFromString(rtype, contents, origin string) error
 		return rc.SetTargetIP(ip) // Reformat to canonical form.
 	case "AKAMAICDN", "ALIAS", "ANAME", "CNAME", "NS", "PTR":
 		return rc.SetTarget(contents)
+	case "AFSDB":
+		return rc.SetTargetAFSDBString(contents)
 	case "CAA":
 		return rc.SetTargetCAAString(contents)
 	case "DS":
 		return rc.SetTargetDSString(contents)
+	case "CERT":
+		return rc.SetTargetCERTString(contents)
 	case "DNSKEY":
 		return rc.SetTargetDNSKEYString(contents)
 	case "DHCID":
 		return rc.SetTarget(contents)
 	case "DNAME":
 		return rc.SetTarget(contents)
+	case "EUI48", "EUI64":
+		return rc.SetTargetEUI(contents)
+	case "L32", "L64", "LP", "NID":
+		return rc.SetTargetILNPString(contents)
 	case "LOC":
 		return rc.SetTargetLOCString(origin, contents)
 	case "MX":
 		return rc.SetTargetMXString(contents)
 	case "NAPTR":
 		return rc.SetTargetNAPTRString(contents)
+	case "HIP":
+		return rc.SetTargetHIPString(contents)
+	case "OPENPGPKEY":
+		return rc.SetTargetOPENPGPKEY(contents)
+	case "RP":
+		return rc.SetTargetRPString(contents)
 	case "SOA":
 		return rc.SetTargetSOAString(contents)
 	case "SPF", "TXT":
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetTargetRP sets the RP fields. The mailbox is stored in the target. Either
+// may be "." (none).
+func (rc *RecordConfig) SetTargetRP(mbox, txt string) error {
+	rc.SetTarget(mbox)
+	rc.RpTxt = txt
+	if rc.Type == "" {
+		rc.Type = "RP"
+	}
+	if rc.Type != "RP" {
+		panic("assertion failed: SetTargetRP called when .Type is not RP")
+	}
+	return nil
+}
+
+// SetTargetRPString is like SetTargetRP but accepts one big string.
+func (rc *RecordConfig) SetTargetRPString(s string) error {
+	part := strings.Fields(s)
+	if len(part) != 2 {
+		return fmt.Errorf("RP value does not contain 2 fields: (%#v)", s)
+	}
+	return rc.SetTargetRP(part[0], part[1])
+}
@@ -0,0 +1,38 @@
+package models
+
+import "testing"
+
+func TestRPRoundTrip(t *testing.T) {
+	rc := &RecordConfig{Type: "RP", Name: "www", NameFQDN: "www.example.com", TTL: 300}
+	if err := rc.SetTargetRPString("hostmaster.example.com. contact.example.com."); err != nil {
+		t.Fatal(err)
+	}
+	if rc.GetTargetField() != "hostmaster.example.com." || rc.RpTxt != "contact.example.com." {
+		t.Fatalf("SetTargetRPString() = %q %q", rc.GetTargetField(), rc.RpTxt)
+	}
+	rr := rc.ToRR()
+	if got, want := rr.String(), "www.example.com.\t300\tIN\tRP\thostmaster.example.com. contact.example.com."; got != want {
+		t.Errorf("ToRR() = %q, want %q", got, want)
+	}
+	back, err := RRtoRC(rr, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.GetTargetCombined() != rc.GetTargetCombined() {
+		t.Errorf("RRtoRC() = %q, want %q", back.GetTargetCombined(), rc.GetTargetCombined())
+	}
+
+	if err := rc.SetTargetRPString("hostmaster.example.com."); err == nil {
+		t.Errorf("SetTargetRPString() with one field: expected an error")
+	}
+}
+
+func TestCanonicalizeTargetsRP(t *testing.T) {
+	rc := &RecordConfig{Type: "RP"}
+	rc.SetLabel("www", "example.com")
+	rc.SetTargetRP("hostmaster", ".")
+	CanonicalizeTargets([]*RecordConfig{rc}, "example.com")
+	if rc.GetTargetField() != "hostmaster.example.com." || rc.RpTxt != "." {
+		t.Errorf("CanonicalizeTargets() = %q %q", rc.GetTargetField(), rc.RpTxt)
+	}
+}
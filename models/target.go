@@ -109,22 +109,32 @@ func (rc *RecordConfig) GetTargetDebug() string {
 	}
 	content := fmt.Sprintf("%s %s %s %d", rc.Type, rc.NameFQDN, target, rc.TTL)
 	switch rc.Type { // #rtype_variations
-	case "A", "AAAA", "AKAMAICDN", "CNAME", "DHCID", "NS", "PTR", "TXT":
+	case "A", "AAAA", "AKAMAICDN", "CNAME", "DHCID", "EUI48", "EUI64", "NS", "OPENPGPKEY", "PTR", "TXT":
 		// Nothing special.
 	case "AZURE_ALIAS":
 		content += fmt.Sprintf(" type=%s", rc.AzureAlias["type"])
+	case "AFSDB":
+		content += fmt.Sprintf(" afsdbsubtype=%d", rc.AfsdbSubtype)
 	case "CAA":
 		content += fmt.Sprintf(" caatag=%s caaflag=%d", rc.CaaTag, rc.CaaFlag)
 	case "DS":
 		content += fmt.Sprintf(" ds_algorithm=%d ds_keytag=%d ds_digesttype=%d ds_digest=%s", rc.DsAlgorithm, rc.DsKeyTag, rc.DsDigestType, rc.DsDigest)
+	case "CERT":
+		content += fmt.Sprintf(" certtype=%d certkeytag=%d certalgorithm=%d", rc.CertType, rc.CertKeyTag, rc.CertAlgorithm)
 	case "DNSKEY":
 		content += fmt.Sprintf(" dnskey_flags=%d dnskey_protocol=%d dnskey_algorithm=%d dnskey_publickey=%s", rc.DnskeyFlags, rc.DnskeyProtocol, rc.DnskeyAlgorithm, rc.DnskeyPublicKey)
+	case "L32", "L64", "LP", "NID":
+		content += fmt.Sprintf(" ilnppreference=%d", rc.IlnpPreference)
 	case "MX":
 		content += fmt.Sprintf(" pref=%d", rc.MxPreference)
 	case "NAPTR":
 		content += fmt.Sprintf(" naptrorder=%d naptrpreference=%d naptrflags=%s naptrservice=%s naptrregexp=%s", rc.NaptrOrder, rc.NaptrPreference, rc.NaptrFlags, rc.NaptrService, rc.NaptrRegexp)
 	case "R53_ALIAS":
 		content += fmt.Sprintf(" type=%s zone_id=%s evaluate_target_health=%s", rc.R53Alias["type"], rc.R53Alias["zone_id"], rc.R53Alias["evaluate_target_health"])
+	case "HIP":
+		content += fmt.Sprintf(" hippkalgorithm=%d hippublickey=%s hiprendezvousservers=%s", rc.HipPkAlgorithm, rc.HipPublicKey, strings.Join(rc.HipServers, ","))
+	case "RP":
+		content += fmt.Sprintf(" rptxt=%s", rc.RpTxt)
 	case "SOA":
 		content = fmt.Sprintf("%s ns=%v mbox=%v serial=%v refresh=%v retry=%v expire=%v minttl=%v", rc.Type, rc.target, rc.SoaMbox, rc.SoaSerial, rc.SoaRefresh, rc.SoaRetry, rc.SoaExpire, rc.SoaMinttl)
 	case "SRV":
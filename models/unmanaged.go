@@ -23,6 +23,10 @@ type UnmanagedConfig struct {
 	// Glob pattern for matching targets.
 	TargetPattern string    `json:"target_pattern,omitempty"`
 	TargetGlob    glob.Glob `json:"-"` // Compiled version
+
+	// IGNORE_RECENT(): Only the records changed at the provider less than
+	// this many seconds ago match. 0 matches all of them.
+	MaxAge uint32 `json:"max_age,omitempty"`
 }
 
 // Uncomment to use:
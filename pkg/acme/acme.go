@@ -2,6 +2,7 @@
 package acme
 
 import (
+	"context"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/pkg/nameservers"
 	"github.com/StackExchange/dnscontrol/v4/pkg/notifications"
 	"github.com/StackExchange/dnscontrol/v4/pkg/zonerecs"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/go-acme/lego/certcrypto"
 	"github.com/go-acme/lego/certificate"
 	"github.com/go-acme/lego/challenge"
@@ -276,7 +278,7 @@ func (c *certManager) getCorrections(d *models.DomainConfig) ([]*models.Correcti
 		if err != nil {
 			return nil, err
 		}
-		reports, corrections, err := zonerecs.CorrectZoneRecords(p.Driver, dc)
+		reports, corrections, _, err := zonerecs.CorrectZoneRecords(context.Background(), p.Driver, dc)
 		if err != nil {
 			return nil, err
 		}
@@ -299,7 +301,10 @@ func (c *certManager) getAndRunCorrections(d *models.DomainConfig) error {
 	fmt.Printf("%d corrections\n", len(cs))
 	for _, corr := range cs {
 		fmt.Printf("Running [%s]\n", corr.Msg)
-		err = corr.F()
+		err = providers.CheckWrite("run correction: " + corr.Msg)
+		if err == nil {
+			err = corr.F()
+		}
 		c.notifier.Notify(d.Name, "certs", corr.Msg, err, false)
 		if err != nil {
 			return err
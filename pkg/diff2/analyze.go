@@ -177,7 +177,11 @@ func findTTLChanges(existing, desired []targetConfig) ([]targetConfig, []targetC
 		}
 
 		if ecomp == dcomp && er.TTL != dr.TTL {
-			m := color.YellowString("± MODIFY-TTL %s %s %s", dr.NameFQDN, dr.Type, humanDiff(existing[ei], desired[di]))
+			m := fmt.Sprintf("± MODIFY-TTL %s %s %s", dr.NameFQDN, dr.Type, humanDiff(existing[ei], desired[di]))
+			if Explain {
+				m += ": " + explain(existing[ei], desired[di])
+			}
+			m = color.YellowString("%s", m)
 			v := mkChange(dr.NameFQDN, dr.Type, []string{m},
 				models.Records{er},
 				models.Records{dr},
@@ -273,7 +277,11 @@ func diffTargets(existing, desired []targetConfig) ChangeList {
 		er := existing[i].rec
 		dr := desired[i].rec
 
-		m := color.YellowString("± MODIFY %s %s %s", dr.NameFQDN, dr.Type, humanDiff(existing[i], desired[i]))
+		m := fmt.Sprintf("± MODIFY %s %s %s", dr.NameFQDN, dr.Type, humanDiff(existing[i], desired[i]))
+		if Explain {
+			m += ": " + explain(existing[i], desired[i])
+		}
+		m = color.YellowString("%s", m)
 
 		mkc := mkChange(dr.NameFQDN, dr.Type, []string{m}, models.Records{er}, models.Records{dr})
 		if len(existing) == 1 && len(desired) == 1 {
@@ -0,0 +1,63 @@
+package diff2
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// explain returns the fields that differ between a and b, with their old
+// and new values. For example: `TTL 300->3600, Preference 10->20`.
+func explain(a, b targetConfig) string {
+	var diffs []string
+
+	if a.rec.TTL != b.rec.TTL {
+		diffs = append(diffs, fmt.Sprintf("TTL %d->%d", a.rec.TTL, b.rec.TTL))
+	}
+
+	ra, rb := a.rec.ToComparableNoTTL(), b.rec.ToComparableNoTTL()
+	if ra != rb {
+		fields := rdataDiffs(a, b)
+		if len(fields) == 0 {
+			// The rdata is the same once parsed, or we don't know the
+			// type's fields. Show the two representations.
+			fields = []string{fmt.Sprintf("rdata %q->%q", ra, rb)}
+		}
+		diffs = append(diffs, fields...)
+	}
+
+	// Anything the provider's ComparableFunc added (proxy settings, etc.)
+	if ea, eb := extraComparable(a, ra), extraComparable(b, rb); ea != eb {
+		diffs = append(diffs, fmt.Sprintf("provider-specific %q->%q", ea, eb))
+	}
+
+	return strings.Join(diffs, ", ")
+}
+
+// extraComparable returns what the provider's ComparableFunc added to the
+// record's comparable string.
+func extraComparable(tc targetConfig, base string) string {
+	return strings.TrimSpace(strings.TrimPrefix(tc.comparableNoTTL, base))
+}
+
+// rdataDiffs compares the rdata fields of two records of a type that
+// miekg/dns knows.
+func rdataDiffs(a, b targetConfig) []string {
+	if _, ok := dns.StringToType[a.rec.Type]; !ok || a.rec.Type != b.rec.Type {
+		return nil
+	}
+	rra, rrb := a.rec.ToRR(), b.rec.ToRR()
+	st := reflect.TypeOf(rra).Elem()
+
+	var diffs []string
+	for i := 1; i <= dns.NumField(rra); i++ {
+		fa, fb := dns.Field(rra, i), dns.Field(rrb, i)
+		if fa != fb {
+			// Field 0 of each RR struct is the header.
+			diffs = append(diffs, fmt.Sprintf("%s %s->%s", st.Field(i).Name, fa, fb))
+		}
+	}
+	return diffs
+}
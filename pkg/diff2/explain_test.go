@@ -0,0 +1,48 @@
+package diff2
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_explain(t *testing.T) {
+	tc := func(rc *models.RecordConfig, f func(*models.RecordConfig) string) targetConfig {
+		noTTL, full := mkCompareBlobs(rc, f)
+		return targetConfig{comparableNoTTL: noTTL, comparableFull: full, rec: rc}
+	}
+	fake := func(target string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: "CF_REDIRECT", TTL: 300}
+		rc.SetLabel("laba", "f.com")
+		rc.SetTarget(target)
+		return rc
+	}
+	proxy := func(rc *models.RecordConfig) string { return "proxy=" + rc.Metadata["proxy"] }
+	withMeta := func(rc *models.RecordConfig, v string) *models.RecordConfig {
+		rc.Metadata = map[string]string{"proxy": v}
+		return rc
+	}
+
+	tests := []struct {
+		name string
+		a, b targetConfig
+		want string
+	}{
+		{"ttl", tc(testDataAA1234, nil), tc(testDataAA1234ttl700, nil), "TTL 300->700"},
+		{"a", tc(testDataAA1234, nil), tc(testDataAA12345, nil), "A 1.2.3.4->1.2.3.5"},
+		{"mx pref", tc(makeRec("laba", "MX", "10 mx"), nil), tc(makeRecTTL("laba", "MX", "20 mx", 60), nil), "TTL 300->60, Preference 10->20"},
+		{"srv", tc(makeRec("_s._tcp", "SRV", "1 2 3 t"), nil), tc(makeRec("_s._tcp", "SRV", "1 2 4 u"), nil), "Port 3->4, Target t->u"},
+		{"unknown type", tc(fake("a,b"), nil), tc(fake("a,c"), nil), `rdata "a,b"->"a,c"`},
+		{"provider-specific",
+			tc(withMeta(makeRec("laba", "A", "1.2.3.4"), "on"), proxy),
+			tc(withMeta(makeRec("laba", "A", "1.2.3.4"), "off"), proxy),
+			`provider-specific "proxy=on"->"proxy=off"`},
+	}
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			if got := explain(tst.a, tst.b); got != tst.want {
+				t.Errorf("explain() = %q, want %q", got, tst.want)
+			}
+		})
+	}
+}
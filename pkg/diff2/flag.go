@@ -2,3 +2,7 @@ package diff2
 
 // DisableOrdering can be set to true to disable the reordering of the changes
 var DisableOrdering bool
+
+// Explain can be set to true to add, to each MODIFY, the fields that
+// differ and their old and new values.
+var Explain bool
@@ -7,6 +7,7 @@ package diff2
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
@@ -41,6 +42,13 @@ and 1 way to make exceptions.
 	    DNSControl and one controlled by an external system.  DNSControl would
 		need to have an IGNORE() statement with a targetglob that matches
 	    the external system's target values.
+* IGNORE_RECENT(age, labelglob, typelist, targetglob):
+    * Like IGNORE(), but only for the records that were changed at the
+      provider less than "age" ago.  Older ones are deleted, unless they are
+      in desired.
+    * The age is known only if the provider reports when a record was
+      changed (rec.ModifiedAt).  A record of unknown age is ignored, since
+      deleting it is the unsafe choice.
 * ENSURE_ABSENT: Override NO_PURGE for specific records. i.e. delete them even
     though NO_PURGE is enabled.
     * If any of these records are in desired (matched on
@@ -245,13 +253,23 @@ func compileUnmanagedConfigs(configs []*models.UnmanagedConfig) error {
 	return nil
 }
 
+// UnmanagedMatcher returns a function that reports if a record matches
+// any of the IGNORE*() patterns in uconfigs.
+func UnmanagedMatcher(uconfigs []*models.UnmanagedConfig) (func(*models.RecordConfig) bool, error) {
+	if err := compileUnmanagedConfigs(uconfigs); err != nil {
+		return nil, err
+	}
+	return func(rec *models.RecordConfig) bool { return matchAny(uconfigs, rec) }, nil
+}
+
 // matchAny returns true if rec matches any of the uconfigs.
 func matchAny(uconfigs []*models.UnmanagedConfig, rec *models.RecordConfig) bool {
 	//fmt.Printf("DEBUG: matchAny(%s, %q, %q, %q)\n", models.DebugUnmanagedConfig(uconfigs), rec.NameFQDN, rec.Type, rec.GetTargetField())
 	for _, uc := range uconfigs {
 		if matchLabel(uc.LabelGlob, rec.GetLabel()) &&
 			matchType(uc.RTypeMap, rec.Type) &&
-			matchTarget(uc.TargetGlob, rec.GetTargetField()) {
+			matchTarget(uc.TargetGlob, rec.GetTargetField()) &&
+			matchAge(uc.MaxAge, rec.ModifiedAt) {
 			return true
 		}
 	}
@@ -276,3 +294,16 @@ func matchTarget(targetGlob glob.Glob, targetName string) bool {
 	}
 	return targetGlob.Match(targetName)
 }
+
+// timeNow is time.Now, replaced by the tests.
+var timeNow = time.Now
+
+// matchAge returns true if a record changed at modified is younger than
+// maxAge seconds, or if maxAge is 0. A record of unknown age (modified is
+// zero) matches.
+func matchAge(maxAge uint32, modified time.Time) bool {
+	if maxAge == 0 || modified.IsZero() {
+		return true
+	}
+	return timeNow().Sub(modified) < time.Duration(maxAge)*time.Second
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/js"
@@ -234,3 +235,46 @@ _2222222222222222.cr CNAME _333333.nnn.acm-validations.aws.
 FOREIGN:
 	`)
 }
+
+func Test_ignore_recent(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	defer func(f func() time.Time) { timeNow = f }(timeNow)
+	timeNow = func() time.Time { return now }
+
+	existing, err := parseZoneContents(`
+_challenge.new IN TXT "fresh"
+_challenge.old IN TXT "stale"
+_challenge.unknown IN TXT "no timestamp"
+www IN A 1.1.1.1
+`, "f.com", "no_file_name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modified := map[string]time.Time{
+		"_challenge.new": now.Add(-10 * time.Minute),
+		"_challenge.old": now.Add(-48 * time.Hour),
+		"www":            now.Add(-10 * time.Minute),
+	}
+	for _, rec := range existing {
+		rec.ModifiedAt = modified[rec.GetLabel()]
+	}
+
+	dnsconfig, err := js.ExecuteJavascriptString([]byte(`
+D("f.com", "none",
+	IGNORE_RECENT("1h", "_challenge.*", "TXT"),
+{})
+`), false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dc := dnsconfig.FindDomain("f.com")
+	if err := compileUnmanagedConfigs(dc.Unmanaged); err != nil {
+		t.Fatal(err)
+	}
+
+	ignored, _ := processIgnoreAndNoPurge("f.com", existing, dc.Records, nil, dc.Unmanaged, false)
+	want := "_challenge.new TXT \"fresh\"\n_challenge.unknown TXT \"no timestamp\"\n"
+	if got := showRecs(ignored); got != want {
+		t.Errorf("got ignored:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,121 @@
+package js
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/robertkrimen/otto"
+)
+
+// csvRow is a row of a CSV file, keyed by the column names of the header
+// row, and the line it starts on.
+type csvRow struct {
+	Line int            `json:"line"`
+	Row  map[string]any `json:"row"`
+}
+
+// csvRows reads a CSV file for FROM_CSV(). It is called as
+// csvRows(filename, delimiter) and returns [{line: N, row: {...}}, ...].
+// The filename is relative to the file being executed, like glob().
+func csvRows(call otto.FunctionCall) otto.Value {
+	if len(call.ArgumentList) < 1 || len(call.ArgumentList) > 2 {
+		throw(call.Otto, "FROM_CSV requires a filename and, optionally, a delimiter")
+	}
+	file := call.Argument(0).String()
+	path := file
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(currentDirectory, path)
+	}
+
+	delim := ','
+	if strings.EqualFold(filepath.Ext(file), ".tsv") {
+		delim = '\t'
+	}
+	if d := call.Argument(1); d.IsDefined() && !d.IsNull() {
+		s := d.String()
+		if len([]rune(s)) != 1 {
+			throw(call.Otto, fmt.Sprintf("FROM_CSV: %s: the delimiter must be one character, not %q", file, s))
+		}
+		delim = []rune(s)[0]
+	}
+
+	f, err := os.Open(filepath.ToSlash(path))
+	if err != nil {
+		throw(call.Otto, fmt.Sprintf("FROM_CSV: %s", err))
+	}
+	defer f.Close()
+	rows, err := parseCSV(f, delim)
+	if err != nil {
+		throw(call.Otto, fmt.Sprintf("FROM_CSV: %s: %s", file, err))
+	}
+
+	b, err := json.Marshal(rows)
+	if err != nil {
+		throw(call.Otto, err.Error())
+	}
+	v, err := call.Otto.Call("JSON.parse", nil, string(b))
+	if err != nil {
+		throw(call.Otto, err.Error())
+	}
+	return v
+}
+
+// parseCSV parses CSV whose first row is the names of the columns. Cells
+// that are decimal integers become numbers; all others are strings. The
+// errors of malformed input include the line number.
+func parseCSV(r io.Reader, delim rune) ([]csvRow, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = delim
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("no header row")
+	} else if err != nil {
+		return nil, err
+	}
+	for i, name := range header {
+		header[i] = strings.TrimSpace(name)
+		if header[i] == "" {
+			return nil, fmt.Errorf("line 1: column %d has no name", i+1)
+		}
+		for _, prev := range header[:i] {
+			if prev == header[i] {
+				return nil, fmt.Errorf("line 1: column %q appears more than once", header[i])
+			}
+		}
+	}
+
+	rows := []csvRow{}
+	for {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		row := csvRow{Line: line, Row: make(map[string]any, len(fields))}
+		for i, v := range fields {
+			row.Row[header[i]] = csvValue(v)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// csvValue returns v as a number if it is a decimal integer without
+// leading zeros (so that "007" stays a string), else v itself.
+func csvValue(v string) any {
+	if v == "0" || (v != "" && v[0] != '0' && !strings.HasPrefix(v, "-0")) {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	}
+	return v
+}
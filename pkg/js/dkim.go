@@ -0,0 +1,76 @@
+package js
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/robertkrimen/otto"
+)
+
+// dkimKey reads a DKIM public key from a file and returns the "k=...; p=..."
+// tags of its TXT record. It is used by DKIM_BUILDER().
+func dkimKey(call otto.FunctionCall) otto.Value {
+	if len(call.ArgumentList) != 1 {
+		throw(call.Otto, "dkimKey takes exactly one argument")
+	}
+	file := call.Argument(0).String()
+
+	// Relative names are resolved the same way as require() does.
+	relFile := file
+	if strings.HasPrefix(file, ".") {
+		relFile = filepath.Clean(filepath.Join(currentDirectory, file))
+	}
+	data, err := os.ReadFile(filepath.ToSlash(relFile))
+	if err != nil {
+		throw(call.Otto, fmt.Sprintf("DKIM_BUILDER: can't read the key: %s", err))
+	}
+	tags, err := dkimKeyTags(data)
+	if err != nil {
+		throw(call.Otto, fmt.Sprintf("DKIM_BUILDER: %s: %s", file, err))
+	}
+	v, _ := otto.ToValue(tags)
+	return v
+}
+
+// dkimKeyTags returns the "k=...; p=..." tags for a public key, which is
+// either PEM or DER encoded. RSA keys are published as the DER
+// SubjectPublicKeyInfo (RFC 6376), Ed25519 keys as the raw key (RFC 8463).
+func dkimKeyTags(data []byte) (string, error) {
+	der := data
+	if block, _ := pem.Decode(data); block != nil {
+		if strings.Contains(block.Type, "PRIVATE") {
+			return "", fmt.Errorf("this is a private key; use the public key")
+		}
+		der = block.Bytes
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		// "RSA PUBLIC KEY" files are PKCS #1, not PKIX.
+		rsaPub, err2 := x509.ParsePKCS1PublicKey(der)
+		if err2 != nil {
+			return "", fmt.Errorf("not a PEM or DER public key: %w", err)
+		}
+		pub = rsaPub
+	}
+
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		spki, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			return "", err
+		}
+		return "k=rsa; p=" + base64.StdEncoding.EncodeToString(spki), nil
+	case ed25519.PublicKey:
+		return "k=ed25519; p=" + base64.StdEncoding.EncodeToString(key), nil
+	default:
+		return "", fmt.Errorf("unsupported key type %T (DKIM keys are RSA or Ed25519)", pub)
+	}
+}
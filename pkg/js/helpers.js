@@ -21,6 +21,10 @@ var conf = {
 
 var defaultArgs = [];
 
+// domainTemplates maps the classes defined by DOMAIN_TEMPLATE() to their
+// modifiers.
+var domainTemplates = {};
+
 function initialize() {
     conf = {
         registrars: [],
@@ -28,6 +32,7 @@ function initialize() {
         domains: [],
     };
     defaultArgs = [];
+    domainTemplates = {};
 }
 
 function _isDomain(d) {
@@ -148,6 +153,7 @@ function D(name, registrar) {
         var m = arguments[i];
         processDargs(m, domain);
     }
+    applyDomainTemplates(domain);
     if (conf.domain_names.indexOf(name) !== -1) {
         throw name + ' is declared more than once';
     }
@@ -220,6 +226,71 @@ function DEFAULTS() {
     }
 }
 
+// DOMAIN_TEMPLATE(class, modifiers...) defines the baseline of the domains
+// of a class: the records and modifiers that are merged into every domain
+// that has the class (see DOMAIN_CLASS()).
+function DOMAIN_TEMPLATE(name) {
+    if (!_.isString(name) || name === '') {
+        throw 'DOMAIN_TEMPLATE requires the name of a class';
+    }
+    if (domainTemplates[name] !== undefined) {
+        throw 'DOMAIN_TEMPLATE("' + name + '") is defined more than once';
+    }
+    domainTemplates[name] = Array.prototype.slice.call(arguments, 1);
+}
+
+// DOMAIN_CLASS(classes...) gives the domain the classes, whose
+// DOMAIN_TEMPLATE() is merged into it. It is the same as the metadata
+// {domain_class: "class1,class2"}.
+function DOMAIN_CLASS() {
+    var classes = Array.prototype.slice.call(arguments);
+    return function (d) {
+        var existing = d.meta.domain_class ? d.meta.domain_class.split(',') : [];
+        d.meta.domain_class = existing.concat(classes).join(',');
+    };
+}
+
+// applyDomainTemplates merges the templates of the classes of the domain
+// into it. The records of a template whose label and type the domain
+// already has are left out, so that a domain can replace a baseline record
+// set with its own. The metadata of the domain wins too.
+function applyDomainTemplates(domain) {
+    if (!domain.meta.domain_class) {
+        return;
+    }
+    var classes = domain.meta.domain_class.split(',');
+    var own = {};
+    for (var i = 0; i < domain.records.length; i++) {
+        var r = domain.records[i];
+        own[r.type + ' ' + r.name.toLowerCase()] = true;
+    }
+    for (var i = 0; i < classes.length; i++) {
+        var cls = classes[i].trim();
+        var template = domainTemplates[cls];
+        if (template === undefined) {
+            throw (
+                domain.name +
+                ': no DOMAIN_TEMPLATE("' +
+                cls +
+                '") is defined (it must come before the D())'
+            );
+        }
+        var t = newDomain(domain.name, domain.registrar);
+        t.defaultTTL = domain.defaultTTL;
+        processDargs(template, t);
+        for (var j = 0; j < t.records.length; j++) {
+            var r = t.records[j];
+            if (!own[r.type + ' ' + r.name.toLowerCase()]) {
+                domain.records.push(r);
+            }
+        }
+        domain.unmanaged.push.apply(domain.unmanaged, t.unmanaged);
+        domain.nameservers.push.apply(domain.nameservers, t.nameservers);
+        _.defaults(domain.dnsProviders, t.dnsProviders);
+        _.defaults(domain.meta, t.meta);
+    }
+}
+
 // TTL(v): Set the TTL for a DNS record.
 function TTL(v) {
     if (_.isString(v)) {
@@ -227,9 +298,58 @@ function TTL(v) {
     }
     return function (r) {
         r.ttl = v;
+        delete r.ttl_origin;
     };
 }
 
+// ONLY_PROVIDERS(name, ...): Create the record only at these DNS providers
+// of the domain. At the others, it is left unmanaged.
+function ONLY_PROVIDERS() {
+    var names = Array.prototype.slice.call(arguments);
+    if (names.length === 0) {
+        throw 'ONLY_PROVIDERS requires at least one DNS provider name';
+    }
+    return { only_providers: names.join(',') };
+}
+
+// RESOLVES_TO(patterns...): The names (globs) and address ranges (CIDR) that
+// "check --check-targets" expects the target of a CNAME or ALIAS to resolve to.
+function RESOLVES_TO() {
+    var patterns = Array.prototype.slice.call(arguments);
+    if (patterns.length === 0) {
+        throw 'RESOLVES_TO requires at least one name or address range';
+    }
+    return { resolves_to: patterns.join(',') };
+}
+
+// DISABLED(): Keep the record in dnsconfig.js (and validate it) but don't
+// push it, as if it were commented out.
+function DISABLED() {
+    return { disabled: 'true' };
+}
+
+// PROXY_ON(): Proxy the traffic of this A, AAAA, CNAME or ALIAS record
+// through the DNS provider's CDN. The provider must support proxying.
+function PROXY_ON() {
+    return { proxy: 'on' };
+}
+
+// PROXY_OFF(): Don't proxy this record, whatever the provider's default.
+function PROXY_OFF() {
+    return { proxy: 'off' };
+}
+
+// SUNSET(date): Warn when the record nears or passes date, e.g. "2025-06-30",
+// so that temporary records are cleaned up.
+function SUNSET(date) {
+    return { sunset: date };
+}
+
+// TTL_FROM(source): Read the TTL from "env:NAME" or "file:PATH" at push time.
+function TTL_FROM(source) {
+    return { ttl_from: source };
+}
+
 function stringToDuration(v) {
     var matches = v.match(/^(\d+)([smhdwny]?)$/);
     if (matches == null) {
@@ -385,6 +505,20 @@ function validateR53AliasType(value) {
     );
 }
 
+// AFSDB(name,subtype,hostname, recordModifiers...)
+var AFSDB = recordBuilder('AFSDB', {
+    args: [
+        ['name', _.isString],
+        ['subtype', _.isNumber],
+        ['target', _.isString],
+    ],
+    transform: function (record, args, modifiers) {
+        record.name = args.name;
+        record.afsdbsubtype = args.subtype;
+        record.target = args.target;
+    },
+});
+
 // CAA(name,tag,value, recordModifiers...)
 var CAA = recordBuilder('CAA', {
     // TODO(tlim): It should be an error if value is not 0 or 128.
@@ -425,12 +559,89 @@ var DS = recordBuilder('DS', {
     },
 });
 
+// Mnemonics of the CERT types (RFC 4398 section 2.1).
+var CERT_TYPES = {
+    PKIX: 1,
+    SPKI: 2,
+    PGP: 3,
+    IPKIX: 4,
+    ISPKI: 5,
+    IPGP: 6,
+    ACPKIX: 7,
+    IACPKIX: 8,
+    URI: 253,
+    OID: 254,
+};
+
+// CERT(name, type, keytag, algorithm, certificate, recordModifiers...)
+var CERT = recordBuilder('CERT', {
+    args: [
+        ['name', _.isString],
+        ['type', function (x) { return _.isNumber(x) || _.isString(x); }],
+        ['keytag', _.isNumber],
+        ['algorithm', _.isNumber],
+        ['target', _.isString], // recordBuilder needs a "target" argument
+    ],
+    transform: function (record, args, modifiers) {
+        var certtype = args.type;
+        if (_.isString(certtype)) {
+            certtype = CERT_TYPES[certtype.toUpperCase()];
+            if (certtype === undefined) {
+                throw 'CERT type "' + args.type + '" is not one of ' + Object.keys(CERT_TYPES).join(', ');
+            }
+        }
+        record.name = args.name;
+        record.certtype = certtype;
+        record.certkeytag = args.keytag;
+        record.certalgorithm = args.algorithm;
+        record.target = args.target;
+    },
+});
+
 // DHCID(name,target, recordModifiers...)
 var DHCID = recordBuilder('DHCID');
 
 // DNAME(name,target, recordModifiers...)
 var DNAME = recordBuilder('DNAME');
 
+// EUI48(name,address, recordModifiers...)
+var EUI48 = recordBuilder('EUI48');
+
+// EUI64(name,address, recordModifiers...)
+var EUI64 = recordBuilder('EUI64');
+
+// ilnpBuilder returns the builder of an ILNP record (RFC 6742): a
+// 16-bit preference followed by the node identifier, locator or FQDN.
+function ilnpBuilder(type) {
+    return recordBuilder(type, {
+        args: [
+            ['name', _.isString],
+            ['preference', _.isNumber],
+            ['target', _.isString],
+        ],
+        transform: function (record, args, modifiers) {
+            if (args.preference < 0 || args.preference > 65535 || args.preference % 1 !== 0) {
+                throw type + ' preference ' + args.preference + ' is not an integer from 0 to 65535';
+            }
+            record.name = args.name;
+            record.ilnppreference = args.preference;
+            record.target = args.target;
+        },
+    });
+}
+
+// NID(name, preference, nodeid, recordModifiers...)
+var NID = ilnpBuilder('NID');
+
+// L32(name, preference, locator, recordModifiers...)
+var L32 = ilnpBuilder('L32');
+
+// L64(name, preference, locator, recordModifiers...)
+var L64 = ilnpBuilder('L64');
+
+// LP(name, preference, fqdn, recordModifiers...)
+var LP = ilnpBuilder('LP');
+
 // DNSKEY(name, flags, protocol, algorithm, publickey)
 var DNSKEY = recordBuilder('DNSKEY', {
     args: [
@@ -466,9 +677,57 @@ var HTTPS = recordBuilder('HTTPS', {
     },
 });
 
+// Mnemonics of the HIP PK algorithms (the IPSECKEY algorithm types, RFC 8005
+// section 5).
+var HIP_ALGORITHMS = {
+    DSA: 1,
+    RSA: 2,
+    ECDSA: 3,
+    EDDSA: 4,
+};
+
+// HIP(name, algorithm, hit, publickey, rendezvousServers, recordModifiers...)
+var HIP = recordBuilder('HIP', {
+    args: [
+        ['name', _.isString],
+        ['algorithm', function (x) { return _.isNumber(x) || _.isString(x); }],
+        ['target', _.isString], // The HIT.
+        ['publickey', _.isString],
+        ['servers', function (x) { return _.isString(x) || (_.isArray(x) && _.all(x, _.isString)); }],
+    ],
+    transform: function (record, args, modifiers) {
+        var algorithm = args.algorithm;
+        if (_.isString(algorithm)) {
+            algorithm = HIP_ALGORITHMS[algorithm.toUpperCase()];
+            if (algorithm === undefined) {
+                throw 'HIP algorithm "' + args.algorithm + '" is not one of ' + Object.keys(HIP_ALGORITHMS).join(', ');
+            }
+        }
+        record.name = args.name;
+        record.hippkalgorithm = algorithm;
+        record.target = args.target;
+        record.hippublickey = args.publickey;
+        record.hiprendezvousservers = _.isString(args.servers) ? [args.servers] : args.servers;
+    },
+});
+
 // PTR(name,target, recordModifiers...)
 var PTR = recordBuilder('PTR');
 
+// RP(name,mbox,txt, recordModifiers...)
+var RP = recordBuilder('RP', {
+    args: [
+        ['name', _.isString],
+        ['target', _.isString], // The mailbox.
+        ['txt', _.isString],
+    ],
+    transform: function (record, args, modifiers) {
+        record.name = args.name;
+        record.target = args.target;
+        record.rptxt = args.txt;
+    },
+});
+
 // NAPTR(name,order,preference,flags,service,regexp,target, recordModifiers...)
 var NAPTR = recordBuilder('NAPTR', {
     args: [
@@ -491,6 +750,23 @@ var NAPTR = recordBuilder('NAPTR', {
     },
 });
 
+// OPENPGPKEY(name,publickey, recordModifiers...)
+// If name is an email address, it is replaced by the RFC 7929 owner name.
+var OPENPGPKEY = recordBuilder('OPENPGPKEY', {
+    args: [
+        ['name', _.isString],
+        ['target', _.isString],
+    ],
+    transform: function (record, args, modifiers) {
+        if (args.name.indexOf('@') === -1) {
+            record.name = args.name;
+        } else {
+            record.name = OPENPGPKEY_LABEL(args.name);
+        }
+        record.target = args.target;
+    },
+});
+
 // SOA(name,ns,mbox,refresh,retry,expire,minimum, recordModifiers...)
 var SOA = recordBuilder('SOA', {
     args: [
@@ -531,6 +807,52 @@ var SRV = recordBuilder('SRV', {
     },
 });
 
+// SRV_SVC_PROTOCOLS are the protocols that SRV_SVC accepts.
+var SRV_SVC_PROTOCOLS = ['tcp', 'udp', 'tls', 'sctp', 'dccp'];
+
+// SRV_SVC(service,protocol,priority,weight,port,target, recordModifiers...)
+// is SRV("_service._protocol", priority, weight, port, target, ...).
+var SRV_SVC = recordBuilder('SRV', {
+    args: [
+        ['service', _.isString],
+        ['protocol', _.isString],
+        ['priority', _.isNumber],
+        ['weight', _.isNumber],
+        ['port', _.isNumber],
+        ['target', _.isString],
+    ],
+    transform: function (record, args, modifiers) {
+        var service = args.service.replace(/^_/, '').toLowerCase();
+        var protocol = args.protocol.replace(/^_/, '').toLowerCase();
+        // As in RFC 6335, section 5.1: letters, digits and hyphens, at least
+        // one letter, and no hyphen at either end or next to another. Its
+        // limit of 15 characters isn't enforced: "_sipfederationtls" is
+        // commonly used.
+        if (
+            !/^[a-z0-9](-?[a-z0-9])*$/.test(service) ||
+            service.length > 62 ||
+            !/[a-z]/.test(service)
+        ) {
+            throw 'SRV_SVC service "' + args.service + '" is not a valid service name (letters, digits and hyphens, e.g. "sip")';
+        }
+        if (SRV_SVC_PROTOCOLS.indexOf(protocol) === -1) {
+            throw 'SRV_SVC protocol "' + args.protocol + '" is not one of ' + SRV_SVC_PROTOCOLS.join(', ');
+        }
+        var fields = ['priority', 'weight', 'port'];
+        for (var i = 0; i < fields.length; i++) {
+            var v = args[fields[i]];
+            if (v < 0 || v > 65535 || v % 1 !== 0) {
+                throw 'SRV_SVC ' + fields[i] + ' ' + v + ' is not an integer from 0 to 65535';
+            }
+        }
+        record.name = '_' + service + '._' + protocol;
+        record.srvpriority = args.priority;
+        record.srvweight = args.weight;
+        record.srvport = args.port;
+        record.target = args.target;
+    },
+});
+
 // SSHFP(name,algorithm,type,value, recordModifiers...)
 var SSHFP = recordBuilder('SSHFP', {
     args: [
@@ -842,6 +1164,25 @@ function NAMESERVER(name) {
     };
 }
 
+// DELEGATE(name, nameservers, modifiers...): Delegate the child zone
+// "name" to the listed nameservers by creating one NS record for each.
+// Unlike NS(), the apex is refused so that delegations can not be
+// confused with the zone's own nameservers (see NAMESERVER()).
+function DELEGATE(name, nameservers) {
+    if (!name || name === '@') {
+        throw 'DELEGATE requires a child label; use NAMESERVER() for the apex.';
+    }
+    if (!_.isArray(nameservers) || nameservers.length === 0) {
+        throw 'DELEGATE requires a non-empty list of nameservers: ' + name;
+    }
+    var modifiers = Array.prototype.slice.call(arguments, 2);
+    var r = [];
+    for (var i = 0; i < nameservers.length; i++) {
+        r.push(NS.apply(null, [name, nameservers[i]].concat(modifiers)));
+    }
+    return r;
+}
+
 // NAMESERVER_TTL(v): Set the TTL for NAMESERVER records.
 function NAMESERVER_TTL(v) {
     if (_.isString(v)) {
@@ -930,6 +1271,31 @@ function IGNORE_TARGET(target, rType) {
     return IGNORE('*', rType, target);
 }
 
+// IGNORE_RECENT(age, labelPattern, rtypePattern, targetPattern) is IGNORE()
+// for the records that were changed at the provider less than age ago.
+function IGNORE_RECENT(age, labelPattern, rtypePattern, targetPattern) {
+    if (_.isString(age)) {
+        age = stringToDuration(age);
+    }
+    if (!_.isNumber(age) || age <= 0) {
+        throw 'IGNORE_RECENT: the age must be a positive duration (Ex: "1h" or 3600)';
+    }
+    return function (d) {
+        IGNORE(labelPattern, rtypePattern, targetPattern)(d);
+        d.unmanaged[d.unmanaged.length - 1].max_age = age;
+    };
+}
+
+// IGNORE_ACME() ignores the TXT records of the ACME DNS-01 challenges
+// (RFC 8555, section 8.4) of the domain and of all its subdomains, which
+// ACME clients create and delete themselves.
+function IGNORE_ACME() {
+    return function (d) {
+        IGNORE('_acme-challenge', 'TXT')(d);
+        IGNORE('_acme-challenge.**', 'TXT')(d);
+    };
+}
+
 // IMPORT_TRANSFORM(translation_table, domain)
 var IMPORT_TRANSFORM = recordBuilder('IMPORT_TRANSFORM', {
     args: [['translation_table'], ['domain'], ['ttl', _.isNumber]],
@@ -1091,6 +1457,10 @@ function recordBuilder(type, opts) {
                 meta: {},
                 ttl: d.defaultTTL,
             };
+            if (d.defaultTTL) {
+                // For print-ir --include-computed; TTL() removes it.
+                record.ttl_origin = 'domain default';
+            }
 
             opts.applyModifier(record, modifiers);
             opts.transform(record, parsedArgs, modifiers);
@@ -1145,6 +1515,9 @@ function addRecord(d, type, name, target, mods) {
         priority: 0,
         meta: {},
     };
+    if (d.defaultTTL) {
+        rec.ttl_origin = 'domain default';
+    }
     // for each modifier, decide based on type:
     // - Function: call is with the record as the argument
     // - Object: merge it into the metadata
@@ -1717,6 +2090,105 @@ function DMARC_BUILDER(value) {
     return TXT(label, record.join('; '));
 }
 
+// DKIM_BUILDER(value): the DKIM TXT record for a selector, with the
+// public key read from a file.
+function DKIM_BUILDER(value) {
+    if (!value || !value.selector) {
+        throw 'DKIM_BUILDER requires a selector';
+    }
+    if (!value.keyfile) {
+        throw 'DKIM_BUILDER requires a keyfile';
+    }
+    if (!value.label) {
+        value.label = '@';
+    }
+
+    var label = value.selector + '._domainkey';
+    if (value.label !== '@') {
+        label += '.' + value.label;
+    }
+
+    var record = ['v=DKIM1'];
+    if (value.hashAlgorithms && value.hashAlgorithms.length > 0) {
+        record.push('h=' + value.hashAlgorithms.join(':'));
+    }
+    if (value.flags && value.flags.length > 0) {
+        record.push('t=' + value.flags.join(':'));
+    }
+    record.push(dkimKey(value.keyfile));
+
+    if (value.ttl) {
+        return TXT(label, record.join('; '), TTL(value.ttl));
+    }
+    return TXT(label, record.join('; '));
+}
+
+// MTA_STS_BUILDER(value): the _mta-sts TXT record that announces an
+// MTA-STS policy (RFC 8461).
+// id: The ID of the policy: 1 to 32 letters and digits, to change each time the policy file changes
+// label: The DNS label the policy is for (_mta-sts prefix is added; default: '@')
+// ttl: Input for TTL method (optional)
+function MTA_STS_BUILDER(value) {
+    if (!value || !value.id) {
+        throw 'MTA_STS_BUILDER requires an id';
+    }
+    if (!/^[A-Za-z0-9]{1,32}$/.test(value.id)) {
+        throw (
+            'MTA_STS_BUILDER id "' +
+            value.id +
+            '" must be 1 to 32 letters and digits (such as a date: 20240131)'
+        );
+    }
+    if (!value.label) {
+        value.label = '@';
+    }
+
+    var label = '_mta-sts';
+    if (value.label !== '@') {
+        label += '.' + value.label;
+    }
+
+    var record = 'v=STSv1; id=' + value.id;
+    if (value.ttl) {
+        return TXT(label, record, TTL(value.ttl));
+    }
+    return TXT(label, record);
+}
+
+// TLSRPT_BUILDER(value): the _smtp._tls TXT record that asks for SMTP TLS
+// reports (RFC 8460).
+// rua: Array of report targets (mailto: or https: URLs)
+// label: The DNS label the reports are for (_smtp._tls prefix is added; default: '@')
+// ttl: Input for TTL method (optional)
+function TLSRPT_BUILDER(value) {
+    if (!value || !value.rua || value.rua.length === 0) {
+        throw 'TLSRPT_BUILDER requires at least one rua';
+    }
+    for (var i = 0; i < value.rua.length; i++) {
+        if (!/^(mailto|https):/.test(value.rua[i])) {
+            throw (
+                'TLSRPT_BUILDER rua "' +
+                value.rua[i] +
+                '" must be a mailto: or https: URL'
+            );
+        }
+    }
+    if (!value.label) {
+        value.label = '@';
+    }
+
+    var label = '_smtp._tls';
+    if (value.label !== '@') {
+        label += '.' + value.label;
+    }
+
+    var record = 'v=TLSRPTv1; rua=' + value.rua.join(',');
+    if (value.ttl) {
+        return TXT(label, record, TTL(value.ttl));
+    }
+    return TXT(label, record);
+}
+
 // Documentation of the records: https://learn.microsoft.com/en-us/microsoft-365/enterprise/external-domain-name-system-records?view=o365-worldwide
 function M365_BUILDER(name, value) {
     // value is optional
@@ -1862,6 +2334,27 @@ function require_glob() {
     return files;
 }
 
+// FROM_CSV(filename, fn[, delimiter]): A domain modifier that calls
+// fn(row, line) for each row of a CSV file. row has the cells of the row,
+// keyed by the names in the header row. fn returns the records (or any
+// other domain modifiers) for the row, or nothing to skip it.
+function FROM_CSV(filename, fn, delimiter) {
+    if (!_.isFunction(fn)) {
+        throw 'FROM_CSV: the second argument must be a function';
+    }
+    // Read the file now, while csvRows() resolves filename relative to the
+    // file that calls FROM_CSV().
+    var rows = csvRows(filename, delimiter);
+    return function (d) {
+        for (var i = 0; i < rows.length; i++) {
+            var m = fn(rows[i].row, rows[i].line);
+            if (m !== undefined && m !== null) {
+                processDargs(m, d);
+            }
+        }
+    };
+}
+
 // Set default values for CLI variables
 function CLI_DEFAULTS(defaults) {
     for (var key in defaults) {
@@ -13,6 +13,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
 	"github.com/StackExchange/dnscontrol/v4/pkg/rfc4183"
 	"github.com/StackExchange/dnscontrol/v4/pkg/transform"
+	"github.com/StackExchange/dnscontrol/v4/pkg/ttlsource"
 	"github.com/robertkrimen/otto"              // load underscore js into vm by default
 	_ "github.com/robertkrimen/otto/underscore" // required by otto
 	"github.com/xddxdd/ottoext/fetch"
@@ -46,7 +47,15 @@ func ExecuteJavaScript(file string, devMode bool, variables map[string]string) (
 	// Record the directory path leading up to this file.
 	currentDirectory = filepath.Dir(file)
 
-	return ExecuteJavascriptString(script, devMode, variables)
+	conf, err := ExecuteJavascriptString(script, devMode, variables)
+	if err != nil {
+		return nil, err
+	}
+	// TTL_FROM("file:...") is relative to dnsconfig.js, not to the current directory.
+	for _, dc := range conf.Domains {
+		ttlsource.RelativeTo(dc.Records, filepath.Dir(file))
+	}
+	return conf, nil
 }
 
 // ExecuteJavascriptString accepts a string containing javascript and runs it, returning the resulting dnsConfig.
@@ -75,6 +84,9 @@ func ExecuteJavascriptString(script []byte, devMode bool, variables map[string]s
 	vm.Set("glob", listFiles) // used for require_glob()
 	vm.Set("PANIC", jsPanic)
 	vm.Set("HASH", hashFunc)
+	vm.Set("OPENPGPKEY_LABEL", openpgpkeyLabel)
+	vm.Set("dkimKey", dkimKey) // used for DKIM_BUILDER()
+	vm.Set("csvRows", csvRows) // used for FROM_CSV()
 
 	// add cli variables to otto
 	for key, value := range variables {
@@ -295,6 +307,18 @@ func reverse(call otto.FunctionCall) otto.Value {
 	return v
 }
 
+func openpgpkeyLabel(call otto.FunctionCall) otto.Value {
+	if len(call.ArgumentList) != 1 {
+		throw(call.Otto, "OPENPGPKEY_LABEL takes exactly one argument")
+	}
+	label, err := models.OpenPGPKeyLabel(call.Argument(0).String())
+	if err != nil {
+		throw(call.Otto, err.Error())
+	}
+	v, _ := otto.ToValue(label)
+	return v
+}
+
 func reverseCompat(call otto.FunctionCall) otto.Value {
 	if len(call.ArgumentList) != 1 {
 		throw(call.Otto, "REVCOMPAT takes exactly one argument")
@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"unicode"
 
+	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/normalize"
 	"github.com/StackExchange/dnscontrol/v4/pkg/prettyzone"
 	"github.com/StackExchange/dnscontrol/v4/providers"
@@ -25,6 +28,23 @@ func init() {
 	os.Chdir("../..") // go up a directory so we helpers.js is in a consistent place.
 }
 
+// parsedFileWarnings are the validation warnings that the files of
+// TestParsedFiles are expected to give. Any other warning is an error.
+var parsedFileWarnings = map[string][]string{
+	"013-mx.js": {"foo.com MX: the target foo.com. is inside foo.com but has no A or AAAA record"},
+	"021-srv.js": {
+		"_ntp._udp.foo.com SRV: the target one.foo.com. is inside foo.com but has no A or AAAA record",
+		"_ntp._udp.foo.com SRV: the target two.foo.com. is inside foo.com but has no A or AAAA record",
+		"_ntp._udp.foo.com SRV: the target localhost.foo.com. is inside foo.com but has no A or AAAA record",
+		"_ntp._udp.foo.com SRV: the target zeros.foo.com. is inside foo.com but has no A or AAAA record",
+	},
+	"039-include.js": {"foo.com!external and foo.com!internal are both at DNS provider Cloudflare (CLOUDFLAREAPI), which doesn't keep views apart: pushing one undoes the other"},
+	"065-SRV_SVC.js": {
+		"_sip._tcp.foo.com SRV: the target bigbox.foo.com. is inside foo.com but has no A or AAAA record",
+		"_xmpp-server._tcp.foo.com SRV: the target xmpp.foo.com. is inside foo.com but has no A or AAAA record",
+	},
+}
+
 func TestParsedFiles(t *testing.T) {
 	files, err := os.ReadDir(testDir)
 	if err != nil {
@@ -91,9 +111,15 @@ func TestParsedFiles(t *testing.T) {
 
 			// For each domain, if there is a zone file, test against it:
 
-			errs := normalize.ValidateAndNormalizeConfig(conf)
-			if len(errs) != 0 {
-				t.Fatal(errs[0])
+			var warnings []string
+			for _, err := range normalize.ValidateAndNormalizeConfig(conf, normalize.Options{}) {
+				if _, ok := err.(normalize.Warning); !ok {
+					t.Fatal(err)
+				}
+				warnings = append(warnings, err.Error())
+			}
+			if !reflect.DeepEqual(warnings, parsedFileWarnings[name]) {
+				t.Fatalf("warnings = %q, want %q", warnings, parsedFileWarnings[name])
 			}
 
 			var dCount int
@@ -106,6 +132,7 @@ func TestParsedFiles(t *testing.T) {
 				dCount++
 
 				// Generate the zonefile
+				models.QualifyTargets(dc.Records)
 				var buf bytes.Buffer
 				err = prettyzone.WriteZoneFileRC(&buf, dc.Records, dc.Name, 300, nil)
 				if err != nil {
@@ -142,6 +169,18 @@ func TestErrors(t *testing.T) {
 		{"Dup domains", `D("example.org", "reg"); D("example.org", "reg")`},
 		{"Bad NAMESERVER", `D("example.com","reg", NAMESERVER("@","ns1.foo.com."))`},
 		{"Bad Hash function", `D(HASH("123", "abc"),"reg")`},
+		{"DKIM key file missing", `D("foo.com","reg",DKIM_BUILDER({selector: "s1", keyfile: "./no-such-file.pem"}))`},
+		{"DKIM selector missing", `D("foo.com","reg",DKIM_BUILDER({keyfile: "./parse_tests/dkim/s1.pem"}))`},
+		{"CERT unknown type", `D("foo.com","reg",CERT("x", "X509", 0, 0, "AAAA"))`},
+		{"HIP unknown algorithm", `D("foo.com","reg",HIP("x", "SHA1", "AB", "AAAA", []))`},
+		{"HIP bad rendezvous servers", `D("foo.com","reg",HIP("x", 2, "AB", "AAAA", [1]))`},
+		{"NID preference too big", `D("foo.com","reg",NID("host", 65536, "0014:4fff:ff20:ee64"))`},
+		{"SRV_SVC unknown protocol", `D("foo.com","reg",SRV_SVC("sip", "tpc", 10, 60, 5060, "sip.foo.com."))`},
+		{"SRV_SVC bad service", `D("foo.com","reg",SRV_SVC("sip_2", "tcp", 10, 60, 5060, "sip.foo.com."))`},
+		{"Unknown DOMAIN_CLASS", `D("foo.com","reg",DOMAIN_CLASS("nope"))`},
+		{"DOMAIN_TEMPLATE after D", `D("foo.com","reg",DOMAIN_CLASS("corp")); DOMAIN_TEMPLATE("corp", A("@","1.2.3.4"))`},
+		{"DOMAIN_TEMPLATE twice", `DOMAIN_TEMPLATE("corp"); DOMAIN_TEMPLATE("corp")`},
+		{"SRV_SVC port too big", `D("foo.com","reg",SRV_SVC("sip", "tcp", 10, 60, 65536, "sip.foo.com."))`},
 	}
 	for _, tst := range tests {
 		t.Run(tst.desc, func(t *testing.T) {
@@ -152,3 +191,32 @@ func TestErrors(t *testing.T) {
 
 	}
 }
+
+func TestParseCSV(t *testing.T) {
+	rows, err := parseCSV(strings.NewReader("name,ip,ttl,zip\nwww,192.0.2.1,300,007\n\nmail,192.0.2.2,,-1\n"), ',')
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []csvRow{
+		{Line: 2, Row: map[string]any{"name": "www", "ip": "192.0.2.1", "ttl": int64(300), "zip": "007"}},
+		{Line: 4, Row: map[string]any{"name": "mail", "ip": "192.0.2.2", "ttl": "", "zip": int64(-1)}},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("got %v, want %v", rows, want)
+	}
+
+	for _, tst := range []struct{ desc, text, wantErr string }{
+		{"empty", "", "no header row"},
+		{"unnamed column", "name,,ip\n", "line 1: column 2 has no name"},
+		{"duplicate column", "name,name\n", `line 1: column "name" appears more than once`},
+		{"short row", "name,ip\nwww,1.2.3.4\nmail\n", "record on line 3: wrong number of fields"},
+		{"bad quote", "name,ip\nw\"w,1.2.3.4\n", "parse error on line 2"},
+	} {
+		t.Run(tst.desc, func(t *testing.T) {
+			_, err := parseCSV(strings.NewReader(tst.text), ',')
+			if err == nil || !strings.Contains(err.Error(), tst.wantErr) {
+				t.Errorf("got error %v, want %q", err, tst.wantErr)
+			}
+		})
+	}
+}
@@ -1,3 +1,3 @@
 D("foo.com","none",
-    DHCID("@", "Test")
+    DHCID("@", "AAIBY2/AuCccgoJbsaxcQc9TUapptP69lOjxfNuVAA2kjEA=")
 );
@@ -0,0 +1,4 @@
+D("foo.com", "none",
+    DELEGATE("sub", ["ns1.example.net.", "ns2.example.net."]),
+    DELEGATE("other", ["ns1.example.org."], TTL(600))
+);
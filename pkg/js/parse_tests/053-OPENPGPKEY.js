@@ -0,0 +1,5 @@
+D("example.com","none",
+    OPENPGPKEY("hugh@example.com", "AQIDBAU="),
+    OPENPGPKEY(OPENPGPKEY_LABEL("alice"), "AQIDBAU="),
+    OPENPGPKEY("hugh@sub.example.com", "AQIDBAU=")
+);
@@ -0,0 +1,4 @@
+D("foo.com", "none",
+  EUI48("host", "00-00-5E-00-53-2A"),
+  EUI64("host", "00-00-5e-ef-10-00-00-2a")
+);
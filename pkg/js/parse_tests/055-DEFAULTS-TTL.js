@@ -0,0 +1,18 @@
+DEFAULTS(DefaultTTL("1h"));
+
+D("foo.com", "none",
+  A("@", "1.2.3.4"),
+  A("www", "1.2.3.4", TTL(60))
+);
+
+D("bar.com", "none",
+  DefaultTTL(600),
+  A("@", "1.2.3.4"),
+  A("www", "1.2.3.4", TTL(60))
+);
+
+DEFAULTS();
+
+D("baz.com", "none",
+  A("@", "1.2.3.4")
+);
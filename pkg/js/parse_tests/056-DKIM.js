@@ -0,0 +1,4 @@
+D("foo.com", "none",
+  DKIM_BUILDER({selector: "s1", keyfile: "./dkim/s1.pem"}),
+  DKIM_BUILDER({selector: "s2", keyfile: "./dkim/s2.der", label: "mail", flags: ["s"], ttl: 600})
+);
@@ -0,0 +1,4 @@
+D("foo.com", "none",
+  A("@", "1.2.3.4", TTL(3600), TTL_FROM("env:MIGRATION_TTL")),
+  A("www", "1.2.3.4", TTL_FROM("file:ttl.txt"))
+);
@@ -0,0 +1,4 @@
+D("foo.com", "none",
+  CERT("pgp", "PGP", 0, 0, "mQINBFkz"),
+  CERT("x509", 1, 12345, 8, "MIIDdzCCAl+gAwIBAgIJ")
+);
@@ -0,0 +1,7 @@
+var DSP_A = NewDnsProvider("dsp_a", "BIND");
+var DSP_B = NewDnsProvider("dsp_b", "BIND");
+D("foo.com", "none", DnsProvider(DSP_A), DnsProvider(DSP_B),
+  A("www", "1.2.3.4"),
+  A("hc", "1.2.3.5", ONLY_PROVIDERS(DSP_A)),
+  TXT("hc", "check", ONLY_PROVIDERS(DSP_A, DSP_B))
+);
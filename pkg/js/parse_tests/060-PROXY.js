@@ -0,0 +1,5 @@
+D("foo.com", "none",
+  A("www", "1.2.3.4", PROXY_ON()),
+  CNAME("cdn", "www", PROXY_OFF()),
+  A("plain", "1.2.3.5")
+);
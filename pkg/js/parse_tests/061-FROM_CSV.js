@@ -0,0 +1,8 @@
+D("foo.com", "none",
+  FROM_CSV("hosts.csv", function (row) {
+    if (row.ttl === "") {
+      return A(row.name, row.ip);
+    }
+    return A(row.name, row.ip, TTL(row.ttl));
+  })
+);
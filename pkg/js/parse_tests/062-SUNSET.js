@@ -0,0 +1,4 @@
+D("foo.com", "none",
+  CNAME("old", "www.foo.com.", SUNSET("2099-12-31")),
+  TXT("verify", "token", SUNSET("2099-12-31T18:00:00-05:00"))
+);
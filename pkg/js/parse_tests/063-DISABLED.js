@@ -0,0 +1,4 @@
+D("foo.com", "none",
+  A("www", "1.2.3.4"),
+  A("next", "1.2.3.5", DISABLED())
+);
@@ -0,0 +1,6 @@
+D("foo.com", "none",
+  NID("host", 10, "0014:4fff:ff20:ee64"),
+  L32("host", 10, "10.1.2.0"),
+  L64("host", 20, "2001:0DB8:1140:1000"),
+  LP("mobile", 10, "l64-subnet1.foo.com.")
+);
@@ -0,0 +1,5 @@
+D("foo.com", "none",
+    SRV_SVC("sip", "tcp", 10, 60, 5060, "bigbox.foo.com."),
+    SRV_SVC("_xmpp-server", "_TCP", 5, 0, 5269, "xmpp.foo.com.", TTL(600)),
+    SRV_SVC("sipfederationtls", "tls", 100, 1, 5061, "sipfed.online.lync.com.")
+);
@@ -0,0 +1,4 @@
+D("foo.com", "none",
+    IGNORE_ACME(),
+    CNAME("_acme-challenge.www", "www.acme-dns.example.net.")
+);
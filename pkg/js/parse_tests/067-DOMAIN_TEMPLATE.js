@@ -0,0 +1,21 @@
+DOMAIN_TEMPLATE("corporate",
+    CAA("@", "issue", "letsencrypt.org"),
+    CNAME("security", "security.corp.example.net."),
+    IGNORE("_acme-challenge", "TXT"),
+    { contact: "dns@example.net" }
+);
+DOMAIN_TEMPLATE("marketing",
+    TXT("@", "google-site-verification=abc")
+);
+
+D("foo.com", "none",
+    DOMAIN_CLASS("corporate"),
+    A("@", "1.2.3.4")
+);
+D("bar.com", "none",
+    { domain_class: "corporate,marketing", contact: "web@bar.com" },
+    CNAME("security", "security.bar.com.")
+);
+D("baz.com", "none",
+    A("@", "1.2.3.4")
+);
@@ -0,0 +1,4 @@
+D("foo.com", "none",
+    IGNORE_RECENT("2d", "_session.*", "TXT"),
+    IGNORE_RECENT(3600)
+);
@@ -0,0 +1,3 @@
+D("foo.com", "none",
+  CNAME("www", "app.cdn.example.net.", RESOLVES_TO("*.cdn.example.net.", "192.0.2.0/24"))
+);
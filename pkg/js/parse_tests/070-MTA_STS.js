@@ -0,0 +1,6 @@
+D("foo.com", "none",
+  MTA_STS_BUILDER({id: "20240131"}),
+  TLSRPT_BUILDER({rua: ["mailto:tlsrpt@foo.com"]}),
+  MTA_STS_BUILDER({id: "v2", label: "eu", ttl: 600}),
+  TLSRPT_BUILDER({rua: ["mailto:tlsrpt@foo.com", "https://reports.foo.com/tlsrpt"], label: "eu", ttl: 600})
+);
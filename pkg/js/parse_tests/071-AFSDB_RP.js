@@ -0,0 +1,6 @@
+D("foo.com", "none",
+  AFSDB("@", 1, "afsdb1.foo.com."),
+  AFSDB("@", 2, "afsdb2"),
+  RP("@", "hostmaster.foo.com.", "contact.foo.com."),
+  RP("www", "webmaster", ".")
+);
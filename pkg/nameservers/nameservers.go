@@ -3,6 +3,7 @@ package nameservers
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -83,3 +84,51 @@ func AddNSRecords(dc *models.DomainConfig) {
 		dc.Records = append(dc.Records, rc)
 	}
 }
+
+// CheckApexNS compares the NS records explicitly declared at the apex
+// of the zone with the nameservers that will be used for the domain
+// (dc.Nameservers). It returns a warning message for each
+// nameserver that appears in one list but not the other. Call it
+// before AddNSRecords(), otherwise the lists trivially match.
+//
+// NS records at any other label are downward delegations and are
+// ignored.
+func CheckApexNS(dc *models.DomainConfig) []string {
+	apex := map[string]bool{}
+	for _, rc := range dc.Records {
+		if rc.Type == "NS" && rc.GetLabel() == "@" {
+			apex[strings.ToLower(strings.TrimSuffix(rc.GetTargetField(), "."))] = true
+		}
+	}
+	if len(apex) == 0 || len(dc.Nameservers) == 0 {
+		// Nothing to compare.
+		return nil
+	}
+
+	authoritative := map[string]bool{}
+	for _, ns := range dc.Nameservers {
+		authoritative[strings.ToLower(strings.TrimSuffix(ns.Name, "."))] = true
+	}
+
+	var msgs []string
+	for _, name := range sortedKeys(apex) {
+		if !authoritative[name] {
+			msgs = append(msgs, fmt.Sprintf("%s: apex NS record %q is not one of the zone's nameservers %v", dc.Name, name, sortedKeys(authoritative)))
+		}
+	}
+	for _, name := range sortedKeys(authoritative) {
+		if !apex[name] {
+			msgs = append(msgs, fmt.Sprintf("%s: nameserver %q has no matching apex NS record", dc.Name, name))
+		}
+	}
+	return msgs
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,57 @@
+package nameservers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func makeNS(label, target string) *models.RecordConfig {
+	rc := &models.RecordConfig{Type: "NS"}
+	rc.SetLabel(label, "example.com")
+	rc.SetTarget(target)
+	return rc
+}
+
+func TestCheckApexNS(t *testing.T) {
+	tests := []struct {
+		name    string
+		records models.Records
+		ns      []string
+		want    []string
+	}{
+		{
+			name:    "no apex NS",
+			records: models.Records{makeNS("child", "ns1.other.com.")},
+			ns:      []string{"ns1.example.net"},
+			want:    nil,
+		},
+		{
+			name:    "match",
+			records: models.Records{makeNS("@", "ns1.example.net."), makeNS("@", "NS2.example.net.")},
+			ns:      []string{"ns2.example.net", "ns1.example.net"},
+			want:    nil,
+		},
+		{
+			name:    "mismatch",
+			records: models.Records{makeNS("@", "ns1.example.net."), makeNS("@", "ns9.example.org."), makeNS("child", "ns3.example.net.")},
+			ns:      []string{"ns1.example.net", "ns2.example.net"},
+			want: []string{
+				`example.com: apex NS record "ns9.example.org" is not one of the zone's nameservers [ns1.example.net ns2.example.net]`,
+				`example.com: nameserver "ns2.example.net" has no matching apex NS record`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dc := &models.DomainConfig{Name: "example.com", Records: tt.records}
+			for _, n := range tt.ns {
+				dc.Nameservers = append(dc.Nameservers, &models.Nameserver{Name: n})
+			}
+			if got := CheckApexNS(dc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckApexNS() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
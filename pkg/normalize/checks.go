@@ -0,0 +1,59 @@
+package normalize
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Check is a normalization check that can be turned off with
+// --disable-check.
+type Check struct {
+	Name        string // Stable name used on the command line.
+	Description string
+}
+
+// Checks lists the checks that can be disabled. Checks that protect the
+// providers (capabilities, provider-specific audits) are not listed, since
+// disabling them would only move the failure to push time.
+var Checks = []Check{
+	{"alias", "an ALIAS shares its label with a CNAME (or an A or AAAA record)"},
+	{"autodnssec", "AUTODNSSEC_ON is used with a DNS provider that is not the registrar"},
+	{"cname", "a CNAME shares its label with another record (or another CNAME)"},
+	{"dname", "a record is below a DNAME, which makes it unreachable (or a name has more than one DNAME)"},
+	{"doubled-domain", "a target ends with the domain's name twice (foo.example.com.example.com.)"},
+	{"duplicates", "the same record appears more than once"},
+	{"glue", "a nameserver inside the zone (or one of its delegations) has no A or AAAA record"},
+	{"in-zone-targets", "the target of an MX or SRV record is inside the domain but has no A or AAAA record (or is a CNAME)"},
+	{"labels", "a label is malformed, or a label with an underscore is of a type that doesn't expect one"},
+	{"low-ttl", "an NS, SOA, MX or apex A/AAAA record has a TTL below --low-ttl, which is off by default (see --low-ttl-types)"},
+	{"mx-preferences", "MX records share a preference, or a backup MX points at the same host as the primary"},
+	{"multiple-spf", "a name has more than one SPF (v=spf1) TXT record (see --merge-spf)"},
+	{"multiple-ttls", "the records of a record set have different TTLs"},
+	{"public-ips", "an A or AAAA record of a public zone (public_zone) has a private or reserved address (see --strict)"},
+	{"record-limits", "a zone has more records than the provider accepts"},
+	{"soa-timers", "an SOA record's retry isn't less than its refresh, or its expire isn't more"},
+	{"split-horizon", "several views of a split horizon domain are at a DNS provider that doesn't keep views apart"},
+	{"sunset", "a record is past, or near, its SUNSET() date"},
+	{"targets", "a record's target is malformed for its type"},
+	{"ttl-range", "a TTL is outside the range the provider accepts (and is clamped)"},
+}
+
+// ValidateChecks returns an error if a name isn't one of Checks.
+func ValidateChecks(names []string) error {
+	for _, name := range names {
+		if !slices.ContainsFunc(Checks, func(c Check) bool { return c.Name == name }) {
+			var all []string
+			for _, c := range Checks {
+				all = append(all, c.Name)
+			}
+			return fmt.Errorf("unknown check %q (valid checks: %s)", name, strings.Join(all, ", "))
+		}
+	}
+	return nil
+}
+
+// checkEnabled returns false if the check is one of opts.DisabledChecks.
+func (opts Options) checkEnabled(name string) bool {
+	return !slices.Contains(opts.DisabledChecks, name)
+}
@@ -0,0 +1,35 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestDisableChecks(t *testing.T) {
+	newConfig := func() *models.DNSConfig {
+		return &models.DNSConfig{Domains: []*models.DomainConfig{{
+			Name: "example.com",
+			Records: []*models.RecordConfig{
+				makeRC("@", "example.com", "1.1.1.1", models.RecordConfig{Type: "A"}),
+				makeRC("@", "example.com", "1.1.1.1", models.RecordConfig{Type: "A"}),
+			},
+		}}}
+	}
+
+	if errs := ValidateAndNormalizeConfig(newConfig(), Options{}); len(errs) != 1 {
+		t.Fatalf("expected the duplicate to be found, got %v", errs)
+	}
+
+	if err := ValidateChecks([]string{"bogus"}); err == nil {
+		t.Error("expected an error for an unknown check")
+	}
+	if err := ValidateChecks([]string{"duplicates"}); err != nil {
+		t.Fatal(err)
+	}
+	// A disabled check isn't a warning, so that --fail-on-warning doesn't
+	// fail because of it.
+	if errs := ValidateAndNormalizeConfig(newConfig(), Options{DisabledChecks: []string{"duplicates"}}); len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}
@@ -0,0 +1,53 @@
+package normalize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// checkDNAMEs reports the records of dc that a DNAME makes unreachable. A
+// DNAME redirects all the names below its owner (but not the owner itself),
+// so a record at one of those names is never returned (RFC 6672 section
+// 2.3): a warning that names both. More than one DNAME at a name is an
+// error (RFC 6672 section 2.4).
+func checkDNAMEs(dc *models.DomainConfig) (errs []error) {
+	dnames := map[string]*models.RecordConfig{}
+	for _, r := range dc.Records {
+		if r.Type != "DNAME" {
+			continue
+		}
+		name := r.GetLabelFQDN()
+		if dnames[name] != nil {
+			errs = append(errs, fmt.Errorf("%s has more than one DNAME record, but a name may only have one (RFC 6672 section 2.4)", name))
+			continue
+		}
+		dnames[name] = r
+	}
+	if len(dnames) == 0 {
+		return errs
+	}
+
+	for _, r := range dc.Records {
+		name := r.GetLabelFQDN()
+		// The DNAME closest to the apex is the one that the resolvers
+		// follow.
+		var occluding *models.RecordConfig
+		for parent := name; parent != dc.Name; {
+			_, rest, ok := strings.Cut(parent, ".")
+			if !ok {
+				break
+			}
+			parent = rest
+			if d := dnames[parent]; d != nil {
+				occluding = d
+			}
+		}
+		if occluding != nil {
+			errs = append(errs, Warning{fmt.Errorf("%s %s is unreachable: it is below the DNAME of %s (to %s), which redirects the names below it (RFC 6672 section 2.3)",
+				name, r.Type, occluding.GetLabelFQDN(), occluding.GetTargetField())})
+		}
+	}
+	return errs
+}
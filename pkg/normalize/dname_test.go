@@ -0,0 +1,56 @@
+package normalize
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestCheckDNAMEs(t *testing.T) {
+	rec := func(label, typ, target string) *models.RecordConfig {
+		return makeRC(label, "example.com", target, models.RecordConfig{Type: typ})
+	}
+	tests := []struct {
+		name    string
+		records []*models.RecordConfig
+		want    []string // The substrings of the errors; "W:" for warnings.
+	}{
+		{"no DNAME", []*models.RecordConfig{rec("www.sub", "A", "192.0.2.1")}, nil},
+		{"at the owner", []*models.RecordConfig{rec("sub", "DNAME", "example.net."), rec("sub", "TXT", "hello"), rec("other", "A", "192.0.2.1")}, nil},
+		{"below", []*models.RecordConfig{rec("sub", "DNAME", "example.net."), rec("www.sub", "CNAME", "x.example.org."), rec("a.b.sub", "A", "192.0.2.1")},
+			[]string{
+				"W:www.sub.example.com CNAME is unreachable: it is below the DNAME of sub.example.com (to example.net.)",
+				"W:a.b.sub.example.com A is unreachable",
+			}},
+		{"not a suffix of labels", []*models.RecordConfig{rec("sub", "DNAME", "example.net."), rec("xsub", "A", "192.0.2.1")}, nil},
+		{"nested", []*models.RecordConfig{rec("sub", "DNAME", "example.net."), rec("in.sub", "DNAME", "example.org."), rec("www.in.sub", "A", "192.0.2.1")},
+			[]string{
+				"W:in.sub.example.com DNAME is unreachable: it is below the DNAME of sub.example.com",
+				"W:www.in.sub.example.com A is unreachable: it is below the DNAME of sub.example.com",
+			}},
+		{"apex", []*models.RecordConfig{rec("@", "DNAME", "example.net."), rec("@", "MX", "mx.example.net."), rec("www", "A", "192.0.2.1")},
+			[]string{"W:www.example.com A is unreachable: it is below the DNAME of example.com"}},
+		{"two DNAMEs", []*models.RecordConfig{rec("sub", "DNAME", "example.net."), rec("sub", "DNAME", "example.org.")},
+			[]string{"sub.example.com has more than one DNAME record"}},
+	}
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			errs := checkDNAMEs(&models.DomainConfig{Name: "example.com", Records: tst.records})
+			if len(errs) != len(tst.want) {
+				t.Fatalf("got %v, want %d errors", errs, len(tst.want))
+			}
+			for i, err := range errs {
+				want, warning := strings.CutPrefix(tst.want[i], "W:")
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %d = %q, want %q", i, err, want)
+				}
+				var w Warning
+				if errors.As(err, &w) != warning {
+					t.Errorf("error %d: warning is %v, want %v", i, !warning, warning)
+				}
+			}
+		})
+	}
+}
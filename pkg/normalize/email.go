@@ -0,0 +1,153 @@
+package normalize
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// soaMboxFromEmail encodes an email address as the mailbox of an SOA
+// record (RFC 1035 section 8): the "@" becomes a dot, and the dots of the
+// local part are escaped. "first.last@example.com" is
+// "first\.last.example.com.".
+func soaMboxFromEmail(email string) string {
+	local, domain, _ := strings.Cut(email, "@")
+	return strings.ReplaceAll(local, ".", `\.`) + "." + dns.Fqdn(domain)
+}
+
+// checkSoaMbox checks the mailbox of an SOA record, which is an email
+// address written as a domain name.
+func checkSoaMbox(mbox string) error {
+	return checkMbox("SOA MBox", mbox)
+}
+
+// checkMbox checks a mailbox (of an SOA or RP record): an email address
+// written as a domain name. what names it in the errors.
+func checkMbox(what, mbox string) error {
+	if strings.ContainsRune(mbox, '@') {
+		if strings.Count(mbox, "@") == 1 {
+			return fmt.Errorf("%s %q must have '.' instead of '@': write %q (the dots before the @ are escaped)", what, mbox, soaMboxFromEmail(strings.TrimPrefix(mbox, "mailto:")))
+		}
+		return fmt.Errorf("%s %q must have '.' instead of '@'", what, mbox)
+	}
+	if strings.HasPrefix(strings.ToLower(mbox), "mailto:") {
+		return fmt.Errorf("%s %q is a domain name, not a URL: remove \"mailto:\"", what, mbox)
+	}
+	if _, ok := dns.IsDomainName(mbox); !ok {
+		return fmt.Errorf("%s %q is not a valid domain name (check the escaping of the dots of the part before the @)", what, mbox)
+	}
+	if labels := dns.SplitDomainName(mbox); len(labels) < 2 && dns.IsFqdn(mbox) {
+		return fmt.Errorf("%s %q has no domain: the first label is the part before the @ and the others are the domain", what, mbox)
+	}
+	if nonASCII(mbox) {
+		return Warning{fmt.Errorf("%s %q has non-ASCII characters, which many DNS servers and tools don't handle; write the domain in punycode (xn--...)", what, mbox)}
+	}
+	return nil
+}
+
+// checkCAAIodef checks the value of a CAA iodef record, which is the URL
+// to report to (RFC 8659 section 4.4): mailto:, http: or https:.
+func checkCAAIodef(value string) error {
+	if !strings.Contains(value, ":") && strings.Count(value, "@") == 1 {
+		return fmt.Errorf("CAA iodef %q must be a URL: %q", value, "mailto:"+value)
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("CAA iodef %q is not a URL: %w", value, err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "mailto":
+		if err := checkMailtoAddresses(u.Opaque); err != nil {
+			return fmt.Errorf("CAA iodef %q: %w", value, err)
+		}
+	case "http", "https":
+		if u.Host == "" {
+			return fmt.Errorf("CAA iodef %q: the URL has no host", value)
+		}
+	default:
+		return fmt.Errorf("CAA iodef %q must be a mailto:, http: or https: URL", value)
+	}
+	if nonASCII(value) {
+		return Warning{fmt.Errorf("CAA iodef %q has non-ASCII characters, which a URL can't have; percent-encode the part before the @ and write the domain in punycode (xn--...)", value)}
+	}
+	return nil
+}
+
+// checkMailtoAddresses checks the addresses of a mailto: URL, given
+// without the scheme.
+func checkMailtoAddresses(opaque string) error {
+	to, _, _ := strings.Cut(opaque, "?")
+	to, err := url.PathUnescape(to)
+	if err != nil {
+		return err
+	}
+	for _, addr := range strings.Split(to, ",") {
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil || parsed.Address != addr {
+			return fmt.Errorf("%q is not an email address", addr)
+		}
+		if _, domain, _ := strings.Cut(addr, "@"); !strings.Contains(domain, ".") {
+			return fmt.Errorf("the domain of %q is incomplete", addr)
+		}
+	}
+	return nil
+}
+
+// mtaSTSID matches the id of an MTA-STS record (RFC 8461 section 3.1).
+var mtaSTSID = regexp.MustCompile(`^[A-Za-z0-9]{1,32}$`)
+
+// checkMailPolicyTXT checks the TXT records of MTA-STS (RFC 8461) at
+// _mta-sts and of SMTP TLS reporting (RFC 8460) at _smtp._tls. The other
+// TXT records at these labels are ignored, like the senders ignore them.
+func checkMailPolicyTXT(label, txt string) error {
+	at := func(prefix string) bool { return label == prefix || strings.HasPrefix(label, prefix+".") }
+	fields := map[string]string{}
+	for _, f := range strings.Split(txt, ";") {
+		k, v, _ := strings.Cut(strings.TrimSpace(f), "=")
+		if k != "" {
+			fields[k] = v
+		}
+	}
+	switch {
+	case at("_mta-sts") && strings.HasPrefix(txt, "v=STSv1"):
+		id, ok := fields["id"]
+		if !ok {
+			return fmt.Errorf("MTA-STS record %q has no id=", txt)
+		}
+		if !mtaSTSID.MatchString(id) {
+			return fmt.Errorf("MTA-STS record %q: the id %q must be 1 to 32 letters and digits", txt, id)
+		}
+	case at("_smtp._tls") && strings.HasPrefix(txt, "v=TLSRPTv1"):
+		rua, ok := fields["rua"]
+		if !ok || rua == "" {
+			return fmt.Errorf("TLS-RPT record %q has no rua=", txt)
+		}
+		for _, uri := range strings.Split(rua, ",") {
+			uri = strings.TrimSpace(uri)
+			scheme, rest, _ := strings.Cut(uri, ":")
+			switch strings.ToLower(scheme) {
+			case "mailto":
+				if err := checkMailtoAddresses(rest); err != nil {
+					return fmt.Errorf("TLS-RPT record %q: %w", txt, err)
+				}
+			case "https":
+			default:
+				return fmt.Errorf("TLS-RPT record %q: the rua %q must be a mailto: or https: URL", txt, uri)
+			}
+		}
+	}
+	return nil
+}
+
+func nonASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckSoaMbox(t *testing.T) {
+	tests := []struct {
+		mbox    string
+		want    string // substring of the error; "" for none
+		warning bool
+	}{
+		{"hostmaster.example.com.", "", false},
+		{"hostmaster", "", false},
+		{`first\.last.example.com.`, "", false},
+		{"first.last@example.com", `write "first\\.last.example.com."`, false},
+		{"mailto:hostmaster@example.com", `write "hostmaster.example.com."`, false},
+		{"mailto:hostmaster.example.com.", `remove "mailto:"`, false},
+		{"hostmaster..example.com.", "not a valid domain name", false},
+		{"hostmaster.", "has no domain", false},
+		{"hostmaster.bücher.example.", "punycode", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mbox, func(t *testing.T) {
+			err := checkSoaMbox(tt.mbox)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got %v, want an error containing %q", err, tt.want)
+			}
+			if _, ok := err.(Warning); ok != tt.warning {
+				t.Errorf("got warning=%v, want %v", ok, tt.warning)
+			}
+		})
+	}
+}
+
+func TestCheckCAAIodef(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string // substring of the error; "" for none
+		warning bool
+	}{
+		{"mailto:security@example.com", "", false},
+		{"mailto:security@example.com,noc@example.net?subject=CAA", "", false},
+		{"mailto:first.last%2Bcaa@example.com", "", false},
+		{"https://caa.example.com/report", "", false},
+		{"security@example.com", `must be a URL: "mailto:security@example.com"`, false},
+		{"mailto:example.com", `"example.com" is not an email address`, false},
+		{"mailto:security@localhost", "the domain of \"security@localhost\" is incomplete", false},
+		{"mailto:Security <security@example.com>", "is not an email address", false},
+		{"https:///report", "the URL has no host", false},
+		{"ftp://example.com/", "must be a mailto:, http: or https: URL", false},
+		{"mailto:sécurité@example.com", "percent-encode", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			err := checkCAAIodef(tt.value)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got %v, want an error containing %q", err, tt.want)
+			}
+			if _, ok := err.(Warning); ok != tt.warning {
+				t.Errorf("got warning=%v, want %v", ok, tt.warning)
+			}
+		})
+	}
+}
+
+func TestCheckMailPolicyTXT(t *testing.T) {
+	tests := []struct {
+		label, txt string
+		want       string // substring of the error; "" for none
+	}{
+		{"_mta-sts", "v=STSv1; id=20240131", ""},
+		{"_mta-sts.eu", "v=STSv1;id=v2", ""},
+		{"_mta-sts", "v=STSv1; id=2024-01-31", "must be 1 to 32 letters and digits"},
+		{"_mta-sts", "v=STSv1; id=123456789012345678901234567890123", "must be 1 to 32 letters and digits"},
+		{"_mta-sts", "v=STSv1", "has no id="},
+		{"_mta-sts", "some other record", ""},
+		{"www", "v=STSv1", ""},
+		{"_smtp._tls", "v=TLSRPTv1; rua=mailto:tlsrpt@example.com", ""},
+		{"_smtp._tls.eu", "v=TLSRPTv1; rua=mailto:a@example.com,https://reports.example.com/v1", ""},
+		{"_smtp._tls", "v=TLSRPTv1", "has no rua="},
+		{"_smtp._tls", "v=TLSRPTv1; rua=tlsrpt@example.com", "must be a mailto: or https: URL"},
+		{"_smtp._tls", "v=TLSRPTv1; rua=http://reports.example.com/", "must be a mailto: or https: URL"},
+		{"_smtp._tls", "v=TLSRPTv1; rua=mailto:tlsrpt", `"tlsrpt" is not an email address`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label+" "+tt.txt, func(t *testing.T) {
+			err := checkMailPolicyTXT(tt.label, tt.txt)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
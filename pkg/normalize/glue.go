@@ -0,0 +1,66 @@
+package normalize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// checkGlue warns about the nameservers of the domain (NAMESERVER() and the
+// apex NS records) and of its delegations (the other NS records) that are
+// inside the domain but have no A or AAAA record in it. The parent zone
+// needs those addresses as glue to reach the nameserver, so the delegation
+// fails without them, in a way that is hard to diagnose.
+func checkGlue(dc *models.DomainConfig) (errs []error) {
+	domain := strings.ToLower(dc.Name)
+	inZone := func(host string) bool {
+		return host == domain || strings.HasSuffix(host, "."+domain)
+	}
+	canonical := func(host string) string {
+		return strings.ToLower(strings.TrimSuffix(host, "."))
+	}
+
+	addresses := map[string]bool{}
+	for _, r := range dc.Records {
+		if r.Type == "A" || r.Type == "AAAA" {
+			addresses[canonical(r.GetLabelFQDN())] = true
+		}
+	}
+
+	// The zone whose NS records name each in-zone nameserver, for the
+	// message.
+	needed := map[string]map[string]bool{}
+	need := func(ns, zone string) {
+		if ns = canonical(ns); inZone(ns) && !addresses[ns] {
+			if needed[ns] == nil {
+				needed[ns] = map[string]bool{}
+			}
+			needed[ns][zone] = true
+		}
+	}
+	for _, ns := range dc.Nameservers {
+		need(ns.Name, domain)
+	}
+	for _, r := range dc.Records {
+		if r.Type == "NS" {
+			need(r.GetTargetField(), canonical(r.GetLabelFQDN()))
+		}
+	}
+
+	names := make([]string, 0, len(needed))
+	for ns := range needed {
+		names = append(names, ns)
+	}
+	sort.Strings(names)
+	for _, ns := range names {
+		zones := make([]string, 0, len(needed[ns]))
+		for z := range needed[ns] {
+			zones = append(zones, z)
+		}
+		sort.Strings(zones)
+		errs = append(errs, Warning{fmt.Errorf("%s is a nameserver of %s inside %s but has no A or AAAA record; the parent zone needs it as glue", ns, strings.Join(zones, ", "), domain)})
+	}
+	return errs
+}
@@ -0,0 +1,57 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestCheckGlue(t *testing.T) {
+	rec := func(label, rtype, target string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: rtype}
+		rc.SetLabel(label, "example.com")
+		rc.SetTarget(target)
+		return rc
+	}
+	tests := []struct {
+		name        string
+		nameservers []string
+		records     []*models.RecordConfig
+		want        []string
+	}{
+		{"out of zone", []string{"ns1.example.net."}, []*models.RecordConfig{rec("sub", "NS", "ns.example.org.")}, nil},
+		{"glue present",
+			[]string{"ns1.example.com.", "ns2.example.com."},
+			[]*models.RecordConfig{rec("ns1", "A", "192.0.2.1"), rec("NS2", "AAAA", "2001:db8::2")},
+			nil},
+		{"NAMESERVER without glue",
+			[]string{"ns1.example.com.", "ns2.example.com."},
+			[]*models.RecordConfig{rec("ns1", "A", "192.0.2.1")},
+			[]string{"ns2.example.com is a nameserver of example.com inside example.com but has no A or AAAA record"}},
+		{"delegation without glue",
+			nil,
+			[]*models.RecordConfig{rec("sub", "NS", "ns1.sub.example.com."), rec("sub", "NS", "ns.example.org."), rec("other", "NS", "ns1.sub.example.com.")},
+			[]string{"ns1.sub.example.com is a nameserver of other.example.com, sub.example.com inside example.com"}},
+	}
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			dc := &models.DomainConfig{Name: "example.com", Records: tst.records}
+			for _, ns := range tst.nameservers {
+				dc.Nameservers = append(dc.Nameservers, &models.Nameserver{Name: ns})
+			}
+			errs := checkGlue(dc)
+			if len(errs) != len(tst.want) {
+				t.Fatalf("got %v, want %d warnings", errs, len(tst.want))
+			}
+			for i, err := range errs {
+				if _, ok := err.(Warning); !ok {
+					t.Errorf("%v is not a warning", err)
+				}
+				if !strings.Contains(err.Error(), tst.want[i]) {
+					t.Errorf("got %q, want it to contain %q", err, tst.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package normalize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// CheckIdenticalDomains warns about the domains whose (normalized) records
+// are the same, apart from the name of the domain: often a copy of
+// another domain that wasn't changed. Parked domains can legitimately
+// share their records, so they are warnings. Domains without records are
+// skipped.
+func CheckIdenticalDomains(domains []*models.DomainConfig) (errs []error) {
+	var order []string
+	groups := map[string][]*models.DomainConfig{}
+	for _, dc := range domains {
+		if len(dc.Records) == 0 {
+			continue
+		}
+		fp := domainFingerprint(dc)
+		if _, ok := groups[fp]; !ok {
+			order = append(order, fp)
+		}
+		groups[fp] = append(groups[fp], dc)
+	}
+	for _, fp := range order {
+		group := groups[fp]
+		if len(group) < 2 {
+			continue
+		}
+		var names []string
+		for _, dc := range group {
+			names = append(names, dc.GetUniqueName())
+		}
+		errs = append(errs, Warning{fmt.Errorf("domains %s have identical records (%d); is one a copy that should have been changed?",
+			strings.Join(names, ", "), len(group[0].Records))})
+	}
+	return errs
+}
+
+// domainFingerprint returns the records of dc as a string, sorted, with
+// the name of the domain replaced by "@" in the labels and targets so
+// that copies of the same records in two domains are equal.
+func domainFingerprint(dc *models.DomainConfig) string {
+	origin := dc.Name + "."
+	lines := make([]string, 0, len(dc.Records))
+	for _, rec := range dc.Records {
+		target := rec.GetTargetCombinedFunc(nil)
+		if field := rec.GetTargetField(); strings.HasSuffix(target, field) {
+			target = strings.TrimSuffix(target, field) + relToOrigin(field, origin)
+		}
+		lines = append(lines, fmt.Sprintf("%s %d %s %s", rec.GetLabel(), rec.TTL, rec.Type, target))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// relToOrigin replaces the origin at the end of the name by "@".
+func relToOrigin(name, origin string) string {
+	switch {
+	case strings.EqualFold(name, origin):
+		return "@"
+	case len(name) > len(origin) && strings.EqualFold(name[len(name)-len(origin)-1:], "."+origin):
+		return name[:len(name)-len(origin)] + "@"
+	}
+	return name
+}
@@ -0,0 +1,53 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func identicalDomain(name string, recs ...[3]string) *models.DomainConfig {
+	dc := &models.DomainConfig{Name: name}
+	dc.UpdateSplitHorizonNames()
+	for _, r := range recs {
+		rc := &models.RecordConfig{Type: r[1], TTL: 300}
+		rc.SetLabel(r[0], name)
+		if err := rc.SetTarget(r[2]); err != nil {
+			panic(err)
+		}
+		dc.Records = append(dc.Records, rc)
+	}
+	return dc
+}
+
+func TestCheckIdenticalDomains(t *testing.T) {
+	domains := []*models.DomainConfig{
+		identicalDomain("example.com", [3]string{"@", "A", "192.0.2.1"}, [3]string{"www", "CNAME", "example.com."}),
+		identicalDomain("example.org", [3]string{"@", "A", "192.0.2.2"}),
+		// The same records in another order, and the CNAME to its own apex.
+		identicalDomain("example.net", [3]string{"www", "CNAME", "example.net."}, [3]string{"@", "A", "192.0.2.1"}),
+		identicalDomain("example.info", [3]string{"www", "CNAME", "example.com."}, [3]string{"@", "A", "192.0.2.1"}),
+		identicalDomain("empty1.com"),
+		identicalDomain("empty2.com"),
+	}
+	errs := CheckIdenticalDomains(domains)
+	if len(errs) != 1 {
+		t.Fatalf("got %d errors, want 1: %v", len(errs), errs)
+	}
+	if _, ok := errs[0].(Warning); !ok {
+		t.Errorf("got %T, want a Warning", errs[0])
+	}
+	if msg := errs[0].Error(); !strings.Contains(msg, "domains example.com, example.net have identical records (2)") {
+		t.Errorf("unexpected message: %s", msg)
+	}
+}
+
+func TestCheckIdenticalDomainsTTL(t *testing.T) {
+	a := identicalDomain("example.com", [3]string{"@", "A", "192.0.2.1"})
+	b := identicalDomain("example.net", [3]string{"@", "A", "192.0.2.1"})
+	b.Records[0].TTL = 3600
+	if errs := CheckIdenticalDomains([]*models.DomainConfig{a, b}); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
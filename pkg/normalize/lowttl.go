@@ -0,0 +1,46 @@
+package normalize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// LowTTLPolicy is which records are expected to have a long TTL. These are
+// the ones that rarely change, and whose short TTL would only add queries.
+type LowTTLPolicy struct {
+	Min   uint32          // Warn about a TTL below this; 0 turns the check off.
+	Types map[string]bool // The types checked; "@A" is A records at the apex only.
+}
+
+// ParseLowTTLPolicy returns the policy that reports the records of the
+// comma-separated types whose TTL is below min. A type can be prefixed
+// with "@" to check only the records at the apex (Ex: "NS,SOA,MX,@A,@AAAA").
+func ParseLowTTLPolicy(min uint32, types string) (LowTTLPolicy, error) {
+	p := LowTTLPolicy{Min: min, Types: map[string]bool{}}
+	for _, t := range strings.Split(types, ",") {
+		t = strings.ToUpper(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if strings.TrimPrefix(t, "@") == "" {
+			return LowTTLPolicy{}, fmt.Errorf("%q is not a record type", t)
+		}
+		p.Types[t] = true
+	}
+	return p, nil
+}
+
+// checkLowTTL warns about a record whose TTL is below the minimum of the
+// policy for its type.
+func checkLowTTL(rec *models.RecordConfig, p LowTTLPolicy) error {
+	if p.Min == 0 || rec.TTL >= p.Min {
+		return nil
+	}
+	if !p.Types[rec.Type] && !(rec.GetLabel() == "@" && p.Types["@"+rec.Type]) {
+		return nil
+	}
+	return Warning{fmt.Errorf("%s %s: the TTL %d is below %d; records of this type rarely change, and a short TTL only adds queries (--low-ttl)",
+		rec.GetLabelFQDN(), rec.Type, rec.TTL, p.Min)}
+}
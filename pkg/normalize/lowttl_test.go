@@ -0,0 +1,50 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestCheckLowTTL(t *testing.T) {
+	tests := []struct {
+		min   uint32
+		label string
+		rtype string
+		ttl   uint32
+		warn  bool
+	}{
+		{300, "@", "NS", 60, true},
+		{300, "@", "NS", 300, false},
+		{300, "sub", "NS", 60, true},
+		{300, "@", "MX", 299, true},
+		{300, "@", "A", 60, true},
+		{300, "www", "A", 60, false}, // only at the apex
+		{300, "@", "TXT", 60, false},
+		{0, "@", "NS", 60, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label+" "+tt.rtype, func(t *testing.T) {
+			p, err := ParseLowTTLPolicy(tt.min, "NS, soa,MX,@A,@AAAA")
+			if err != nil {
+				t.Fatal(err)
+			}
+			rec := makeRC(tt.label, "example.com", "target", models.RecordConfig{Type: tt.rtype, TTL: tt.ttl})
+			err = checkLowTTL(rec, p)
+			if !tt.warn {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if _, ok := err.(Warning); !ok || !strings.Contains(err.Error(), "is below 300") {
+				t.Errorf("got %v, want a warning", err)
+			}
+		})
+	}
+
+	if _, err := ParseLowTTLPolicy(300, "NS,@"); err == nil {
+		t.Errorf("expected an error for \"@\"")
+	}
+}
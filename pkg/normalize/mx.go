@@ -0,0 +1,56 @@
+package normalize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// checkMXPreferences warns about two MX mistakes that only show when mail
+// isn't delivered: MX records of a label that share a preference (which
+// is only right for load balancing), and backup MX records that point at
+// the same host as the only MX at preference 0, and so back up nothing.
+// A null MX (RFC 7505) is not reported.
+func checkMXPreferences(records []*models.RecordConfig) (errs []error) {
+	byLabel := map[string][]*models.RecordConfig{}
+	for _, r := range records {
+		if r.Type == "MX" && r.GetTargetField() != "." {
+			byLabel[r.GetLabelFQDN()] = append(byLabel[r.GetLabelFQDN()], r)
+		}
+	}
+	labels := make([]string, 0, len(byLabel))
+	for label := range byLabel {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	for _, label := range labels {
+		mxs := byLabel[label]
+
+		targets := map[uint16][]string{}
+		for _, r := range mxs {
+			targets[r.MxPreference] = append(targets[r.MxPreference], r.GetTargetField())
+		}
+		var prefs []int
+		for p := range targets {
+			prefs = append(prefs, int(p))
+		}
+		sort.Ints(prefs)
+		for _, p := range prefs {
+			if t := targets[uint16(p)]; len(t) > 1 {
+				errs = append(errs, Warning{fmt.Errorf("%s MX: %d records have preference %d (%s); this balances mail between them, otherwise give each a different preference", label, len(t), p, strings.Join(t, ", "))})
+			}
+		}
+
+		if primary := targets[0]; len(primary) == 1 {
+			for _, r := range mxs {
+				if r.MxPreference != 0 && strings.EqualFold(r.GetTargetField(), primary[0]) {
+					errs = append(errs, Warning{fmt.Errorf("%s MX: the backup at preference %d points at the same host as the primary at preference 0 (%s), so it backs up nothing", label, r.MxPreference, primary[0])})
+				}
+			}
+		}
+	}
+	return errs
+}
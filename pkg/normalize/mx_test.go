@@ -0,0 +1,51 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestCheckMXPreferences(t *testing.T) {
+	mx := func(label string, pref uint16, target string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: "MX"}
+		rc.SetLabel(label, "example.com")
+		rc.SetTargetMX(pref, target)
+		return rc
+	}
+	tests := []struct {
+		name    string
+		records []*models.RecordConfig
+		want    []string
+	}{
+		{"distinct", []*models.RecordConfig{mx("@", 10, "mx1.example.com."), mx("@", 20, "mx2.example.com.")}, nil},
+		{"null MX", []*models.RecordConfig{mx("@", 0, ".")}, nil},
+		{"other labels", []*models.RecordConfig{mx("@", 10, "mx1.example.com."), mx("sub", 10, "mx1.example.com.")}, nil},
+		{"shared preference",
+			[]*models.RecordConfig{mx("@", 10, "mx1.example.com."), mx("@", 10, "mx2.example.com."), mx("@", 20, "mx3.example.com.")},
+			[]string{"example.com MX: 2 records have preference 10 (mx1.example.com., mx2.example.com.)"}},
+		{"backup to the primary",
+			[]*models.RecordConfig{mx("@", 0, "mx.example.com."), mx("@", 10, "MX.example.com."), mx("@", 20, "mx2.example.com.")},
+			[]string{"example.com MX: the backup at preference 10 points at the same host as the primary at preference 0 (mx.example.com.)"}},
+		{"two primaries",
+			[]*models.RecordConfig{mx("@", 0, "mx.example.com."), mx("@", 0, "mx2.example.com."), mx("@", 10, "mx.example.com.")},
+			[]string{"example.com MX: 2 records have preference 0"}},
+	}
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			errs := checkMXPreferences(tst.records)
+			if len(errs) != len(tst.want) {
+				t.Fatalf("got %v, want %d warnings", errs, len(tst.want))
+			}
+			for i, err := range errs {
+				if _, ok := err.(Warning); !ok {
+					t.Errorf("%v is not a warning", err)
+				}
+				if !strings.HasPrefix(err.Error(), tst.want[i]) {
+					t.Errorf("got %q, want it to start with %q", err, tst.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package normalize
+
+// Options are the settings of ValidateAndNormalizeConfig.
+type Options struct {
+	DisabledChecks  []string        // Names of Checks that are skipped; see ValidateChecks.
+	Sunset          SunsetPolicy    // How records with a SUNSET() date are reported.
+	RelativeTargets RelativeTargets // How relative targets are reported; "" allows them.
+	LowTTL          LowTTLPolicy    // Which records are expected to have a long TTL; off by default.
+	MergeSPF        bool            // Merge the SPF records at the same name, instead of an error.
+	DropUnsupported bool            // Leave out of a provider the records it doesn't support, instead of an error.
+	TypePolicy      *TypePolicy     // Which record types the domains may have; nil for any.
+	RecordPolicy    *RecordPolicy   // Which records the domains must have; nil for none.
+	Strict          bool            // A private address in a public zone is an error, not a warning.
+}
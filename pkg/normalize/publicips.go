@@ -0,0 +1,84 @@
+package normalize
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// specialRange is a range of addresses that aren't reachable on the
+// Internet (RFC 6890 and the IANA special-purpose address registries).
+type specialRange struct {
+	prefix netip.Prefix
+	what   string
+}
+
+var specialRanges = []specialRange{
+	{netip.MustParsePrefix("0.0.0.0/8"), `a "this network" address (RFC 791)`},
+	{netip.MustParsePrefix("10.0.0.0/8"), "a private address (RFC 1918)"},
+	{netip.MustParsePrefix("100.64.0.0/10"), "a shared (carrier-grade NAT) address (RFC 6598)"},
+	{netip.MustParsePrefix("127.0.0.0/8"), "a loopback address (RFC 1122)"},
+	{netip.MustParsePrefix("169.254.0.0/16"), "a link-local address (RFC 3927)"},
+	{netip.MustParsePrefix("172.16.0.0/12"), "a private address (RFC 1918)"},
+	{netip.MustParsePrefix("192.0.0.0/24"), "an IETF protocol assignment (RFC 6890)"},
+	{netip.MustParsePrefix("192.0.2.0/24"), "a documentation address (RFC 5737)"},
+	{netip.MustParsePrefix("192.168.0.0/16"), "a private address (RFC 1918)"},
+	{netip.MustParsePrefix("198.18.0.0/15"), "a benchmarking address (RFC 2544)"},
+	{netip.MustParsePrefix("198.51.100.0/24"), "a documentation address (RFC 5737)"},
+	{netip.MustParsePrefix("203.0.113.0/24"), "a documentation address (RFC 5737)"},
+	{netip.MustParsePrefix("224.0.0.0/4"), "a multicast address (RFC 5771)"},
+	{netip.MustParsePrefix("240.0.0.0/4"), "a reserved address (RFC 1112)"},
+
+	{netip.MustParsePrefix("::/128"), "the unspecified address (RFC 4291)"},
+	{netip.MustParsePrefix("::1/128"), "the loopback address (RFC 4291)"},
+	{netip.MustParsePrefix("::ffff:0:0/96"), "an IPv4-mapped address (RFC 4291)"},
+	{netip.MustParsePrefix("64:ff9b:1::/48"), "a local-use NAT64 address (RFC 8215)"},
+	{netip.MustParsePrefix("100::/64"), "a discard-only address (RFC 6666)"},
+	{netip.MustParsePrefix("2001:db8::/32"), "a documentation address (RFC 3849)"},
+	{netip.MustParsePrefix("3fff::/20"), "a documentation address (RFC 9637)"},
+	{netip.MustParsePrefix("fc00::/7"), "a unique local address (RFC 4193)"},
+	{netip.MustParsePrefix("fe80::/10"), "a link-local address (RFC 4291)"},
+	{netip.MustParsePrefix("ff00::/8"), "a multicast address (RFC 4291)"},
+}
+
+// specialAddress returns what kind of address ip is if it isn't reachable
+// on the Internet, or "" if it is.
+func specialAddress(ip netip.Addr) string {
+	for _, r := range specialRanges {
+		if r.prefix.Contains(ip) {
+			return r.what
+		}
+	}
+	return ""
+}
+
+// checkPublicIPs reports the A and AAAA records of a public zone (one with
+// the metadata public_zone=true) whose address isn't reachable on the
+// Internet, such as a private or documentation address, which is usually
+// an internal address that leaked into the public DNS. They are warnings,
+// or errors if strict is true.
+func checkPublicIPs(dc *models.DomainConfig, strict bool) (errs []error) {
+	if dc.Metadata["public_zone"] != "true" {
+		return nil
+	}
+	for _, rec := range dc.Records {
+		if rec.Type != "A" && rec.Type != "AAAA" {
+			continue
+		}
+		ip, err := netip.ParseAddr(rec.GetTargetField())
+		if err != nil {
+			continue // Reported by checkTargets.
+		}
+		what := specialAddress(ip)
+		if what == "" {
+			continue
+		}
+		err = fmt.Errorf("%s %s %s is %s, but %s is a public zone (public_zone)", rec.GetLabelFQDN(), rec.Type, ip, what, dc.Name)
+		if !strict {
+			err = Warning{err}
+		}
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,59 @@
+package normalize
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestCheckPublicIPs(t *testing.T) {
+	makeDC := func(public bool) *models.DomainConfig {
+		dc := &models.DomainConfig{Name: "example.com", Metadata: map[string]string{}}
+		if public {
+			dc.Metadata["public_zone"] = "true"
+		}
+		dc.Records = []*models.RecordConfig{
+			makeRC("www", "example.com", "93.184.215.14", models.RecordConfig{Type: "A"}),
+			makeRC("db", "example.com", "10.1.2.3", models.RecordConfig{Type: "A"}),
+			makeRC("lo", "example.com", "127.0.0.1", models.RecordConfig{Type: "A"}),
+			makeRC("doc", "example.com", "2001:db8::1", models.RecordConfig{Type: "AAAA"}),
+			makeRC("v6", "example.com", "2606:2800:21f:cb07:6820:80da:af6b:8b2c", models.RecordConfig{Type: "AAAA"}),
+			makeRC("ula", "example.com", "fd00::1", models.RecordConfig{Type: "AAAA"}),
+			makeRC("txt", "example.com", "10.0.0.1", models.RecordConfig{Type: "TXT"}),
+		}
+		return dc
+	}
+
+	if errs := checkPublicIPs(makeDC(false), false); len(errs) != 0 {
+		t.Errorf("a zone that isn't public is checked: %v", errs)
+	}
+
+	errs := checkPublicIPs(makeDC(true), false)
+	want := []string{
+		"db.example.com A 10.1.2.3 is a private address (RFC 1918), but example.com is a public zone (public_zone)",
+		"lo.example.com A 127.0.0.1 is a loopback address (RFC 1122)",
+		"doc.example.com AAAA 2001:db8::1 is a documentation address (RFC 3849)",
+		"ula.example.com AAAA fd00::1 is a unique local address (RFC 4193)",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, err := range errs {
+		if !strings.Contains(err.Error(), want[i]) {
+			t.Errorf("error %d = %q, want %q", i, err, want[i])
+		}
+		var w Warning
+		if !errors.As(err, &w) {
+			t.Errorf("error %d isn't a warning: %v", i, err)
+		}
+	}
+
+	for _, err := range checkPublicIPs(makeDC(true), true) {
+		var w Warning
+		if errors.As(err, &w) {
+			t.Errorf("a warning with strict: %v", err)
+		}
+	}
+}
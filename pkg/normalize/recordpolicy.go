@@ -0,0 +1,170 @@
+package normalize
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"gopkg.in/yaml.v3"
+)
+
+// RecordPolicy is which records the domains must have, for example the
+// CAA, SPF and DMARC records of an organization's baseline. It is the
+// inverse of the TypePolicy: it requires records, rather than permitting
+// types. It is read from a YAML (or JSON) file:
+//
+//	classes:
+//	  production:
+//	    environment: production
+//	    domains: [example.com]
+//	    required:
+//	      - type: CAA
+//	        name: "@"
+//	      - type: TXT
+//	        name: _dmarc
+//	        value: "^v=DMARC1;"
+//
+// A class applies to the domains it lists (by name, or as "name!tag" for
+// one view of a split horizon domain) and to those whose "environment"
+// metadata is its environment (D("example.com", REG, {environment:
+// "production"}, ...)). A class with neither applies to every domain. A
+// domain must have the required records of all its classes.
+type RecordPolicy struct {
+	File    string                  `yaml:"-"` // Where it was read from, for the errors.
+	Classes map[string]*PolicyClass `yaml:"classes"`
+}
+
+// PolicyClass is the records that a class of domains must have.
+type PolicyClass struct {
+	Environment string           `yaml:"environment"`
+	Domains     []string         `yaml:"domains"`
+	Required    []RequiredRecord `yaml:"required"`
+}
+
+// RequiredRecord is a record that must exist. Name is the label ("@" for
+// the apex); without it, a record of the type at any label will do. Value
+// is a regular expression that the target (all the fields of the record
+// after the type, e.g. "0 issue letsencrypt.org") must match.
+type RequiredRecord struct {
+	Type  string `yaml:"type"`
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+
+	value *regexp.Regexp
+}
+
+// ReadRecordPolicy reads the record policy from the YAML or JSON file at
+// path. An empty path is no policy (nil).
+func ReadRecordPolicy(path string) (*RecordPolicy, error) {
+	if path == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := parseRecordPolicy(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	p.File = path
+	return p, nil
+}
+
+// parseRecordPolicy parses a record policy, uppercases its types and
+// compiles its values.
+func parseRecordPolicy(b []byte) (*RecordPolicy, error) {
+	p := &RecordPolicy{}
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	for name, class := range p.Classes {
+		if class == nil {
+			return nil, fmt.Errorf("class %q is empty", name)
+		}
+		for i := range class.Required {
+			r := &class.Required[i]
+			if r.Type = strings.ToUpper(strings.TrimSpace(r.Type)); r.Type == "" {
+				return nil, fmt.Errorf("class %q: required record %d has no type", name, i+1)
+			}
+			if r.Value != "" {
+				re, err := regexp.Compile(r.Value)
+				if err != nil {
+					return nil, fmt.Errorf("class %q: %s value: %w", name, r.Type, err)
+				}
+				r.value = re
+			}
+		}
+	}
+	return p, nil
+}
+
+// appliesTo reports whether the class applies to dc.
+func (c *PolicyClass) appliesTo(dc *models.DomainConfig) bool {
+	if c.Environment == "" && len(c.Domains) == 0 {
+		return true
+	}
+	if c.Environment != "" && strings.EqualFold(dc.Metadata["environment"], c.Environment) {
+		return true
+	}
+	return slices.Contains(c.Domains, dc.GetUniqueName()) || slices.Contains(c.Domains, dc.Name)
+}
+
+// matches reports whether rec is the required record.
+func (r *RequiredRecord) matches(rec *models.RecordConfig) bool {
+	if rec.Type != r.Type {
+		return false
+	}
+	if r.Name != "" && !strings.EqualFold(rec.GetLabel(), r.Name) {
+		return false
+	}
+	return r.value == nil || r.value.MatchString(rec.GetTargetCombinedFunc(nil))
+}
+
+// String describes the required record, for the errors.
+func (r *RequiredRecord) String() string {
+	s := r.Type
+	if r.Name != "" {
+		s = r.Name + " " + s
+	}
+	if r.Value != "" {
+		s += " matching " + r.Value
+	}
+	return s
+}
+
+// checkRecordPolicy returns an error for each record that the record
+// policy p requires in dc and that dc doesn't have.
+func checkRecordPolicy(dc *models.DomainConfig, p *RecordPolicy) (errs []error) {
+	if p == nil {
+		return nil
+	}
+	names := make([]string, 0, len(p.Classes))
+	for name := range p.Classes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		class := p.Classes[name]
+		if !class.appliesTo(dc) {
+			continue
+		}
+		for i := range class.Required {
+			r := &class.Required[i]
+			if !slices.ContainsFunc(dc.Records, r.matches) {
+				errs = append(errs, fmt.Errorf("domain %s: the required record %s is missing (%s: class %q)",
+					dc.GetUniqueName(), r, p.File, name))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,95 @@
+package normalize
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestCheckRecordPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	policy := `
+classes:
+  baseline:
+    required:
+      - type: caa
+        name: "@"
+  production:
+    environment: production
+    domains: [example.net!inside]
+    required:
+      - type: TXT
+        name: _dmarc
+        value: "^v=DMARC1;"
+`
+	if err := os.WriteFile(path, []byte(policy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := ReadRecordPolicy(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	makeDC := func(name, environment string, recs ...*models.RecordConfig) *models.DomainConfig {
+		dc := &models.DomainConfig{Name: name, Metadata: map[string]string{"environment": environment}}
+		dc.UpdateSplitHorizonNames()
+		for _, rec := range recs {
+			rec.SetLabel(rec.Name, dc.Name)
+			dc.Records = append(dc.Records, rec)
+		}
+		return dc
+	}
+	caa := func(label string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: "CAA", Name: label}
+		rc.SetTargetCAA(0, "issue", "letsencrypt.org")
+		return rc
+	}
+	dmarc := func(txt string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: "TXT", Name: "_dmarc"}
+		rc.SetTargetTXT(txt)
+		return rc
+	}
+	tests := []struct {
+		dc   *models.DomainConfig
+		want []string // The substrings of the errors.
+	}{
+		{makeDC("example.com", "", caa("@")), nil},
+		{makeDC("example.com", "", caa("www")), []string{
+			`domain example.com: the required record @ CAA is missing (` + path + `: class "baseline")`,
+		}},
+		{makeDC("example.org", "Production", caa("@"), dmarc("v=DMARC1; p=reject")), nil},
+		{makeDC("example.org", "production", caa("@"), dmarc("v=spf1 -all")), []string{
+			`domain example.org: the required record _dmarc TXT matching ^v=DMARC1; is missing (` + path + `: class "production")`,
+		}},
+		{makeDC("example.net!inside", "", caa("@")), []string{`class "production"`}},
+		{makeDC("example.net!outside", "", caa("@")), nil},
+	}
+	for _, tst := range tests {
+		errs := checkRecordPolicy(tst.dc, p)
+		if len(errs) != len(tst.want) {
+			t.Errorf("%s: got %d errors, want %d: %v", tst.dc.GetUniqueName(), len(errs), len(tst.want), errs)
+			continue
+		}
+		for i, err := range errs {
+			if !strings.Contains(err.Error(), tst.want[i]) {
+				t.Errorf("%s: got %q, want it to contain %q", tst.dc.GetUniqueName(), err, tst.want[i])
+			}
+		}
+	}
+}
+
+func TestParseRecordPolicyErrors(t *testing.T) {
+	for _, policy := range []string{
+		`{"classes": {"x": {"required": [{"type": ""}]}}}`,
+		`{"classes": {"x": {"required": [{"type": "TXT", "value": "("}]}}}`,
+		`{"classes": {"x": {"requires": []}}}`,
+		`classes: {x: }`,
+	} {
+		if _, err := parseRecordPolicy([]byte(policy)); err == nil {
+			t.Errorf("%s: expected an error", policy)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package normalize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// isSPF returns true if txt is an SPF record (RFC 7208 section 4.5).
+func isSPF(txt string) bool {
+	return strings.EqualFold(txt, "v=spf1") || (len(txt) > 7 && strings.EqualFold(txt[:7], "v=spf1 "))
+}
+
+// checkMultipleSPF finds the names of dc with more than one SPF record,
+// which makes the receivers fail the SPF check (RFC 7208 section 4.5).
+// Each is an error that suggests the merged record or, if merge is true,
+// is replaced by it (a warning).
+func checkMultipleSPF(dc *models.DomainConfig, merge bool) (errs []error) {
+	var names []string
+	spfs := map[string][]*models.RecordConfig{}
+	for _, rec := range dc.Records {
+		if rec.Type != "TXT" || !isSPF(rec.GetTargetTXTJoined()) {
+			continue
+		}
+		name := rec.GetLabelFQDN()
+		if _, ok := spfs[name]; !ok {
+			names = append(names, name)
+		}
+		spfs[name] = append(spfs[name], rec)
+	}
+
+	drop := map[*models.RecordConfig]bool{}
+	for _, name := range names {
+		recs := spfs[name]
+		if len(recs) < 2 {
+			continue
+		}
+		merged, err := mergeSPFRecords(recs)
+		msg := fmt.Sprintf("%s has %d SPF records, but receivers fail the SPF check of a name with more than one (RFC 7208 section 4.5)", name, len(recs))
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%s; merge them into one (they can't be merged automatically: %w)", msg, err))
+		case !merge:
+			errs = append(errs, fmt.Errorf("%s; merge them into %q, or use --merge-spf", msg, merged))
+		default:
+			if err := recs[0].SetTargetTXT(merged); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, rec := range recs[1:] {
+				drop[rec] = true
+			}
+			errs = append(errs, Warning{fmt.Errorf("merged the %d SPF records of %s into %q (--merge-spf)", len(recs), name, merged)})
+		}
+	}
+	if len(drop) > 0 {
+		kept := dc.Records[:0]
+		for _, rec := range dc.Records {
+			if !drop[rec] {
+				kept = append(kept, rec)
+			}
+		}
+		dc.Records = kept
+	}
+	return errs
+}
+
+// mergeSPFRecords returns one SPF record with the mechanisms of recs, in
+// their order and without duplicates, and the "all" mechanism last. It is
+// an error if the records have different "all" mechanisms, or modifiers
+// (redirect=, exp=), whose merge would change what the records mean.
+func mergeSPFRecords(recs []*models.RecordConfig) (string, error) {
+	terms := []string{"v=spf1"}
+	seen := map[string]bool{}
+	all := "" // The first "all" mechanism.
+	for _, rec := range recs {
+		if rec.Metadata["flatten"] != "" || rec.Metadata["split"] != "" {
+			return "", fmt.Errorf("one is made by SPF_BUILDER with flatten or split; add the mechanisms of the others to its parts")
+		}
+		for _, term := range strings.Fields(rec.GetTargetTXTJoined())[1:] {
+			name := strings.ToLower(strings.TrimLeft(term, "+-~?"))
+			if name == "all" {
+				if all != "" && spfQualifier(all) != spfQualifier(term) {
+					return "", fmt.Errorf("they end with different all mechanisms (%s and %s)", all, term)
+				}
+				if all == "" {
+					all = term
+				}
+				break // The receivers ignore the mechanisms after "all".
+			}
+			if i := strings.IndexAny(name, "=:/"); i >= 0 && name[i] == '=' {
+				return "", fmt.Errorf("%q is a modifier", term)
+			}
+			if key := strings.ToLower(term); !seen[key] {
+				seen[key] = true
+				terms = append(terms, term)
+			}
+		}
+	}
+	if all != "" {
+		terms = append(terms, all)
+	}
+	return strings.Join(terms, " "), nil
+}
+
+// spfQualifier returns the qualifier of an SPF mechanism: "+" if it has
+// none.
+func spfQualifier(term string) byte {
+	switch term[0] {
+	case '+', '-', '~', '?':
+		return term[0]
+	}
+	return '+'
+}
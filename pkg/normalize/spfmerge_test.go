@@ -0,0 +1,98 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestMergeSPFRecords(t *testing.T) {
+	tests := []struct {
+		txts []string
+		want string // the merged record, or a substring of the error
+		err  bool
+	}{
+		{[]string{"v=spf1 include:_spf.google.com ~all", "v=spf1 include:mailgun.org ~all"}, "v=spf1 include:_spf.google.com include:mailgun.org ~all", false},
+		{[]string{"v=spf1 mx ip4:192.0.2.0/24 -all", "v=spf1 ip4:192.0.2.0/24 a -all"}, "v=spf1 mx ip4:192.0.2.0/24 a -all", false},
+		{[]string{"v=spf1 mx", "v=spf1 a -all"}, "v=spf1 mx a -all", false},
+		{[]string{"v=spf1 mx all", "v=spf1 a +all"}, "v=spf1 mx a all", false},
+		{[]string{"v=spf1 mx -all a", "v=spf1 a"}, "v=spf1 mx a -all", false},
+		{[]string{"v=spf1 mx ~all", "v=spf1 a -all"}, "different all mechanisms (~all and -all)", true},
+		{[]string{"v=spf1 mx", "v=spf1 redirect=_spf.example.com"}, `"redirect=_spf.example.com" is a modifier`, true},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.txts, " + "), func(t *testing.T) {
+			var recs []*models.RecordConfig
+			for _, txt := range tt.txts {
+				rec := &models.RecordConfig{Type: "TXT"}
+				rec.SetLabel("@", "example.com")
+				rec.SetTargetTXT(txt)
+				recs = append(recs, rec)
+			}
+			got, err := mergeSPFRecords(recs)
+			if tt.err {
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Fatalf("got %q, %v; want an error containing %q", got, err, tt.want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMultipleSPF(t *testing.T) {
+	domain := func() *models.DomainConfig {
+		dc := &models.DomainConfig{Name: "example.com"}
+		for _, r := range [][2]string{
+			{"@", "v=spf1 include:_spf.google.com ~all"},
+			{"@", "google-site-verification=abc"},
+			{"@", "v=spf1 include:mailgun.org ~all"},
+			{"mail", "v=spf1 a -all"},
+		} {
+			rec := &models.RecordConfig{Type: "TXT"}
+			rec.SetLabel(r[0], "example.com")
+			rec.SetTargetTXT(r[1])
+			dc.Records = append(dc.Records, rec)
+		}
+		return dc
+	}
+	dc := domain()
+	errs := checkMultipleSPF(dc, false)
+	if len(errs) != 1 {
+		t.Fatalf("got %v, want 1 error", errs)
+	}
+	if _, ok := errs[0].(Warning); ok || !strings.Contains(errs[0].Error(), `merge them into "v=spf1 include:_spf.google.com include:mailgun.org ~all", or use --merge-spf`) {
+		t.Errorf("unexpected error: %v", errs[0])
+	}
+	if len(dc.Records) != 4 {
+		t.Errorf("the records were changed without --merge-spf")
+	}
+
+	dc = domain()
+	errs = checkMultipleSPF(dc, true)
+	if len(errs) != 1 {
+		t.Fatalf("got %v, want 1 warning", errs)
+	}
+	if _, ok := errs[0].(Warning); !ok {
+		t.Errorf("got %v, want a warning", errs[0])
+	}
+	var got []string
+	for _, rec := range dc.Records {
+		got = append(got, rec.GetLabel()+" "+rec.GetTargetTXTJoined())
+	}
+	want := []string{
+		"@ v=spf1 include:_spf.google.com include:mailgun.org ~all",
+		"@ google-site-verification=abc",
+		"mail v=spf1 a -all",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
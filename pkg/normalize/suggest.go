@@ -0,0 +1,106 @@
+package normalize
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// CNAMESuggestion is a group of labels of a domain that have the same A
+// and AAAA records, and could be CNAMEs to one of them.
+type CNAMESuggestion struct {
+	Records   []string // The shared records, e.g. "A 192.0.2.1".
+	Canonical string   // The label the others could point to.
+	Labels    []string // The labels that could be CNAMEs to Canonical.
+	Kept      []string // Labels with the same records that can't be CNAMEs.
+}
+
+// SuggestCNAMEs finds the labels of dc that have identical sets of A and
+// AAAA records. Only labels that have nothing but A and AAAA records, and
+// aren't the apex, can become CNAMEs; the apex is the preferred canonical
+// name. Nothing is changed: a CNAME can't coexist with other records, so
+// whether to consolidate is up to the user.
+func SuggestCNAMEs(dc *models.DomainConfig) []CNAMESuggestion {
+	addresses := map[string][]string{} // label -> "A 192.0.2.1", ...
+	others := map[string]bool{}        // labels with other types of records
+	var order []string
+	for _, rec := range dc.Records {
+		label := rec.GetLabel()
+		if _, ok := addresses[label]; !ok && !others[label] {
+			order = append(order, label)
+		}
+		switch rec.Type {
+		case "A", "AAAA":
+			addresses[label] = append(addresses[label], rec.Type+" "+rec.GetTargetField())
+		default:
+			others[label] = true
+		}
+	}
+
+	groups := map[string][]string{} // record set -> labels
+	var keys []string
+	for _, label := range order {
+		set := addresses[label]
+		if len(set) == 0 {
+			continue
+		}
+		sort.Strings(set)
+		key := strings.Join(set, ", ")
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], label)
+	}
+
+	var suggestions []CNAMESuggestion
+	for _, key := range keys {
+		labels := groups[key]
+		if len(labels) < 2 {
+			continue
+		}
+		s := CNAMESuggestion{Records: addresses[labels[0]]}
+		var movable []string
+		for _, label := range labels {
+			if label == "@" || others[label] {
+				s.Kept = append(s.Kept, label)
+			} else {
+				movable = append(movable, label)
+			}
+		}
+		// Prefer a label that must keep its records as the canonical name,
+		// the apex first; else the shortest label.
+		switch {
+		case len(s.Kept) > 0:
+			sort.SliceStable(s.Kept, func(i, j int) bool { return s.Kept[i] == "@" && s.Kept[j] != "@" })
+			s.Canonical, s.Kept = s.Kept[0], s.Kept[1:]
+		default:
+			sort.SliceStable(movable, func(i, j int) bool { return len(movable[i]) < len(movable[j]) })
+			s.Canonical, movable = movable[0], movable[1:]
+		}
+		if len(movable) == 0 {
+			continue
+		}
+		s.Labels = movable
+		suggestions = append(suggestions, s)
+	}
+	return suggestions
+}
+
+// WriteCNAMESuggestions writes the suggestions for the domain named
+// domain to w, grouping the labels that share each record set.
+func WriteCNAMESuggestions(w io.Writer, domain string, suggestions []CNAMESuggestion) {
+	for _, s := range suggestions {
+		fmt.Fprintf(w, "%s: %d labels have the same records (%s):\n", domain, 1+len(s.Labels)+len(s.Kept), strings.Join(s.Records, ", "))
+		fmt.Fprintf(w, "    %s\n", strings.Join(append(append([]string{s.Canonical}, s.Labels...), s.Kept...), " "))
+		fmt.Fprintf(w, "  Consider replacing them with CNAMEs to %q:\n", s.Canonical)
+		for _, label := range s.Labels {
+			fmt.Fprintf(w, "    CNAME(%q, %q),\n", label, s.Canonical)
+		}
+		for _, label := range s.Kept {
+			fmt.Fprintf(w, "  (%s keeps its records: it has other records or is the apex.)\n", label)
+		}
+	}
+}
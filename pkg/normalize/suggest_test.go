@@ -0,0 +1,43 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestSuggestCNAMEs(t *testing.T) {
+	rec := func(label, typ, target string) *models.RecordConfig {
+		return makeRC(label, "example.com", target, models.RecordConfig{Type: typ})
+	}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			rec("@", "A", "192.0.2.1"), rec("@", "AAAA", "2001:db8::1"),
+			rec("www", "AAAA", "2001:db8::1"), rec("www", "A", "192.0.2.1"),
+			rec("mail", "A", "192.0.2.1"), rec("mail", "AAAA", "2001:db8::1"), rec("mail", "MX", "10 mail"),
+			rec("api", "A", "192.0.2.1"), rec("api", "AAAA", "2001:db8::1"),
+			rec("partial", "A", "192.0.2.1"),
+			rec("web2", "A", "192.0.2.9"), rec("w", "A", "192.0.2.9"),
+			rec("solo", "A", "192.0.2.10"),
+		},
+	}
+	// "partial" has only some of the records, so it is not in a group.
+	want := []CNAMESuggestion{
+		{
+			Records:   []string{"A 192.0.2.1", "AAAA 2001:db8::1"},
+			Canonical: "@",
+			Labels:    []string{"www", "api"},
+			Kept:      []string{"mail"},
+		},
+		{
+			Records:   []string{"A 192.0.2.9"},
+			Canonical: "w",
+			Labels:    []string{"web2"},
+		},
+	}
+	if got := SuggestCNAMEs(dc); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,55 @@
+package normalize
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// SunsetPolicy is how records with a SUNSET() date are reported.
+type SunsetPolicy struct {
+	WarnBefore time.Duration // Warn this long before the sunset date.
+	Error      bool          // Records past their sunset date are errors, not warnings.
+}
+
+// sunsetNow is the current time; tests replace it.
+var sunsetNow = time.Now
+
+// parseSunset parses the date given to SUNSET(): either a date, which is
+// the start of that day in UTC, or an RFC 3339 timestamp with its own time
+// zone.
+func parseSunset(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("SUNSET(%q) is not a date (2006-01-02) or an RFC 3339 timestamp (2006-01-02T15:04:05-07:00)", s)
+}
+
+// checkSunset reports a record that is past, or within the policy's
+// warning period of, its sunset date.
+func checkSunset(rec *models.RecordConfig, now time.Time, p SunsetPolicy) error {
+	s, ok := rec.Metadata["sunset"]
+	if !ok {
+		return nil
+	}
+	sunset, err := parseSunset(s)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", rec.GetLabelFQDN(), rec.Type, err)
+	}
+	date := sunset.Format(time.RFC3339)
+	switch left := sunset.Sub(now); {
+	case left <= 0:
+		err := fmt.Errorf("%s %s: passed its sunset date %s %d days ago; remove it", rec.GetLabelFQDN(), rec.Type, date, int(-left.Hours()/24))
+		if p.Error {
+			return err
+		}
+		return Warning{err}
+	case left <= p.WarnBefore:
+		return Warning{fmt.Errorf("%s %s: reaches its sunset date %s in %d days", rec.GetLabelFQDN(), rec.Type, date, int(left.Hours()/24))}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestCheckSunset(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		sunset  string
+		errors  bool
+		want    string // substring of the error; "" for none
+		warning bool
+	}{
+		{"", false, "", false},
+		{"2025-12-31", false, "", false},
+		{"2025-06-20", false, "in 18 days", true},
+		{"2025-06-01T13:00:00+02:00", false, "passed its sunset date 2025-06-01T13:00:00+02:00 0 days ago", true},
+		{"2025-05-01", false, "31 days ago", true},
+		{"2025-05-01", true, "31 days ago", false},
+		{"June 1", false, "is not a date", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sunset, func(t *testing.T) {
+			p := SunsetPolicy{WarnBefore: 30 * 24 * time.Hour, Error: tt.errors}
+			rec := makeRC("www", "example.com", "192.0.2.1", models.RecordConfig{Type: "A"})
+			if tt.sunset != "" {
+				rec.Metadata = map[string]string{"sunset": tt.sunset}
+			}
+			err := checkSunset(rec, now, p)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got %v, want an error containing %q", err, tt.want)
+			}
+			if _, ok := err.(Warning); ok != tt.warning {
+				t.Errorf("got warning=%v, want %v", ok, tt.warning)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package normalize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/miekg/dns/dnsutil"
+)
+
+// RelativeTargets is how a relative target (one without a trailing dot,
+// such as "mail") is handled. It is always qualified with the origin.
+type RelativeTargets string
+
+// The values of RelativeTargets.
+const (
+	RelativeTargetsAllow RelativeTargets = "allow" // silently
+	RelativeTargetsWarn  RelativeTargets = "warn"
+	RelativeTargetsError RelativeTargets = "error"
+)
+
+// ParseRelativeTargets parses how relative targets are handled: "allow"
+// (or ""), "warn" or "error".
+func ParseRelativeTargets(p string) (RelativeTargets, error) {
+	switch RelativeTargets(p) {
+	case "":
+		return RelativeTargetsAllow, nil
+	case RelativeTargetsAllow, RelativeTargetsWarn, RelativeTargetsError:
+		return RelativeTargets(p), nil
+	}
+	return "", fmt.Errorf("%q is not allow, warn or error", p)
+}
+
+// canonicalizeTarget makes the hostname target of rec a FQDN, relative to
+// origin (e.g. "example.com."). It returns an error (or a warning) if the
+// target was relative and the policy says so, and a warning if the target
+// ends with the domain twice, the usual result of qualifying a name that
+// was meant to be absolute.
+func canonicalizeTarget(rec *models.RecordConfig, origin, domain string, opts Options) (errs []error) {
+	target := rec.GetTargetField()
+	fqdn := dnsutil.AddOrigin(target, origin)
+	if p := opts.RelativeTargets; target != fqdn && target != "@" && (p == RelativeTargetsWarn || p == RelativeTargetsError) {
+		err := fmt.Errorf("in %s %s.%s: the target %q is relative, and means %q; write %q (with the trailing dot) if that is intended [https://docs.dnscontrol.org/language-reference/why-the-dot]",
+			rec.Type, rec.GetLabel(), domain, target, fqdn, fqdn)
+		if p == RelativeTargetsWarn {
+			err = Warning{err}
+		}
+		errs = append(errs, err)
+	}
+	rec.SetTarget(fqdn)
+
+	if opts.checkEnabled("doubled-domain") {
+		d := strings.ToLower(strings.TrimSuffix(domain, "."))
+		if lower := strings.ToLower(fqdn); lower == d+"."+d+"." || strings.HasSuffix(lower, "."+d+"."+d+".") {
+			errs = append(errs, Warning{fmt.Errorf("in %s %s.%s: the target %q ends with %s twice; if %q was meant, remove one",
+				rec.Type, rec.GetLabel(), domain, fqdn, d, fqdn[:len(fqdn)-len(d)-1])})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,94 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestCanonicalizeTarget(t *testing.T) {
+	tests := []struct {
+		policy     string
+		rtype      string
+		target     string
+		origin     string
+		wantTarget string
+		wantErr    string // "" for none
+		wantWarn   bool
+	}{
+		{"allow", "CNAME", "foo", "example.com.", "foo.example.com.", "", false},
+		{"allow", "MX", "@", "example.com.", "example.com.", "", false},
+		{"allow", "CNAME", "foo.example.net.", "example.com.", "foo.example.net.", "", false},
+		{"allow", "CNAME", "foo", "sub.example.com.", "foo.sub.example.com.", "", false},
+		{"warn", "CNAME", "foo", "example.com.", "foo.example.com.", `the target "foo" is relative, and means "foo.example.com."`, true},
+		{"warn", "MX", "@", "example.com.", "example.com.", "", false},
+		{"warn", "NS", "ns1.example.net.", "example.com.", "ns1.example.net.", "", false},
+		{"error", "MX", "foo", "example.com.", "foo.example.com.", `the target "foo" is relative`, false},
+		{"allow", "CNAME", "foo.Example.com.example.com.", "example.com.", "foo.Example.com.example.com.", `ends with example.com twice; if "foo.Example.com." was meant`, true},
+		{"allow", "CNAME", "example.com.example.com.", "example.com.", "example.com.example.com.", "ends with example.com twice", true},
+		{"allow", "CNAME", "notexample.com.example.com.", "example.com.", "notexample.com.example.com.", "", false},
+		{"warn", "DNAME", "foo", "example.com.", "foo.example.com.", `the target "foo" is relative`, true},
+		{"warn", "PTR", "host", "2.0.192.in-addr.arpa.", "host.2.0.192.in-addr.arpa.", `the target "host" is relative`, true},
+		{"error", "LP", "l64", "example.com.", "l64.example.com.", `the target "l64" is relative`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy+" "+tt.target, func(t *testing.T) {
+			p, err := ParseRelativeTargets(tt.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rec := &models.RecordConfig{Type: tt.rtype}
+			rec.SetLabel("www", "example.com")
+			rec.SetTarget(tt.target)
+			errs := canonicalizeTarget(rec, tt.origin, "example.com", Options{RelativeTargets: p})
+			if got := rec.GetTargetField(); got != tt.wantTarget {
+				t.Errorf("got target %q, want %q", got, tt.wantTarget)
+			}
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Fatalf("got %v, want an error containing %q", errs, tt.wantErr)
+			}
+			if _, ok := errs[0].(Warning); ok != tt.wantWarn {
+				t.Errorf("got a warning: %v, want %v", ok, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestParseRelativeTargets(t *testing.T) {
+	if _, err := ParseRelativeTargets("strict"); err == nil {
+		t.Errorf("expected an error for an unknown policy")
+	}
+	if p, err := ParseRelativeTargets(""); err != nil || p != RelativeTargetsAllow {
+		t.Errorf("\"\" should be allow, got %q, %v", p, err)
+	}
+}
+
+func TestIRTargets(t *testing.T) {
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{{
+		Name: "example.com",
+		Records: []*models.RecordConfig{
+			makeRC("www", "example.com", "foo", models.RecordConfig{Type: "CNAME"}),
+			makeRC("@", "example.com", "mx.example.net.", models.RecordConfig{Type: "MX", MxPreference: 10}),
+			makeRC("mail", "example.com", ".", models.RecordConfig{Type: "MX"}),
+			makeRC("old", "example.com", "new", models.RecordConfig{Type: "DNAME"}),
+		},
+	}}}
+	if errs := ValidateAndNormalizeConfig(cfg, Options{}); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	var got []string
+	for _, rec := range cfg.Domains[0].Records {
+		got = append(got, rec.GetTargetField())
+	}
+	// FQDNs without the trailing dot; the root stays ".".
+	if want := "foo.example.com mx.example.net . new.example.com"; strings.Join(got, " ") != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+package normalize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// checkInZoneTargets warns about the MX and SRV records whose target is a
+// name inside the domain without an A or AAAA record, which breaks the
+// delivery of mail, or the service, in a way that only shows when it is
+// used. The targets outside the domain, or in a zone delegated from it, are
+// not checked, and neither is a null target (".", RFC 7505 and RFC 2782).
+// A target that is a CNAME is also reported: the target of an MX or SRV
+// must have the addresses itself (RFC 2181 section 10.3).
+func checkInZoneTargets(dc *models.DomainConfig) (errs []error) {
+	domain := strings.ToLower(dc.Name)
+	canonical := func(host string) string {
+		return strings.ToLower(strings.TrimSuffix(host, "."))
+	}
+
+	addresses := map[string]bool{}
+	cnames := map[string]bool{}
+	delegations := map[string]bool{}
+	for _, r := range dc.Records {
+		name := canonical(r.GetLabelFQDN())
+		switch r.Type {
+		case "A", "AAAA", "ALIAS":
+			addresses[name] = true
+		case "CNAME":
+			cnames[name] = true
+		case "NS":
+			if name != domain {
+				delegations[name] = true
+			}
+		}
+	}
+
+	// resolves returns true if host has an address in the zone: at the name
+	// or from a wildcard above it. ok is false if host is outside the zone
+	// or delegated from it, where it can't be checked.
+	resolves := func(host string) (found, ok bool) {
+		if host != domain && !strings.HasSuffix(host, "."+domain) {
+			return false, false
+		}
+		if addresses[host] {
+			return true, true
+		}
+		for name := host; name != domain; {
+			if delegations[name] {
+				return false, false
+			}
+			_, parent, _ := strings.Cut(name, ".")
+			if addresses["*."+parent] {
+				return true, true
+			}
+			name = parent
+		}
+		return false, true
+	}
+
+	for _, r := range dc.Records {
+		if r.Type != "MX" && r.Type != "SRV" {
+			continue
+		}
+		target := canonical(r.GetTargetField())
+		if target == "" {
+			continue // The null MX or SRV.
+		}
+		found, ok := resolves(target)
+		switch {
+		case !ok || found:
+		case cnames[target]:
+			errs = append(errs, Warning{fmt.Errorf("%s %s: the target %s is a CNAME, but the target of an %s must have its own A or AAAA records (RFC 2181 section 10.3)", r.GetLabelFQDN(), r.Type, r.GetTargetField(), r.Type)})
+		default:
+			errs = append(errs, Warning{fmt.Errorf("%s %s: the target %s is inside %s but has no A or AAAA record", r.GetLabelFQDN(), r.Type, r.GetTargetField(), domain)})
+		}
+	}
+	return errs
+}
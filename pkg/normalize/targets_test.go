@@ -0,0 +1,51 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestCheckInZoneTargets(t *testing.T) {
+	rec := func(label, typ, target string) *models.RecordConfig {
+		return makeRC(label, "example.com", target, models.RecordConfig{Type: typ})
+	}
+	tests := []struct {
+		name    string
+		records []*models.RecordConfig
+		want    []string
+	}{
+		{"with addresses", []*models.RecordConfig{
+			rec("@", "MX", "mx.example.com."), rec("mx", "A", "192.0.2.1"),
+			rec("_sip._tcp", "SRV", "sip.example.com."), rec("sip", "AAAA", "2001:db8::1"),
+		}, nil},
+		{"outside the zone", []*models.RecordConfig{rec("@", "MX", "mx.example.net."), rec("_sip._tcp", "SRV", "sip.notexample.com.")}, nil},
+		{"null targets", []*models.RecordConfig{rec("@", "MX", "."), rec("_sip._tcp", "SRV", ".")}, nil},
+		{"delegated", []*models.RecordConfig{rec("@", "MX", "mx.sub.example.com."), rec("sub", "NS", "ns1.example.net.")}, nil},
+		{"wildcard", []*models.RecordConfig{rec("@", "MX", "mx.hosts.example.com."), rec("*.hosts", "A", "192.0.2.1")}, nil},
+		{"ALIAS", []*models.RecordConfig{rec("@", "MX", "mx.example.com."), rec("mx", "ALIAS", "lb.example.net.")}, nil},
+		{"missing", []*models.RecordConfig{
+			rec("@", "MX", "mx.example.com."), rec("mx", "TXT", "hello"),
+			rec("_sip._tcp", "SRV", "sip.example.com."),
+		}, []string{
+			"example.com MX: the target mx.example.com. is inside example.com but has no A or AAAA record",
+			"_sip._tcp.example.com SRV: the target sip.example.com. is inside example.com but has no A or AAAA record",
+		}},
+		{"CNAME", []*models.RecordConfig{rec("@", "MX", "mail.example.com."), rec("mail", "CNAME", "mx.example.net.")},
+			[]string{"example.com MX: the target mail.example.com. is a CNAME, but the target of an MX must have its own A or AAAA records"}},
+	}
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			errs := checkInZoneTargets(&models.DomainConfig{Name: "example.com", Records: tst.records})
+			if len(errs) != len(tst.want) {
+				t.Fatalf("got %v, want %d warnings", errs, len(tst.want))
+			}
+			for i, err := range errs {
+				if !strings.Contains(err.Error(), tst.want[i]) {
+					t.Errorf("warning %d = %q, want %q", i, err, tst.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package normalize
+
+import (
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/ttlsource"
+)
+
+// ResolveTTLSources reads the TTL_FROM() sources of the records of dc,
+// which is done at push time, after ValidateAndNormalizeConfig. The TTLs
+// read are checked as those of dnsconfig.js are: against the TTL ranges of
+// the providers (and clamped with clamp_ttl), the low-TTL policy, and the
+// TTLs of the other records of their record sets.
+func ResolveTTLSources(dc *models.DomainConfig, opts Options) (errs []error) {
+	resolved, err := ttlsource.Apply(dc.Records)
+	if err != nil {
+		return []error{err}
+	}
+	if len(resolved) == 0 {
+		return nil
+	}
+	if opts.checkEnabled("ttl-range") {
+		errs = append(errs, checkTTLRanges(&models.DomainConfig{
+			Metadata:             dc.Metadata,
+			DNSProviderInstances: dc.DNSProviderInstances,
+			Records:              resolved,
+		})...)
+	}
+	if opts.checkEnabled("low-ttl") {
+		for _, r := range resolved {
+			if err := checkLowTTL(r, opts.LowTTL); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if opts.checkEnabled("multiple-ttls") {
+		names := map[string]bool{}
+		for _, r := range resolved {
+			names[r.GetLabelFQDN()] = true
+		}
+		var sets models.Records
+		for _, r := range dc.Records {
+			if names[r.GetLabelFQDN()] {
+				sets = append(sets, r)
+			}
+		}
+		errs = append(errs, checkRecordSetHasMultipleTTLs(sets)...)
+	}
+	return errs
+}
@@ -0,0 +1,60 @@
+package normalize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/ttlsource"
+)
+
+func TestResolveTTLSources(t *testing.T) {
+	t.Setenv("TTLFROM_TEST", "30")
+	from := func(label string, ttl uint32, source string) *models.RecordConfig {
+		rc := makeRC(label, "example.com", "192.0.2.1", models.RecordConfig{Type: "A", TTL: ttl})
+		if source != "" {
+			rc.Metadata = map[string]string{ttlsource.MetaKey: source}
+		}
+		return rc
+	}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			from("@", 3600, "env:TTLFROM_TEST"),
+			from("www", 3600, "env:TTLFROM_TEST"),
+			from("www", 3600, ""),
+			from("mail", 3600, "env:TTLFROM_UNSET"),
+		},
+		DNSProviderInstances: []*models.DNSProviderInstance{
+			{ProviderBase: models.ProviderBase{Name: "ranged", ProviderType: ProviderTTLRange}},
+		},
+	}
+	lowTTL, err := ParseLowTTLPolicy(300, "@A")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := ResolveTTLSources(dc, Options{LowTTL: lowTTL})
+	want := []string{
+		"example.com A: TTL 30 is outside the range of provider ranged (min 60, max 86400)",
+		"www.example.com A: TTL 30 is outside the range of provider ranged (min 60, max 86400)",
+		"example.com A: the TTL 30 is below 300",
+		`inconsistent TTLs at "www.example.com": A:30,3600`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, err := range errs {
+		if !strings.Contains(err.Error(), want[i]) {
+			t.Errorf("error %d = %q, want it to contain %q", i, err, want[i])
+		}
+	}
+	if dc.Records[0].TTL != 30 || dc.Records[3].TTL != 3600 {
+		t.Errorf("TTLs = %d, %d; want 30, 3600", dc.Records[0].TTL, dc.Records[3].TTL)
+	}
+
+	// The sources were read and removed, so nothing is left to check.
+	if errs := ResolveTTLSources(dc, Options{LowTTL: lowTTL}); len(errs) != 0 {
+		t.Errorf("second call: got %v", errs)
+	}
+}
@@ -0,0 +1,114 @@
+package normalize
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// TypePolicy is which record types the domains may have, so that the
+// teams that manage them can't add, for example, NS records that delegate
+// a subdomain. It is read from a JSON file:
+//
+//	{
+//	  "default": ["A", "AAAA", "CNAME", "MX", "TXT"],
+//	  "domains": {
+//	    "example.com": ["A", "AAAA", "CNAME", "MX", "NS", "TXT"]
+//	  }
+//	}
+//
+// A domain that is listed (by name, or as "name!tag" for one view of a
+// split horizon domain) may only have the types of its list; the others
+// may only have the default types. Without a default, they may have any.
+type TypePolicy struct {
+	File    string              `json:"-"` // Where it was read from, for the errors.
+	Default []string            `json:"default"`
+	Domains map[string][]string `json:"domains"`
+}
+
+// ReadTypePolicy reads the type policy from the JSON file at path. An
+// empty path is no policy (nil).
+func ReadTypePolicy(path string) (*TypePolicy, error) {
+	if path == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := parseTypePolicy(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	p.File = path
+	return p, nil
+}
+
+// parseTypePolicy parses a type policy, and uppercases its types.
+func parseTypePolicy(b []byte) (*TypePolicy, error) {
+	p := &TypePolicy{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(p); err != nil {
+		return nil, err
+	}
+	upper := func(where string, types []string) error {
+		for i, t := range types {
+			if types[i] = strings.ToUpper(strings.TrimSpace(t)); types[i] == "" {
+				return fmt.Errorf("%s has an empty record type", where)
+			}
+		}
+		return nil
+	}
+	if err := upper(`"default"`, p.Default); err != nil {
+		return nil, err
+	}
+	for name, types := range p.Domains {
+		if types == nil {
+			return nil, fmt.Errorf(`"domains" %q has no list of types (use [] to permit none)`, name)
+		}
+		if err := upper(fmt.Sprintf(`"domains" %q`, name), types); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// permitted returns the types that the policy permits in dc, and where the
+// policy says so (for the errors). The types are nil if any is permitted.
+func (p *TypePolicy) permitted(dc *models.DomainConfig) ([]string, string) {
+	for _, name := range []string{dc.GetUniqueName(), dc.Name} {
+		if types, ok := p.Domains[name]; ok {
+			return types, fmt.Sprintf(`"domains" %q`, name)
+		}
+	}
+	return p.Default, `"default"`
+}
+
+// checkTypePolicy returns an error for each record of dc whose type the
+// type policy p doesn't permit.
+func checkTypePolicy(dc *models.DomainConfig, p *TypePolicy) (errs []error) {
+	if p == nil {
+		return nil
+	}
+	types, where := p.permitted(dc)
+	if types == nil {
+		return nil
+	}
+	allowed := "no types"
+	if len(types) != 0 {
+		allowed = strings.Join(types, ", ")
+	}
+	for _, rec := range dc.Records {
+		if !slices.Contains(types, rec.Type) {
+			errs = append(errs, fmt.Errorf("domain %s: %s %s is not permitted by the type policy (%s: %s permits %s)",
+				dc.GetUniqueName(), rec.GetLabelFQDN(), rec.Type, p.File, where, allowed))
+		}
+	}
+	return errs
+}
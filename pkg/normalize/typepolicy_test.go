@@ -0,0 +1,83 @@
+package normalize
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestCheckTypePolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	policy := `{
+		"default": ["a", "TXT"],
+		"domains": {
+			"example.com": ["A", "MX"],
+			"example.net!inside": ["A", "NS"],
+			"example.org": []
+		}
+	}`
+	if err := os.WriteFile(path, []byte(policy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := ReadTypePolicy(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	makeDC := func(name string, types ...string) *models.DomainConfig {
+		dc := &models.DomainConfig{Name: name}
+		dc.UpdateSplitHorizonNames()
+		for _, typ := range types {
+			dc.Records = append(dc.Records, makeRC("www", dc.Name, "", models.RecordConfig{Type: typ}))
+		}
+		return dc
+	}
+	tests := []struct {
+		dc   *models.DomainConfig
+		want []string // The substrings of the errors.
+	}{
+		{makeDC("example.com", "A", "MX"), nil},
+		{makeDC("example.com", "A", "TXT", "NS"), []string{
+			`www.example.com TXT is not permitted by the type policy (` + path + `: "domains" "example.com" permits A, MX)`,
+			`www.example.com NS is not permitted`,
+		}},
+		{makeDC("example.net!inside", "NS"), nil},
+		{makeDC("example.net!outside", "NS"), []string{`"default" permits A, TXT`}},
+		{makeDC("example.org", "A"), []string{`"domains" "example.org" permits no types`}},
+		{makeDC("example.info", "A", "TXT"), nil},
+	}
+	for _, tst := range tests {
+		errs := checkTypePolicy(tst.dc, p)
+		if len(errs) != len(tst.want) {
+			t.Errorf("%s: got %v, want %d errors", tst.dc.GetUniqueName(), errs, len(tst.want))
+			continue
+		}
+		for i, err := range errs {
+			if !strings.Contains(err.Error(), tst.want[i]) {
+				t.Errorf("%s: got %q, want it to contain %q", tst.dc.GetUniqueName(), err, tst.want[i])
+			}
+		}
+	}
+
+	// Without a default, the domains not listed may have any type.
+	p = &TypePolicy{Domains: map[string][]string{"example.com": {"A"}}}
+	if errs := checkTypePolicy(makeDC("example.info", "NS"), p); len(errs) != 0 {
+		t.Errorf("without a default: got %v", errs)
+	}
+}
+
+func TestParseTypePolicyErrors(t *testing.T) {
+	for _, policy := range []string{
+		`{"default": ["A"], "allow": ["TXT"]}`,
+		`{"domains": {"example.com": null}}`,
+		`{"default": ["A", " "]}`,
+		`["A"]`,
+	} {
+		if _, err := parseTypePolicy([]byte(policy)); err == nil {
+			t.Errorf("%s: expected an error", policy)
+		}
+	}
+}
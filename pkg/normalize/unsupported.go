@@ -0,0 +1,43 @@
+package normalize
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// dropUnsupportedRecords limits recs, which provider doesn't support, to
+// the other providers of dc, as ONLY_PROVIDERS() would. The records left
+// with no provider are removed from dc. It returns the warning that lists
+// them.
+func dropUnsupportedRecords(dc *models.DomainConfig, provider *models.DNSProviderInstance, recs models.Records) error {
+	var names []string
+	for _, p := range dc.DNSProviderInstances {
+		names = append(names, p.Name)
+	}
+	removed := map[*models.RecordConfig]bool{}
+	var list []string
+	for _, rec := range recs {
+		only := rec.OnlyProviders()
+		if only == nil {
+			only = names
+		}
+		only = slices.DeleteFunc(slices.Clone(only), func(name string) bool { return name == provider.Name })
+		if len(only) == 0 {
+			removed[rec] = true
+		} else {
+			if rec.Metadata == nil {
+				rec.Metadata = map[string]string{}
+			}
+			rec.Metadata["only_providers"] = strings.Join(only, ",")
+		}
+		list = append(list, fmt.Sprintf("%s %s %s", rec.GetLabelFQDN(), rec.Type, rec.GetTargetCombinedFunc(nil)))
+	}
+	if len(removed) > 0 {
+		dc.Records = slices.DeleteFunc(dc.Records, func(rec *models.RecordConfig) bool { return removed[rec] })
+	}
+	return Warning{fmt.Errorf("domain %s: DNS provider %s (%s) does not support %s records; %d dropped there (--drop-unsupported): %s",
+		dc.Name, provider.Name, provider.ProviderType, recs[0].Type, len(recs), strings.Join(list, "; "))}
+}
@@ -1,13 +1,18 @@
 package normalize
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"net"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/transform"
+	"github.com/StackExchange/dnscontrol/v4/pkg/ttlsource"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/miekg/dns"
 	"github.com/miekg/dns/dnsutil"
@@ -56,20 +61,31 @@ func validateRecordTypes(rec *models.RecordConfig, domain string, pTypes []strin
 	var validTypes = map[string]bool{
 		"A":                true,
 		"AAAA":             true,
+		"AFSDB":            true,
 		"ALIAS":            false,
 		"CAA":              true,
+		"CERT":             true,
 		"CNAME":            true,
 		"DHCID":            true,
 		"DNAME":            true,
 		"DS":               true,
 		"DNSKEY":           true,
+		"EUI48":            true,
+		"EUI64":            true,
+		"HIP":              true,
 		"HTTPS":            true,
 		"IMPORT_TRANSFORM": false,
+		"L32":              true,
+		"L64":              true,
 		"LOC":              true,
+		"LP":               true,
 		"MX":               true,
 		"NAPTR":            true,
+		"NID":              true,
 		"NS":               true,
+		"OPENPGPKEY":       true,
 		"PTR":              true,
+		"RP":               true,
 		"SOA":              true,
 		"SRV":              true,
 		"SSHFP":            true,
@@ -162,8 +178,153 @@ func checkSoa(expire uint32, minttl uint32, refresh uint32, retry uint32, mbox s
 	if mbox == "" {
 		return fmt.Errorf("SOA MBox must be specified")
 	}
-	if strings.ContainsRune(mbox, '@') {
-		return fmt.Errorf("SOA MBox must have '.' instead of '@'")
+	return checkSoaMbox(mbox)
+}
+
+// checkSoaTimers warns about SOA timers that defeat their purpose: a
+// secondary should retry a failed refresh before the next refresh is due,
+// and should keep serving the zone for longer than a refresh takes.
+func checkSoaTimers(rec *models.RecordConfig) error {
+	if rec.Type != "SOA" {
+		return nil
+	}
+	switch {
+	case rec.SoaRetry >= rec.SoaRefresh:
+		return Warning{fmt.Errorf("%s SOA: the retry (%d) should be less than the refresh (%d)", rec.GetLabelFQDN(), rec.SoaRetry, rec.SoaRefresh)}
+	case rec.SoaExpire <= rec.SoaRefresh:
+		return Warning{fmt.Errorf("%s SOA: the expire (%d) should be more than the refresh (%d)", rec.GetLabelFQDN(), rec.SoaExpire, rec.SoaRefresh)}
+	}
+	return nil
+}
+
+// checkOpenPGPKey verifies that an OPENPGPKEY record holds a base64
+// encoded key. A label that isn't of the RFC 7929 form
+// "<56 hex digits>._openpgpkey[.subdomain]" only earns a warning, since
+// OPENPGPKEY_LABEL() is merely a convenience.
+func checkOpenPGPKey(label, target string) error {
+	if target == "" {
+		return fmt.Errorf("OPENPGPKEY public key must be specified")
+	}
+	if _, err := base64.StdEncoding.DecodeString(target); err != nil {
+		return fmt.Errorf("OPENPGPKEY public key is not valid base64: %w", err)
+	}
+	parts := strings.SplitN(label, ".", 3)
+	if len(parts) < 2 || parts[1] != "_openpgpkey" || len(parts[0]) != 56 {
+		return Warning{fmt.Errorf("OPENPGPKEY label %q is not of the form <hash>._openpgpkey (see OPENPGPKEY_LABEL())", label)}
+	}
+	if _, err := hex.DecodeString(parts[0]); err != nil {
+		return Warning{fmt.Errorf("OPENPGPKEY label %q does not start with a hex-encoded hash", label)}
+	}
+	return nil
+}
+
+// txtCharNames names the characters that checkTXT reports, where the
+// character itself would be invisible or easily mistaken.
+var txtCharNames = map[rune]string{
+	'\t':     "a tab",
+	'\n':     "a newline",
+	'\r':     "a carriage return",
+	'\u201c': "a curly double quote (“)",
+	'\u201d': "a curly double quote (”)",
+	'\u2018': "a curly single quote (‘)",
+	'\u2019': "a curly single quote (’)",
+}
+
+// checkTXT verifies that the strings of a TXT record have no control
+// characters, which many providers reject with an opaque error. Curly
+// quotes and backslash-escaped quotes only earn a warning: they are valid,
+// but are usually copy-paste artifacts, and DNSControl quotes the strings
+// itself.
+func checkTXT(txts []string) error {
+	for _, txt := range txts {
+		for i, r := range txt {
+			if r < 0x20 || r == 0x7f {
+				name, ok := txtCharNames[r]
+				if !ok {
+					name = fmt.Sprintf("the control character %U", r)
+				}
+				return fmt.Errorf("TXT string %q contains %s at offset %d", txt, name, i)
+			}
+		}
+	}
+	for _, txt := range txts {
+		for i, r := range txt {
+			switch r {
+			case '\u2018', '\u2019', '\u201c', '\u201d':
+				return Warning{fmt.Errorf("TXT string %q contains %s at offset %d; use a straight quote", txt, txtCharNames[r], i)}
+			}
+		}
+		if i := strings.Index(txt, `\"`); i >= 0 {
+			return Warning{fmt.Errorf(`TXT string %q contains \" at offset %d; the backslash will be part of the text (quotes don't need escaping)`, txt, i)}
+		}
+	}
+	return nil
+}
+
+// checkCERT verifies the type and algorithm of a CERT record (RFC 4398)
+// and that the certificate is base64.
+func checkCERT(rec *models.RecordConfig) error {
+	if _, ok := dns.CertTypeToString[rec.CertType]; !ok && (rec.CertType < 65280 || rec.CertType == 65535) {
+		return fmt.Errorf("CERT type %d is not assigned (use 1-8, 253, 254 or 65280-65534)", rec.CertType)
+	}
+	if _, ok := dns.AlgorithmToString[rec.CertAlgorithm]; !ok && rec.CertAlgorithm != 0 {
+		return fmt.Errorf("CERT algorithm %d is not a DNSSEC algorithm", rec.CertAlgorithm)
+	}
+	if rec.GetTargetField() == "" {
+		return fmt.Errorf("CERT certificate must be specified")
+	}
+	if _, err := base64.StdEncoding.DecodeString(rec.GetTargetField()); err != nil {
+		return fmt.Errorf("CERT certificate is not valid base64: %w", err)
+	}
+	return nil
+}
+
+// hipAlgorithms are the PK algorithms of HIP records: the IPSECKEY
+// algorithm types (RFC 8005 section 5, RFC 9373).
+var hipAlgorithms = map[uint8]string{1: "DSA", 2: "RSA", 3: "ECDSA", 4: "EdDSA"}
+
+// checkHIP verifies the PK algorithm of a HIP record (RFC 8005), that the
+// HIT is hex and the public key base64, and that the rendezvous servers
+// are hostnames.
+func checkHIP(rec *models.RecordConfig) []error {
+	var errs []error
+	if _, ok := hipAlgorithms[rec.HipPkAlgorithm]; !ok {
+		errs = append(errs, fmt.Errorf("HIP PK algorithm %d is not 1 (DSA), 2 (RSA), 3 (ECDSA) or 4 (EdDSA)", rec.HipPkAlgorithm))
+	}
+	if hit, err := hex.DecodeString(rec.GetTargetField()); err != nil {
+		errs = append(errs, fmt.Errorf("HIP HIT is not valid hex: %w", err))
+	} else if len(hit) == 0 || len(hit) > 255 {
+		errs = append(errs, fmt.Errorf("HIP HIT must be 1 to 255 octets, not %d", len(hit)))
+	}
+	if pk, err := base64.StdEncoding.DecodeString(rec.HipPublicKey); err != nil {
+		errs = append(errs, fmt.Errorf("HIP public key is not valid base64: %w", err))
+	} else if len(pk) == 0 || len(pk) > 65535 {
+		errs = append(errs, fmt.Errorf("HIP public key must be 1 to 65535 octets, not %d", len(pk)))
+	}
+	for _, server := range rec.HipServers {
+		if err := checkTarget(server); err != nil {
+			errs = append(errs, fmt.Errorf("HIP rendezvous server: %w", err))
+		}
+	}
+	return errs
+}
+
+// checkDHCID verifies that the RDATA of a DHCID record (RFC 4701) is
+// base64 and long enough: a 2-octet identifier type, a 1-octet digest type
+// and the digest, which is 32 octets for SHA-256 (digest type 1).
+func checkDHCID(target string) error {
+	if target == "" {
+		return fmt.Errorf("DHCID digest must be specified")
+	}
+	b, err := base64.StdEncoding.DecodeString(target)
+	if err != nil {
+		return fmt.Errorf("DHCID digest is not valid base64: %w", err)
+	}
+	if len(b) < 4 {
+		return fmt.Errorf("DHCID digest is %d octets; it must have an identifier type, a digest type and a digest (at least 4 octets)", len(b))
+	}
+	if b[2] == 1 && len(b) != 3+sha256.Size {
+		return fmt.Errorf("DHCID SHA-256 digest is %d octets, not %d", len(b)-3, sha256.Size)
 	}
 	return nil
 }
@@ -186,6 +347,11 @@ func checkTargets(rec *models.RecordConfig, domain string) (errs []error) {
 		check(checkIPv4(target))
 	case "AAAA":
 		check(checkIPv6(target))
+	case "AFSDB":
+		check(checkTarget(target))
+		if rec.AfsdbSubtype != 1 && rec.AfsdbSubtype != 2 {
+			check(fmt.Errorf("AFSDB subtype %d is not 1 (AFS cell database server) or 2 (DCE authenticated name server) (RFC 1183 section 1)", rec.AfsdbSubtype))
+		}
 	case "ALIAS":
 		check(checkTarget(target))
 	case "CNAME":
@@ -200,7 +366,17 @@ func checkTargets(rec *models.RecordConfig, domain string) (errs []error) {
 		}
 	case "DNAME":
 		check(checkTarget(target))
+	case "L32":
+		check(checkIPv4(target))
+	case "L64", "NID":
+		_, err := models.ParseILNP64(target)
+		check(err)
 	case "LOC":
+	case "LP":
+		check(checkTarget(target))
+		if dnsutil.AddOrigin(target, domain) == dnsutil.AddOrigin(label, domain) {
+			check(fmt.Errorf("LP target must not be the name of the LP record itself (RFC 6742 section 2.4)"))
+		}
 	case "MX":
 		check(checkTarget(target))
 	case "NAPTR":
@@ -216,8 +392,27 @@ func checkTargets(rec *models.RecordConfig, domain string) (errs []error) {
 		if len(strings.Fields(target)) != 5 {
 			check(fmt.Errorf("record should follow format: \"from to redirectType pathForwardingMode queryForwarding\""))
 		}
+	case "EUI48", "EUI64":
+		_, err := models.ParseEUI(rec.Type, target)
+		check(err)
+	case "CERT":
+		check(checkCERT(rec))
+	case "HIP":
+		for _, err := range checkHIP(rec) {
+			check(err)
+		}
+	case "OPENPGPKEY":
+		check(checkOpenPGPKey(label, target))
 	case "PTR":
 		check(checkTarget(target))
+	case "RP":
+		// "." is for no mailbox, or no TXT record (RFC 1183 section 2.2).
+		if target != "." {
+			check(checkMbox("RP mailbox", target))
+		}
+		if rec.RpTxt != "." {
+			check(checkTarget(rec.RpTxt))
+		}
 	case "SOA":
 		check(checkSoa(rec.SoaExpire, rec.SoaMinttl, rec.SoaRefresh, rec.SoaRetry, rec.SoaMbox))
 		check(checkTarget(target))
@@ -226,7 +421,16 @@ func checkTargets(rec *models.RecordConfig, domain string) (errs []error) {
 		}
 	case "SRV":
 		check(checkTarget(target))
-	case "CAA", "DHCID", "DNSKEY", "DS", "HTTPS", "IMPORT_TRANSFORM", "SSHFP", "SVCB", "TLSA", "TXT":
+	case "TXT":
+		check(checkTXT(rec.GetTargetTXTSegmented()))
+		check(checkMailPolicyTXT(label, rec.GetTargetTXTJoined()))
+	case "DHCID":
+		check(checkDHCID(target))
+	case "CAA":
+		if rec.CaaTag == "iodef" {
+			check(checkCAAIodef(target))
+		}
+	case "DNSKEY", "DS", "HTTPS", "IMPORT_TRANSFORM", "SSHFP", "SVCB", "TLSA":
 	default:
 		if rec.Metadata["orig_custom_type"] != "" {
 			// it is a valid custom type. We perform no validation on target
@@ -308,11 +512,14 @@ type Warning struct {
 }
 
 // ValidateAndNormalizeConfig performs and normalization and/or validation of the IR.
-func ValidateAndNormalizeConfig(config *models.DNSConfig) (errs []error) {
+func ValidateAndNormalizeConfig(config *models.DNSConfig, opts Options) (errs []error) {
 	err := processSplitHorizonDomains(config)
 	if err != nil {
 		return []error{err}
 	}
+	if opts.checkEnabled("split-horizon") {
+		errs = append(errs, checkSplitHorizonProviders(config)...)
+	}
 
 	for _, domain := range config.Domains {
 		pTypes := []string{}
@@ -379,26 +586,44 @@ func ValidateAndNormalizeConfig(config *models.DNSConfig) (errs []error) {
 			if err := validateRecordTypes(rec, domain.Name, pTypes); err != nil {
 				errs = append(errs, err)
 			}
-			if err := checkLabel(rec.GetLabel(), rec.Type, domain.Name, rec.Metadata); err != nil {
-				errs = append(errs, err)
+			if opts.checkEnabled("labels") {
+				if err := checkLabel(rec.GetLabel(), rec.Type, domain.Name, rec.Metadata); err != nil {
+					errs = append(errs, err)
+				}
 			}
 
-			if errs2 := checkTargets(rec, domain.Name); errs2 != nil {
-				errs = append(errs, errs2...)
+			if opts.checkEnabled("targets") {
+				if errs2 := checkTargets(rec, domain.Name); errs2 != nil {
+					errs = append(errs, errs2...)
+				}
 			}
 
-			// Canonicalize Targets.
-			if rec.Type == "ALIAS" || rec.Type == "CNAME" || rec.Type == "MX" || rec.Type == "NS" || rec.Type == "SRV" {
-				// #rtype_variations
-				// These record types have a target that is a hostname.
-				// We normalize them to a FQDN so there is less variation to handle.  If a
-				// provider API requires a shortname, the provider must do the shortening.
+			// Canonicalize Targets. They keep the trailing dot until the
+			// checks below are done; see models.UnqualifyTargets.
+			if models.HasHostnameTarget(rec.Type) {
+				origin := domain.Name + "."
+				if rec.SubDomain != "" {
+					origin = rec.SubDomain + "." + origin
+				}
+				errs = append(errs, canonicalizeTarget(rec, origin, domain.Name, opts)...)
+				if rec.Type == "RP" {
+					rec.RpTxt = dnsutil.AddOrigin(rec.RpTxt, origin)
+				}
+			}
+			if rec.Type == "HIP" {
+				// The HIT is uppercase, as models.SetTargetHIP stores it. The
+				// rendezvous servers are hostnames, like the targets of
+				// models.HasHostnameTarget.
+				rec.SetTarget(strings.ToUpper(rec.GetTargetField()))
 				origin := domain.Name + "."
 				if rec.SubDomain != "" {
 					origin = rec.SubDomain + "." + origin
 				}
-				rec.SetTarget(dnsutil.AddOrigin(rec.GetTargetField(), origin))
-			} else if rec.Type == "A" || rec.Type == "AAAA" {
+				for i, server := range rec.HipServers {
+					rec.HipServers[i] = dnsutil.AddOrigin(server, origin)
+				}
+			}
+			if rec.Type == "A" || rec.Type == "AAAA" {
 				rec.SetTarget(net.ParseIP(rec.GetTargetField()).String())
 			} else if rec.Type == "PTR" {
 				var err error
@@ -429,6 +654,20 @@ func ValidateAndNormalizeConfig(config *models.DNSConfig) (errs []error) {
 			// Populate FQDN:
 			rec.SetLabel(rec.GetLabel(), domain.Name)
 
+			if source, ok := rec.Metadata[ttlsource.MetaKey]; ok {
+				if err := ttlsource.Check(source); err != nil {
+					errs = append(errs, fmt.Errorf("%s %s: %w", rec.GetLabelFQDN(), rec.Type, err))
+				}
+			}
+			for _, name := range rec.OnlyProviders() {
+				if _, ok := domain.DNSProviderNames[name]; !ok {
+					errs = append(errs, fmt.Errorf("%s %s: ONLY_PROVIDERS(%q) is not a DNS provider of %s", rec.GetLabelFQDN(), rec.Type, name, domain.Name))
+				}
+			}
+			if err := checkProxy(rec); err != nil {
+				errs = append(errs, err)
+			}
+
 			if _, ok := rec.Metadata["ignore_name_disable_safety_check"]; ok {
 				errs = append(errs, fmt.Errorf("IGNORE_NAME_DISABLE_SAFETY_CHECK no longer supported. Please use DISABLE_IGNORE_SAFETY_CHECK for the entire domain"))
 			}
@@ -436,6 +675,13 @@ func ValidateAndNormalizeConfig(config *models.DNSConfig) (errs []error) {
 		}
 	}
 
+	// Multiple SPF records at the same name (merged with opts.MergeSPF)
+	if opts.checkEnabled("multiple-spf") {
+		for _, domain := range config.Domains {
+			errs = append(errs, checkMultipleSPF(domain, opts.MergeSPF)...)
+		}
+	}
+
 	// SPF flattening
 	if ers := flattenSPFs(config); len(ers) > 0 {
 		errs = append(errs, ers...)
@@ -475,24 +721,90 @@ func ValidateAndNormalizeConfig(config *models.DNSConfig) (errs []error) {
 
 	for _, d := range config.Domains {
 		// Check that CNAMES don't have to co-exist with any other records
-		errs = append(errs, checkCNAMEs(d)...)
+		if opts.checkEnabled("cname") {
+			errs = append(errs, checkCNAMEs(d)...)
+		}
+		// Check that ALIASes don't co-exist with CNAMEs (or A/AAAA records)
+		if opts.checkEnabled("alias") {
+			errs = append(errs, checkALIASes(d)...)
+		}
+		// Check for records that a DNAME makes unreachable
+		if opts.checkEnabled("dname") {
+			errs = append(errs, checkDNAMEs(d)...)
+		}
+		// Check that the record types are permitted by the type policy
+		errs = append(errs, checkTypePolicy(d, opts.TypePolicy)...)
+		// Check that the records the record policy requires exist
+		errs = append(errs, checkRecordPolicy(d, opts.RecordPolicy)...)
 		// Check that if any advanced record types are used in a domain, every provider for that domain supports them
-		err := checkProviderCapabilities(d)
-		if err != nil {
-			errs = append(errs, err)
+		errs = append(errs, checkProviderCapabilities(d, opts.DropUnsupported)...)
+		// Check that TTLs are within the range the providers accept
+		if opts.checkEnabled("ttl-range") {
+			errs = append(errs, checkTTLRanges(d)...)
 		}
 		// Check for duplicates
-		errs = append(errs, checkDuplicates(d.Records)...)
+		if opts.checkEnabled("duplicates") {
+			errs = append(errs, checkDuplicates(d.Records)...)
+		}
 		// Check for different TTLs under the same label
-		errs = append(errs, checkRecordSetHasMultipleTTLs(d.Records)...)
+		if opts.checkEnabled("multiple-ttls") {
+			errs = append(errs, checkRecordSetHasMultipleTTLs(d.Records)...)
+		}
+		// Check for MX records that share a preference or back up nothing
+		if opts.checkEnabled("mx-preferences") {
+			errs = append(errs, checkMXPreferences(d.Records)...)
+		}
+		// Check for private and reserved addresses in public zones
+		if opts.checkEnabled("public-ips") {
+			errs = append(errs, checkPublicIPs(d, opts.Strict)...)
+		}
+		// Check for in-zone nameservers without glue
+		if opts.checkEnabled("glue") {
+			errs = append(errs, checkGlue(d)...)
+		}
+		// Check for MX and SRV records whose in-zone target has no address
+		if opts.checkEnabled("in-zone-targets") {
+			errs = append(errs, checkInZoneTargets(d)...)
+		}
 		// Validate FQDN consistency
 		for _, r := range d.Records {
 			if r.NameFQDN == "" || !strings.HasSuffix(r.NameFQDN, d.Name) {
 				errs = append(errs, fmt.Errorf("record named '%s' does not have correct FQDN for domain '%s'. FQDN: %s", r.Name, d.Name, r.NameFQDN))
 			}
 		}
+		// Report records near or past their SUNSET() date
+		if opts.checkEnabled("sunset") {
+			now := sunsetNow()
+			for _, r := range d.Records {
+				if err := checkSunset(r, now, opts.Sunset); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+		// Report records of the stable types with a short TTL
+		if opts.checkEnabled("low-ttl") {
+			for _, r := range d.Records {
+				if err := checkLowTTL(r, opts.LowTTL); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+		// Report SOA records whose timers are inconsistent
+		if opts.checkEnabled("soa-timers") {
+			for _, r := range d.Records {
+				if err := checkSoaTimers(r); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
 		// Verify AutoDNSSEC is valid.
-		errs = append(errs, checkAutoDNSSEC(d)...)
+		if opts.checkEnabled("autodnssec") {
+			errs = append(errs, checkAutoDNSSEC(d)...)
+		}
+		// Verify the final zone fits within the providers' limits.
+		if opts.checkEnabled("record-limits") {
+			errs = append(errs, checkRecordLimits(d)...)
+		}
 	}
 
 	// At this point we've munged anything that needs to be munged, and
@@ -517,6 +829,14 @@ func ValidateAndNormalizeConfig(config *models.DNSConfig) (errs []error) {
 		}
 	}
 
+	// The IR stores the hostname targets as FQDNs without the trailing
+	// dot. Whatever works with DNS names adds it back with
+	// models.QualifyTargets.
+	for _, domain := range config.Domains {
+		models.UnqualifyTargets(domain.Records)
+	}
+	config.Normalized = true
+
 	return errs
 }
 
@@ -540,6 +860,38 @@ func processSplitHorizonDomains(config *models.DNSConfig) error {
 	return nil
 }
 
+// checkSplitHorizonProviders warns when several views of a split horizon
+// domain are at the same DNS provider, unless the provider keeps views
+// apart. Otherwise the views are the same zone, and pushing one view undoes
+// the others.
+func checkSplitHorizonProviders(config *models.DNSConfig) (errs []error) {
+	pTypes := map[string]string{}
+	for _, p := range config.DNSProviders {
+		pTypes[p.Name] = p.Type
+	}
+	seen := map[string]string{} // domain name + provider name -> first view
+	for _, d := range config.Domains {
+		names := make([]string, 0, len(d.DNSProviderNames))
+		for name := range d.DNSProviderNames {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			pType := pTypes[name]
+			if pType == "" || pType == "-" || providers.ProviderHasCapability(pType, providers.CanSplitHorizon) {
+				continue
+			}
+			key := d.Name + "\x00" + name
+			if first, ok := seen[key]; ok {
+				errs = append(errs, Warning{fmt.Errorf("%s and %s are both at DNS provider %s (%s), which doesn't keep views apart: pushing one undoes the other", first, d.GetUniqueName(), name, pType)})
+				continue
+			}
+			seen[key] = d.GetUniqueName()
+		}
+	}
+	return errs
+}
+
 func checkAutoDNSSEC(dc *models.DomainConfig) (errs []error) {
 	if strings.ToLower(dc.RegistrarName) == "none" {
 		return
@@ -565,13 +917,39 @@ func checkCNAMEs(dc *models.DomainConfig) (errs []error) {
 		}
 	}
 	for _, r := range dc.Records {
-		if cnames[r.GetLabel()] && r.Type != "CNAME" {
+		// ALIAS is reported by checkALIASes.
+		if cnames[r.GetLabel()] && r.Type != "CNAME" && r.Type != "ALIAS" {
 			errs = append(errs, fmt.Errorf("cannot have CNAME and %s record with same name: %s", r.Type, r.GetLabelFQDN()))
 		}
 	}
 	return
 }
 
+// checkALIASes reports an ALIAS that shares its label with a CNAME, which
+// is an error, or with an A or AAAA record. The latter is a warning: the
+// ALIAS stands for A and AAAA records, and, depending on the provider, the
+// zone is rejected or one of them is ignored.
+func checkALIASes(dc *models.DomainConfig) (errs []error) {
+	aliases := map[string]bool{}
+	for _, r := range dc.Records {
+		if r.Type == "ALIAS" {
+			aliases[r.GetLabel()] = true
+		}
+	}
+	for _, r := range dc.Records {
+		if !aliases[r.GetLabel()] {
+			continue
+		}
+		switch r.Type {
+		case "CNAME":
+			errs = append(errs, fmt.Errorf("cannot have ALIAS and CNAME record with same name: %s", r.GetLabelFQDN()))
+		case "A", "AAAA":
+			errs = append(errs, Warning{fmt.Errorf("ALIAS and %s record with same name: %s (the provider may reject this, or ignore one of them)", r.Type, r.GetLabelFQDN())})
+		}
+	}
+	return
+}
+
 func checkDuplicates(records []*models.RecordConfig) (errs []error) {
 	seen := map[string]*models.RecordConfig{}
 	for _, r := range records {
@@ -584,6 +962,93 @@ func checkDuplicates(records []*models.RecordConfig) (errs []error) {
 	return errs
 }
 
+// checkRecordLimits reports domains that exceed the record limits
+// documented by one of their DNS providers. Such a push would fail part
+// way through, so this is an error unless the domain has the metadata
+// ignore_record_limits=true (for example, because the provider raised
+// a quota for this account), in which case it is only a warning.
+func checkRecordLimits(dc *models.DomainConfig) (errs []error) {
+	report := func(e error) {
+		if dc.Metadata["ignore_record_limits"] == "true" {
+			e = Warning{e}
+		}
+		errs = append(errs, e)
+	}
+
+	sets := map[models.RecordKey]int{}
+	for _, r := range dc.Records {
+		sets[r.Key()]++
+	}
+	var keys []models.RecordKey
+	for k := range sets {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].NameFQDN != keys[j].NameFQDN {
+			return keys[i].NameFQDN < keys[j].NameFQDN
+		}
+		return keys[i].Type < keys[j].Type
+	})
+
+	for _, provider := range dc.DNSProviderInstances {
+		limits, ok := providers.ProviderRecordLimits(provider.ProviderType)
+		if !ok {
+			continue
+		}
+		if limits.MaxRecords != 0 && len(dc.Records) > limits.MaxRecords {
+			report(fmt.Errorf("domain %s has %d records; provider %s permits at most %d per zone", dc.Name, len(dc.Records), provider.Name, limits.MaxRecords))
+		}
+		if limits.MaxRecordSets != 0 && len(sets) > limits.MaxRecordSets {
+			report(fmt.Errorf("domain %s has %d record sets; provider %s permits at most %d per zone", dc.Name, len(sets), provider.Name, limits.MaxRecordSets))
+		}
+		if limits.MaxPerRecordSet != 0 {
+			for _, k := range keys {
+				if sets[k] > limits.MaxPerRecordSet {
+					report(fmt.Errorf("%s %s has %d records; provider %s permits at most %d per record set", k.NameFQDN, k.Type, sets[k], provider.Name, limits.MaxPerRecordSet))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// checkTTLRanges warns about records whose TTL is outside the range
+// accepted by one of the domain's DNS providers. Such records
+// are usually clamped by the provider, which results in a change that
+// never converges. If the domain has the metadata clamp_ttl=true the
+// TTL is clamped here instead.
+func checkTTLRanges(dc *models.DomainConfig) (errs []error) {
+	clamp := dc.Metadata["clamp_ttl"] == "true"
+	for _, provider := range dc.DNSProviderInstances {
+		limits, ok := providers.ProviderTTLRange(provider.ProviderType)
+		if !ok {
+			continue
+		}
+		for _, r := range dc.Records {
+			ttl := r.TTL
+			if ttl < limits.Min {
+				ttl = limits.Min
+			} else if limits.Max != 0 && ttl > limits.Max {
+				ttl = limits.Max
+			}
+			if ttl == r.TTL {
+				continue
+			}
+			limit := fmt.Sprintf("min %d", limits.Min)
+			if limits.Max != 0 {
+				limit += fmt.Sprintf(", max %d", limits.Max)
+			}
+			if clamp {
+				errs = append(errs, Warning{fmt.Errorf("%s %s: TTL %d is outside the range of provider %s (%s); clamped to %d", r.GetLabelFQDN(), r.Type, r.TTL, provider.Name, limit, ttl)})
+				r.TTL = ttl
+			} else {
+				errs = append(errs, Warning{fmt.Errorf("%s %s: TTL %d is outside the range of provider %s (%s); add {clamp_ttl:'true'} to D() to clamp it", r.GetLabelFQDN(), r.Type, r.TTL, provider.Name, limit)})
+			}
+		}
+	}
+	return errs
+}
+
 func checkRecordSetHasMultipleTTLs(records []*models.RecordConfig) (errs []error) {
 	// The RFCs say that all records at a particular recordset should have
 	// the same TTL.  Most providers don't care, and if they do the
@@ -679,20 +1144,31 @@ var providerCapabilityChecks = []pairTypeCapability{
 	// #rtype_variations
 	// If a zone uses rType X, the provider must support capability Y.
 	//{"X", providers.Y},
+	capabilityCheck("AFSDB", providers.CanUseAFSDB),
 	capabilityCheck("AKAMAICDN", providers.CanUseAKAMAICDN),
 	capabilityCheck("ALIAS", providers.CanUseAlias),
 	capabilityCheck("AUTODNSSEC", providers.CanAutoDNSSEC),
 	capabilityCheck("AZURE_ALIAS", providers.CanUseAzureAlias),
 	capabilityCheck("CAA", providers.CanUseCAA),
+	capabilityCheck("CERT", providers.CanUseCERT),
 	capabilityCheck("DHCID", providers.CanUseDHCID),
 	capabilityCheck("DNAME", providers.CanUseDNAME),
 	capabilityCheck("DNSKEY", providers.CanUseDNSKEY),
+	capabilityCheck("EUI48", providers.CanUseEUI48),
+	capabilityCheck("EUI64", providers.CanUseEUI64),
+	capabilityCheck("HIP", providers.CanUseHIP),
 	capabilityCheck("HTTPS", providers.CanUseHTTPS),
+	capabilityCheck("L32", providers.CanUseILNP),
+	capabilityCheck("L64", providers.CanUseILNP),
 	capabilityCheck("LOC", providers.CanUseLOC),
+	capabilityCheck("LP", providers.CanUseILNP),
 	capabilityCheck("NAPTR", providers.CanUseNAPTR),
+	capabilityCheck("NID", providers.CanUseILNP),
+	capabilityCheck("OPENPGPKEY", providers.CanUseOPENPGPKEY),
 	capabilityCheck("PTR", providers.CanUsePTR),
 	capabilityCheck("R53_ALIAS", providers.CanUseRoute53Alias),
-	capabilityCheck("SOA", providers.CanUseSOA),
+	capabilityCheck("RP", providers.CanUseRP),
+	ignoredCapabilityCheck("SOA", providers.CanUseSOA),
 	capabilityCheck("SRV", providers.CanUseSRV),
 	capabilityCheck("SSHFP", providers.CanUseSSHFP),
 	capabilityCheck("SVCB", providers.CanUseSVCB),
@@ -715,6 +1191,9 @@ type pairTypeCapability struct {
 	// checkFunc provides additional checks of each provider. This function should be
 	// called if records of type rType are found in the zonefile.
 	checkFunc func(pType string, _ models.Records) error
+	// ignored is true if the providers without the capability ignore the
+	// records (and a warning says so) instead of failing.
+	ignored bool
 }
 
 func capabilityCheck(rType string, caps ...providers.Capability) pairTypeCapability {
@@ -724,6 +1203,16 @@ func capabilityCheck(rType string, caps ...providers.Capability) pairTypeCapabil
 	}
 }
 
+// ignoredCapabilityCheck is for the records that the providers without
+// the capability manage themselves, such as the SOA.
+func ignoredCapabilityCheck(rType string, caps ...providers.Capability) pairTypeCapability {
+	return pairTypeCapability{
+		rType:   rType,
+		caps:    caps,
+		ignored: true,
+	}
+}
+
 func providerHasAtLeastOneCapability(pType string, caps ...providers.Capability) bool {
 	for _, cap := range caps {
 		if providers.ProviderHasCapability(pType, cap) {
@@ -757,7 +1246,11 @@ func checkProviderDS(pType string, records models.Records) error {
 	return nil
 }
 
-func checkProviderCapabilities(dc *models.DomainConfig) error {
+// checkProviderCapabilities checks that the providers of dc support the
+// record types it uses. If dropUnsupported is true, the records that a
+// provider doesn't support are left out of it (a warning), instead of
+// being an error.
+func checkProviderCapabilities(dc *models.DomainConfig, dropUnsupported bool) (errs []error) {
 	// Check if the zone uses a capability that the provider doesn't
 	// support.
 	for _, ty := range providerCapabilityChecks {
@@ -789,19 +1282,78 @@ func checkProviderCapabilities(dc *models.DomainConfig) error {
 				// be performed.
 				continue
 			}
+			// The records that ONLY_PROVIDERS() (or --drop-unsupported)
+			// limits to other providers don't count.
+			var at models.Records
+			if ty.rType != "AUTODNSSEC" {
+				for _, r := range dc.Records {
+					if only := r.OnlyProviders(); r.Type == ty.rType && (only == nil || slices.Contains(only, provider.Name)) {
+						at = append(at, r)
+					}
+				}
+				if len(at) == 0 {
+					continue
+				}
+			}
 			// fmt.Printf("  (checking if %q can %q for domain %q)\n", provider.ProviderType, ty.rType, dc.Name)
 			if !providerHasAtLeastOneCapability(provider.ProviderType, ty.caps...) {
-				return fmt.Errorf("domain %s uses %s records, but DNS provider type %s does not support them", dc.Name, ty.rType, provider.ProviderType)
+				if ty.ignored {
+					errs = append(errs, Warning{fmt.Errorf("domain %s has %s records, but DNS provider type %s manages them itself; they are ignored there", dc.Name, ty.rType, provider.ProviderType)})
+					continue
+				}
+				if dropUnsupported && len(at) != 0 {
+					errs = append(errs, dropUnsupportedRecords(dc, provider, at))
+					continue
+				}
+				return append(errs, fmt.Errorf("domain %s uses %s records, but DNS provider type %s does not support them", dc.Name, ty.rType, provider.ProviderType))
 			}
 
 			if ty.checkFunc != nil {
 				checkErr := ty.checkFunc(provider.ProviderType, dc.Records)
 				if checkErr != nil {
-					return fmt.Errorf("while checking %s records in domain %s: %w", ty.rType, dc.Name, checkErr)
+					return append(errs, fmt.Errorf("while checking %s records in domain %s: %w", ty.rType, dc.Name, checkErr))
 				}
 			}
 		}
 	}
+	if err := checkProviderProxy(dc); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// checkProxy checks the PROXY_ON() or PROXY_OFF() setting of rec, if any.
+func checkProxy(rec *models.RecordConfig) error {
+	p := rec.Proxy()
+	switch {
+	case p == "":
+		return nil
+	case p != "on" && p != "off":
+		return fmt.Errorf("%s %s: invalid proxy setting %q (use PROXY_ON() or PROXY_OFF())", rec.GetLabelFQDN(), rec.Type, p)
+	case rec.Type != "A" && rec.Type != "AAAA" && rec.Type != "CNAME" && rec.Type != "ALIAS":
+		return fmt.Errorf("%s %s: PROXY_%s() is only for A, AAAA, CNAME and ALIAS records", rec.GetLabelFQDN(), rec.Type, strings.ToUpper(p))
+	}
+	return nil
+}
+
+// checkProviderProxy checks that the DNS providers of the records that use
+// PROXY_ON() or PROXY_OFF() can proxy. Records that ONLY_PROVIDERS() keeps
+// from a provider don't count against it.
+func checkProviderProxy(dc *models.DomainConfig) error {
+	for _, provider := range dc.DNSProviderInstances {
+		if provider.ProviderType == "-" || providers.ProviderHasCapability(provider.ProviderType, providers.CanProxy) {
+			continue
+		}
+		for _, rec := range dc.Records {
+			if rec.Proxy() == "" {
+				continue
+			}
+			if only := rec.OnlyProviders(); only != nil && !slices.Contains(only, provider.Name) {
+				continue
+			}
+			return fmt.Errorf("domain %s: %s %s uses PROXY_%s(), but DNS provider type %s can't proxy", dc.Name, rec.GetLabelFQDN(), rec.Type, strings.ToUpper(rec.Proxy()), provider.ProviderType)
+		}
+	}
 	return nil
 }
 
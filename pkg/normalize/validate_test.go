@@ -2,6 +2,7 @@ package normalize
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
@@ -287,6 +288,49 @@ func TestCNAMEMutex(t *testing.T) {
 	}
 }
 
+func TestALIASMutex(t *testing.T) {
+	tests := []struct {
+		rType   string
+		name    string
+		fail    bool
+		warning bool
+	}{
+		{"CNAME", "@", true, false},
+		{"A", "@", true, true},
+		{"AAAA", "@", true, true},
+		{"MX", "@", false, false},
+		{"TXT", "@", false, false},
+		{"CNAME", "www", false, false},
+	}
+	for _, tst := range tests {
+		t.Run(fmt.Sprintf("%s %s", tst.rType, tst.name), func(t *testing.T) {
+			var recA = &models.RecordConfig{Type: "ALIAS"}
+			recA.SetLabel("@", "example.com")
+			recA.SetTarget("example.net.")
+			var recB = &models.RecordConfig{Type: tst.rType}
+			recB.SetLabel(tst.name, "example.com")
+			recB.SetTarget("example2.com.")
+			dc := &models.DomainConfig{
+				Name:    "example.com",
+				Records: []*models.RecordConfig{recA, recB},
+			}
+			errs := checkALIASes(dc)
+			if (len(errs) != 0) != tst.fail {
+				t.Fatalf("errors = %v, expected failure: %v", errs, tst.fail)
+			}
+			if tst.fail {
+				if _, ok := errs[0].(Warning); ok != tst.warning {
+					t.Errorf("error %v: expected warning: %v", errs[0], tst.warning)
+				}
+			}
+			// checkCNAMEs leaves the ALIAS to checkALIASes.
+			if errs := checkCNAMEs(dc); errs != nil {
+				t.Errorf("checkCNAMEs() = %v", errs)
+			}
+		})
+	}
+}
+
 func TestCAAValidation(t *testing.T) {
 	config := &models.DNSConfig{
 		Domains: []*models.DomainConfig{
@@ -299,7 +343,7 @@ func TestCAAValidation(t *testing.T) {
 			},
 		},
 	}
-	errs := ValidateAndNormalizeConfig(config)
+	errs := ValidateAndNormalizeConfig(config, Options{})
 	if len(errs) != 1 {
 		t.Error("Expect error on invalid CAA but got none")
 	}
@@ -438,17 +482,73 @@ func TestTLSAValidation(t *testing.T) {
 			},
 		},
 	}
-	errs := ValidateAndNormalizeConfig(config)
+	errs := ValidateAndNormalizeConfig(config, Options{})
 	if len(errs) != 1 {
 		t.Error("Expect error on invalid TLSA but got none")
 	}
 }
 
+func TestOnlyProvidersValidation(t *testing.T) {
+	for _, tst := range []struct {
+		only    string
+		wantErr bool
+	}{
+		{"dsp_a", false},
+		{"dsp_a,dsp_b", false},
+		{"dsp_a,dsp_c", true},
+	} {
+		config := &models.DNSConfig{
+			Domains: []*models.DomainConfig{
+				{
+					Name:             "example.com",
+					DNSProviderNames: map[string]int{"dsp_a": -1, "dsp_b": -1},
+					Records: []*models.RecordConfig{
+						makeRC("hc", "example.com", "1.2.3.4", models.RecordConfig{
+							Type: "A", Metadata: map[string]string{"only_providers": tst.only}}),
+					},
+				},
+			},
+		}
+		if errs := ValidateAndNormalizeConfig(config, Options{}); (len(errs) != 0) != tst.wantErr {
+			t.Errorf("ONLY_PROVIDERS(%s): errors = %v, expected error=%v", tst.only, errs, tst.wantErr)
+		}
+	}
+}
+
+func TestCheckOpenPGPKey(t *testing.T) {
+	const label = "c93f1e400f26708f98cb19d936620da35eec8f72e57f9eec01c1afd6._openpgpkey"
+	tests := []struct {
+		label, target string
+		isError       bool
+		isWarning     bool
+	}{
+		{label, "AQIDBAU=", false, false},
+		{label + ".sub", "AQIDBAU=", false, false},
+		{label, "", true, false},
+		{label, "not base64!", true, false},
+		{"hugh", "AQIDBAU=", true, true},
+		{"zz3f1e400f26708f98cb19d936620da35eec8f72e57f9eec01c1afd6._openpgpkey", "AQIDBAU=", true, true},
+	}
+	for _, tst := range tests {
+		err := checkOpenPGPKey(tst.label, tst.target)
+		if (err != nil) != tst.isError {
+			t.Errorf("checkOpenPGPKey(%q, %q) = %v, expected error=%v", tst.label, tst.target, err, tst.isError)
+			continue
+		}
+		if _, ok := err.(Warning); ok != tst.isWarning {
+			t.Errorf("checkOpenPGPKey(%q, %q) = %v, expected warning=%v", tst.label, tst.target, err, tst.isWarning)
+		}
+	}
+}
+
 const (
 	ProviderNoDS        = "NO_DS_SUPPORT"
 	ProviderFullDS      = "FULL_DS_SUPPORT"
 	ProviderChildDSOnly = "CHILD_DS_SUPPORT"
 	ProviderBothDSCaps  = "BOTH_DS_CAPABILITIES"
+	ProviderTTLRange    = "TTL_RANGE"
+	ProviderRecLimits   = "RECORD_LIMITS"
+	ProviderProxy       = "PROXY"
 )
 
 func init() {
@@ -463,6 +563,169 @@ func init() {
 		providers.CanUseDS:            providers.Can(),
 		providers.CanUseDSForChildren: providers.Can(),
 	})
+	providers.RegisterDomainServiceProviderType(ProviderTTLRange, providers.DspFuncs{}, providers.TTLRange{Min: 60, Max: 86400})
+	providers.RegisterDomainServiceProviderType(ProviderRecLimits, providers.DspFuncs{}, providers.RecordLimits{MaxRecords: 3, MaxPerRecordSet: 2})
+	providers.RegisterDomainServiceProviderType(ProviderProxy, providers.DspFuncs{}, providers.CanProxy)
+}
+
+func TestCheckProxy(t *testing.T) {
+	tests := []struct {
+		rtype, proxy string
+		isError      bool
+	}{
+		{"A", "", false},
+		{"A", "on", false},
+		{"CNAME", "off", false},
+		{"A", "full", true},
+		{"MX", "on", true},
+	}
+	for _, tst := range tests {
+		rec := makeRC("www", "example.com", "1.2.3.4", models.RecordConfig{Type: tst.rtype, Metadata: map[string]string{"proxy": tst.proxy}})
+		if err := checkProxy(rec); (err != nil) != tst.isError {
+			t.Errorf("%s proxy=%q: got error %v, expected error=%v", tst.rtype, tst.proxy, err, tst.isError)
+		}
+	}
+}
+
+func TestCheckTXT(t *testing.T) {
+	tests := []struct {
+		txts    []string
+		want    string // substring of the error; "" for none
+		warning bool
+	}{
+		{[]string{"v=spf1 -all"}, "", false},
+		{[]string{`say "hi"`, "naïve ünïcode"}, "", false},
+		{[]string{"token\n"}, "a newline at offset 5", false},
+		{[]string{"ok", "a\x00b"}, "the control character U+0000 at offset 1", false},
+		{[]string{"it\u2019s"}, "a curly single quote", true},
+		{[]string{`say \"hi\"`}, `contains \" at offset 4`, true},
+	}
+	for _, tst := range tests {
+		err := checkTXT(tst.txts)
+		if tst.want == "" {
+			if err != nil {
+				t.Errorf("%q: unexpected error %v", tst.txts, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tst.want) {
+			t.Errorf("%q: got %v, want an error containing %q", tst.txts, err, tst.want)
+			continue
+		}
+		if _, ok := err.(Warning); ok != tst.warning {
+			t.Errorf("%q: got warning=%v, want %v", tst.txts, ok, tst.warning)
+		}
+	}
+}
+
+func TestCheckProviderProxy(t *testing.T) {
+	makeDC := func(meta map[string]string) *models.DomainConfig {
+		return &models.DomainConfig{
+			Name: "example.com",
+			Records: models.Records{
+				makeRC("www", "example.com", "1.2.3.4", models.RecordConfig{Type: "A", Metadata: meta}),
+			},
+			DNSProviderInstances: []*models.DNSProviderInstance{
+				{ProviderBase: models.ProviderBase{Name: "cdn", ProviderType: ProviderProxy}},
+				{ProviderBase: models.ProviderBase{Name: "plain", ProviderType: ProviderNoDS}},
+			},
+		}
+	}
+	if err := checkProviderProxy(makeDC(map[string]string{})); err != nil {
+		t.Errorf("no proxy setting: unexpected error %v", err)
+	}
+	if err := checkProviderProxy(makeDC(map[string]string{"proxy": "on"})); err == nil {
+		t.Errorf("PROXY_ON() with a provider that can't proxy: expected an error")
+	}
+	if err := checkProviderProxy(makeDC(map[string]string{"proxy": "on", "only_providers": "cdn"})); err != nil {
+		t.Errorf("PROXY_ON() limited to the proxying provider: unexpected error %v", err)
+	}
+}
+
+func TestCheckTTLRanges(t *testing.T) {
+	makeDC := func(meta map[string]string) *models.DomainConfig {
+		return &models.DomainConfig{
+			Name:     "example.com",
+			Metadata: meta,
+			Records: models.Records{
+				makeRC("low", "example.com", "1.1.1.1", models.RecordConfig{Type: "A", TTL: 30}),
+				makeRC("ok", "example.com", "1.1.1.2", models.RecordConfig{Type: "A", TTL: 300}),
+				makeRC("high", "example.com", "1.1.1.3", models.RecordConfig{Type: "A", TTL: 172800}),
+			},
+			DNSProviderInstances: []*models.DNSProviderInstance{
+				{ProviderBase: models.ProviderBase{Name: "ranged", ProviderType: ProviderTTLRange}},
+				{ProviderBase: models.ProviderBase{Name: "other", ProviderType: ProviderFullDS}},
+			},
+		}
+	}
+
+	t.Run("warn", func(t *testing.T) {
+		dc := makeDC(map[string]string{})
+		errs := checkTTLRanges(dc)
+		if len(errs) != 2 {
+			t.Fatalf("expected 2 warnings, got %v", errs)
+		}
+		for _, err := range errs {
+			if _, ok := err.(Warning); !ok {
+				t.Errorf("expected a warning, got %v", err)
+			}
+		}
+		if dc.Records[0].TTL != 30 || dc.Records[2].TTL != 172800 {
+			t.Errorf("TTLs were modified without clamp_ttl")
+		}
+	})
+
+	t.Run("clamp", func(t *testing.T) {
+		dc := makeDC(map[string]string{"clamp_ttl": "true"})
+		errs := checkTTLRanges(dc)
+		if len(errs) != 2 {
+			t.Fatalf("expected 2 warnings, got %v", errs)
+		}
+		if got := []uint32{dc.Records[0].TTL, dc.Records[1].TTL, dc.Records[2].TTL}; got[0] != 60 || got[1] != 300 || got[2] != 86400 {
+			t.Errorf("clamped TTLs = %v, want [60 300 86400]", got)
+		}
+	})
+}
+
+func TestCheckRecordLimits(t *testing.T) {
+	makeDC := func(meta map[string]string, n int) *models.DomainConfig {
+		dc := &models.DomainConfig{
+			Name:     "example.com",
+			Metadata: meta,
+			DNSProviderInstances: []*models.DNSProviderInstance{
+				{ProviderBase: models.ProviderBase{Name: "limited", ProviderType: ProviderRecLimits}},
+			},
+		}
+		for i := 0; i < n; i++ {
+			dc.Records = append(dc.Records, makeRC("www", "example.com", fmt.Sprintf("1.1.1.%d", i), models.RecordConfig{Type: "A"}))
+		}
+		return dc
+	}
+
+	if errs := checkRecordLimits(makeDC(map[string]string{}, 2)); len(errs) != 0 {
+		t.Errorf("expected no errors within limits, got %v", errs)
+	}
+
+	// 4 records: over both the per-zone and the per-record-set limit.
+	errs := checkRecordLimits(makeDC(map[string]string{}, 4))
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	for _, err := range errs {
+		if _, ok := err.(Warning); ok {
+			t.Errorf("expected an error, got warning %v", err)
+		}
+	}
+
+	errs = checkRecordLimits(makeDC(map[string]string{"ignore_record_limits": "true"}, 4))
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", errs)
+	}
+	for _, err := range errs {
+		if _, ok := err.(Warning); !ok {
+			t.Errorf("expected a warning, got %v", err)
+		}
+	}
 }
 
 func Test_DSChecks(t *testing.T) {
@@ -551,3 +814,241 @@ func Test_errorRepeat(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckCERT(t *testing.T) {
+	tests := []struct {
+		certtype, keytag uint16
+		algorithm        uint8
+		cert             string
+		isError          bool
+	}{
+		{1, 12345, 8, "AQIDBAU=", false},
+		{3, 0, 0, "AQIDBAU=", false},
+		{65280, 0, 0, "AQIDBAU=", false},
+		{0, 0, 0, "AQIDBAU=", true},
+		{9, 0, 0, "AQIDBAU=", true},
+		{65535, 0, 0, "AQIDBAU=", true},
+		{1, 0, 200, "AQIDBAU=", true},
+		{1, 0, 8, "", true},
+		{1, 0, 8, "not base64!", true},
+	}
+	for _, tst := range tests {
+		rc := &models.RecordConfig{Type: "CERT"}
+		rc.SetTargetCERT(tst.certtype, tst.keytag, tst.algorithm, tst.cert)
+		if err := checkCERT(rc); (err != nil) != tst.isError {
+			t.Errorf("checkCERT(%d %d %d %q) = %v, expected error=%v", tst.certtype, tst.keytag, tst.algorithm, tst.cert, err, tst.isError)
+		}
+	}
+}
+
+func TestCheckSplitHorizonProviders(t *testing.T) {
+	providers.RegisterDomainServiceProviderType("VIEWS", providers.DspFuncs{}, providers.CanSplitHorizon)
+	makeConfig := func(insideType, outsideType string) *models.DNSConfig {
+		config := &models.DNSConfig{
+			DNSProviders: []*models.DNSProviderConfig{{Name: "inside", Type: insideType}, {Name: "outside", Type: outsideType}},
+			Domains: []*models.DomainConfig{
+				{Name: "example.com!in", DNSProviderNames: map[string]int{"inside": -1}},
+				{Name: "example.com!out", DNSProviderNames: map[string]int{"inside": -1, "outside": -1}},
+				{Name: "example.org", DNSProviderNames: map[string]int{"inside": -1}},
+			},
+		}
+		for _, d := range config.Domains {
+			d.UpdateSplitHorizonNames()
+		}
+		return config
+	}
+
+	errs := checkSplitHorizonProviders(makeConfig(ProviderNoDS, ProviderNoDS))
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 warning, got %v", errs)
+	}
+	if _, ok := errs[0].(Warning); !ok {
+		t.Errorf("expected a warning, got %v", errs[0])
+	}
+	if errs := checkSplitHorizonProviders(makeConfig("VIEWS", ProviderNoDS)); len(errs) != 0 {
+		t.Errorf("provider that keeps views apart: expected no warnings, got %v", errs)
+	}
+}
+
+func TestCheckTargetsILNP(t *testing.T) {
+	tests := []struct {
+		rtype, target string
+		isError       bool
+	}{
+		{"NID", "0014:4fff:ff20:ee64", false},
+		{"NID", "0014:4fff:ff20", true},
+		{"L64", "2001:0DB8:1140:1000", false},
+		{"L64", "2001:db8::", true},
+		{"L32", "10.1.2.0", false},
+		{"L32", "2001:db8::1", true},
+		{"LP", "l64-subnet1.example.com.", false},
+		{"LP", "l64-subnet1", false},
+		{"LP", "host", true},
+	}
+	for _, tst := range tests {
+		rc := &models.RecordConfig{Type: tst.rtype, Metadata: map[string]string{}}
+		rc.SetLabel("host", "example.com")
+		rc.SetTargetILNP(10, tst.target)
+		if errs := checkTargets(rc, "example.com"); (len(errs) != 0) != tst.isError {
+			t.Errorf("checkTargets(%s %q) = %v, expected error=%v", tst.rtype, tst.target, errs, tst.isError)
+		}
+	}
+}
+
+func TestCheckDHCID(t *testing.T) {
+	tests := []struct {
+		target  string
+		isError bool
+	}{
+		{"AAIBY2/AuCccgoJbsaxcQc9TUapptP69lOjxfNuVAA2kjEA=", false}, // RFC 4701, section 3.6
+		{"AAEBOSD+XR3Os/0LozeXVqcNc7FwCfQdWL3b/NaiUDlW2No=", false},
+		{"AAAAAAAA", false}, // unassigned digest type: any length
+		{"", true},
+		{"ABCDEFG", true},  // not base64
+		{"AAIB", true},     // no digest
+		{"AAIBY2/A", true}, // SHA-256 digest too short
+	}
+	for _, tst := range tests {
+		if err := checkDHCID(tst.target); (err != nil) != tst.isError {
+			t.Errorf("checkDHCID(%q) = %v, expected error=%v", tst.target, err, tst.isError)
+		}
+	}
+}
+
+func TestCheckSoaTimers(t *testing.T) {
+	tests := []struct {
+		refresh, retry, expire uint32
+		want                   string // substring of the warning; "" for none
+	}{
+		{3600, 600, 604800, ""},
+		{3600, 3600, 604800, "the retry (3600) should be less than the refresh (3600)"},
+		{3600, 600, 3600, "the expire (3600) should be more than the refresh (3600)"},
+	}
+	for _, tst := range tests {
+		rc := makeRC("@", "example.com", "ns1.example.com.", models.RecordConfig{Type: "SOA",
+			SoaRefresh: tst.refresh, SoaRetry: tst.retry, SoaExpire: tst.expire, SoaMinttl: 300, SoaMbox: "hostmaster.example.com."})
+		err := checkSoaTimers(rc)
+		if tst.want == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", tst, err)
+			}
+			continue
+		}
+		if _, ok := err.(Warning); !ok || !strings.Contains(err.Error(), tst.want) {
+			t.Errorf("%v: got %v, want a warning containing %q", tst, err, tst.want)
+		}
+	}
+}
+
+func TestCheckProviderCapabilitiesSOA(t *testing.T) {
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			makeRC("@", "example.com", "ns1.example.com.", models.RecordConfig{Type: "SOA"}),
+		},
+		DNSProviderInstances: []*models.DNSProviderInstance{
+			{ProviderBase: models.ProviderBase{Name: "plain", ProviderType: ProviderNoDS}},
+		},
+	}
+	errs := checkProviderCapabilities(dc, false)
+	if len(errs) != 1 {
+		t.Fatalf("got %v, want one warning", errs)
+	}
+	if _, ok := errs[0].(Warning); !ok || !strings.Contains(errs[0].Error(), "manages them itself") {
+		t.Errorf("got %v, want a warning that the SOA is ignored", errs[0])
+	}
+}
+
+func TestCheckProviderCapabilitiesDropUnsupported(t *testing.T) {
+	makeDC := func(providerTypes ...string) *models.DomainConfig {
+		dc := &models.DomainConfig{
+			Name: "example.com",
+			Records: models.Records{
+				makeRC("@", "example.com", "1.2.3.4", models.RecordConfig{Type: "A"}),
+				makeRC("sub", "example.com", "", models.RecordConfig{Type: "DS", DsKeyTag: 1, DsAlgorithm: 13, DsDigestType: 2, DsDigest: "abcd"}),
+			},
+		}
+		for i, pt := range providerTypes {
+			dc.DNSProviderInstances = append(dc.DNSProviderInstances,
+				&models.DNSProviderInstance{ProviderBase: models.ProviderBase{Name: fmt.Sprintf("p%d", i), ProviderType: pt}})
+		}
+		return dc
+	}
+
+	isWarning := func(err error) bool {
+		_, ok := err.(Warning)
+		return ok
+	}
+	if errs := checkProviderCapabilities(makeDC(ProviderNoDS, ProviderFullDS), false); len(errs) != 1 || isWarning(errs[0]) {
+		t.Fatalf("without --drop-unsupported: got %v, want one error", errs)
+	}
+
+	dc := makeDC(ProviderNoDS, ProviderFullDS)
+	errs := checkProviderCapabilities(dc, true)
+	if len(errs) != 1 || !isWarning(errs[0]) || !strings.Contains(errs[0].Error(), "1 dropped there") {
+		t.Fatalf("two providers: got %v, want one warning", errs)
+	}
+	if len(dc.Records) != 2 || dc.Records[1].Metadata["only_providers"] != "p1" {
+		t.Errorf("two providers: the DS record should be limited to p1, got %v", dc.Records)
+	}
+
+	dc = makeDC(ProviderNoDS)
+	errs = checkProviderCapabilities(dc, true)
+	if len(errs) != 1 || !isWarning(errs[0]) {
+		t.Fatalf("one provider: got %v, want one warning", errs)
+	}
+	if len(dc.Records) != 1 || dc.Records[0].Type != "A" {
+		t.Errorf("one provider: the DS record should be removed, got %v", dc.Records)
+	}
+}
+
+func TestCheckTargetsAFSDBRP(t *testing.T) {
+	tests := []struct {
+		rc      *models.RecordConfig
+		isError bool
+	}{
+		{makeRC("@", "example.com", "afsdb1", models.RecordConfig{Type: "AFSDB", AfsdbSubtype: 1}), false},
+		{makeRC("@", "example.com", "dce.example.net.", models.RecordConfig{Type: "AFSDB", AfsdbSubtype: 2}), false},
+		{makeRC("@", "example.com", "afsdb1", models.RecordConfig{Type: "AFSDB", AfsdbSubtype: 3}), true},
+		{makeRC("@", "example.com", "afsdb1.example.com", models.RecordConfig{Type: "AFSDB", AfsdbSubtype: 1}), true},
+		{makeRC("@", "example.com", "hostmaster.example.com.", models.RecordConfig{Type: "RP", RpTxt: "contact"}), false},
+		{makeRC("@", "example.com", ".", models.RecordConfig{Type: "RP", RpTxt: "."}), false},
+		{makeRC("@", "example.com", "hostmaster@example.com", models.RecordConfig{Type: "RP", RpTxt: "."}), true},
+		{makeRC("@", "example.com", "hostmaster.example.com.", models.RecordConfig{Type: "RP", RpTxt: "contact.example.com"}), true},
+	}
+	for _, tst := range tests {
+		errs := checkTargets(tst.rc, "example.com")
+		if (len(errs) != 0) != tst.isError {
+			t.Errorf("%s %s %d %s: got %v, expected error=%v", tst.rc.Type, tst.rc.GetTargetField(), tst.rc.AfsdbSubtype, tst.rc.RpTxt, errs, tst.isError)
+		}
+	}
+}
+
+func TestCheckHIP(t *testing.T) {
+	const hit = "200100107B1A74DF365639CC39F1D578"
+	const pk = "AwEAAbdxyhNuSutc5EMzxTs9LBPCIkOFH8cIvM4p9+LrV4e19WzK00+CI6zBCQTdtWsuxKbWIy87UOoJTwkUs7lBu+Upr1gsNrut79ryra+bSRGQb1slImA8YVJyuIDsj7kwzG7jnERNqnWxZ48AWkskmdHaVDP4BcelrTI3rMXdXF5D"
+	tests := []struct {
+		algorithm uint8
+		hit, pk   string
+		servers   []string
+		isError   bool
+	}{
+		{2, hit, pk, nil, false},
+		{4, hit, pk, []string{"rvs", "rvs.example.net."}, false},
+		{0, hit, pk, nil, true},
+		{5, hit, pk, nil, true},
+		{2, "", pk, nil, true},
+		{2, "20010010X", pk, nil, true},
+		{2, hit[:31], pk, nil, true},
+		{2, hit, "", nil, true},
+		{2, hit, "not base64!", nil, true},
+		{2, hit, pk, []string{"rvs.example.net"}, true},
+	}
+	for _, tst := range tests {
+		rc := &models.RecordConfig{Type: "HIP"}
+		rc.SetTargetHIP(tst.algorithm, tst.hit, tst.pk, tst.servers)
+		if errs := checkHIP(rc); (len(errs) != 0) != tst.isError {
+			t.Errorf("checkHIP(%d %q %q %v) = %v, expected error=%v", tst.algorithm, tst.hit, tst.pk, tst.servers, errs, tst.isError)
+		}
+	}
+}
@@ -46,6 +46,13 @@ func MostCommonTTL(records models.Records) uint32 {
 
 // WriteZoneFileRC writes a beautifully formatted zone file.
 func WriteZoneFileRC(w io.Writer, records models.Records, origin string, defaultTTL uint32, comments []string) error {
+	return WriteZoneFileRCOrdered(w, records, origin, defaultTTL, comments, nil)
+}
+
+// WriteZoneFileRCOrdered is like WriteZoneFileRC but writes the records
+// in the given order (nil means DefaultRecordOrder). The order only
+// affects presentation, never the meaning of the zone.
+func WriteZoneFileRCOrdered(w io.Writer, records models.Records, origin string, defaultTTL uint32, comments []string, order *RecordOrder) error {
 	// This function prioritizes beauty over output size.
 	// * The zone records are sorted by label, grouped by subzones to
 	//   be easy to read and pleasant to the eye.
@@ -62,6 +69,7 @@ func WriteZoneFileRC(w io.Writer, records models.Records, origin string, default
 	}
 
 	z := PrettySort(records, origin, defaultTTL, comments)
+	z.Order = order
 
 	return z.generateZoneFileHelper(w)
 }
@@ -607,11 +607,11 @@ func TestZoneRrtypeLess(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		actual := zoneRrtypeLess(test.e1, test.e2)
+		actual := DefaultRecordOrder.rrtypeLess(test.e1, test.e2)
 		if test.expected != actual {
 			t.Errorf("%v: expected (%v) got (%v)\n", test.e1, test.e2, actual)
 		}
-		actual = zoneRrtypeLess(test.e2, test.e1)
+		actual = DefaultRecordOrder.rrtypeLess(test.e2, test.e1)
 		// The reverse should work too:
 		var expected bool
 		if test.e1 == test.e2 {
@@ -624,3 +624,86 @@ func TestZoneRrtypeLess(t *testing.T) {
 		}
 	}
 }
+
+func TestParseRecordOrder(t *testing.T) {
+	tests := []struct {
+		given   string
+		byType  bool
+		types   []string
+		wantErr bool
+	}{
+		{"", false, DefaultRecordOrder.Types, false},
+		{"name", false, DefaultRecordOrder.Types, false},
+		{"type", true, DefaultRecordOrder.Types, false},
+		{"name:NS,mx", false, []string{"NS", "MX"}, false},
+		{"type:SOA, NS ,TXT", true, []string{"SOA", "NS", "TXT"}, false},
+		{"MX,A", true, []string{"MX", "A"}, false},
+		{"label:A", false, nil, true},
+		{"type:A,,MX", false, nil, true},
+		{"A,MX,a", false, nil, true},
+	}
+	for _, tst := range tests {
+		t.Run(tst.given, func(t *testing.T) {
+			got, err := ParseRecordOrder(tst.given)
+			if (err != nil) != tst.wantErr {
+				t.Fatalf("ParseRecordOrder(%q) error = %v, wantErr %v", tst.given, err, tst.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.ByType != tst.byType || strings.Join(got.Types, ",") != strings.Join(tst.types, ",") {
+				t.Errorf("ParseRecordOrder(%q) = %+v, want ByType=%v Types=%v", tst.given, got, tst.byType, tst.types)
+			}
+		})
+	}
+}
+
+func TestWriteZoneFileRecordOrder(t *testing.T) {
+	r1, _ := dns.NewRR("bosun.org. 300 IN A 192.30.252.153")
+	r2, _ := dns.NewRR("bosun.org. 300 IN MX 10 mx.bosun.org.")
+	r3, _ := dns.NewRR("mx.bosun.org. 300 IN A 192.30.252.155")
+	r4, _ := dns.NewRR("www.bosun.org. 300 IN CNAME bosun.org.")
+	recs, err := rrstoRCs([]dns.RR{r1, r2, r3, r4}, "bosun.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		order    string
+		expected string
+	}{
+		{"type", `$TTL 300
+www              IN CNAME bosun.org.
+@                IN A     192.30.252.153
+mx               IN A     192.30.252.155
+@                IN MX    10 mx.bosun.org.
+`},
+		{"type:MX,A", `$TTL 300
+@                IN MX    10 mx.bosun.org.
+                 IN A     192.30.252.153
+mx               IN A     192.30.252.155
+www              IN CNAME bosun.org.
+`},
+		{"name:MX", `$TTL 300
+@                IN MX    10 mx.bosun.org.
+                 IN A     192.30.252.153
+mx               IN A     192.30.252.155
+www              IN CNAME bosun.org.
+`},
+	}
+	for _, tst := range tests {
+		t.Run(tst.order, func(t *testing.T) {
+			order, err := ParseRecordOrder(tst.order)
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf := &bytes.Buffer{}
+			WriteZoneFileRCOrdered(buf, recs, "bosun.org", 0, nil, order)
+			if buf.String() != tst.expected {
+				t.Log(buf.String())
+				t.Log(tst.expected)
+				t.Fatalf("Zone file does not match.")
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package prettyzone
 
 import (
 	"bytes"
+	"fmt"
 	"log"
 	"strconv"
 	"strings"
@@ -18,12 +19,79 @@ type ZoneGenData struct {
 	DefaultTTL uint32
 	Records    models.Records
 	Comments   []string
+	Order      *RecordOrder // nil means DefaultRecordOrder
+}
+
+// RecordOrder controls the order in which records are written.
+type RecordOrder struct {
+	ByType bool     // Group records by type first, then by name.
+	Types  []string // Rtypes in this order come first; the rest follow alphabetically.
+}
+
+// DefaultRecordOrder groups records by name. Within a name, SOA and NS
+// records come first.
+var DefaultRecordOrder = &RecordOrder{
+	Types: []string{"SOA", "NS", "CNAME", "A", "AAAA", "MX", "SRV", "TXT"},
+}
+
+// ParseRecordOrder parses a record order specification:
+//
+//	name            group by name (the default)
+//	type            group by type
+//	name:T1,T2,...  group by name; within a name, list types T1, T2, ... first
+//	type:T1,T2,...  group by type, listing types T1, T2, ... first
+//	T1,T2,...       same as type:T1,T2,...
+//
+// When no list is given, the types of DefaultRecordOrder are used.
+func ParseRecordOrder(s string) (*RecordOrder, error) {
+	s = strings.TrimSpace(s)
+	group, list, hasList := strings.Cut(s, ":")
+	switch {
+	case s == "" || s == "name":
+		return DefaultRecordOrder, nil
+	case s == "type":
+		return &RecordOrder{ByType: true, Types: DefaultRecordOrder.Types}, nil
+	case !hasList:
+		// A bare list of types.
+		group, list = "type", s
+	}
+	if group != "name" && group != "type" {
+		return nil, fmt.Errorf("record order %q: expected \"name\" or \"type\" before \":\"", s)
+	}
+
+	order := &RecordOrder{ByType: group == "type"}
+	seen := map[string]bool{}
+	for _, t := range strings.Split(list, ",") {
+		t = strings.ToUpper(strings.TrimSpace(t))
+		if t == "" {
+			return nil, fmt.Errorf("record order %q: empty type in list", s)
+		}
+		if seen[t] {
+			return nil, fmt.Errorf("record order %q: %s is listed twice", s, t)
+		}
+		seen[t] = true
+		order.Types = append(order.Types, t)
+	}
+	return order, nil
+}
+
+func (z *ZoneGenData) order() *RecordOrder {
+	if z.Order == nil {
+		return DefaultRecordOrder
+	}
+	return z.Order
 }
 
 func (z *ZoneGenData) Len() int      { return len(z.Records) }
 func (z *ZoneGenData) Swap(i, j int) { z.Records[i], z.Records[j] = z.Records[j], z.Records[i] }
 func (z *ZoneGenData) Less(i, j int) bool {
 	a, b := z.Records[i], z.Records[j]
+	order := z.order()
+
+	// When grouping by type, sort by type first.
+	if order.ByType && a.Type != b.Type {
+		return order.rrtypeLess(a.Type, b.Type)
+	}
 
 	// Sort by name.
 
@@ -41,7 +109,7 @@ func (z *ZoneGenData) Less(i, j int) bool {
 
 	// sub-sort by type
 	if a.Type != b.Type {
-		return zoneRrtypeLess(a.Type, b.Type)
+		return order.rrtypeLess(a.Type, b.Type)
 	}
 
 	// sub-sort within type:
@@ -60,12 +128,24 @@ func (z *ZoneGenData) Less(i, j int) bool {
 			log.Fatalf("should not happen: IPs are not 16 bytes: %#v %#v", ta2, tb2)
 		}
 		return bytes.Compare(ipa, ipb) == -1
+	case "AFSDB":
+		// sort by subtype. If they are equal, sort by hostname.
+		if a.AfsdbSubtype == b.AfsdbSubtype {
+			return a.GetTargetField() < b.GetTargetField()
+		}
+		return a.AfsdbSubtype < b.AfsdbSubtype
 	case "MX":
 		// sort by priority. If they are equal, sort by Mx.
 		if a.MxPreference == b.MxPreference {
 			return a.GetTargetField() < b.GetTargetField()
 		}
 		return a.MxPreference < b.MxPreference
+	case "L32", "L64", "LP", "NID":
+		// sort by preference. If they are equal, sort by target.
+		if a.IlnpPreference == b.IlnpPreference {
+			return a.GetTargetField() < b.GetTargetField()
+		}
+		return a.IlnpPreference < b.IlnpPreference
 	case "SRV":
 		//ta2, tb2 := a.(*dns.SRV), b.(*dns.SRV)
 		pa, pb := a.SrvPort, b.SrvPort
@@ -197,18 +277,17 @@ func LabelLess(a, b string) bool {
 	return ia < ib
 }
 
-func zoneRrtypeLess(a, b string) bool {
+func (o *RecordOrder) rrtypeLess(a, b string) bool {
 	// Compare two RR types for the purpose of sorting the RRs in a Zone.
 
 	if a == b {
 		return false
 	}
 
-	// List SOAs, NSs, etc. then all others alphabetically.
+	// List the types in order (by default SOAs, NSs, etc.) then all
+	// others alphabetically.
 
-	for _, t := range []string{"SOA", "NS", "CNAME",
-		"A", "AAAA", "MX", "SRV", "TXT",
-	} {
+	for _, t := range o.Types {
 		if a == t {
 			return true
 		}
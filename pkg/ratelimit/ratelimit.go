@@ -0,0 +1,283 @@
+// Package ratelimit provides an http.RoundTripper that paces requests
+// using the rate-limit headers that many DNS provider APIs return.
+//
+// The headers understood are:
+//
+//	Retry-After                              (on 429 responses)
+//	X-RateLimit-Limit / -Remaining / -Reset
+//	RateLimit-Limit / -Remaining / -Reset    (IETF draft names)
+//
+// Requests to a host are sent without delay while more than half of the
+// quota remains. After that they are spread evenly over the time left until
+// the quota resets. When the quota is exhausted, requests wait for the
+// reset. A 429 response is retried after Retry-After, if the request can
+// be replayed. No wait is longer than MaxDelay, and the waits end when the
+// request or the run (--timeout) is canceled.
+//
+// Only the clients that send through http.DefaultTransport (see Install)
+// are paced. The providers whose SDK brings its own HTTP transport, such
+// as ROUTE53, AZURE_DNS, AZURE_PRIVATE_DNS, GCLOUD, ORACLE and
+// HUAWEICLOUD, are not; they rely on the SDK's own retries, if any.
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
+)
+
+// MaxRetries is the number of times a request that was rate-limited is
+// retried before the 429 response is returned to the caller.
+var MaxRetries = 5
+
+// MaxDelay is the longest a request waits. A 429 response whose
+// Retry-After is longer is returned to the caller instead of retried, and
+// the pacing of the other requests waits at most this long.
+var MaxDelay = 5 * time.Minute
+
+// Transport wraps another http.RoundTripper and paces requests per host.
+type Transport struct {
+	Base http.RoundTripper // nil means http.DefaultTransport
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+
+	now   func() time.Time
+	sleep func(context.Context, time.Duration) error
+}
+
+type hostState struct {
+	mu          sync.Mutex    // guards the fields; never held while sleeping
+	next        time.Time     // don't send before this time
+	interval    time.Duration // between the paced requests, so that they don't all fire at once
+	retryOnFail time.Duration
+}
+
+// NewTransport returns a Transport that sends requests via base.
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base, now: time.Now, sleep: sleep}
+}
+
+// Install wraps http.DefaultTransport so that http.DefaultClient, and any
+// client without its own Transport, is paced.
+func Install() {
+	if _, ok := http.DefaultTransport.(*Transport); ok {
+		return
+	}
+	http.DefaultTransport = NewTransport(http.DefaultTransport)
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+func (t *Transport) host(name string) *hostState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.hosts == nil {
+		t.hosts = map[string]*hostState{}
+	}
+	h, ok := t.hosts[name]
+	if !ok {
+		h = &hostState{}
+		t.hosts[name] = h
+	}
+	return h
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	h := t.host(req.URL.Host)
+
+	for attempt := 0; ; attempt++ {
+		if err := t.wait(req.Context(), h); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base().RoundTrip(req)
+		if err != nil {
+			return resp, err
+		}
+
+		limited := resp.StatusCode == http.StatusTooManyRequests
+		h.mu.Lock()
+		delay := t.update(h, resp.Header, limited)
+		h.mu.Unlock()
+
+		if !limited || attempt >= MaxRetries || delay > MaxDelay {
+			return resp, nil
+		}
+		retry, ok := rewind(req)
+		if !ok {
+			return resp, nil
+		}
+		printer.Debugf("Rate-limited by %s; retrying in %v\n", req.URL.Host, delay)
+		resp.Body.Close()
+		req = retry
+	}
+}
+
+// wait sleeps until h permits the next request, for at most MaxDelay. The
+// slot is reserved before sleeping, by moving h.next one interval past it,
+// so that the concurrent requests to the host take the following slots.
+func (t *Transport) wait(ctx context.Context, h *hostState) error {
+	h.mu.Lock()
+	now := t.now()
+	at := h.next
+	if at.Before(now) {
+		at = now
+	}
+	if at.Sub(now) > MaxDelay {
+		at = now.Add(MaxDelay)
+	}
+	h.next = later(h.next, at.Add(h.interval))
+	h.mu.Unlock()
+
+	if d := at.Sub(now); d > 0 {
+		return t.sleep(ctx, d)
+	}
+	return nil
+}
+
+// later returns the later of a and b.
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// sleep waits for d, unless ctx or the run is canceled first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	run := runctx.Context()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-run.Done():
+		return run.Err()
+	}
+}
+
+// update records what the response headers say about the quota and
+// returns how long the next request must wait. It never moves h.next
+// backwards: a concurrent response without a hint doesn't erase the
+// Retry-After of a 429.
+func (t *Transport) update(h *hostState, header http.Header, limited bool) time.Duration {
+	now := t.now()
+	var delay time.Duration
+
+	if limited {
+		var ok bool
+		delay, ok = retryAfter(header, now)
+		if !ok {
+			// No hint; back off exponentially, starting at one second.
+			if h.retryOnFail == 0 {
+				h.retryOnFail = time.Second
+			} else {
+				h.retryOnFail *= 2
+			}
+			delay = h.retryOnFail
+		}
+		h.next = later(h.next, now.Add(delay))
+		return delay
+	}
+	h.retryOnFail = 0
+
+	remaining, ok := headerInt(header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	if !ok {
+		h.interval = 0
+		h.next = later(h.next, now)
+		return 0
+	}
+	reset := resetIn(header, now)
+	limit, ok := headerInt(header, "X-RateLimit-Limit", "RateLimit-Limit")
+	if !ok {
+		limit = 2 * remaining
+	}
+
+	h.interval = 0
+	switch {
+	case remaining <= 0:
+		// Quota exhausted. Wait until it resets.
+		delay = reset
+	case remaining > limit/2:
+		// Burst through half of the quota, ...
+		delay = 0
+	default:
+		// ... then spread requests evenly throughout the window.
+		delay = reset / time.Duration(remaining+1)
+		h.interval = delay
+	}
+	h.next = later(h.next, now.Add(delay))
+	return delay
+}
+
+// rewind returns a copy of req that can be sent again.
+func rewind(req *http.Request) (*http.Request, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	r := req.Clone(req.Context())
+	r.Body = body
+	return r, true
+}
+
+// retryAfter parses Retry-After, which is either a number of seconds
+// or an HTTP date.
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.ParseInt(v, 10, 64); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// resetIn returns the time until the quota resets. The reset header is a
+// number of seconds, except that some APIs send a Unix timestamp instead.
+func resetIn(header http.Header, now time.Time) time.Duration {
+	v, ok := headerInt(header, "X-RateLimit-Reset", "RateLimit-Reset")
+	if !ok || v < 0 {
+		return 0
+	}
+	if v > 1_000_000_000 {
+		return max(time.Unix(v, 0).Sub(now), 0)
+	}
+	return time.Duration(v) * time.Second
+}
+
+// headerInt returns the value of the first of names that is set and numeric.
+func headerInt(header http.Header, names ...string) (int64, bool) {
+	for _, name := range names {
+		if v := header.Get(name); v != "" {
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
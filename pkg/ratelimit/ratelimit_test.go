@@ -0,0 +1,251 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
+)
+
+// fakeClock records sleeps instead of sleeping.
+type fakeClock struct {
+	t      time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+func (c *fakeClock) sleep(_ context.Context, d time.Duration) error {
+	c.sleeps = append(c.sleeps, d)
+	c.t = c.t.Add(d)
+	return nil
+}
+
+func newTestTransport() (*Transport, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(1700000000, 0)}
+	tr := NewTransport(http.DefaultTransport)
+	tr.now = clock.now
+	tr.sleep = clock.sleep
+	return tr, clock
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		limited bool
+		want    time.Duration
+	}{
+		{"no headers", nil, false, 0},
+		{"plenty left", map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "80", "X-RateLimit-Reset": "60"}, false, 0},
+		{"half left", map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "49", "X-RateLimit-Reset": "50"}, false, time.Second},
+		{"exhausted", map[string]string{"RateLimit-Limit": "10", "RateLimit-Remaining": "0", "RateLimit-Reset": "30"}, false, 30 * time.Second},
+		{"reset as timestamp", map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1700000020"}, false, 20 * time.Second},
+		{"429 seconds", map[string]string{"Retry-After": "7"}, true, 7 * time.Second},
+		{"429 date", map[string]string{"Retry-After": "Tue, 14 Nov 2023 22:13:25 GMT"}, true, 5 * time.Second},
+		{"429 no hint", nil, true, time.Second},
+	}
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			tr, clock := newTestTransport()
+			h := http.Header{}
+			for k, v := range tst.headers {
+				h.Set(k, v)
+			}
+			hs := &hostState{}
+			if got := tr.update(hs, h, tst.limited); got != tst.want {
+				t.Errorf("update() = %v, want %v", got, tst.want)
+			}
+			if got := hs.next.Sub(clock.t); got != tst.want {
+				t.Errorf("next request in %v, want %v", got, tst.want)
+			}
+		})
+	}
+}
+
+func TestRoundTripRetries429(t *testing.T) {
+	calls := 0
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if calls < 3 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "10")
+	}))
+	defer srv.Close()
+
+	tr, clock := newTestTransport()
+	client := &http.Client{Transport: tr}
+
+	resp, err := client.Post(srv.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if calls != 3 {
+		t.Errorf("server saw %d calls, want 3", calls)
+	}
+	for i, b := range bodies {
+		if b != "payload" {
+			t.Errorf("call %d: body = %q, want %q", i, b, "payload")
+		}
+	}
+
+	// The quota is now exhausted, so the next request waits for the reset.
+	resp, err = client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	want := []time.Duration{2 * time.Second, 2 * time.Second, 10 * time.Second}
+	if len(clock.sleeps) != len(want) {
+		t.Fatalf("sleeps = %v, want %v", clock.sleeps, want)
+	}
+	for i := range want {
+		if clock.sleeps[i] != want[i] {
+			t.Errorf("sleeps = %v, want %v", clock.sleeps, want)
+			break
+		}
+	}
+}
+
+func TestRoundTripGivesUp(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	tr, _ := newTestTransport()
+	resp, err := (&http.Client{Transport: tr}).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want 429", resp.StatusCode)
+	}
+	if calls != MaxRetries+1 {
+		t.Errorf("server saw %d calls, want %d", calls, MaxRetries+1)
+	}
+}
+
+func TestRoundTripLongRetryAfter(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "86400")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	tr, clock := newTestTransport()
+	client := &http.Client{Transport: tr}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || calls != 1 {
+		t.Errorf("status = %d after %d calls, want 429 after 1", resp.StatusCode, calls)
+	}
+
+	// The next request waits no longer than MaxDelay.
+	resp, err = client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(clock.sleeps) != 1 || clock.sleeps[0] != MaxDelay {
+		t.Errorf("sleeps = %v, want [%v]", clock.sleeps, MaxDelay)
+	}
+}
+
+func TestSleepCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("sleep() = %v, want %v", err, context.Canceled)
+	}
+
+	run, stop := context.WithTimeout(context.Background(), time.Millisecond)
+	defer stop()
+	defer runctx.Set(run)()
+	if err := sleep(context.Background(), time.Hour); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("sleep() after the run timed out = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestUpdateKeepsRetryAfter(t *testing.T) {
+	tr, clock := newTestTransport()
+	hs := &hostState{}
+	h := http.Header{}
+	h.Set("Retry-After", "30")
+	tr.update(hs, h, true)
+	// A concurrent response without rate-limit headers doesn't erase it.
+	tr.update(hs, http.Header{}, false)
+	if got := hs.next.Sub(clock.t); got != 30*time.Second {
+		t.Errorf("next request in %v, want 30s", got)
+	}
+}
+
+func TestWaitReservesSlots(t *testing.T) {
+	tr, clock := newTestTransport()
+	hs := &hostState{next: clock.t.Add(time.Second), interval: 2 * time.Second}
+	for i := 0; i < 3; i++ {
+		if err := tr.wait(context.Background(), hs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The fake clock advances by each sleep: 1s, then 2s, then 2s.
+	want := []time.Duration{time.Second, 2 * time.Second, 2 * time.Second}
+	if len(clock.sleeps) != len(want) {
+		t.Fatalf("sleeps = %v, want %v", clock.sleeps, want)
+	}
+	for i := range want {
+		if clock.sleeps[i] != want[i] {
+			t.Errorf("sleeps = %v, want %v", clock.sleeps, want)
+			break
+		}
+	}
+}
+
+func TestWaitDoesNotHoldTheLock(t *testing.T) {
+	tr, _ := newTestTransport()
+	hs := &hostState{}
+	tr.sleep = func(context.Context, time.Duration) error {
+		// A response to the same host arrives while this request sleeps.
+		done := make(chan struct{})
+		go func() {
+			hs.mu.Lock()
+			tr.update(hs, http.Header{}, false)
+			hs.mu.Unlock()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Error("update blocked while a request was waiting")
+		}
+		return nil
+	}
+	hs.next = tr.now().Add(time.Second)
+	if err := tr.wait(context.Background(), hs); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,101 @@
+// Package runctx holds the context of the running command, which is
+// canceled when its --timeout expires.
+//
+// The commands pass their context explicitly, down to the calls of
+// pkg/zonerecs and the corrections. The providers, whose methods don't
+// take one, pass Context() to the calls of their API clients instead of
+// context.Background(). As a last resort, Install wraps
+// http.DefaultTransport so that the requests of the clients that use it
+// are canceled too, even if they were made without a context.
+package runctx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+var (
+	mu  sync.Mutex
+	ctx = context.Background()
+)
+
+// Context returns the context of the running command.
+func Context() context.Context {
+	mu.Lock()
+	defer mu.Unlock()
+	return ctx
+}
+
+// Set makes Context() return c, the context of the running command, and
+// returns the function that restores the previous one.
+func Set(c context.Context) (restore func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	prev := ctx
+	ctx = c
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		ctx = prev
+	}
+}
+
+// Transport wraps another http.RoundTripper and cancels the requests when
+// Context() is done.
+type Transport struct {
+	Base http.RoundTripper // nil means http.DefaultTransport
+}
+
+// Install wraps http.DefaultTransport so that http.DefaultClient, and any
+// client without its own Transport, is canceled by the timeout.
+func Install() {
+	if _, ok := http.DefaultTransport.(*Transport); ok {
+		return
+	}
+	http.DefaultTransport = &Transport{Base: http.DefaultTransport}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	run := Context()
+	if run.Done() == nil {
+		return base.RoundTrip(req)
+	}
+	if err := run.Err(); err != nil {
+		return nil, err
+	}
+	// The request is canceled by its own context or by the run's,
+	// whichever is done first. The response body may be read after
+	// RoundTrip returns, so the merged context isn't released here.
+	c, cancel := context.WithCancelCause(req.Context())
+	stop := context.AfterFunc(run, func() { cancel(context.Cause(run)) })
+	resp, err := base.RoundTrip(req.WithContext(c))
+	if err != nil {
+		stop()
+		cancel(nil)
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{resp.Body, func() { stop(); cancel(nil) }}
+	return resp, nil
+}
+
+// cancelOnClose releases the context of a request when its response body
+// is closed.
+type cancelOnClose struct {
+	body  io.ReadCloser
+	close func()
+}
+
+func (b *cancelOnClose) Read(p []byte) (int, error) { return b.body.Read(p) }
+
+func (b *cancelOnClose) Close() error {
+	err := b.body.Close()
+	b.close()
+	return err
+}
@@ -0,0 +1,59 @@
+package runctx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTransport(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hang" {
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+	defer close(release)
+	client := &http.Client{Transport: &Transport{}}
+
+	// Without a timeout, requests are passed through.
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(b) != "ok" {
+		t.Errorf("got %q, expected ok", b)
+	}
+
+	run, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	restore := Set(run)
+	start := time.Now()
+	if _, err := client.Get(srv.URL + "/hang"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("the request was canceled after %s", d)
+	}
+	// Once timed out, requests fail without being sent.
+	if _, err := client.Get(srv.URL); err == nil {
+		t.Errorf("a request after the timeout succeeded")
+	}
+
+	restore()
+	if Context().Err() != nil {
+		t.Errorf("Context() is still canceled once restored")
+	}
+}
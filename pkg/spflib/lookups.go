@@ -0,0 +1,104 @@
+package spflib
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// MaxLookups is the number of DNS lookups that an SPF evaluation may do
+// (RFC 7208 section 4.6.4). A record that needs more fails with a
+// "permerror".
+const MaxLookups = 10
+
+// LookupTree is an SPF term that causes DNS lookups at evaluation time.
+// For include: and redirect=, Children are the lookup terms of the
+// included record.
+type LookupTree struct {
+	Term     string // e.g. "include:_spf.google.com"; "" for the record itself
+	Lookups  int    // lookups caused by this term, including its children
+	Children []*LookupTree
+}
+
+// ResolveLookups resolves the includes and redirects of the SPF record
+// text, recursively, and returns the tree of terms that cause DNS lookups.
+// Unlike counting the terms of text, this finds the lookups of nested
+// includes.
+func ResolveLookups(text string, dnsres Resolver) (*LookupTree, error) {
+	return resolveLookups(text, dnsres, nil)
+}
+
+func resolveLookups(text string, dnsres Resolver, seen []string) (*LookupTree, error) {
+	rec, err := Parse(text, nil)
+	if err != nil {
+		return nil, err
+	}
+	tree := &LookupTree{}
+	for _, p := range rec.Parts {
+		if !p.IsLookup {
+			continue
+		}
+		child := &LookupTree{Term: p.Text, Lookups: 1}
+		if p.IncludeDomain != "" {
+			for _, s := range seen {
+				if s == p.IncludeDomain {
+					return nil, fmt.Errorf("include loop: %s -> %s", strings.Join(seen, " -> "), p.IncludeDomain)
+				}
+			}
+			sub, err := dnsres.GetSPF(p.IncludeDomain)
+			if err != nil {
+				return nil, err
+			}
+			subTree, err := resolveLookups(sub, dnsres, append(seen, p.IncludeDomain))
+			if err != nil {
+				return nil, fmt.Errorf("in %s: %w", p.IncludeDomain, err)
+			}
+			child.Children = subTree.Children
+			child.Lookups += subTree.Lookups
+		}
+		tree.Children = append(tree.Children, child)
+		tree.Lookups += child.Lookups
+	}
+	return tree, nil
+}
+
+// Write writes the tree to w, one term per line, indented by depth and
+// followed by its number of lookups.
+func (t *LookupTree) Write(w io.Writer, indent string) {
+	for _, c := range t.Children {
+		fmt.Fprintf(w, "%s%s (%d)\n", indent, c.Term, c.Lookups)
+		c.Write(w, indent+"  ")
+	}
+}
+
+// memoResolver remembers the results of another resolver, so that an
+// include shared by several records is only resolved once.
+type memoResolver struct {
+	inner Resolver
+
+	mu      sync.Mutex
+	results map[string]memoResult
+}
+
+type memoResult struct {
+	spf string
+	err error
+}
+
+// NewMemoResolver returns a Resolver that caches the results of inner for
+// its lifetime. Unlike NewCache, nothing is read from or written to disk.
+func NewMemoResolver(inner Resolver) Resolver {
+	return &memoResolver{inner: inner, results: map[string]memoResult{}}
+}
+
+func (m *memoResolver) GetSPF(name string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.results[name]
+	if !ok {
+		r.spf, r.err = m.inner.GetSPF(name)
+		m.results[name] = r
+	}
+	return r.spf, r.err
+}
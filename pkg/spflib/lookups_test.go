@@ -0,0 +1,82 @@
+package spflib
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+type mapResolver map[string]string
+
+func (m mapResolver) GetSPF(name string) (string, error) {
+	if v, ok := m[name]; ok {
+		return v, nil
+	}
+	return "", fmt.Errorf("%s has no SPF record", name)
+}
+
+func TestResolveLookups(t *testing.T) {
+	res := mapResolver{
+		"_spf.example.net": "v=spf1 include:_a.example.net include:_b.example.net ~all",
+		"_a.example.net":   "v=spf1 ip4:192.0.2.0/24 a mx ~all",
+		"_b.example.net":   "v=spf1 exists:%{i}.bl.example.net -all",
+		"other.example":    "v=spf1 redirect=_a.example.net",
+	}
+	tree, err := ResolveLookups("v=spf1 mx include:_spf.example.net include:other.example -all", res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	tree.Write(&buf, "")
+	want := `mx (1)
+include:_spf.example.net (6)
+  include:_a.example.net (3)
+    a (1)
+    mx (1)
+  include:_b.example.net (2)
+    exists:%{i}.bl.example.net (1)
+include:other.example (4)
+  redirect=_a.example.net (3)
+    a (1)
+    mx (1)
+`
+	if buf.String() != want {
+		t.Errorf("tree:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	if tree.Lookups != 11 {
+		t.Errorf("Lookups = %d, want 11", tree.Lookups)
+	}
+}
+
+func TestResolveLookupsErrors(t *testing.T) {
+	res := mapResolver{
+		"loop1.example": "v=spf1 include:loop2.example -all",
+		"loop2.example": "v=spf1 include:loop1.example -all",
+	}
+	for _, text := range []string{
+		"v=spf1 include:loop1.example -all",
+		"v=spf1 include:missing.example -all",
+	} {
+		if _, err := ResolveLookups(text, res); err == nil {
+			t.Errorf("ResolveLookups(%q): expected an error", text)
+		}
+	}
+}
+
+func TestMemoResolver(t *testing.T) {
+	calls := 0
+	inner := resolverFunc(func(name string) (string, error) {
+		calls++
+		return "v=spf1 -all", nil
+	})
+	m := NewMemoResolver(inner)
+	m.GetSPF("a.example")
+	m.GetSPF("a.example")
+	if calls != 1 {
+		t.Errorf("inner resolver called %d times, want 1", calls)
+	}
+}
+
+type resolverFunc func(string) (string, error)
+
+func (f resolverFunc) GetSPF(name string) (string, error) { return f(name) }
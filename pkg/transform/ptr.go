@@ -58,7 +58,9 @@ func ipv4magic(name, domain string) (string, error) {
 	return "", fmt.Errorf("PTR record %v in wrong IPv4 domain (%v)", name, domain)
 }
 
-var isRfc2317Format1 = regexp.MustCompile(`(\d{1,3})/(\d{1,3})\.(\d{1,3})\.(\d{1,3})\.(\d{1,3})\.in-addr\.arpa$`)
+// isRfc2317Format1 also matches the RFC4183 format ("128-27" instead of
+// "128/27") for masks of /25 and longer.
+var isRfc2317Format1 = regexp.MustCompile(`(\d{1,3})[/-](\d{1,3})\.(\d{1,3})\.(\d{1,3})\.(\d{1,3})\.in-addr\.arpa$`)
 
 // ipMatchesClasslessDomain returns true if ip is appropriate for domain.
 // domain is a reverse DNS lookup zone (in-addr.arpa) as described in RFC2317.
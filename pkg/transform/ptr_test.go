@@ -48,6 +48,11 @@ func TestPtrMagic(t *testing.T) {
 		{"172.20.18.191", "160/27.18.20.172.in-addr.arpa", "191", false},
 		{"172.20.18.192", "160/27.18.20.172.in-addr.arpa", "", true},
 
+		// RFC4183 (Classless)
+		{"172.20.18.159", "160-27.18.20.172.in-addr.arpa", "", true},
+		{"172.20.18.160", "160-27.18.20.172.in-addr.arpa", "160", false},
+		{"172.20.18.191", "160-27.18.20.172.in-addr.arpa", "191", false},
+
 		// If it doesn't end in .arpa, the magic is disabled:
 		{"1.2.3.4", "example.com", "1.2.3.4", false},
 		{"1", "example.com", "1", false},
@@ -0,0 +1,116 @@
+// Package ttlsource resolves the TTLs that are set with TTL_FROM(). Such a
+// record keeps its normal TTL in the IR, and the source (an environment
+// variable or a file) is read at push time, before the TTLs are checked
+// and the corrections are computed. If the source is empty, the normal TTL
+// is used.
+package ttlsource
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+// MetaKey is the record metadata that holds the source.
+const MetaKey = "ttl_from"
+
+// Check returns an error if source is not "env:NAME" or "file:PATH".
+func Check(source string) error {
+	kind, arg, ok := strings.Cut(source, ":")
+	if !ok || arg == "" || (kind != "env" && kind != "file") {
+		return fmt.Errorf(`TTL_FROM(%q): the source must be "env:NAME" or "file:PATH"`, source)
+	}
+	return nil
+}
+
+// Resolve reads source and returns the TTL it holds. ok is false if the
+// source is empty (an unset environment variable or an empty file).
+func Resolve(source string) (ttl uint32, ok bool, err error) {
+	if err := Check(source); err != nil {
+		return 0, false, err
+	}
+	kind, arg, _ := strings.Cut(source, ":")
+
+	var value string
+	switch kind {
+	case "env":
+		value = os.Getenv(arg)
+	case "file":
+		b, err := os.ReadFile(arg)
+		if err != nil {
+			return 0, false, fmt.Errorf("TTL_FROM(%q): %w", source, err)
+		}
+		value = string(b)
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false, nil
+	}
+	ttl, err = parseDuration(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("TTL_FROM(%q): %w", source, err)
+	}
+	return ttl, true, nil
+}
+
+// Apply sets the TTL of each record that has a TTL_FROM() source, and
+// removes the source, which has been read. It returns the records whose
+// TTL was read from their source.
+func Apply(records models.Records) (resolved models.Records, err error) {
+	for _, rc := range records {
+		source, ok := rc.Metadata[MetaKey]
+		if !ok {
+			continue
+		}
+		ttl, ok, err := Resolve(source)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", rc.GetLabelFQDN(), rc.Type, err)
+		}
+		delete(rc.Metadata, MetaKey)
+		if ok {
+			rc.TTL = ttl
+			resolved = append(resolved, rc)
+		}
+	}
+	return resolved, nil
+}
+
+// RelativeTo makes the relative "file:" sources of records relative to
+// dir, the directory of dnsconfig.js, instead of the current directory.
+func RelativeTo(records models.Records, dir string) {
+	for _, rc := range records {
+		source, ok := rc.Metadata[MetaKey]
+		if !ok {
+			continue
+		}
+		if path, ok := strings.CutPrefix(source, "file:"); ok && path != "" && !filepath.IsAbs(path) {
+			rc.Metadata[MetaKey] = "file:" + filepath.Join(dir, path)
+		}
+	}
+}
+
+var durationRE = regexp.MustCompile(`^(\d+)([smhdwny]?)$`)
+
+// parseDuration parses a TTL the same way as TTL() in dnsconfig.js: a
+// number of seconds, or a number with a unit.
+func parseDuration(s string) (uint32, error) {
+	m := durationRE.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("%q is not a valid TTL", s)
+	}
+	n, err := strconv.ParseUint(m[1], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid TTL", s)
+	}
+	unit := map[string]uint64{"": 1, "s": 1, "m": 60, "h": 3600, "d": 86400, "w": 7 * 86400, "n": 30 * 86400, "y": 365 * 86400}[m[2]]
+	ttl := n * unit
+	if ttl == 0 || ttl > 1<<31-1 {
+		return 0, fmt.Errorf("%q is not a valid TTL (must be between 1 and 2147483647 seconds)", s)
+	}
+	return uint32(ttl), nil
+}
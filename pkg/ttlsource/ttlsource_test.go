@@ -0,0 +1,85 @@
+package ttlsource
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	ttlFile := filepath.Join(dir, "ttl.txt")
+	if err := os.WriteFile(ttlFile, []byte("5m\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(emptyFile, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TTLSOURCE_TEST", "60")
+	t.Setenv("TTLSOURCE_BAD", "soon")
+
+	tests := []struct {
+		source  string
+		ttl     uint32
+		ok      bool
+		wantErr bool
+	}{
+		{"env:TTLSOURCE_TEST", 60, true, false},
+		{"env:TTLSOURCE_UNSET", 0, false, false},
+		{"env:TTLSOURCE_BAD", 0, false, true},
+		{"file:" + ttlFile, 300, true, false},
+		{"file:" + emptyFile, 0, false, false},
+		{"file:" + filepath.Join(dir, "missing.txt"), 0, false, true},
+		{"url:http://example.com", 0, false, true},
+		{"env:", 0, false, true},
+	}
+	for _, tst := range tests {
+		t.Run(tst.source, func(t *testing.T) {
+			ttl, ok, err := Resolve(tst.source)
+			if (err != nil) != tst.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tst.wantErr)
+			}
+			if ttl != tst.ttl || ok != tst.ok {
+				t.Errorf("Resolve() = %d, %v; want %d, %v", ttl, ok, tst.ttl, tst.ok)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Setenv("TTLSOURCE_TEST", "1h")
+	from := &models.RecordConfig{Type: "A", TTL: 300, Metadata: map[string]string{MetaKey: "env:TTLSOURCE_TEST"}}
+	unset := &models.RecordConfig{Type: "A", TTL: 300, Metadata: map[string]string{MetaKey: "env:TTLSOURCE_UNSET"}}
+	plain := &models.RecordConfig{Type: "A", TTL: 300}
+	resolved, err := Apply(models.Records{from, unset, plain})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from.TTL != 3600 || unset.TTL != 300 || plain.TTL != 300 {
+		t.Errorf("TTLs = %d, %d, %d; want 3600, 300, 300", from.TTL, unset.TTL, plain.TTL)
+	}
+	if len(resolved) != 1 || resolved[0] != from {
+		t.Errorf("resolved = %v, want only the record whose source is set", resolved)
+	}
+	if _, ok := from.Metadata[MetaKey]; ok {
+		t.Errorf("the source of a resolved record was not removed")
+	}
+}
+
+func TestRelativeTo(t *testing.T) {
+	abs := filepath.Join(t.TempDir(), "ttl.txt")
+	recs := models.Records{
+		{Metadata: map[string]string{MetaKey: "file:ttl.txt"}},
+		{Metadata: map[string]string{MetaKey: "file:" + abs}},
+		{Metadata: map[string]string{MetaKey: "env:TTL"}},
+	}
+	RelativeTo(recs, filepath.Join("config", "dns"))
+	for i, want := range []string{"file:" + filepath.Join("config", "dns", "ttl.txt"), "file:" + abs, "env:TTL"} {
+		if got := recs[i].Metadata[MetaKey]; got != want {
+			t.Errorf("source %d = %q, want %q", i, got, want)
+		}
+	}
+}
@@ -1,17 +1,62 @@
 package zonerecs
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 // CorrectZoneRecords calls both GetZoneRecords, does any
 // post-processing, and then calls GetZoneRecordsCorrections.  The
 // name sucks because all the good names were taken.
-func CorrectZoneRecords(driver models.DNSProvider, dc *models.DomainConfig) ([]*models.Correction, []*models.Correction, error) {
+//
+// The provider isn't called once ctx is done. The provider methods don't
+// take a context: the providers use runctx.Context().
+//
+// The string returned is the RecordsHash of the records at the provider,
+// which the plan and apply commands use to detect that a zone changed
+// between the two.
+func CorrectZoneRecords(ctx context.Context, driver models.DNSProvider, dc *models.DomainConfig) ([]*models.Correction, []*models.Correction, string, error) {
+	return correctZoneRecords(ctx, driver, dc, false)
+}
+
+// CorrectDelegationRecords is like CorrectZoneRecords but only the NS
+// and SOA records are changed. All other records are left as they are
+// at the provider, even if they differ from dc.Records.
+func CorrectDelegationRecords(ctx context.Context, driver models.DNSProvider, dc *models.DomainConfig) ([]*models.Correction, []*models.Correction, string, error) {
+	return correctZoneRecords(ctx, driver, dc, true)
+}
+
+// RecordsHash returns a hash of the records, which doesn't depend on the
+// order the provider returned them in.
+func RecordsHash(records models.Records) string {
+	lines := make([]string, 0, len(records))
+	for _, rc := range records {
+		lines = append(lines, fmt.Sprintf("%s %d %s %s", rc.NameFQDN, rc.TTL, rc.Type, rc.GetTargetCombined()))
+	}
+	sort.Strings(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func correctZoneRecords(ctx context.Context, driver models.DNSProvider, dc *models.DomainConfig, delegationOnly bool) ([]*models.Correction, []*models.Correction, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, "", err
+	}
 
 	existingRecords, err := driver.GetZoneRecords(dc.Name, dc.Metadata)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, "", err
 	}
 
 	// downcase
@@ -19,6 +64,7 @@ func CorrectZoneRecords(driver models.DNSProvider, dc *models.DomainConfig) ([]*
 	models.Downcase(dc.Records)
 	models.CanonicalizeTargets(existingRecords, dc.Name)
 	models.CanonicalizeTargets(dc.Records, dc.Name)
+	state := RecordsHash(existingRecords)
 
 	// Copy dc so that any corrections code that wants to
 	// modify the records may. For example, if the provider only
@@ -26,7 +72,30 @@ func CorrectZoneRecords(driver models.DNSProvider, dc *models.DomainConfig) ([]*
 	// dc.Records.
 	dc, err = dc.Copy()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, "", err
+	}
+
+	pName, pType := providerOf(driver, dc)
+	dc.Records = removeDisabledRecords(dc, existingRecords, pName)
+
+	dc.Records, existingRecords = removeOtherProvidersRecords(dc, existingRecords, pName)
+	if providers.ProviderHasCapability(pType, providers.ManagedApexRecords) {
+		dc.Records, existingRecords = removeManagedApex(dc, existingRecords, pType)
+	}
+	if pType != "" && !providers.ProviderHasCapability(pType, providers.CanUseSOA) {
+		// The provider manages the SOA itself; normalization warned
+		// about an SOA() in dnsconfig.js.
+		dc.Records = removeSOA(dc.Records)
+	}
+
+	if delegationOnly {
+		// Replace the desired non-delegation records with the existing
+		// ones. That way the diff finds no change for them, no matter
+		// if the provider updates by record, recordset or zone.
+		dc.Records, err = delegationRecords(dc, existingRecords)
+		if err != nil {
+			return nil, nil, "", err
+		}
 	}
 
 	// punycode
@@ -34,9 +103,12 @@ func CorrectZoneRecords(driver models.DNSProvider, dc *models.DomainConfig) ([]*
 	// FIXME(tlim) It is a waste to PunyCode every iteration.
 	// This should be moved to where the JavaScript is processed.
 
+	if err := ctx.Err(); err != nil {
+		return nil, nil, "", err
+	}
 	everything, err := driver.GetZoneRecordsCorrections(dc, existingRecords)
 	reports, corrections := splitReportsAndCorrections(everything)
-	return reports, corrections, err
+	return reports, corrections, state, err
 }
 
 func splitReportsAndCorrections(everything []*models.Correction) (reports, corrections []*models.Correction) {
@@ -49,3 +121,159 @@ func splitReportsAndCorrections(everything []*models.Correction) (reports, corre
 	}
 	return reports, corrections
 }
+
+// providerOf returns the name and type of the DNS provider of dc whose
+// driver is driver, or "", "" if there is none.
+func providerOf(driver models.DNSProvider, dc *models.DomainConfig) (name, pType string) {
+	if driver == nil || !reflect.TypeOf(driver).Comparable() {
+		return "", ""
+	}
+	for _, p := range dc.DNSProviderInstances {
+		if p.Driver == driver {
+			return p.Name, p.ProviderType
+		}
+	}
+	return "", ""
+}
+
+// removeOtherProvidersRecords handles ONLY_PROVIDERS(). The desired
+// records that are limited to other providers than pName are removed, and
+// so is any existing copy of them: at this provider they are unmanaged,
+// neither added nor deleted. If pName is unknown, nothing is removed.
+func removeOtherProvidersRecords(dc *models.DomainConfig, existing models.Records, pName string) (models.Records, models.Records) {
+	if pName == "" {
+		return dc.Records, existing
+	}
+	elsewhere := map[string]bool{}
+	var kept models.Records
+	for _, rec := range dc.Records {
+		only := rec.OnlyProviders()
+		if only == nil || slices.Contains(only, pName) {
+			kept = append(kept, rec)
+			continue
+		}
+		elsewhere[rec.GetLabel()+" "+rec.Type+" "+rec.ToComparableNoTTL()] = true
+		printer.Printf("Note: %s %s %s is only for %s; not managed at %s\n", rec.GetLabelFQDN(), rec.Type, rec.GetTargetCombined(), strings.Join(only, ", "), pName)
+	}
+	if len(elsewhere) == 0 {
+		return dc.Records, existing
+	}
+	var keptExisting models.Records
+	for _, rec := range existing {
+		if !elsewhere[rec.GetLabel()+" "+rec.Type+" "+rec.ToComparableNoTTL()] {
+			keptExisting = append(keptExisting, rec)
+		}
+	}
+	return kept, keptExisting
+}
+
+// removeDisabledRecords handles DISABLED(). The disabled records are
+// removed from the desired records, as if they were commented out of
+// dnsconfig.js: they are not created, and an existing copy is deleted.
+// A note is printed for each.
+func removeDisabledRecords(dc *models.DomainConfig, existing models.Records, pName string) models.Records {
+	var kept models.Records
+	var exists map[string]bool
+	for _, rec := range dc.Records {
+		if !rec.Disabled() {
+			kept = append(kept, rec)
+			continue
+		}
+		if exists == nil {
+			exists = map[string]bool{}
+			for _, e := range existing {
+				exists[e.GetLabel()+" "+e.Type+" "+e.ToComparableNoTTL()] = true
+			}
+		}
+		what := "not created"
+		if exists[rec.GetLabel()+" "+rec.Type+" "+rec.ToComparableNoTTL()] {
+			what = "deleting the existing record"
+		}
+		at := ""
+		if pName != "" {
+			at = " at " + pName
+		}
+		printer.Printf("Note: %s %s %s is DISABLED(); %s%s\n", rec.GetLabelFQDN(), rec.Type, rec.GetTargetCombined(), what, at)
+	}
+	return kept
+}
+
+// isManagedApex returns true for the records that a provider with the
+// ManagedApexRecords capability manages itself.
+func isManagedApex(rec *models.RecordConfig) bool {
+	return rec.Type == "SOA" || (rec.Type == "NS" && rec.GetLabel() == "@")
+}
+
+// removeManagedApex removes the SOA and apex NS records from the desired
+// and the existing records. A note is printed for each desired record that
+// the provider doesn't already have, since it will not be added.
+func removeManagedApex(dc *models.DomainConfig, existing models.Records, pType string) (models.Records, models.Records) {
+	has := map[string]bool{}
+	var keptExisting models.Records
+	for _, rec := range existing {
+		if isManagedApex(rec) {
+			has[rec.Type+" "+rec.ToComparableNoTTL()] = true
+			continue
+		}
+		keptExisting = append(keptExisting, rec)
+	}
+	var kept models.Records
+	for _, rec := range dc.Records {
+		if isManagedApex(rec) {
+			if !has[rec.Type+" "+rec.ToComparableNoTTL()] {
+				printer.Printf("Note: %s manages the SOA and apex NS records of %s; not adding %s %s\n", pType, dc.Name, rec.Type, rec.GetTargetCombined())
+			}
+			continue
+		}
+		kept = append(kept, rec)
+	}
+	return kept, keptExisting
+}
+
+// removeSOA returns the records without the SOA.
+func removeSOA(recs models.Records) models.Records {
+	var kept models.Records
+	for _, rec := range recs {
+		if rec.Type != "SOA" {
+			kept = append(kept, rec)
+		}
+	}
+	return kept
+}
+
+// isDelegationType returns true for the record types that
+// CorrectDelegationRecords may change.
+func isDelegationType(rtype string) bool {
+	return rtype == "NS" || rtype == "SOA"
+}
+
+// delegationRecords returns the NS and SOA records of dc plus a copy of
+// all the other existing records.  Existing records that match an
+// IGNORE*() are skipped since diff2 retains them anyway.
+func delegationRecords(dc *models.DomainConfig, existing models.Records) (models.Records, error) {
+	isUnmanaged, err := diff2.UnmanagedMatcher(dc.Unmanaged)
+	if err != nil {
+		return nil, err
+	}
+
+	var recs models.Records
+	for _, rec := range dc.Records {
+		if isDelegationType(rec.Type) {
+			recs = append(recs, rec)
+		}
+	}
+	for _, rec := range existing {
+		if isDelegationType(rec.Type) {
+			continue
+		}
+		if isUnmanaged(rec) {
+			continue
+		}
+		cp, err := rec.Copy()
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, cp)
+	}
+	return recs, nil
+}
@@ -0,0 +1,174 @@
+package zonerecs
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_delegationRecords(t *testing.T) {
+	rec := func(typ, label, target string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: typ}
+		rc.SetLabel(label, "example.com")
+		rc.SetTarget(target)
+		return rc
+	}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			rec("NS", "@", "ns1.new.net."),
+			rec("A", "www", "9.9.9.9"),
+		},
+		Unmanaged: []*models.UnmanagedConfig{{LabelPattern: "ignored"}},
+	}
+	existing := models.Records{
+		rec("NS", "@", "ns1.old.net."),
+		rec("A", "www", "1.1.1.1"),
+		rec("A", "ignored", "2.2.2.2"),
+	}
+
+	got, err := delegationRecords(dc, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary []string
+	for _, r := range got {
+		summary = append(summary, r.GetLabel()+" "+r.Type+" "+r.GetTargetField())
+	}
+	want := []string{"@ NS ns1.new.net.", "www A 1.1.1.1"}
+	if len(summary) != len(want) {
+		t.Fatalf("got %q, want %q", summary, want)
+	}
+	for i := range want {
+		if summary[i] != want[i] {
+			t.Errorf("got %q, want %q", summary, want)
+		}
+	}
+	if got[1] == existing[1] {
+		t.Errorf("existing record was not copied")
+	}
+}
+
+func Test_removeManagedApex(t *testing.T) {
+	rec := func(typ, label, target string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: typ}
+		rc.SetLabel(label, "example.com")
+		rc.SetTarget(target)
+		return rc
+	}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			rec("NS", "@", "ns1.other.net."),
+			rec("NS", "sub", "ns1.sub.net."),
+			rec("A", "www", "9.9.9.9"),
+		},
+	}
+	existing := models.Records{
+		rec("NS", "@", "dns1.provider.net."),
+		rec("SOA", "@", "dns1.provider.net."),
+		rec("A", "www", "9.9.9.9"),
+	}
+
+	desired, existing := removeManagedApex(dc, existing, "TEST")
+	summary := func(recs models.Records) (s []string) {
+		for _, r := range recs {
+			s = append(s, r.GetLabel()+" "+r.Type)
+		}
+		return s
+	}
+	if got, want := summary(desired), []string{"sub NS", "www A"}; !slices.Equal(got, want) {
+		t.Errorf("desired = %q, want %q", got, want)
+	}
+	if got, want := summary(existing), []string{"www A"}; !slices.Equal(got, want) {
+		t.Errorf("existing = %q, want %q", got, want)
+	}
+}
+
+func Test_removeOtherProvidersRecords(t *testing.T) {
+	rec := func(typ, label, target, only string) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: typ}
+		rc.SetLabel(label, "example.com")
+		rc.SetTarget(target)
+		if only != "" {
+			rc.Metadata = map[string]string{"only_providers": only}
+		}
+		return rc
+	}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			rec("A", "www", "1.1.1.1", ""),
+			rec("A", "hc", "2.2.2.2", "dsp_a"),
+			rec("A", "hc", "3.3.3.3", "dsp_b,dsp_c"),
+		},
+	}
+	existing := models.Records{
+		rec("A", "www", "1.1.1.1", ""),
+		rec("A", "hc", "2.2.2.2", ""),
+		rec("A", "hc", "4.4.4.4", ""),
+	}
+	summary := func(recs models.Records) (s []string) {
+		for _, r := range recs {
+			s = append(s, r.GetLabel()+" "+r.GetTargetField())
+		}
+		return s
+	}
+
+	tests := []struct {
+		provider               string
+		wantDesired, wantExist []string
+	}{
+		{"dsp_a", []string{"www 1.1.1.1", "hc 2.2.2.2"}, []string{"www 1.1.1.1", "hc 2.2.2.2", "hc 4.4.4.4"}},
+		// 2.2.2.2 exists at dsp_b but is left alone; 4.4.4.4 is not scoped, so it is still managed.
+		{"dsp_b", []string{"www 1.1.1.1", "hc 3.3.3.3"}, []string{"www 1.1.1.1", "hc 4.4.4.4"}},
+		{"", []string{"www 1.1.1.1", "hc 2.2.2.2", "hc 3.3.3.3"}, []string{"www 1.1.1.1", "hc 2.2.2.2", "hc 4.4.4.4"}},
+	}
+	for _, tt := range tests {
+		desired, exist := removeOtherProvidersRecords(dc, existing, tt.provider)
+		if got := summary(desired); !slices.Equal(got, tt.wantDesired) {
+			t.Errorf("%q: desired = %q, want %q", tt.provider, got, tt.wantDesired)
+		}
+		if got := summary(exist); !slices.Equal(got, tt.wantExist) {
+			t.Errorf("%q: existing = %q, want %q", tt.provider, got, tt.wantExist)
+		}
+	}
+}
+
+func Test_removeDisabledRecords(t *testing.T) {
+	rec := func(label, target string, disabled bool) *models.RecordConfig {
+		rc := &models.RecordConfig{Type: "A"}
+		rc.SetLabel(label, "example.com")
+		rc.SetTarget(target)
+		if disabled {
+			rc.Metadata = map[string]string{"disabled": "true"}
+		}
+		return rc
+	}
+	dc := &models.DomainConfig{
+		Name: "example.com",
+		Records: models.Records{
+			rec("www", "1.1.1.1", false),
+			rec("new", "2.2.2.2", true),
+			rec("old", "3.3.3.3", true),
+		},
+	}
+	existing := models.Records{rec("www", "1.1.1.1", false), rec("old", "3.3.3.3", false)}
+
+	got := removeDisabledRecords(dc, existing, "bind")
+	if len(got) != 1 || got[0].GetLabel() != "www" {
+		t.Errorf("got %v, want only www", got)
+	}
+	if len(dc.Records) != 3 {
+		t.Errorf("dc.Records was modified: %v", dc.Records)
+	}
+}
+
+func Test_removeSOA(t *testing.T) {
+	soa := &models.RecordConfig{Type: "SOA"}
+	a := &models.RecordConfig{Type: "A"}
+	if got := removeSOA(models.Records{soa, a}); len(got) != 1 || got[0] != a {
+		t.Errorf("got %v, want only the A record", got)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"sort"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 // ZoneListFilter describes a JSON list filter.
@@ -101,6 +102,9 @@ func (api *autoDNSProvider) getZone(domain string) (*Zone, error) {
 }
 
 func (api *autoDNSProvider) updateZone(domain string, resourceRecords []*ResourceRecord, nameServers []*models.Nameserver, zoneTTL uint32) error {
+	if err := providers.CheckWrite("update zone " + domain); err != nil {
+		return err
+	}
 	systemNameServer, err := api.findZoneSystemNameServer(domain)
 
 	if err != nil {
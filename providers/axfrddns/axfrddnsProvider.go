@@ -42,12 +42,20 @@ var features = providers.DocumentationNotes{
 	providers.CanAutoDNSSEC:          providers.Can("Just warn when DNSSEC is requested but no RRSIG is found in the AXFR or warn when DNSSEC is not requested but RRSIG are found in the AXFR."),
 	providers.CanGetZones:            providers.Cannot(),
 	providers.CanConcur:              providers.Cannot(),
+	providers.CanUseAFSDB:            providers.Can(),
 	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseCERT:             providers.Can(),
 	providers.CanUseDHCID:            providers.Can(),
+	providers.CanUseEUI48:            providers.Can(),
+	providers.CanUseEUI64:            providers.Can(),
+	providers.CanUseHIP:              providers.Can(),
 	providers.CanUseHTTPS:            providers.Can(),
+	providers.CanUseILNP:             providers.Can(),
 	providers.CanUseLOC:              providers.Unimplemented(),
 	providers.CanUseNAPTR:            providers.Can(),
+	providers.CanUseOPENPGPKEY:       providers.Can(),
 	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseRP:               providers.Can(),
 	providers.CanUseSRV:              providers.Can(),
 	providers.CanUseSSHFP:            providers.Can(),
 	providers.CanUseSVCB:             providers.Can(),
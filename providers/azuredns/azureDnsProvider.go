@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
@@ -84,13 +86,15 @@ func init() {
 		Initializer:   newAzureDNSDsp,
 		RecordAuditor: AuditRecords,
 	}
-	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	// https://learn.microsoft.com/azure/azure-resource-manager/management/azure-subscription-service-limits#azure-dns-limits
+	limits := providers.RecordLimits{MaxRecordSets: 10000, MaxPerRecordSet: 20}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features, limits)
 	providers.RegisterCustomRecordType("AZURE_ALIAS", providerName, "")
 	providers.RegisterMaintainer(providerName, providerMaintainer)
 }
 
 func (a *azurednsProvider) getExistingZones() ([]*adns.Zone, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	ctx, cancel := context.WithTimeout(runctx.Context(), 6000*time.Second)
 	defer cancel()
 	zonesPager := a.zonesClient.NewListByResourceGroupPager(*a.resourceGroup, nil)
 	var zones []*adns.Zone
@@ -200,29 +204,54 @@ func (a *azurednsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, ex
 		return nil, err
 	}
 
+	// The etag of each record set as it was read. The changes are made only
+	// if the record set wasn't changed (or created) by someone else since.
+	// An alias and the records it replaces are the same Azure record set:
+	// the second change to it would fail on the etag, so such changes are
+	// unconditional.
+	etags := map[azureSetKey]*string{}
+	for _, rec := range existingRecords {
+		if set, ok := rec.Original.(*adns.RecordSet); ok && set.Etag != nil {
+			etags[toAzureSetKey(rec.Key())] = set.Etag
+		}
+	}
+	changesPerSet := map[azureSetKey]int{}
+	for _, change := range changes {
+		changesPerSet[toAzureSetKey(change.Key)]++
+	}
+
 	for _, change := range changes {
 
 		// Copy all param values to local variables to avoid overwrites
 		msgs := change.MsgsJoined
 		dcn := dc.Name
 		chaKey := change.Key
+		etag := etags[toAzureSetKey(change.Key)]
+		locked := changesPerSet[toAzureSetKey(change.Key)] == 1
+		if !locked {
+			etag = nil
+		}
 
 		switch change.Type {
 		case diff2.REPORT:
 			corrections = append(corrections, &models.Correction{Msg: change.MsgsJoined})
 		case diff2.CHANGE, diff2.CREATE:
 			changeNew := change.New
+			opts := &adns.RecordSetsClientCreateOrUpdateOptions{IfMatch: etag}
+			if change.Type == diff2.CREATE && locked {
+				opts = &adns.RecordSetsClientCreateOrUpdateOptions{IfNoneMatch: to.StringPtr("*")}
+			}
 			corrections = append(corrections, &models.Correction{
 				Msg: msgs,
 				F: func() error {
-					return a.recordCreate(dcn, chaKey, changeNew)
+					return a.recordCreate(dcn, chaKey, changeNew, opts)
 				},
 			})
 		case diff2.DELETE:
 			corrections = append(corrections, &models.Correction{
 				Msg: msgs,
 				F: func() error {
-					return a.recordDelete(dcn, chaKey)
+					return a.recordDelete(dcn, chaKey, etag)
 				},
 			})
 		default:
@@ -233,7 +262,20 @@ func (a *azurednsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, ex
 	return corrections, nil
 }
 
-func (a *azurednsProvider) recordCreate(zoneName string, reckey models.RecordKey, recs models.Records) error {
+// azureSetKey identifies an Azure record set.
+type azureSetKey struct {
+	name  string
+	rtype adns.RecordType
+}
+
+func toAzureSetKey(key models.RecordKey) azureSetKey {
+	rtype, _ := nativeToRecordTypeDiff2(to.StringPtr(key.Type))
+	return azureSetKey{name: strings.ToLower(key.NameFQDN), rtype: rtype}
+}
+
+// recordCreate creates or replaces a record set. opts has the conditions
+// (the etag) that the existing record set must meet.
+func (a *azurednsProvider) recordCreate(zoneName string, reckey models.RecordKey, recs models.Records, opts *adns.RecordSetsClientCreateOrUpdateOptions) error {
 
 	rrset, azRecType, err := a.recordToNativeDiff2(reckey, recs)
 	if err != nil {
@@ -251,9 +293,9 @@ func (a *azurednsProvider) recordCreate(zoneName string, reckey models.RecordKey
 	waitTime := 1
 retry:
 
-	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	ctx, cancel := context.WithTimeout(runctx.Context(), 6000*time.Second)
 	defer cancel()
-	_, err = a.recordsClient.CreateOrUpdate(ctx, *a.resourceGroup, zoneName, recordName, azRecType, *rrset, nil)
+	_, err = a.recordsClient.CreateOrUpdate(ctx, *a.resourceGroup, zoneName, recordName, azRecType, *rrset, opts)
 
 	if e, ok := err.(*azcore.ResponseError); ok {
 		if e.StatusCode == 429 {
@@ -265,12 +307,16 @@ retry:
 			time.Sleep(time.Duration(waitTime+1) * time.Second)
 			goto retry
 		}
+		if e.StatusCode == http.StatusPreconditionFailed {
+			return providers.WrapError(providers.ErrConflict, err)
+		}
 	}
 
 	return err
 }
 
-func (a *azurednsProvider) recordDelete(zoneName string, reckey models.RecordKey) error {
+// recordDelete deletes a record set, if it still has the etag (when known).
+func (a *azurednsProvider) recordDelete(zoneName string, reckey models.RecordKey, etag *string) error {
 
 	shortName := strings.TrimSuffix(reckey.NameFQDN, "."+zoneName)
 	if shortName == zoneName {
@@ -285,9 +331,9 @@ func (a *azurednsProvider) recordDelete(zoneName string, reckey models.RecordKey
 	waitTime := 1
 retry:
 
-	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	ctx, cancel := context.WithTimeout(runctx.Context(), 6000*time.Second)
 	defer cancel()
-	_, err = a.recordsClient.Delete(ctx, *a.resourceGroup, zoneName, shortName, azRecType, nil)
+	_, err = a.recordsClient.Delete(ctx, *a.resourceGroup, zoneName, shortName, azRecType, &adns.RecordSetsClientDeleteOptions{IfMatch: etag})
 
 	if e, ok := err.(*azcore.ResponseError); ok {
 		if e.StatusCode == 429 {
@@ -299,6 +345,9 @@ retry:
 			time.Sleep(time.Duration(waitTime+1) * time.Second)
 			goto retry
 		}
+		if e.StatusCode == http.StatusPreconditionFailed {
+			return providers.WrapError(providers.ErrConflict, err)
+		}
 	}
 
 	return err
@@ -567,7 +616,7 @@ func (a *azurednsProvider) fetchRecordSets(zoneName string) ([]*adns.RecordSet,
 		return nil, nil
 	}
 	var records []*adns.RecordSet
-	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	ctx, cancel := context.WithTimeout(runctx.Context(), 6000*time.Second)
 	defer cancel()
 	recordsPager := a.recordsClient.NewListAllByDNSZonePager(*a.resourceGroup, zoneName, nil)
 
@@ -606,7 +655,7 @@ func (a *azurednsProvider) EnsureZoneExists(domain string) error {
 	}
 	printer.Printf("Adding zone for %s to Azure dns account\n", domain)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	ctx, cancel := context.WithTimeout(runctx.Context(), 6000*time.Second)
 	defer cancel()
 
 	_, err := a.zonesClient.CreateOrUpdate(ctx, *a.resourceGroup, domain, adns.Zone{Location: to.StringPtr("global")}, nil)
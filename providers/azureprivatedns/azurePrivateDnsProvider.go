@@ -14,6 +14,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
@@ -94,7 +95,7 @@ func init() {
 }
 
 func (a *azurednsProvider) getExistingZones() ([]*adns.PrivateZone, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	ctx, cancel := context.WithTimeout(runctx.Context(), 6000*time.Second)
 	defer cancel()
 	zonesPager := a.zonesClient.NewListByResourceGroupPager(*a.resourceGroup, nil)
 	var zones []*adns.PrivateZone
@@ -246,7 +247,7 @@ func (a *azurednsProvider) recordCreate(zoneName string, reckey models.RecordKey
 	waitTime := 1
 retry:
 
-	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	ctx, cancel := context.WithTimeout(runctx.Context(), 6000*time.Second)
 	defer cancel()
 	_, err = a.recordsClient.CreateOrUpdate(ctx, *a.resourceGroup, zoneName, azRecType, recordName, *rrset, nil)
 
@@ -280,7 +281,7 @@ func (a *azurednsProvider) recordDelete(zoneName string, reckey models.RecordKey
 	waitTime := 1
 retry:
 
-	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	ctx, cancel := context.WithTimeout(runctx.Context(), 6000*time.Second)
 	defer cancel()
 	_, err = a.recordsClient.Delete(ctx, *a.resourceGroup, zoneName, azRecType, shortName, nil)
 
@@ -495,7 +496,7 @@ func (a *azurednsProvider) fetchRecordSets(zoneName string) ([]*adns.RecordSet,
 		return nil, nil
 	}
 	var records []*adns.RecordSet
-	ctx, cancel := context.WithTimeout(context.Background(), 6000*time.Second)
+	ctx, cancel := context.WithTimeout(runctx.Context(), 6000*time.Second)
 	defer cancel()
 
 	recordsPager := a.recordsClient.NewListPager(*a.resourceGroup, zoneName, nil)
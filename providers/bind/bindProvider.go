@@ -36,15 +36,24 @@ var features = providers.DocumentationNotes{
 	providers.CanAutoDNSSEC:          providers.Can("Just writes out a comment indicating DNSSEC was requested"),
 	providers.CanGetZones:            providers.Can(),
 	providers.CanConcur:              providers.Cannot(),
+	providers.CanSplitHorizon:        providers.Can("With a filenameformat that includes %U or %T, as the default does"),
+	providers.CanUseAFSDB:            providers.Can(),
 	providers.CanUseCAA:              providers.Can(),
+	providers.CanUseCERT:             providers.Can(),
 	providers.CanUseDHCID:            providers.Can(),
 	providers.CanUseDNAME:            providers.Can(),
 	providers.CanUseDS:               providers.Can(),
 	providers.CanUseDNSKEY:           providers.Can(),
+	providers.CanUseEUI48:            providers.Can(),
+	providers.CanUseEUI64:            providers.Can(),
+	providers.CanUseHIP:              providers.Can(),
 	providers.CanUseHTTPS:            providers.Can(),
+	providers.CanUseILNP:             providers.Can(),
 	providers.CanUseLOC:              providers.Can(),
 	providers.CanUseNAPTR:            providers.Can(),
+	providers.CanUseOPENPGPKEY:       providers.Can(),
 	providers.CanUsePTR:              providers.Can(),
+	providers.CanUseRP:               providers.Can(),
 	providers.CanUseSOA:              providers.Can(),
 	providers.CanUseSRV:              providers.Can(),
 	providers.CanUseSSHFP:            providers.Can(),
@@ -68,6 +77,16 @@ func initBind(config map[string]string, providermeta json.RawMessage) (providers
 	if api.filenameformat == "" {
 		api.filenameformat = "%U.zone"
 	}
+	order, err := prettyzone.ParseRecordOrder(config["record_order"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid record_order: %w", err)
+	}
+	api.recordOrder = order
+	switch api.generatedComment = config["generated_comment"]; api.generatedComment {
+	case "", "timestamp", "none":
+	default:
+		return nil, fmt.Errorf("invalid generated_comment %q (want timestamp or none)", api.generatedComment)
+	}
 	if len(providermeta) != 0 {
 		err := json.Unmarshal(providermeta, api)
 		if err != nil {
@@ -87,7 +106,6 @@ func initBind(config map[string]string, providermeta json.RawMessage) (providers
 		// name without the trailing dot to indicate a FQDN.
 		nss = append(nss, strings.TrimSuffix(ns, "."))
 	}
-	var err error
 	api.nameservers, err = models.ToNameservers(nss)
 	return api, err
 }
@@ -121,13 +139,16 @@ func (s SoaDefaults) String() string {
 
 // bindProvider is the provider handle for the bindProvider driver.
 type bindProvider struct {
-	DefaultNS      []string    `json:"default_ns"`
-	DefaultSoa     SoaDefaults `json:"default_soa"`
-	nameservers    []*models.Nameserver
-	directory      string
-	filenameformat string
-	zonefile       string // Where the zone data is e texpected
-	zoneFileFound  bool   // Did the zonefile exist?
+	DefaultNS        []string    `json:"default_ns"`
+	DefaultSoa       SoaDefaults `json:"default_soa"`
+	SerialMode       string      `json:"soa_serial"`
+	nameservers      []*models.Nameserver
+	directory        string
+	filenameformat   string
+	recordOrder      *prettyzone.RecordOrder
+	generatedComment string // "none" leaves out the comment with the time of the write.
+	zonefile         string // Where the zone data is e texpected
+	zoneFileFound    bool   // Did the zonefile exist?
 }
 
 // GetNameservers returns the nameservers for a domain.
@@ -238,7 +259,14 @@ func (c *bindProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, foundR
 			break
 		}
 	}
-	soaRec, nextSerial := makeSoa(dc.Name, &c.DefaultSoa, foundSoa, desiredSoa)
+	serialMode := c.SerialMode
+	if m, ok := dc.Metadata["soa_serial"]; ok {
+		serialMode = m
+	}
+	soaRec, nextSerial, err := makeSoa(dc.Name, serialMode, &c.DefaultSoa, foundSoa, desiredSoa)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", dc.Name, err)
+	}
 	if desiredSoa == nil {
 		dc.Records = append(dc.Records, soaRec)
 		desiredSoa = dc.Records[len(dc.Records)-1]
@@ -247,7 +275,6 @@ func (c *bindProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, foundR
 	}
 
 	var msgs []string
-	var err error
 	msgs, changes, err = diff2.ByZone(foundRecords, dc, nil)
 	if err != nil {
 		return nil, err
@@ -255,12 +282,13 @@ func (c *bindProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, foundR
 	if !changes {
 		return nil, nil
 	}
-	msg = strings.Join(msgs, "\n")
 
 	comments := make([]string, 0, 5)
-	comments = append(comments,
-		fmt.Sprintf("generated with dnscontrol %s", time.Now().Format(time.RFC3339)),
-	)
+	if c.generatedComment != "none" {
+		comments = append(comments,
+			fmt.Sprintf("generated with dnscontrol %s", time.Now().Format(time.RFC3339)),
+		)
+	}
 	if dc.AutoDNSSEC == "on" {
 		// This does nothing but reminds the user to add the correct
 		// auto-dnssecc zone statement to named.conf.
@@ -282,6 +310,10 @@ func (c *bindProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, foundR
 		desiredSoa.SoaSerial = uint32(bindserial.ForcedValue & 0xFFFF)
 	}
 
+	// Tell the user which serial will be written.
+	msgs = append(msgs, serialMessage(serialMode, foundSoa, desiredSoa.SoaSerial))
+	msg = strings.Join(msgs, "\n")
+
 	corrections = append(corrections,
 		&models.Correction{
 			Msg: msg,
@@ -291,22 +323,40 @@ func (c *bindProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, foundR
 				if err != nil {
 					return fmt.Errorf("could not create zonefile: %w", err)
 				}
-				zf, err := os.Create(fname)
+				// The zone is written to a temporary file that replaces
+				// the zonefile once complete, so that whatever loads the
+				// zonefiles (named, a sidecar watching a git checkout) never
+				// sees a partial zone.
+				zf, err := os.CreateTemp(filepath.Dir(fname), "."+filepath.Base(fname)+".*.tmp")
 				if err != nil {
 					return fmt.Errorf("could not create zonefile: %w", err)
 				}
+				defer os.Remove(zf.Name()) // No-op once renamed.
 				// Beware that if there are any fake types, then they will
 				// be commented out on write, but we don't reverse that when
 				// reading, so there will be a diff on every invocation.
-				err = prettyzone.WriteZoneFileRC(zf, dc.Records, dc.Name, 0, comments)
+				err = prettyzone.WriteZoneFileRCOrdered(zf, dc.Records, dc.Name, 0, comments, c.recordOrder)
 
 				if err != nil {
+					zf.Close()
 					return fmt.Errorf("failed WriteZoneFile: %w", err)
 				}
 				err = zf.Close()
 				if err != nil {
 					return fmt.Errorf("closing: %w", err)
 				}
+				// Keep the permissions of the zonefile (os.CreateTemp makes
+				// the file private).
+				mode := os.FileMode(0o644)
+				if fi, err := os.Stat(fname); err == nil {
+					mode = fi.Mode().Perm()
+				}
+				if err := os.Chmod(zf.Name(), mode); err != nil {
+					return fmt.Errorf("could not create zonefile: %w", err)
+				}
+				if err := os.Rename(zf.Name(), fname); err != nil {
+					return fmt.Errorf("could not replace zonefile: %w", err)
+				}
 				return nil
 			},
 		})
@@ -314,6 +364,23 @@ func (c *bindProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, foundR
 	return corrections, nil
 }
 
+// serialMessage describes the SOA serial change that goes with a zone update.
+func serialMessage(mode string, foundSoa *models.RecordConfig, newSerial uint32) string {
+	if mode == "" {
+		mode = serialModeDate
+	}
+	if bindserial.ForcedValue != 0 {
+		mode = "--bindserial"
+	}
+	if foundSoa == nil {
+		return fmt.Sprintf("SOA serial: %d (%s)", newSerial, mode)
+	}
+	if foundSoa.SoaSerial == newSerial {
+		return fmt.Sprintf("WARNING: SOA serial: %d unchanged (%s); secondaries will not notice this update", newSerial, mode)
+	}
+	return fmt.Sprintf("SOA serial: %d -> %d (%s)", foundSoa.SoaSerial, newSerial, mode)
+}
+
 // preprocessFilename pre-processes a filename we're about to os.Create()
 // * On Windows systems, it translates the seperator.
 // * It attempts to mkdir the directories leading up to the filename.
@@ -0,0 +1,68 @@
+package bind
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+)
+
+func Test_writeZonefile(t *testing.T) {
+	dir := t.TempDir()
+	p, err := initBind(map[string]string{"directory": dir, "generated_comment": "none"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := p.(*bindProvider)
+	c.SerialMode = serialModeIncrement
+
+	push := func(target string) {
+		t.Helper()
+		rec := &models.RecordConfig{Type: "A"}
+		rec.SetLabel("www", "example.com")
+		rec.SetTarget(target)
+		dc := &models.DomainConfig{Name: "example.com", Records: models.Records{rec},
+			Metadata: map[string]string{models.DomainUniqueName: "example.com"}}
+		found, err := c.GetZoneRecords(dc.Name, dc.Metadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		corrections, err := c.GetZoneRecordsCorrections(dc, found)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, correction := range corrections {
+			if err := correction.F(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	push("192.0.2.1")
+	os.Chmod(filepath.Join(dir, "example.com.zone"), 0o640)
+	push("192.0.2.2")
+
+	content, err := os.ReadFile(filepath.Join(dir, "example.com.zone"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "generated with dnscontrol") {
+		t.Errorf("generated_comment=none wrote the comment:\n%s", content)
+	}
+	if !strings.Contains(string(content), "192.0.2.2") {
+		t.Errorf("the zonefile isn't updated:\n%s", content)
+	}
+	if fi, err := os.Stat(filepath.Join(dir, "example.com.zone")); err != nil || fi.Mode().Perm() != 0o640 {
+		t.Errorf("the permissions of the zonefile aren't kept: %v %v", fi.Mode(), err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("temporary files are left in the directory: %v", entries)
+	}
+
+	if _, err := initBind(map[string]string{"generated_comment": "bogus"}, nil); err == nil {
+		t.Errorf("initBind accepted generated_comment=bogus")
+	}
+}
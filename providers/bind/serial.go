@@ -1,6 +1,7 @@
 package bind
 
 import (
+	"fmt"
 	"log"
 	"strconv"
 	"time"
@@ -10,6 +11,34 @@ import (
 
 var nowFunc = time.Now
 
+// SOA serial number modes, selected with the "soa_serial" provider
+// metadata or the per-domain "soa_serial" metadata.
+const (
+	serialModeDate      = "date"      // yyyymmddvv (the default)
+	serialModeIncrement = "increment" // old serial + 1
+	serialModeFixed     = "fixed"     // never changed by DNSControl
+)
+
+// nextSerial returns the serial number to write when a zone changes.
+// oldSerial is the serial in effect before the change. In fixed mode it
+// is returned as-is.
+func nextSerial(mode string, oldSerial uint32) (uint32, error) {
+	switch mode {
+	case "", serialModeDate:
+		return generateSerial(oldSerial), nil
+	case serialModeIncrement:
+		if oldSerial == 0xFFFFFFFF {
+			// Skip 0 when wrapping around.
+			return 1, nil
+		}
+		return oldSerial + 1, nil
+	case serialModeFixed:
+		return oldSerial, nil
+	}
+	return 0, fmt.Errorf("invalid soa_serial mode %q (valid: %s, %s, %s)",
+		mode, serialModeDate, serialModeIncrement, serialModeFixed)
+}
+
 // generateSerial takes an old SOA serial number and increments it.
 func generateSerial(oldSerial uint32) uint32 {
 	// Serial numbers are in the format yyyymmddvv
@@ -52,3 +52,34 @@ func Test_generate_serial_1(t *testing.T) {
 		}
 	}
 }
+
+func Test_nextSerial(t *testing.T) {
+	d1, _ := time.Parse("20060102", "20150108")
+	nowFunc = func() time.Time { return d1 }
+	defer func() { nowFunc = time.Now }()
+
+	var tests = []struct {
+		Mode     string
+		Given    uint32
+		Expected uint32
+		IsError  bool
+	}{
+		{"", 123, 2015010800, false},
+		{"date", 2015010800, 2015010801, false},
+		{"increment", 123, 124, false},
+		{"increment", 2015010800, 2015010801, false},
+		{"increment", 0xFFFFFFFF, 1, false},
+		{"fixed", 123, 123, false},
+		{"bogus", 123, 0, true},
+	}
+
+	for i, tst := range tests {
+		found, err := nextSerial(tst.Mode, tst.Given)
+		if (err != nil) != tst.IsError {
+			t.Fatalf("Test:%d/%v: unexpected error state: %v", i, tst.Mode, err)
+		}
+		if found != tst.Expected {
+			t.Fatalf("Test:%d/%v: Expected (%d) got (%d)\n", i, tst.Mode, tst.Expected, found)
+		}
+	}
+}
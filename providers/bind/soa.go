@@ -7,7 +7,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/pkg/soautil"
 )
 
-func makeSoa(origin string, defSoa *SoaDefaults, existing, desired *models.RecordConfig) (*models.RecordConfig, uint32) {
+func makeSoa(origin, serialMode string, defSoa *SoaDefaults, existing, desired *models.RecordConfig) (*models.RecordConfig, uint32, error) {
 	// Create a SOA record.  Take data from desired, existing, default,
 	// or hardcoded defaults.
 	soaRec := models.RecordConfig{}
@@ -29,18 +29,26 @@ func makeSoa(origin string, defSoa *SoaDefaults, existing, desired *models.Recor
 		soaMail = soautil.RFC5322MailToBind(soaMail)
 	}
 
+	serial := firstNonZero(desired.SoaSerial, existing.SoaSerial, defSoa.Serial, 1)
+	if serialMode == serialModeFixed {
+		// The serial is whatever the user says it is. The existing
+		// serial is only used if they didn't say.
+		serial = firstNonZero(desired.SoaSerial, defSoa.Serial, existing.SoaSerial, 1)
+	}
+
 	soaRec.TTL = firstNonZero(desired.TTL, defSoa.TTL, existing.TTL, models.DefaultTTL)
 	soaRec.SetTargetSOA(
 		firstNonNull(desired.GetTargetField(), existing.GetTargetField(), defSoa.Ns, "DEFAULT_NOT_SET."),
 		soaMail,
-		firstNonZero(desired.SoaSerial, existing.SoaSerial, defSoa.Serial, 1),
+		serial,
 		firstNonZero(desired.SoaRefresh, existing.SoaRefresh, defSoa.Refresh, 3600),
 		firstNonZero(desired.SoaRetry, existing.SoaRetry, defSoa.Retry, 600),
 		firstNonZero(desired.SoaExpire, existing.SoaExpire, defSoa.Expire, 604800),
 		firstNonZero(desired.SoaMinttl, existing.SoaMinttl, defSoa.Minttl, 1440),
 	)
 
-	return &soaRec, generateSerial(soaRec.SoaSerial)
+	next, err := nextSerial(serialMode, soaRec.SoaSerial)
+	return &soaRec, next, err
 }
 
 func firstNonNull(items ...string) string {
@@ -101,7 +101,10 @@ func Test_makeSoa(t *testing.T) {
 		tst.expectedSoa.SetLabel("@", origin)
 		tst.expectedSoa.Type = "SOA"
 
-		r1, r2 := makeSoa(origin, tst.def, tst.existing, tst.desired)
+		r1, r2, err := makeSoa(origin, "", tst.def, tst.existing, tst.desired)
+		if err != nil {
+			t.Fatal(err)
+		}
 		if !areEqualSoa(r1, tst.expectedSoa) {
 			t.Fatalf("Test %d soa:\nExpected (%v)\n     got (%v)\n", i, tst.expectedSoa.String(), r1.String())
 		}
@@ -111,6 +114,34 @@ func Test_makeSoa(t *testing.T) {
 	}
 }
 
+func Test_makeSoa_fixed(t *testing.T) {
+	origin := "example.com"
+	def := &SoaDefaults{"ns.example.com", "root@example.com", 42, 2, 3, 4, 5, models.DefaultTTL}
+	existing := mkRC("a", &models.RecordConfig{Type: "SOA", SoaSerial: 2019022301})
+
+	// The default_soa serial beats the existing serial, and is not bumped.
+	_, serial, err := makeSoa(origin, "fixed", def, existing, &models.RecordConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serial != 42 {
+		t.Errorf("Expected (42) got (%v)", serial)
+	}
+
+	// A serial in the desired SOA beats both.
+	_, serial, err = makeSoa(origin, "fixed", def, existing, &models.RecordConfig{SoaSerial: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serial != 7 {
+		t.Errorf("Expected (7) got (%v)", serial)
+	}
+
+	if _, _, err := makeSoa(origin, "bogus", def, existing, nil); err == nil {
+		t.Errorf("Expected error for unknown mode")
+	}
+}
+
 func areEqualSoa(r1, r2 *models.RecordConfig) bool {
 	if r1.NameFQDN != r2.NameFQDN {
 		fmt.Printf("ERROR: fqdn %q != %q\n", r1.NameFQDN, r2.NameFQDN)
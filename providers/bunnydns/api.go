@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 	"golang.org/x/exp/slices"
 	"io"
 	"net/http"
@@ -125,6 +126,9 @@ func (b *bunnydnsProvider) getAllZones() ([]*zone, error) {
 }
 
 func (b *bunnydnsProvider) createZone(domain string) (*zone, error) {
+	if err := providers.CheckWrite("create zone " + domain); err != nil {
+		return nil, err
+	}
 	zone := &zone{}
 	body := map[string]string{"domain": domain}
 	err := b.request("POST", "/dnszone", nil, body, &zone, []int{http.StatusCreated})
@@ -153,16 +157,25 @@ func (b *bunnydnsProvider) getAllRecords(zoneID int64) ([]*record, error) {
 }
 
 func (b *bunnydnsProvider) createRecord(zoneID int64, r *record) error {
+	if err := providers.CheckWrite(fmt.Sprintf("create a record in zone %d", zoneID)); err != nil {
+		return err
+	}
 	url := fmt.Sprintf("/dnszone/%d/records", zoneID)
 	return b.request("PUT", url, nil, r, nil, []int{http.StatusCreated})
 }
 
 func (b *bunnydnsProvider) modifyRecord(zoneID int64, recordID int64, r *record) error {
+	if err := providers.CheckWrite(fmt.Sprintf("modify record %d of zone %d", recordID, zoneID)); err != nil {
+		return err
+	}
 	url := fmt.Sprintf("/dnszone/%d/records/%d", zoneID, recordID)
 	return b.request("POST", url, nil, r, nil, []int{http.StatusNoContent})
 }
 
 func (b *bunnydnsProvider) deleteRecord(zoneID, recordID int64) error {
+	if err := providers.CheckWrite(fmt.Sprintf("delete record %d of zone %d", recordID, zoneID)); err != nil {
+		return err
+	}
 	url := fmt.Sprintf("/dnszone/%d/records/%d", zoneID, recordID)
 	return b.request("DELETE", url, nil, nil, nil, []int{http.StatusNoContent})
 }
@@ -213,7 +226,7 @@ func (b *bunnydnsProvider) request(method, endpoint string, query queryParams, b
 		data, _ := io.ReadAll(resp.Body)
 		printer.Println(fmt.Sprintf("BUNNY_DNS: Bad API response for %s %s: %s", method, endpoint, string(data)))
 		cleanup()
-		return fmt.Errorf("bad status code from BUNNY_DNS: %d not in %v", resp.StatusCode, validStatus)
+		return providers.WrapStatus(resp.StatusCode, fmt.Errorf("bad status code from BUNNY_DNS: %d not in %v", resp.StatusCode, validStatus))
 	}
 
 	if target == nil {
@@ -28,6 +28,18 @@ const (
 	// CanGetZones indicates the provider supports the get-zones subcommand.
 	CanGetZones
 
+	// CanProxy indicates the provider can proxy the traffic of A, AAAA and
+	// CNAME records through its CDN, as requested by PROXY_ON().
+	CanProxy
+
+	// CanSplitHorizon indicates the provider keeps the views of a split
+	// horizon domain ("example.com!tag") apart, so several views can be at
+	// the same provider.
+	CanSplitHorizon
+
+	// CanUseAFSDB indicates the provider can handle AFSDB records
+	CanUseAFSDB
+
 	// CanUseAKAMAICDN indicates the provider support the specific AKAMAICDN records that only the Akamai EdgeDns provider supports
 	CanUseAKAMAICDN
 
@@ -40,6 +52,9 @@ const (
 	// CanUseCAA indicates the provider can handle CAA records
 	CanUseCAA
 
+	// CanUseCERT indicates the provider can handle CERT records
+	CanUseCERT
+
 	// CanUseDHCID indicates the provider can handle DHCID records
 	CanUseDHCID
 
@@ -54,21 +69,40 @@ const (
 	// only for children records, not at the root of the zone.
 	CanUseDSForChildren
 
+	// CanUseEUI48 indicates the provider can handle EUI48 records
+	CanUseEUI48
+
+	// CanUseEUI64 indicates the provider can handle EUI64 records
+	CanUseEUI64
+
+	// CanUseHIP indicates the provider can handle HIP records
+	CanUseHIP
+
 	// CanUseHTTPS indicates the provider can handle HTTPS records
 	CanUseHTTPS
 
+	// CanUseILNP indicates the provider can handle the ILNP records: NID,
+	// L32, L64 and LP
+	CanUseILNP
+
 	// CanUseLOC indicates whether service provider handles LOC records
 	CanUseLOC
 
 	// CanUseNAPTR indicates the provider can handle NAPTR records
 	CanUseNAPTR
 
+	// CanUseOPENPGPKEY indicates the provider can handle OPENPGPKEY records
+	CanUseOPENPGPKEY
+
 	// CanUsePTR indicates the provider can handle PTR records
 	CanUsePTR
 
 	// CanUseRoute53Alias indicates the provider support the specific R53_ALIAS records that only the Route53 provider supports
 	CanUseRoute53Alias
 
+	// CanUseRP indicates the provider can handle RP records
+	CanUseRP
+
 	// CanUseSOA indicates the provider supports full management of a zone's SOA record
 	CanUseSOA
 
@@ -87,6 +121,11 @@ const (
 	// CanUseDNSKEY indicates that the provider can handle DNSKEY records
 	CanUseDNSKEY
 
+	// ManagedApexRecords indicates the provider manages the SOA and apex NS
+	// records itself and rejects changes to them. They are left out of the
+	// corrections for this provider.
+	ManagedApexRecords
+
 	// DocCreateDomains means provider can add domains with the `dnscontrol create-domains` command
 	DocCreateDomains
 
@@ -95,8 +134,20 @@ const (
 
 	// DocOfficiallySupported means it is actively used and maintained by stack exchange
 	DocOfficiallySupported
+
+	// numCapabilities is the number of capabilities. It must stay last.
+	numCapabilities
 )
 
+// AllCapabilities returns every capability, in the order they are declared.
+func AllCapabilities() []Capability {
+	caps := make([]Capability, numCapabilities)
+	for i := range caps {
+		caps[i] = Capability(i)
+	}
+	return caps
+}
+
 var providerCapabilities = map[string]map[Capability]bool{}
 
 // ProviderHasCapability returns true if provider has capability.
@@ -124,6 +175,41 @@ type ProviderMetadata interface{}
 // Notes is a collection of all documentation notes, keyed by provider type
 var Notes = map[string]DocumentationNotes{}
 
+// TTLRange is the range of TTLs a provider accepts. A zero Max means
+// there is no maximum. Providers declare it by passing a TTLRange
+// to RegisterDomainServiceProviderType along with their capabilities.
+type TTLRange struct {
+	Min uint32
+	Max uint32
+}
+
+var providerTTLRanges = map[string]TTLRange{}
+
+// ProviderTTLRange returns the range of TTLs accepted by a provider
+// type, and false if the provider did not declare one.
+func ProviderTTLRange(pType string) (TTLRange, bool) {
+	r, ok := providerTTLRanges[pType]
+	return r, ok
+}
+
+// RecordLimits are the documented limits on the size of a zone. A zero
+// field means there is no limit. Providers declare them the same way as
+// a TTLRange.
+type RecordLimits struct {
+	MaxRecords      int // Records (RRs) per zone.
+	MaxRecordSets   int // Distinct label+type pairs per zone.
+	MaxPerRecordSet int // Records sharing one label+type.
+}
+
+var providerRecordLimits = map[string]RecordLimits{}
+
+// ProviderRecordLimits returns the record limits of a provider type, and
+// false if the provider did not declare any.
+func ProviderRecordLimits(pType string) (RecordLimits, bool) {
+	l, ok := providerRecordLimits[pType]
+	return l, ok
+}
+
 func unwrapProviderCapabilities(pName string, meta []ProviderMetadata) {
 	if providerCapabilities[pName] == nil {
 		providerCapabilities[pName] = map[Capability]bool{}
@@ -132,6 +218,10 @@ func unwrapProviderCapabilities(pName string, meta []ProviderMetadata) {
 		switch x := pm.(type) {
 		case Capability:
 			providerCapabilities[pName][x] = true
+		case TTLRange:
+			providerTTLRanges[pName] = x
+		case RecordLimits:
+			providerRecordLimits[pName] = x
 		case DocumentationNotes:
 			if Notes[pName] == nil {
 				Notes[pName] = DocumentationNotes{}
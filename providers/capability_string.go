@@ -11,33 +11,45 @@ func _() {
 	_ = x[CanAutoDNSSEC-0]
 	_ = x[CanConcur-1]
 	_ = x[CanGetZones-2]
-	_ = x[CanUseAKAMAICDN-3]
-	_ = x[CanUseAlias-4]
-	_ = x[CanUseAzureAlias-5]
-	_ = x[CanUseCAA-6]
-	_ = x[CanUseDHCID-7]
-	_ = x[CanUseDNAME-8]
-	_ = x[CanUseDS-9]
-	_ = x[CanUseDSForChildren-10]
-	_ = x[CanUseHTTPS-11]
-	_ = x[CanUseLOC-12]
-	_ = x[CanUseNAPTR-13]
-	_ = x[CanUsePTR-14]
-	_ = x[CanUseRoute53Alias-15]
-	_ = x[CanUseSOA-16]
-	_ = x[CanUseSRV-17]
-	_ = x[CanUseSSHFP-18]
-	_ = x[CanUseSVCB-19]
-	_ = x[CanUseTLSA-20]
-	_ = x[CanUseDNSKEY-21]
-	_ = x[DocCreateDomains-22]
-	_ = x[DocDualHost-23]
-	_ = x[DocOfficiallySupported-24]
+	_ = x[CanProxy-3]
+	_ = x[CanSplitHorizon-4]
+	_ = x[CanUseAFSDB-5]
+	_ = x[CanUseAKAMAICDN-6]
+	_ = x[CanUseAlias-7]
+	_ = x[CanUseAzureAlias-8]
+	_ = x[CanUseCAA-9]
+	_ = x[CanUseCERT-10]
+	_ = x[CanUseDHCID-11]
+	_ = x[CanUseDNAME-12]
+	_ = x[CanUseDS-13]
+	_ = x[CanUseDSForChildren-14]
+	_ = x[CanUseEUI48-15]
+	_ = x[CanUseEUI64-16]
+	_ = x[CanUseHIP-17]
+	_ = x[CanUseHTTPS-18]
+	_ = x[CanUseILNP-19]
+	_ = x[CanUseLOC-20]
+	_ = x[CanUseNAPTR-21]
+	_ = x[CanUseOPENPGPKEY-22]
+	_ = x[CanUsePTR-23]
+	_ = x[CanUseRoute53Alias-24]
+	_ = x[CanUseRP-25]
+	_ = x[CanUseSOA-26]
+	_ = x[CanUseSRV-27]
+	_ = x[CanUseSSHFP-28]
+	_ = x[CanUseSVCB-29]
+	_ = x[CanUseTLSA-30]
+	_ = x[CanUseDNSKEY-31]
+	_ = x[ManagedApexRecords-32]
+	_ = x[DocCreateDomains-33]
+	_ = x[DocDualHost-34]
+	_ = x[DocOfficiallySupported-35]
+	_ = x[numCapabilities-36]
 }
 
-const _Capability_name = "CanAutoDNSSECCanConcurCanGetZonesCanUseAKAMAICDNCanUseAliasCanUseAzureAliasCanUseCAACanUseDHCIDCanUseDNAMECanUseDSCanUseDSForChildrenCanUseHTTPSCanUseLOCCanUseNAPTRCanUsePTRCanUseRoute53AliasCanUseSOACanUseSRVCanUseSSHFPCanUseSVCBCanUseTLSACanUseDNSKEYDocCreateDomainsDocDualHostDocOfficiallySupported"
+const _Capability_name = "CanAutoDNSSECCanConcurCanGetZonesCanProxyCanSplitHorizonCanUseAFSDBCanUseAKAMAICDNCanUseAliasCanUseAzureAliasCanUseCAACanUseCERTCanUseDHCIDCanUseDNAMECanUseDSCanUseDSForChildrenCanUseEUI48CanUseEUI64CanUseHIPCanUseHTTPSCanUseILNPCanUseLOCCanUseNAPTRCanUseOPENPGPKEYCanUsePTRCanUseRoute53AliasCanUseRPCanUseSOACanUseSRVCanUseSSHFPCanUseSVCBCanUseTLSACanUseDNSKEYManagedApexRecordsDocCreateDomainsDocDualHostDocOfficiallySupportednumCapabilities"
 
-var _Capability_index = [...]uint16{0, 13, 22, 33, 48, 59, 75, 84, 95, 106, 114, 133, 144, 153, 164, 173, 191, 200, 209, 220, 230, 240, 252, 268, 279, 301}
+var _Capability_index = [...]uint16{0, 13, 22, 33, 41, 56, 67, 82, 93, 109, 118, 128, 139, 150, 158, 177, 188, 199, 208, 219, 229, 238, 249, 265, 274, 292, 300, 309, 318, 329, 339, 349, 361, 379, 395, 406, 428, 443}
 
 func (i Capability) String() string {
 	if i >= Capability(len(_Capability_index)-1) {
@@ -1,7 +1,6 @@
 package cloudflare
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -15,6 +14,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/StackExchange/dnscontrol/v4/pkg/transform"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/StackExchange/dnscontrol/v4/providers/cloudflare/rtypes/cfsingleredirect"
@@ -46,6 +46,7 @@ var features = providers.DocumentationNotes{
 	// See providers/capabilities.go for the entire list of capabilities.
 	providers.CanGetZones:            providers.Can(),
 	providers.CanConcur:              providers.Can(),
+	providers.CanProxy:               providers.Can(),
 	providers.CanUseAlias:            providers.Can("CF automatically flattens CNAME records into A records dynamically"),
 	providers.CanUseCAA:              providers.Can(),
 	providers.CanUseDNSKEY:           providers.Cannot(),
@@ -515,6 +516,15 @@ func (c *cloudflareProvider) preprocessConfig(dc *models.DomainConfig) error {
 			rec.TTL = 60
 		}
 
+		// PROXY_ON() and PROXY_OFF() are the provider-neutral equivalents
+		// of CF_PROXY_ON and CF_PROXY_OFF.
+		if p := rec.Proxy(); p != "" {
+			if v := rec.Metadata[metaProxy]; v != "" && v != p {
+				return fmt.Errorf("%s %s: both PROXY_%s() and cloudflare_proxy=%s are set", rec.Type, rec.GetLabel(), strings.ToUpper(p), v)
+			}
+			rec.Metadata[metaProxy] = p
+		}
+
 		if rec.Type != "A" && rec.Type != "CNAME" && rec.Type != "AAAA" && rec.Type != "ALIAS" {
 			if rec.Metadata[metaProxy] != "" {
 				return fmt.Errorf("cloudflare_proxy set on %v record: %#v cloudflare_proxy=%#v", rec.Type, rec.GetLabel(), rec.Metadata[metaProxy])
@@ -847,9 +857,10 @@ func (c *cloudflareProvider) nativeToRecord(domain string, cr cloudflare.DNSReco
 	}
 
 	rc := &models.RecordConfig{
-		TTL:      uint32(cr.TTL),
-		Original: cr,
-		Metadata: map[string]string{},
+		TTL:        uint32(cr.TTL),
+		Original:   cr,
+		Metadata:   map[string]string{},
+		ModifiedAt: cr.ModifiedOn,
 	}
 	rc.SetLabelFromFQDN(cr.Name, domain)
 
@@ -958,6 +969,6 @@ func (c *cloudflareProvider) createTestWorker(workerName string) error {
 			});`,
 	}
 
-	_, err := c.cfClient.UploadWorker(context.Background(), cloudflare.AccountIdentifier(c.accountID), wp)
+	_, err := c.cfClient.UploadWorker(runctx.Context(), cloudflare.AccountIdentifier(c.accountID), wp)
 	return err
 }
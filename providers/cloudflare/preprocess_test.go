@@ -77,6 +77,28 @@ func TestPreprocess_DefaultProxy(t *testing.T) {
 	}
 }
 
+func TestPreprocess_NeutralProxy(t *testing.T) {
+	cf := &cloudflareProvider{}
+	domain := newDomainConfig()
+	domain.Metadata[metaProxyDefault] = "on"
+	domain.Records = append(domain.Records, makeRCmeta(map[string]string{"proxy": "off"}))
+	domain.Records = append(domain.Records, makeRCmeta(map[string]string{"proxy": "on", metaProxy: "on"}))
+	if err := cf.preprocessConfig(domain); err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []string{"off", "on"} {
+		if got := domain.Records[i].Metadata[metaProxy]; got != expected {
+			t.Errorf("At index %d: expect '%s' but found '%s'", i, expected, got)
+		}
+	}
+
+	domain = newDomainConfig()
+	domain.Records = append(domain.Records, makeRCmeta(map[string]string{"proxy": "off", metaProxy: "on"}))
+	if err := cf.preprocessConfig(domain); err == nil {
+		t.Fatal("Expected an error for conflicting proxy settings, but got none")
+	}
+}
+
 func TestPreprocess_DefaultProxy_Validation(t *testing.T) {
 	cf := &cloudflareProvider{}
 	domain := newDomainConfig()
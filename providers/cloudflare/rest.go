@@ -1,7 +1,6 @@
 package cloudflare
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -9,6 +8,7 @@ import (
 	"golang.org/x/net/idna"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/StackExchange/dnscontrol/v4/providers/cloudflare/rtypes/cfsingleredirect"
 	"github.com/cloudflare/cloudflare-go"
 )
@@ -23,7 +23,7 @@ func (c *cloudflareProvider) cacheDomainList() error {
 	c.domainIndex = map[string]string{}
 	c.nameservers = map[string][]string{}
 	//fmt.Printf("DEBUG: CLOUDFLARE POPULATING CACHE\n")
-	zones, err := c.cfClient.ListZones(context.Background())
+	zones, err := c.cfClient.ListZones(runctx.Context())
 	if err != nil {
 		return fmt.Errorf("failed fetching domain list from cloudflare(%q): %s", c.cfClient.APIEmail, err)
 	}
@@ -49,7 +49,7 @@ func (c *cloudflareProvider) cacheDomainList() error {
 // get all records for a domain
 func (c *cloudflareProvider) getRecordsForDomain(id string, domain string) ([]*models.RecordConfig, error) {
 	records := []*models.RecordConfig{}
-	rrs, _, err := c.cfClient.ListDNSRecords(context.Background(), cloudflare.ZoneIdentifier(id), cloudflare.ListDNSRecordsParams{})
+	rrs, _, err := c.cfClient.ListDNSRecords(runctx.Context(), cloudflare.ZoneIdentifier(id), cloudflare.ListDNSRecordsParams{})
 	if err != nil {
 		return nil, fmt.Errorf("failed fetching record list from cloudflare(%q): %w", c.cfClient.APIEmail, err)
 	}
@@ -64,11 +64,11 @@ func (c *cloudflareProvider) getRecordsForDomain(id string, domain string) ([]*m
 }
 
 func (c *cloudflareProvider) deleteDNSRecord(rec cloudflare.DNSRecord, domainID string) error {
-	return c.cfClient.DeleteDNSRecord(context.Background(), cloudflare.ZoneIdentifier(domainID), rec.ID)
+	return c.cfClient.DeleteDNSRecord(runctx.Context(), cloudflare.ZoneIdentifier(domainID), rec.ID)
 }
 
 func (c *cloudflareProvider) createZone(domainName string) (string, error) {
-	zone, err := c.cfClient.CreateZone(context.Background(), domainName, false, cloudflare.Account{ID: c.accountID}, "full")
+	zone, err := c.cfClient.CreateZone(runctx.Context(), domainName, false, cloudflare.Account{ID: c.accountID}, "full")
 	return zone.ID, err
 }
 
@@ -203,7 +203,7 @@ func (c *cloudflareProvider) createRecDiff2(rec *models.RecordConfig, domainID s
 			} else if rec.Type == "HTTPS" || rec.Type == "SVCB" {
 				cf.Data = cfSvcbData(rec)
 			}
-			resp, err := c.cfClient.CreateDNSRecord(context.Background(), cloudflare.ZoneIdentifier(domainID), cf)
+			resp, err := c.cfClient.CreateDNSRecord(runctx.Context(), cloudflare.ZoneIdentifier(domainID), cf)
 			if err != nil {
 				return err
 			}
@@ -261,24 +261,24 @@ func (c *cloudflareProvider) modifyRecord(domainID, recID string, proxied bool,
 	} else if rec.Type == "HTTPS" || rec.Type == "SVCB" {
 		r.Data = cfSvcbData(rec)
 	}
-	_, err := c.cfClient.UpdateDNSRecord(context.Background(), cloudflare.ZoneIdentifier(domainID), r)
+	_, err := c.cfClient.UpdateDNSRecord(runctx.Context(), cloudflare.ZoneIdentifier(domainID), r)
 	return err
 }
 
 // change universal ssl state
 func (c *cloudflareProvider) changeUniversalSSL(domainID string, state bool) error {
-	_, err := c.cfClient.EditUniversalSSLSetting(context.Background(), domainID, cloudflare.UniversalSSLSetting{Enabled: state})
+	_, err := c.cfClient.EditUniversalSSLSetting(runctx.Context(), domainID, cloudflare.UniversalSSLSetting{Enabled: state})
 	return err
 }
 
 // get universal ssl state
 func (c *cloudflareProvider) getUniversalSSL(domainID string) (bool, error) {
-	result, err := c.cfClient.UniversalSSLSettingDetails(context.Background(), domainID)
+	result, err := c.cfClient.UniversalSSLSettingDetails(runctx.Context(), domainID)
 	return result.Enabled, err
 }
 
 func (c *cloudflareProvider) getSingleRedirects(id string, domain string) ([]*models.RecordConfig, error) {
-	rules, err := c.cfClient.GetEntrypointRuleset(context.Background(), cloudflare.ZoneIdentifier(id), "http_request_dynamic_redirect")
+	rules, err := c.cfClient.GetEntrypointRuleset(runctx.Context(), cloudflare.ZoneIdentifier(id), "http_request_dynamic_redirect")
 	if err != nil {
 		var e *cloudflare.NotFoundError
 		if errors.As(err, &e) {
@@ -346,7 +346,7 @@ func (c *cloudflareProvider) createSingleRedirect(domainID string, cfr models.Cl
 	newSingleRedirectRules[0].ActionParameters = &newSingleRedirectRulesActionParameters
 
 	// Get a list of current redirects so that the new redirect get appended to it
-	rules, err := c.cfClient.GetEntrypointRuleset(context.Background(), cloudflare.ZoneIdentifier(domainID), "http_request_dynamic_redirect")
+	rules, err := c.cfClient.GetEntrypointRuleset(runctx.Context(), cloudflare.ZoneIdentifier(domainID), "http_request_dynamic_redirect")
 	var e *cloudflare.NotFoundError
 	if err != nil && !errors.As(err, &e) {
 		return fmt.Errorf("failed fetching redirect rule list cloudflare: %s", err)
@@ -354,7 +354,7 @@ func (c *cloudflareProvider) createSingleRedirect(domainID string, cfr models.Cl
 	newSingleRedirect.Rules = newSingleRedirectRules
 	newSingleRedirect.Rules = append(newSingleRedirect.Rules, rules.Rules...)
 
-	_, err = c.cfClient.UpdateEntrypointRuleset(context.Background(), cloudflare.ZoneIdentifier(domainID), newSingleRedirect)
+	_, err = c.cfClient.UpdateEntrypointRuleset(runctx.Context(), cloudflare.ZoneIdentifier(domainID), newSingleRedirect)
 
 	return err
 }
@@ -365,7 +365,7 @@ func (c *cloudflareProvider) deleteSingleRedirects(domainID string, cfr models.C
 	// updatedRuleset := cloudflare.UpdateEntrypointRulesetParams{}
 	// updatedRulesetRules := []cloudflare.RulesetRule{}
 
-	// rules, err := c.cfClient.GetEntrypointRuleset(context.Background(), cloudflare.ZoneIdentifier(domainID), "http_request_dynamic_redirect")
+	// rules, err := c.cfClient.GetEntrypointRuleset(runctx.Context(), cloudflare.ZoneIdentifier(domainID), "http_request_dynamic_redirect")
 	// if err != nil {
 	// 	return fmt.Errorf("failed fetching redirect rule list cloudflare: %s", err)
 	// }
@@ -378,17 +378,17 @@ func (c *cloudflareProvider) deleteSingleRedirects(domainID string, cfr models.C
 	// 	}
 	// }
 	// updatedRuleset.Rules = updatedRulesetRules
-	// _, err = c.cfClient.UpdateEntrypointRuleset(context.Background(), cloudflare.ZoneIdentifier(domainID), updatedRuleset)
+	// _, err = c.cfClient.UpdateEntrypointRuleset(runctx.Context(), cloudflare.ZoneIdentifier(domainID), updatedRuleset)
 
 	// Old Code
 
-	// rules, err := c.cfClient.GetEntrypointRuleset(context.Background(), cloudflare.ZoneIdentifier(domainID), "http_request_dynamic_redirect")
+	// rules, err := c.cfClient.GetEntrypointRuleset(runctx.Context(), cloudflare.ZoneIdentifier(domainID), "http_request_dynamic_redirect")
 	// if err != nil {
 	// 	return err
 	// }
 	//printer.Printf("DEBUG: CALLING API DeleteRulesetRule: SRRRulesetID=%v, cfr.SRRRulesetRuleID=%v\n", cfr.SRRRulesetID, cfr.SRRRulesetRuleID)
 
-	err := c.cfClient.DeleteRulesetRule(context.Background(), cloudflare.ZoneIdentifier(domainID), cloudflare.DeleteRulesetRuleParams{
+	err := c.cfClient.DeleteRulesetRule(runctx.Context(), cloudflare.ZoneIdentifier(domainID), cloudflare.DeleteRulesetRuleParams{
 		RulesetID:     cfr.SRRRulesetID,
 		RulesetRuleID: cfr.SRRRulesetRuleID},
 	)
@@ -408,7 +408,7 @@ func (c *cloudflareProvider) updateSingleRedirect(domainID string, oldrec, newre
 }
 
 func (c *cloudflareProvider) getPageRules(id string, domain string) ([]*models.RecordConfig, error) {
-	rules, err := c.cfClient.ListPageRules(context.Background(), id)
+	rules, err := c.cfClient.ListPageRules(runctx.Context(), id)
 	if err != nil {
 		return nil, fmt.Errorf("failed fetching page rule list cloudflare: %s", err)
 	}
@@ -442,12 +442,12 @@ func (c *cloudflareProvider) getPageRules(id string, domain string) ([]*models.R
 }
 
 func (c *cloudflareProvider) deletePageRule(recordID, domainID string) error {
-	return c.cfClient.DeletePageRule(context.Background(), domainID, recordID)
+	return c.cfClient.DeletePageRule(runctx.Context(), domainID, recordID)
 }
 
 func (c *cloudflareProvider) updatePageRule(recordID, domainID string, cfr models.CloudflareSingleRedirectConfig) error {
 	// maybe someday?
-	//c.apiProvider.UpdatePageRule(context.Background(), domainId, recordID, )
+	//c.apiProvider.UpdatePageRule(runctx.Context(), domainId, recordID, )
 	if err := c.deletePageRule(recordID, domainID); err != nil {
 		return err
 	}
@@ -472,12 +472,12 @@ func (c *cloudflareProvider) createPageRule(domainID string, cfr models.Cloudfla
 			}},
 		},
 	}
-	_, err := c.cfClient.CreatePageRule(context.Background(), domainID, pr)
+	_, err := c.cfClient.CreatePageRule(runctx.Context(), domainID, pr)
 	return err
 }
 
 func (c *cloudflareProvider) getWorkerRoutes(id string, domain string) ([]*models.RecordConfig, error) {
-	res, err := c.cfClient.ListWorkerRoutes(context.Background(), cloudflare.ZoneIdentifier(id), cloudflare.ListWorkerRoutesParams{})
+	res, err := c.cfClient.ListWorkerRoutes(runctx.Context(), cloudflare.ZoneIdentifier(id), cloudflare.ListWorkerRoutesParams{})
 	if err != nil {
 		return nil, fmt.Errorf("failed fetching worker route list cloudflare: %s", err)
 	}
@@ -500,7 +500,7 @@ func (c *cloudflareProvider) getWorkerRoutes(id string, domain string) ([]*model
 }
 
 func (c *cloudflareProvider) deleteWorkerRoute(recordID, domainID string) error {
-	_, err := c.cfClient.DeleteWorkerRoute(context.Background(), cloudflare.ZoneIdentifier(domainID), recordID)
+	_, err := c.cfClient.DeleteWorkerRoute(runctx.Context(), cloudflare.ZoneIdentifier(domainID), recordID)
 	return err
 }
 
@@ -525,7 +525,7 @@ func (c *cloudflareProvider) createWorkerRoute(domainID string, target string) e
 		Script:  parts[1],
 	}
 
-	_, err := c.cfClient.CreateWorkerRoute(context.Background(), cloudflare.ZoneIdentifier(domainID), wr)
+	_, err := c.cfClient.CreateWorkerRoute(runctx.Context(), cloudflare.ZoneIdentifier(domainID), wr)
 	return err
 }
 
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"strconv"
 	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 // Api layer for ClouDNS
@@ -140,6 +142,9 @@ func (c *cloudnsProvider) fetchDomainList() error {
 }
 
 func (c *cloudnsProvider) createDomain(domain string) error {
+	if err := providers.CheckWrite("create zone " + domain); err != nil {
+		return err
+	}
 	params := requestParams{
 		"domain-name": domain,
 		"zone-type":   "master",
@@ -151,6 +156,9 @@ func (c *cloudnsProvider) createDomain(domain string) error {
 }
 
 func (c *cloudnsProvider) createRecord(domainID string, rec requestParams) error {
+	if err := providers.CheckWrite("create a record in " + domainID); err != nil {
+		return err
+	}
 	rec["domain-name"] = domainID
 	if _, err := c.get("/dns/add-record.json", rec); err != nil { // here we add record
 		return fmt.Errorf("failed create record (ClouDNS): %s", err)
@@ -159,6 +167,9 @@ func (c *cloudnsProvider) createRecord(domainID string, rec requestParams) error
 }
 
 func (c *cloudnsProvider) deleteRecord(domainID string, recordID string) error {
+	if err := providers.CheckWrite("delete record " + recordID + " of " + domainID); err != nil {
+		return err
+	}
 	params := requestParams{
 		"domain-name": domainID,
 		"record-id":   recordID,
@@ -170,6 +181,9 @@ func (c *cloudnsProvider) deleteRecord(domainID string, recordID string) error {
 }
 
 func (c *cloudnsProvider) modifyRecord(domainID string, recordID string, rec requestParams) error {
+	if err := providers.CheckWrite("modify record " + recordID + " of " + domainID); err != nil {
+		return err
+	}
 	rec["domain-name"] = domainID
 	rec["record-id"] = recordID
 	if _, err := c.get("/dns/mod-record.json", rec); err != nil {
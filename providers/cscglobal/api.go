@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/mattn/go-isatty"
 )
 
@@ -214,6 +215,9 @@ func (client *providerClient) getNameservers(domain string) ([]string, error) {
 }
 
 func (client *providerClient) updateNameservers(ns []string, domain string) error {
+	if err := providers.CheckWrite("update the nameservers of " + domain); err != nil {
+		return err
+	}
 	req := nsModRequest{
 		Domain:      domain,
 		NameServers: ns,
@@ -360,6 +364,9 @@ func (client *providerClient) getZoneRecordsAll(zone string) (*zoneResponse, err
 // It is best to send all the changes for a zone in one big request
 // because the zone is locked until the change propagates.
 func (client *providerClient) sendZoneEditRequest(domainname string, edits []zoneResourceRecordEdit) error {
+	if err := providers.CheckWrite("edit zone " + domainname); err != nil {
+		return err
+	}
 
 	req := zoneEditRequest{
 		ZoneName: domainname,
@@ -518,6 +525,9 @@ func (client *providerClient) clearRequests(domain string) error {
 }
 
 func (client *providerClient) cancelRequest(reqID string) error {
+	if err := providers.CheckWrite("cancel request " + reqID); err != nil {
+		return err
+	}
 	_, err := client.delete("/zones/edits/" + reqID)
 	return err
 }
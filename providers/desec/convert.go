@@ -19,8 +19,9 @@ func nativeToRecords(n resourceRecord, origin string) (rcs []*models.RecordConfi
 	// We must split them out into individual records, one for each value.
 	for _, value := range n.Records {
 		rc := &models.RecordConfig{
-			TTL:      n.TTL,
-			Original: n,
+			TTL:        n.TTL,
+			Original:   n,
+			ModifiedAt: n.touched,
 		}
 		rc.SetLabel(n.Subname, origin)
 		switch rtype := n.Type; rtype {
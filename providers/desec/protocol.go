@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 const apiBase = "https://desec.io/api/v1"
@@ -33,16 +34,18 @@ type domainObject struct {
 }
 
 type resourceRecord struct {
-	Subname string   `json:"subname"`
-	Records []string `json:"records"`
-	TTL     uint32   `json:"ttl,omitempty"`
-	Type    string   `json:"type"`
-	Target  string   `json:"-"`
+	Subname string    `json:"subname"`
+	Records []string  `json:"records"`
+	TTL     uint32    `json:"ttl,omitempty"`
+	Type    string    `json:"type"`
+	Target  string    `json:"-"`
+	touched time.Time // When the rrset was last changed (reported by deSEC)
 }
 
 type rrResponse struct {
 	resourceRecord
 	Created time.Time `json:"created"`
+	Touched time.Time `json:"touched"`
 	Domain  string    `json:"domain"`
 	Name    string    `json:"name"`
 }
@@ -239,6 +242,7 @@ func generateRRSETfromResponse(bodyString []byte) ([]resourceRecord, error) {
 			Type:    rrs[i].Type,
 			Subname: rrs[i].Subname,
 			Records: rrs[i].Records,
+			touched: rrs[i].Touched,
 		}
 		rrsNew = append(rrsNew, tmp)
 	}
@@ -246,6 +250,9 @@ func generateRRSETfromResponse(bodyString []byte) ([]resourceRecord, error) {
 }
 
 func (c *desecProvider) createDomain(domain string) error {
+	if err := providers.CheckWrite("create zone " + domain); err != nil {
+		return err
+	}
 	endpoint := "/domains/"
 	pl := domainObject{Name: domain}
 	byt, _ := json.Marshal(pl)
@@ -266,6 +273,9 @@ func (c *desecProvider) createDomain(domain string) error {
 
 // upsertRR will create or override the RRSet with the provided resource record.
 func (c *desecProvider) upsertRR(rr []resourceRecord, domain string) error {
+	if err := providers.CheckWrite("update the records of " + domain); err != nil {
+		return err
+	}
 	endpoint := fmt.Sprintf("/domains/%s/rrsets/", domain)
 	byt, _ := json.Marshal(rr)
 	if _, err := c.post(endpoint, "PUT", byt); err != nil {
@@ -398,15 +408,15 @@ retry:
 		var nfieldErrors []nonFieldError
 		err = json.Unmarshal(bodyString, &errResp)
 		if err == nil {
-			return bodyString, fmt.Errorf("HTTP status %d %s details: %s", resp.StatusCode, resp.Status, errResp.Detail)
+			return bodyString, providers.WrapStatus(resp.StatusCode, fmt.Errorf("HTTP status %d %s details: %s", resp.StatusCode, resp.Status, errResp.Detail))
 		}
 		err = json.Unmarshal(bodyString, &nfieldErrors)
 		if err == nil && len(nfieldErrors) > 0 {
 			if len(nfieldErrors[0].Errors) > 0 {
-				return bodyString, fmt.Errorf("%s", nfieldErrors[0].Errors[0])
+				return bodyString, providers.WrapStatus(resp.StatusCode, fmt.Errorf("%s", nfieldErrors[0].Errors[0]))
 			}
 		}
-		return bodyString, fmt.Errorf("HTTP status %s Body: %s, the API does not provide more information", resp.Status, bodyString)
+		return bodyString, providers.WrapStatus(resp.StatusCode, fmt.Errorf("HTTP status %s Body: %s, the API does not provide more information", resp.Status, bodyString))
 	}
 	//time.Sleep(334 * time.Millisecond)
 	return bodyString, nil
@@ -1,7 +1,6 @@
 package digitalocean
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -10,6 +9,7 @@ import (
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/digitalocean/godo"
 	"github.com/miekg/dns/dnsutil"
@@ -44,7 +44,7 @@ func NewDo(m map[string]string, metadata json.RawMessage) (providers.DNSServiceP
 		return nil, fmt.Errorf("no DigitalOcean token provided")
 	}
 
-	ctx := context.Background()
+	ctx := runctx.Context()
 	oauthClient := oauth2.NewClient(
 		ctx,
 		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: m["token"]}),
@@ -95,7 +95,7 @@ func init() {
 // EnsureZoneExists creates a zone if it does not exist
 func (api *digitaloceanProvider) EnsureZoneExists(domain string) error {
 retry:
-	ctx := context.Background()
+	ctx := runctx.Context()
 	_, resp, err := api.client.Domains.Get(ctx, domain)
 	if err != nil {
 		if pauseAndRetry(resp) {
@@ -115,7 +115,7 @@ retry:
 
 // ListZones returns the list of zones (domains) in this account.
 func (api *digitaloceanProvider) ListZones() ([]string, error) {
-	ctx := context.Background()
+	ctx := runctx.Context()
 	zones := []string{}
 	opt := &godo.ListOptions{PerPage: perPageSize}
 retry:
@@ -173,7 +173,7 @@ func (api *digitaloceanProvider) GetZoneRecords(domain string, meta map[string]s
 
 // GetZoneRecordsCorrections returns a list of corrections that will turn existing records into dc.Records.
 func (api *digitaloceanProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecords models.Records) ([]*models.Correction, error) {
-	ctx := context.Background()
+	ctx := runctx.Context()
 
 	toReport, toCreate, toDelete, toModify, err := diff.NewCompat(dc).IncrementalDiff(existingRecords)
 	if err != nil {
@@ -240,7 +240,7 @@ func (api *digitaloceanProvider) GetZoneRecordsCorrections(dc *models.DomainConf
 }
 
 func getRecords(api *digitaloceanProvider, name string) ([]godo.DomainRecord, error) {
-	ctx := context.Background()
+	ctx := runctx.Context()
 
 retry:
 
@@ -1,7 +1,6 @@
 package dnsimple
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +12,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/StackExchange/dnscontrol/v4/pkg/txtutil"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	dnsimpleapi "github.com/dnsimple/dnsimple-go/dnsimple"
@@ -283,7 +283,7 @@ func (c *dnsimpleProvider) getDNSSECCorrections(dc *models.DomainConfig) ([]*mod
 // - if "DNSIMPLE_DEBUG_HTTP" is set to "1", it enables the API client logging.
 func (c *dnsimpleProvider) getClient() *dnsimpleapi.Client {
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.AccountToken})
-	tc := oauth2.NewClient(context.Background(), ts)
+	tc := oauth2.NewClient(runctx.Context(), ts)
 
 	// new client
 	client := dnsimpleapi.NewClient(tc)
@@ -301,7 +301,7 @@ func (c *dnsimpleProvider) getClient() *dnsimpleapi.Client {
 func (c *dnsimpleProvider) getAccountID() (string, error) {
 	if c.accountID == "" {
 		client := c.getClient()
-		whoamiResponse, err := client.Identity.Whoami(context.Background())
+		whoamiResponse, err := client.Identity.Whoami(runctx.Context())
 		if err != nil {
 			return "", err
 		}
@@ -330,7 +330,7 @@ func (c *dnsimpleProvider) getRecords(domainName string) ([]dnsimpleapi.ZoneReco
 	page := 1
 	for {
 		opts.Page = &page
-		recordsResponse, err := client.Zones.ListRecords(context.Background(), accountID, domainName, opts)
+		recordsResponse, err := client.Zones.ListRecords(runctx.Context(), accountID, domainName, opts)
 		if err != nil {
 			var errorResponse *dnsimpleapi.ErrorResponse
 			if errors.As(err, &errorResponse) {
@@ -364,7 +364,7 @@ func (c *dnsimpleProvider) getDnssec(domainName string) (bool, error) {
 		return false, err
 	}
 
-	dnssecResponse, err := client.Domains.GetDnssec(context.Background(), accountID, domainName)
+	dnssecResponse, err := client.Domains.GetDnssec(runctx.Context(), accountID, domainName)
 	if err != nil {
 		var errorResponse *dnsimpleapi.ErrorResponse
 		if errors.As(err, &errorResponse) {
@@ -393,7 +393,7 @@ func (c *dnsimpleProvider) enableDnssec(domainName string) (bool, error) {
 		return false, err
 	}
 
-	dnssecResponse, err := client.Domains.EnableDnssec(context.Background(), accountID, domainName)
+	dnssecResponse, err := client.Domains.EnableDnssec(runctx.Context(), accountID, domainName)
 	if err != nil {
 		var errorResponse *dnsimpleapi.ErrorResponse
 		if errors.As(err, &errorResponse) {
@@ -422,7 +422,7 @@ func (c *dnsimpleProvider) disableDnssec(domainName string) (bool, error) {
 		return false, err
 	}
 
-	dnssecResponse, err := client.Domains.DisableDnssec(context.Background(), accountID, domainName)
+	dnssecResponse, err := client.Domains.DisableDnssec(runctx.Context(), accountID, domainName)
 	if err != nil {
 		var errorResponse *dnsimpleapi.ErrorResponse
 		if errors.As(err, &errorResponse) {
@@ -451,7 +451,7 @@ func (c *dnsimpleProvider) getNameservers(domainName string) ([]string, error) {
 		return nil, err
 	}
 
-	domainResponse, err := client.Domains.GetDomain(context.Background(), accountID, domainName)
+	domainResponse, err := client.Domains.GetDomain(runctx.Context(), accountID, domainName)
 	if err != nil {
 		var errorResponse *dnsimpleapi.ErrorResponse
 		if errors.As(err, &errorResponse) {
@@ -462,7 +462,7 @@ func (c *dnsimpleProvider) getNameservers(domainName string) ([]string, error) {
 
 	if domainResponse.Data.State == stateRegistered {
 
-		delegationResponse, err := client.Registrar.GetDomainDelegation(context.Background(), accountID, domainName)
+		delegationResponse, err := client.Registrar.GetDomainDelegation(runctx.Context(), accountID, domainName)
 		if err != nil {
 			var errorResponse *dnsimpleapi.ErrorResponse
 			if errors.As(err, &errorResponse) {
@@ -492,7 +492,7 @@ func (c *dnsimpleProvider) updateNameserversFunc(nameServerNames []string, domai
 
 		nameServers := dnsimpleapi.Delegation(nameServerNames)
 
-		_, err = client.Registrar.ChangeDomainDelegation(context.Background(), accountID, domainName, &nameServers)
+		_, err = client.Registrar.ChangeDomainDelegation(runctx.Context(), accountID, domainName, &nameServers)
 		if err != nil {
 			var errorResponse *dnsimpleapi.ErrorResponse
 			if errors.As(err, &errorResponse) {
@@ -525,7 +525,7 @@ func (c *dnsimpleProvider) createRecordFunc(rc *models.RecordConfig, domainName
 			TTL:      int(rc.TTL),
 			Priority: getTargetRecordPriority(rc),
 		}
-		_, err = client.Zones.CreateRecord(context.Background(), accountID, domainName, record)
+		_, err = client.Zones.CreateRecord(runctx.Context(), accountID, domainName, record)
 		if err != nil {
 			var errorResponse *dnsimpleapi.ErrorResponse
 			if errors.As(err, &errorResponse) {
@@ -552,7 +552,7 @@ func (c *dnsimpleProvider) deleteRecordFunc(recordID int64, domainName string) f
 			return err
 		}
 
-		_, err = client.Zones.DeleteRecord(context.Background(), accountID, domainName, recordID)
+		_, err = client.Zones.DeleteRecord(runctx.Context(), accountID, domainName, recordID)
 		if err != nil {
 			var errorResponse *dnsimpleapi.ErrorResponse
 			if errors.As(err, &errorResponse) {
@@ -587,7 +587,7 @@ func (c *dnsimpleProvider) updateRecordFunc(old *dnsimpleapi.ZoneRecord, rc *mod
 			Priority: getTargetRecordPriority(rc),
 		}
 
-		_, err = client.Zones.UpdateRecord(context.Background(), accountID, domainName, old.ID, record)
+		_, err = client.Zones.UpdateRecord(runctx.Context(), accountID, domainName, old.ID, record)
 		if err != nil {
 			var errorResponse *dnsimpleapi.ErrorResponse
 			if errors.As(err, &errorResponse) {
@@ -617,7 +617,7 @@ func (c *dnsimpleProvider) ListZones() ([]string, error) {
 	page := 1
 	for {
 		opts.Page = &page
-		zonesResponse, err := client.Zones.ListZones(context.Background(), accountID, opts)
+		zonesResponse, err := client.Zones.ListZones(runctx.Context(), accountID, opts)
 		if err != nil {
 			var errorResponse *dnsimpleapi.ErrorResponse
 			if errors.As(err, &errorResponse) {
@@ -729,7 +729,7 @@ func compileAttributeErrors(err *dnsimpleapi.ErrorResponse) error {
 		e := strings.Join(errors, "& ")
 		message += fmt.Sprintf(": %s %s", field, e)
 	}
-	return fmt.Errorf(message)
+	return providers.WrapStatus(err.HTTPResponse.StatusCode, fmt.Errorf(message))
 }
 
 // Return true if the string ends in one of DNSimple's name server domains
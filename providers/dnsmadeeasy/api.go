@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 type dnsMadeEasyProvider struct {
@@ -126,6 +127,9 @@ func (api *dnsMadeEasyProvider) fetchDomainNameServers(domainName string) ([]str
 }
 
 func (api *dnsMadeEasyProvider) createDomain(domain string) error {
+	if err := providers.CheckWrite("create zone " + domain); err != nil {
+		return err
+	}
 	_, err := api.restAPI.singleDomainCreate(singleDomainRequestData{Name: domain})
 
 	if err != nil {
@@ -139,18 +143,27 @@ func (api *dnsMadeEasyProvider) createDomain(domain string) error {
 }
 
 func (api *dnsMadeEasyProvider) deleteRecords(domainID int, recordIds []int) error {
+	if err := providers.CheckWrite(fmt.Sprintf("delete %d records of domain %d", len(recordIds), domainID)); err != nil {
+		return err
+	}
 	err := api.restAPI.multiRecordDelete(domainID, recordIds)
 
 	return err
 }
 
 func (api *dnsMadeEasyProvider) updateRecords(domainID int, records []recordRequestData) error {
+	if err := providers.CheckWrite(fmt.Sprintf("update %d records of domain %d", len(records), domainID)); err != nil {
+		return err
+	}
 	err := api.restAPI.multiRecordUpdate(domainID, records)
 
 	return err
 }
 
 func (api *dnsMadeEasyProvider) createRecords(domainID int, records []recordRequestData) error {
+	if err := providers.CheckWrite(fmt.Sprintf("create %d records in domain %d", len(records), domainID)); err != nil {
+		return err
+	}
 	_, err := api.restAPI.multiRecordCreate(domainID, records)
 
 	return err
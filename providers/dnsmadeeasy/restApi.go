@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 	"net/http"
 	"net/http/httputil"
 	"strings"
@@ -241,7 +242,7 @@ retry:
 		var apiErr apiErrorResponse
 		err = json.NewDecoder(res.Body).Decode(&apiErr)
 		if err != nil {
-			return res.StatusCode, fmt.Errorf("DNSMADEEASY API unknown error, status code: %d", res.StatusCode)
+			return res.StatusCode, providers.WrapStatus(res.StatusCode, fmt.Errorf("DNSMADEEASY API unknown error, status code: %d", res.StatusCode))
 		}
 
 		if len(apiErr.Error) == 1 && apiErr.Error[0] == "Rate limit exceeded" {
@@ -257,7 +258,7 @@ retry:
 			goto retry
 		}
 
-		return res.StatusCode, fmt.Errorf("DNSMADEEASY API error: %s", strings.Join(apiErr.Error, " "))
+		return res.StatusCode, providers.WrapStatus(res.StatusCode, fmt.Errorf("DNSMADEEASY API error: %s", strings.Join(apiErr.Error, " ")))
 	}
 
 	backoff = initialBackoff
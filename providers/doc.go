@@ -0,0 +1,39 @@
+// Package providers is the registry of the DNS providers and registrars
+// that DNSControl can use, and the interfaces they implement.
+//
+// A provider is a Go package that registers itself from an init function:
+//
+//	func init() {
+//		providers.RegisterDomainServiceProviderType("EXAMPLEDNS", providers.DspFuncs{
+//			Initializer:   newProvider,
+//			RecordAuditor: auditRecords,
+//		}, features)
+//	}
+//
+// The name is the TYPE of the creds.json entries that use the provider.
+// The initializer receives the other fields of the entry and returns a
+// DNSServiceProvider (or, for RegisterRegistrarType, a Registrar).
+// features declares the Capabilities of the provider; records that need
+// a capability it lacks are rejected before the provider sees them.
+//
+// A DNSServiceProvider implements:
+//
+//   - GetNameservers: the nameservers of a zone, for the registrar.
+//   - GetZoneRecords: the records of a zone, as models.Records.
+//   - GetZoneRecordsCorrections: the changes that make a zone match the
+//     desired records, usually computed with pkg/diff2. The changes are
+//     made by the functions of the corrections, which dnscontrol only
+//     calls after CheckWrite. The API client of a provider should call
+//     CheckWrite in its functions that write too (see readonly.go).
+//
+// It may also implement ZoneLister (for "get-zones ... all") and
+// ZoneCreator (for "push" to create missing zones).
+//
+// The providers built into dnscontrol are imported by providers/_all. A
+// provider maintained in another module is registered the same way, by
+// a binary whose main package imports both it and providers/_all and
+// calls commands.Run; see documentation/out-of-tree-providers.md. The
+// types and functions of this package, models.DNSProvider,
+// models.Registrar and models.Correction are the API such providers use,
+// and they change only in major versions.
+package providers
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/StackExchange/dnscontrol/v4/providers"
 	"golang.org/x/net/idna"
 )
 
@@ -182,6 +183,9 @@ func (api *domainNameShopProvider) UpdateRecord(dnsR *domainNameShopRecord) erro
 }
 
 func (api *domainNameShopProvider) sendChangeRequest(method string, uri string, payload *bytes.Buffer) error {
+	if err := providers.CheckWrite("send " + method + " " + uri); err != nil {
+		return err
+	}
 	client := &http.Client{}
 
 	var req *http.Request
@@ -210,14 +214,14 @@ func (api *domainNameShopProvider) sendChangeRequest(method string, uri string,
 		//Update successful
 		return nil
 	case 400:
-		return fmt.Errorf("DNS record failed validation")
+		return providers.WrapError(providers.ErrInvalidRecord, fmt.Errorf("DNS record failed validation"))
 	case 403:
-		return fmt.Errorf("not authorized")
+		return providers.WrapError(providers.ErrAuth, fmt.Errorf("not authorized"))
 	case 404:
-		return fmt.Errorf("does not exist")
+		return providers.WrapError(providers.ErrNotFound, fmt.Errorf("does not exist"))
 	case 409:
-		return fmt.Errorf("collision")
+		return providers.WrapError(providers.ErrConflict, fmt.Errorf("collision"))
 	default:
-		return fmt.Errorf("unknown statuscode: %v", resp.StatusCode)
+		return providers.WrapStatus(resp.StatusCode, fmt.Errorf("unknown statuscode: %v", resp.StatusCode))
 	}
 }
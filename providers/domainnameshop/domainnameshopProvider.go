@@ -54,7 +54,7 @@ func init() {
 		RecordAuditor: AuditRecords,
 	}
 
-	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterDomainServiceProviderType(providerName, fns, features, providers.TTLRange{Min: minAllowedTTL, Max: maxAllowedTTL})
 	providers.RegisterMaintainer(providerName, providerMaintainer)
 }
 
@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 // API layer for Dynadot
@@ -61,6 +63,9 @@ func (c *dynadotProvider) getNameservers(domain string) ([]string, error) {
 }
 
 func (c *dynadotProvider) updateNameservers(ns []string, domain string) error {
+	if err := providers.CheckWrite("update the nameservers of " + domain); err != nil {
+		return err
+	}
 	if len(ns) > 13 {
 		return fmt.Errorf("failed NS update (Dynadot): only up to 13 nameservers are supported")
 	}
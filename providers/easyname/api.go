@@ -9,6 +9,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 type easynameResponse interface {
@@ -82,6 +84,9 @@ func (c *easynameProvider) request(method, uri string, body *bytes.Buffer, resul
 }
 
 func (c *easynameProvider) updateNameservers(nss []string, domain int) error {
+	if err := providers.CheckWrite(fmt.Sprintf("update the nameservers of domain %d", domain)); err != nil {
+		return err
+	}
 	var signature string
 	enc := easynameNameserveChange{Nameservers: map[string]string{}}
 	for i, ns := range nss {
@@ -0,0 +1,107 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// The kinds of error a provider can report. A provider marks an error with
+// WrapError (or WrapStatus), and the caller tests for the kind with
+// errors.Is:
+//
+//	if errors.Is(err, providers.ErrRateLimit) { ... }
+var (
+	ErrAuth          = errors.New("authentication failed")
+	ErrRateLimit     = errors.New("rate limited")
+	ErrInvalidRecord = errors.New("invalid record")
+	ErrNotFound      = errors.New("not found")
+	ErrTransient     = errors.New("transient error")
+	ErrConflict      = errors.New("changed concurrently")
+)
+
+// Error is an error from a provider, marked with its kind.
+type Error struct {
+	Kind error // One of ErrAuth, ErrRateLimit, etc.
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap makes both the kind and the original error visible to errors.Is
+// and errors.As.
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// WrapError marks err as being of the given kind. It returns nil if err is nil.
+func WrapError(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// WrapStatus marks err with the kind that matches an HTTP status code. err
+// is returned unchanged if the status code doesn't match a kind.
+func WrapStatus(statusCode int, err error) error {
+	if kind := StatusKind(statusCode); kind != nil {
+		return WrapError(kind, err)
+	}
+	return err
+}
+
+// StatusKind returns the kind of error that an HTTP status code
+// indicates, or nil.
+func StatusKind(statusCode int) error {
+	switch {
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return ErrAuth
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimit
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusBadRequest, statusCode == http.StatusUnprocessableEntity:
+		return ErrInvalidRecord
+	case statusCode == http.StatusPreconditionFailed:
+		return ErrConflict
+	case statusCode == http.StatusRequestTimeout, statusCode >= 500:
+		return ErrTransient
+	}
+	return nil
+}
+
+// IsRetryable reports whether the operation that failed with err may
+// succeed if it is tried again. Only an operation that can safely run
+// twice, such as a read, should be retried on ErrTransient: the failed
+// attempt may have been made.
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrTransient) || errors.Is(err, ErrRateLimit)
+}
+
+// ErrorHint returns advice for the user about err, or "" if there is none.
+func ErrorHint(err error) string {
+	switch {
+	case errors.Is(err, ErrAuth):
+		return "check the credentials for this provider in creds.json"
+	case errors.Is(err, ErrRateLimit):
+		return "the provider is rate limiting requests; try again later"
+	case errors.Is(err, ErrInvalidRecord):
+		return "the provider rejected the record; check that it supports this record type and value"
+	case errors.Is(err, ErrTransient):
+		return "the provider had a temporary problem; try again later"
+	case errors.Is(err, ErrConflict):
+		return "the records were changed by someone else since they were read; run preview again to see the changes"
+	}
+	return ""
+}
+
+// WithHint adds ErrorHint(err), if any, to the message of err.
+func WithHint(err error) error {
+	if hint := ErrorHint(err); hint != "" {
+		return fmt.Errorf("%w (%s)", err, hint)
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWrapStatus(t *testing.T) {
+	tests := []struct {
+		status    int
+		kind      error
+		retryable bool
+	}{
+		{401, ErrAuth, false},
+		{403, ErrAuth, false},
+		{404, ErrNotFound, false},
+		{422, ErrInvalidRecord, false},
+		{429, ErrRateLimit, true},
+		{503, ErrTransient, true},
+		{412, ErrConflict, false},
+		{409, nil, false},
+	}
+	for _, tst := range tests {
+		t.Run(fmt.Sprint(tst.status), func(t *testing.T) {
+			orig := fmt.Errorf("bad status code: %d", tst.status)
+			// Callers usually add context, which must not hide the kind.
+			err := fmt.Errorf("failed fetching zones: %w", WrapStatus(tst.status, orig))
+			if !errors.Is(err, orig) {
+				t.Errorf("the original error is lost: %v", err)
+			}
+			if tst.kind != nil && !errors.Is(err, tst.kind) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tst.kind)
+			}
+			if got := IsRetryable(err); got != tst.retryable {
+				t.Errorf("IsRetryable() = %v, want %v", got, tst.retryable)
+			}
+			if err.Error() != "failed fetching zones: "+orig.Error() {
+				t.Errorf("message changed: %q", err)
+			}
+		})
+	}
+}
+
+func TestWithHint(t *testing.T) {
+	err := WithHint(WrapError(ErrAuth, errors.New("401")))
+	if !errors.Is(err, ErrAuth) || !strings.Contains(err.Error(), "creds.json") {
+		t.Errorf("WithHint() = %v", err)
+	}
+	if err := WithHint(nil); err != nil {
+		t.Errorf("WithHint(nil) = %v", err)
+	}
+}
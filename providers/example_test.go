@@ -0,0 +1,65 @@
+package providers_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/providers"
+)
+
+// exampleProvider is a provider as it could be written in another module.
+type exampleProvider struct {
+	endpoint string
+}
+
+func (p *exampleProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
+	return models.ToNameservers([]string{"ns1.example.net", "ns2.example.net"})
+}
+
+func (p *exampleProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
+	// Download the records from p.endpoint and convert them.
+	return models.Records{}, nil
+}
+
+func (p *exampleProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, existing models.Records) ([]*models.Correction, error) {
+	changes, err := diff2.ByRecord(existing, dc, nil)
+	if err != nil {
+		return nil, err
+	}
+	var corrections []*models.Correction
+	for _, change := range changes {
+		corrections = append(corrections, &models.Correction{
+			Msg: change.Msgs[0],
+			F: func() error {
+				// Call the API of p.endpoint.
+				return nil
+			},
+		})
+	}
+	return corrections, nil
+}
+
+func Example_outOfTreeProvider() {
+	// This is usually done in the init function of the provider's package.
+	providers.RegisterDomainServiceProviderType("EXAMPLEDNS", providers.DspFuncs{
+		Initializer: func(config map[string]string, meta json.RawMessage) (providers.DNSServiceProvider, error) {
+			if config["endpoint"] == "" {
+				return nil, fmt.Errorf("missing endpoint")
+			}
+			return &exampleProvider{endpoint: config["endpoint"]}, nil
+		},
+		RecordAuditor: func([]*models.RecordConfig) []error { return nil },
+	}, providers.DocumentationNotes{
+		providers.CanUseSRV: providers.Can(),
+	})
+
+	// dnscontrol then creates it from a creds.json entry whose TYPE is EXAMPLEDNS.
+	p, err := providers.CreateDNSProvider("-", map[string]string{"TYPE": "EXAMPLEDNS", "endpoint": "https://dns.example.net/api"}, nil)
+	fmt.Println(err, p.(*exampleProvider).endpoint)
+	fmt.Println(providers.ProviderHasCapability("EXAMPLEDNS", providers.CanUseSRV))
+	// Output:
+	// <nil> https://dns.example.net/api
+	// true
+}
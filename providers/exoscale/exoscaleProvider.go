@@ -1,7 +1,6 @@
 package exoscale
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,6 +12,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
@@ -102,7 +102,7 @@ func (c *exoscaleProvider) GetZoneRecords(domainName string, meta map[string]str
 	}
 	domainID := *domain.ID
 
-	ctx := context.Background()
+	ctx := runctx.Context()
 	records, err := c.client.ListDNSDomainRecords(ctx, c.apiZone, domainID)
 	if err != nil {
 		return nil, err
@@ -274,7 +274,7 @@ func (c *exoscaleProvider) createRecordFunc(rc *models.RecordConfig, domainID st
 			record.TTL = &ttl
 		}
 
-		_, err := c.client.CreateDNSDomainRecord(context.Background(), c.apiZone, domainID, &record)
+		_, err := c.client.CreateDNSDomainRecord(runctx.Context(), c.apiZone, domainID, &record)
 
 		return err
 	}
@@ -284,7 +284,7 @@ func (c *exoscaleProvider) createRecordFunc(rc *models.RecordConfig, domainID st
 func (c *exoscaleProvider) deleteRecordFunc(recordID, domainID string) func() error {
 	return func() error {
 		return c.client.DeleteDNSDomainRecord(
-			context.Background(),
+			runctx.Context(),
 			c.apiZone,
 			domainID,
 			&egoscale.DNSDomainRecord{ID: &recordID},
@@ -331,7 +331,7 @@ func (c *exoscaleProvider) updateRecordFunc(record *egoscale.DNSDomainRecord, rc
 		}
 
 		return c.client.UpdateDNSDomainRecord(
-			context.Background(),
+			runctx.Context(),
 			c.apiZone,
 			domainID,
 			record,
@@ -340,7 +340,7 @@ func (c *exoscaleProvider) updateRecordFunc(record *egoscale.DNSDomainRecord, rc
 }
 
 func (c *exoscaleProvider) findDomainByName(name string) (*egoscale.DNSDomain, error) {
-	domains, err := c.client.ListDNSDomains(context.Background(), c.apiZone)
+	domains, err := c.client.ListDNSDomains(runctx.Context(), c.apiZone)
 	if err != nil {
 		return nil, err
 	}
@@ -10,6 +10,8 @@ import (
 	"path"
 	"strings"
 
+	"github.com/StackExchange/dnscontrol/v4/providers"
+
 	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
 )
 
@@ -125,6 +127,9 @@ func (c *gcoreProvider) dnssdkGetDNSSEC(domain string) (bool, error) {
 }
 
 func (c *gcoreProvider) dnssdkSetDNSSEC(domain string, enabled bool) error {
+	if err := providers.CheckWrite("change the DNSSEC of " + domain); err != nil {
+		return err
+	}
 	var request gcoreDNSSECRequest
 	request.Enabled = enabled
 
@@ -311,7 +311,7 @@ func (c *hednsProvider) GetZoneRecords(domain string, meta map[string]string) (m
 			return false
 		}
 
-		// Ignore record types that dnscontrol does not support
+		// Ignore record types that this provider does not implement
 		if rc.Type == "HINFO" || rc.Type == "AFSDB" || rc.Type == "RP" {
 			return true
 		}
@@ -514,6 +514,9 @@ func (c *hednsProvider) listDomains() (map[string]uint64, error) {
 }
 
 func (c *hednsProvider) createDomain(domain string) error {
+	if err := providers.CheckWrite("create zone " + domain); err != nil {
+		return err
+	}
 	values := url.Values{
 		"action":     {"add_zone"},
 		"retmain":    {"0"},
@@ -532,6 +535,9 @@ func (c *hednsProvider) createDomain(domain string) error {
 }
 
 func (c *hednsProvider) editZoneRecord(zoneID uint64, recordID uint64, rc *models.RecordConfig, create bool) error {
+	if err := providers.CheckWrite(fmt.Sprintf("edit a record of zone %d", zoneID)); err != nil {
+		return err
+	}
 	values := url.Values{
 		"account":             {},
 		"menu":                {"edit_zone"},
@@ -588,6 +594,9 @@ func (c *hednsProvider) changeZoneRecord(zoneID uint64, recordID uint64, rc *mod
 }
 
 func (c *hednsProvider) deleteZoneRecord(zoneID uint64, recordID uint64) error {
+	if err := providers.CheckWrite(fmt.Sprintf("delete record %d of zone %d", recordID, zoneID)); err != nil {
+		return err
+	}
 	values := url.Values{
 		"menu":                  {"edit_zone"},
 		"hosted_dns_zoneid":     {strconv.FormatUint(zoneID, 10)},
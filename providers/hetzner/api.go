@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 const (
@@ -43,6 +44,9 @@ func parseHeaderAsInt(headers http.Header, headerName string, fallback int64) (i
 }
 
 func (api *hetznerProvider) bulkCreateRecords(records []record) error {
+	if err := providers.CheckWrite(fmt.Sprintf("create %d records", len(records))); err != nil {
+		return err
+	}
 	request := bulkCreateRecordsRequest{
 		Records: records,
 	}
@@ -50,6 +54,9 @@ func (api *hetznerProvider) bulkCreateRecords(records []record) error {
 }
 
 func (api *hetznerProvider) bulkUpdateRecords(records []record) error {
+	if err := providers.CheckWrite(fmt.Sprintf("update %d records", len(records))); err != nil {
+		return err
+	}
 	request := bulkUpdateRecordsRequest{
 		Records: records,
 	}
@@ -57,6 +64,9 @@ func (api *hetznerProvider) bulkUpdateRecords(records []record) error {
 }
 
 func (api *hetznerProvider) createZone(name string) error {
+	if err := providers.CheckWrite("create zone " + name); err != nil {
+		return err
+	}
 	request := createZoneRequest{
 		Name: name,
 	}
@@ -64,6 +74,9 @@ func (api *hetznerProvider) createZone(name string) error {
 }
 
 func (api *hetznerProvider) deleteRecord(record *record) error {
+	if err := providers.CheckWrite("delete record " + record.ID); err != nil {
+		return err
+	}
 	url := fmt.Sprintf("/records/%s", record.ID)
 	return api.request(url, "DELETE", nil, nil, nil)
 }
@@ -200,7 +213,7 @@ func (api *hetznerProvider) request(endpoint string, method string, request inte
 			data, _ := io.ReadAll(resp.Body)
 			printer.Println(string(data))
 			cleanupResponseBody()
-			return fmt.Errorf("bad status code from HETZNER: %d not 200", resp.StatusCode)
+			return providers.WrapStatus(resp.StatusCode, fmt.Errorf("bad status code from HETZNER: %d not 200", resp.StatusCode))
 		}
 		if target == nil {
 			cleanupResponseBody()
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 	"golang.org/x/net/idna"
 )
 
@@ -48,6 +49,9 @@ func (hp *hostingdeProvider) getDomainConfig(domain string) (*domainConfig, erro
 }
 
 func (hp *hostingdeProvider) createZone(domain string) error {
+	if err := providers.CheckWrite("create zone " + domain); err != nil {
+		return err
+	}
 	t, err := idna.ToASCII(domain)
 	if err != nil {
 		return err
@@ -128,6 +132,9 @@ func (hp *hostingdeProvider) updateNameservers(nss []string, domain string) func
 }
 
 func (hp *hostingdeProvider) updateZone(zc *zoneConfig, options *dnsSecOptions, create, del, mod diff.Changeset) error {
+	if err := providers.CheckWrite("update zone " + zc.Name); err != nil {
+		return err
+	}
 	toAdd := []*record{}
 	for _, c := range create {
 		r := recordToNative(c.Desired)
@@ -249,6 +256,9 @@ func (hp *hostingdeProvider) getDNSSECOptions(zoneConfigID string) (*dnsSecOptio
 }
 
 func (hp *hostingdeProvider) dnsSecKeyModify(domain string, add []dnsSecEntry, remove []dnsSecEntry) error {
+	if err := providers.CheckWrite("modify the DNSSEC keys of " + domain); err != nil {
+		return err
+	}
 	params := request{
 		DomainName: domain,
 		Add:        add,
@@ -44,7 +44,7 @@ func init() {
 		Initializer:   newHostingdeDsp,
 		RecordAuditor: AuditRecords,
 	}
-	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterDomainServiceProviderType(providerName, fns, features, providers.TTLRange{Min: 60, Max: 31556926})
 	providers.RegisterMaintainer(providerName, providerMaintainer)
 }
 
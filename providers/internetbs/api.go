@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 // Api layer for Internet.bs
@@ -41,6 +43,9 @@ func (c *internetbsProvider) getNameservers(domain string) ([]string, error) {
 }
 
 func (c *internetbsProvider) updateNameservers(ns []string, domain string) error {
+	if err := providers.CheckWrite("update the nameservers of " + domain); err != nil {
+		return err
+	}
 	rec := requestParams{}
 	rec["Domain"] = domain
 	rec["Ns_list"] = strings.Join(ns, ",")
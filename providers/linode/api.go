@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 const (
@@ -57,6 +59,9 @@ func (api *linodeProvider) getRecords(id int) ([]domainRecord, error) {
 }
 
 func (api *linodeProvider) createRecord(domainID int, rec *recordEditRequest) (*domainRecord, error) {
+	if err := providers.CheckWrite(fmt.Sprintf("create a record in domain %d", domainID)); err != nil {
+		return nil, err
+	}
 	endpoint := fmt.Sprintf("%s/%d/records", domainsPath, domainID)
 
 	req, err := api.newRequest(http.MethodPost, endpoint, rec)
@@ -85,6 +90,9 @@ func (api *linodeProvider) createRecord(domainID int, rec *recordEditRequest) (*
 }
 
 func (api *linodeProvider) modifyRecord(domainID, recordID int, rec *recordEditRequest) error {
+	if err := providers.CheckWrite(fmt.Sprintf("modify record %d of domain %d", recordID, domainID)); err != nil {
+		return err
+	}
 	endpoint := fmt.Sprintf("%s/%d/records/%d", domainsPath, domainID, recordID)
 
 	req, err := api.newRequest(http.MethodPut, endpoint, rec)
@@ -105,6 +113,9 @@ func (api *linodeProvider) modifyRecord(domainID, recordID int, rec *recordEditR
 }
 
 func (api *linodeProvider) deleteRecord(domainID, recordID int) error {
+	if err := providers.CheckWrite(fmt.Sprintf("delete record %d of domain %d", recordID, domainID)); err != nil {
+		return err
+	}
 	endpoint := fmt.Sprintf("%s/%d/records/%d", domainsPath, domainID, recordID)
 	req, err := api.newRequest(http.MethodDelete, endpoint, nil)
 	if err != nil {
@@ -173,7 +184,7 @@ func (api *linodeProvider) handleErrors(resp *http.Response) error {
 	errs := &errorResponse{}
 
 	if err := decoder.Decode(errs); err != nil {
-		return fmt.Errorf("bad status code from Linode: %d not 200. Failed to decode response", resp.StatusCode)
+		return providers.WrapStatus(resp.StatusCode, fmt.Errorf("bad status code from Linode: %d not 200. Failed to decode response", resp.StatusCode))
 	}
 
 	buf := bytes.NewBufferString(fmt.Sprintf("bad status code from Linode: %d not 200", resp.StatusCode))
@@ -189,7 +200,7 @@ func (api *linodeProvider) handleErrors(resp *http.Response) error {
 		buf.WriteString(err.Reason)
 	}
 
-	return errors.New(buf.String())
+	return providers.WrapStatus(resp.StatusCode, errors.New(buf.String()))
 }
 
 type basicResponse struct {
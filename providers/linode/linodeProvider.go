@@ -1,7 +1,6 @@
 package linode
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -11,6 +10,7 @@ import (
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/miekg/dns/dnsutil"
 	"golang.org/x/oauth2"
@@ -66,7 +66,7 @@ func NewLinode(m map[string]string, metadata json.RawMessage) (providers.DNSServ
 		return nil, fmt.Errorf("missing Linode token")
 	}
 
-	ctx := context.Background()
+	ctx := runctx.Context()
 	client := oauth2.NewClient(
 		ctx,
 		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: m["token"]}),
@@ -106,7 +106,7 @@ func init() {
 		Initializer:   NewLinode,
 		RecordAuditor: AuditRecords,
 	}
-	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterDomainServiceProviderType(providerName, fns, features, providers.TTLRange{Min: allowedTTLValues[1], Max: allowedTTLValues[len(allowedTTLValues)-1]})
 	providers.RegisterMaintainer(providerName, providerMaintainer)
 }
 
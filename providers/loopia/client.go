@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/StackExchange/dnscontrol/v4/providers"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -144,6 +145,9 @@ func (c *APIClient) CreateRecordSimulate(domain string, subdomain string, record
 
 // CreateRecord adds a record.
 func (c *APIClient) CreateRecord(domain string, subdomain string, record paramStruct) error {
+	if err := providers.CheckWrite("create a record in " + subdomain + "." + domain); err != nil {
+		return err
+	}
 	call := &methodCall{
 		MethodName: "addZoneRecord",
 		Params: []param{
@@ -275,6 +279,9 @@ func (c *APIClient) UpdateRecordSimulate(domain string, subdomain string, rec pa
 
 // UpdateRecord updates a record.
 func (c *APIClient) UpdateRecord(domain string, subdomain string, rec paramStruct) error {
+	if err := providers.CheckWrite("update a record of " + subdomain + "." + domain); err != nil {
+		return err
+	}
 	call := &methodCall{
 		MethodName: "updateZoneRecord",
 		Params: []param{
@@ -316,6 +323,9 @@ func (c *APIClient) DeleteRecordSimulate(domain string, subdomain string, record
 
 // DeleteRecord deletes a record.
 func (c *APIClient) DeleteRecord(domain string, subdomain string, recordID uint32) error {
+	if err := providers.CheckWrite(fmt.Sprintf("delete record %d of %s.%s", recordID, subdomain, domain)); err != nil {
+		return err
+	}
 	call := &methodCall{
 		MethodName: "removeZoneRecord",
 		Params: []param{
@@ -338,6 +348,9 @@ func (c *APIClient) DeleteRecord(domain string, subdomain string, recordID uint3
 
 // DeleteSubdomain deletes a sub-domain and its child records.
 func (c *APIClient) DeleteSubdomain(domain, subdomain string) error {
+	if err := providers.CheckWrite("delete subdomain " + subdomain + "." + domain); err != nil {
+		return err
+	}
 	call := &methodCall{
 		MethodName: "removeSubdomain",
 		Params: []param{
@@ -425,7 +438,7 @@ func (c *APIClient) httpPost(url string, bodyType string, body io.Reader) ([]byt
 		c.requestRateLimiter.handleRateLimitedRequest()
 		cleanupResponseBody()
 	} else if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP Post Error: %d", resp.StatusCode)
+		return nil, providers.WrapStatus(resp.StatusCode, fmt.Errorf("HTTP Post Error: %d", resp.StatusCode))
 	}
 
 	defer cleanupResponseBody()
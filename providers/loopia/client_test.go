@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -218,6 +219,29 @@ func TestClient_GetDomainRecords(t *testing.T) {
 	assert.EqualValues(t, zrs, recordObjs)
 }
 
+func TestClient_ReadOnly(t *testing.T) {
+	serverResponses := map[string]string{
+		getZoneRecords:        getZoneRecordsResponse,
+		addZoneRecordGoodAuth: responseOk,
+	}
+
+	serverURL := createFakeServer(t, serverResponses)
+
+	client := NewClient("apiuser", "goodpassword", "", false, true, false)
+	client.BaseURL = serverURL + "/"
+
+	providers.SetReadOnly(true)
+	defer providers.SetReadOnly(false)
+
+	// The API reads with POST too.
+	_, err := client.getDomainRecords(exampleDomain, exampleSubDomain)
+	require.NoError(t, err)
+
+	zr := zRec{Type: "TXT", TTL: 123, Rdata: "TXTrecord"}
+	err = client.CreateRecord(exampleDomain, exampleSubDomain, zr.SetPS())
+	assert.ErrorIs(t, err, providers.ErrReadOnly)
+}
+
 func TestClient_rpcCall_404(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		_, err := io.ReadAll(r.Body)
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 // Api layer for LuaDNS
@@ -100,6 +101,9 @@ func (l *luadnsProvider) getDomainID(name string) (uint32, error) {
 }
 
 func (l *luadnsProvider) createDomain(domain string) error {
+	if err := providers.CheckWrite("create zone " + domain); err != nil {
+		return err
+	}
 	params := jsonRequestParams{
 		"name": domain,
 	}
@@ -110,6 +114,9 @@ func (l *luadnsProvider) createDomain(domain string) error {
 }
 
 func (l *luadnsProvider) createRecord(domainID uint32, rec jsonRequestParams) error {
+	if err := providers.CheckWrite(fmt.Sprintf("create a record in zone %d", domainID)); err != nil {
+		return err
+	}
 	if _, err := l.get(fmt.Sprintf("/zones/%d/records", domainID), "POST", rec); err != nil {
 		return fmt.Errorf("failed create record (LuaDNS): %s", err)
 	}
@@ -117,6 +124,9 @@ func (l *luadnsProvider) createRecord(domainID uint32, rec jsonRequestParams) er
 }
 
 func (l *luadnsProvider) deleteRecord(domainID uint32, recordID uint32) error {
+	if err := providers.CheckWrite(fmt.Sprintf("delete record %d of zone %d", recordID, domainID)); err != nil {
+		return err
+	}
 	if _, err := l.get(fmt.Sprintf("/zones/%d/records/%d", domainID, recordID), "DELETE", requestParams{}); err != nil {
 		return fmt.Errorf("failed delete record (LuaDNS): %s", err)
 	}
@@ -124,6 +134,9 @@ func (l *luadnsProvider) deleteRecord(domainID uint32, recordID uint32) error {
 }
 
 func (l *luadnsProvider) modifyRecord(domainID uint32, recordID uint32, rec jsonRequestParams) error {
+	if err := providers.CheckWrite(fmt.Sprintf("modify record %d of zone %d", recordID, domainID)); err != nil {
+		return err
+	}
 	if _, err := l.get(fmt.Sprintf("/zones/%d/records/%d", domainID, recordID), "PUT", rec); err != nil {
 		return fmt.Errorf("failed update (LuaDNS): %s", err)
 	}
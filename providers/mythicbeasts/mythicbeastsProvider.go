@@ -138,6 +138,9 @@ func (n *mythicBeastsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig
 						}
 					}
 
+					if err := providers.CheckWrite("replace the records of " + dc.Name); err != nil {
+						return err
+					}
 					resp, err := n.httpRequest("PUT", "/zones/"+dc.Name+"/records", strings.NewReader(b.String()))
 					if err != nil {
 						return err
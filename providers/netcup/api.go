@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 const (
@@ -23,6 +25,9 @@ type netcupProvider struct {
 }
 
 func (api *netcupProvider) createRecord(domain string, rec *record) error {
+	if err := providers.CheckWrite("create a record in " + domain); err != nil {
+		return err
+	}
 	rec.Delete = false
 	data := paramUpdateRecords{
 		Key:            api.credentials.apikey,
@@ -41,6 +46,9 @@ func (api *netcupProvider) createRecord(domain string, rec *record) error {
 }
 
 func (api *netcupProvider) deleteRecord(domain string, rec *record) error {
+	if err := providers.CheckWrite("delete a record of " + domain); err != nil {
+		return err
+	}
 	rec.Delete = true
 	data := paramUpdateRecords{
 		Key:            api.credentials.apikey,
@@ -59,6 +67,9 @@ func (api *netcupProvider) deleteRecord(domain string, rec *record) error {
 }
 
 func (api *netcupProvider) modifyRecord(domain string, rec *record) error {
+	if err := providers.CheckWrite("modify a record of " + domain); err != nil {
+		return err
+	}
 	rec.Delete = false
 	data := paramUpdateRecords{
 		Key:            api.credentials.apikey,
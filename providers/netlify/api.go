@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 const baseURL = "https://api.netlify.com/api/v1"
@@ -119,6 +121,9 @@ func (n *netlifyProvider) getDNSRecords(zoneID string) ([]*dnsRecord, error) {
 }
 
 func (n *netlifyProvider) deleteDNSRecord(zoneID string, recordID string) error {
+	if err := providers.CheckWrite("delete record " + recordID + " of zone " + zoneID); err != nil {
+		return err
+	}
 	reqURL := fmt.Sprintf("%s/dns_zones/%s/dns_records/%s", baseURL, zoneID, recordID)
 
 	req, err := http.NewRequest("DELETE", reqURL, nil)
@@ -138,6 +143,9 @@ func (n *netlifyProvider) deleteDNSRecord(zoneID string, recordID string) error
 }
 
 func (n *netlifyProvider) createDNSRecord(zoneID string, rec *dnsRecordCreate) (*dnsRecord, error) {
+	if err := providers.CheckWrite("create a record in zone " + zoneID); err != nil {
+		return nil, err
+	}
 	reqURL := fmt.Sprintf("%s/dns_zones/%s/dns_records", baseURL, zoneID)
 
 	data, err := json.Marshal(rec)
@@ -27,6 +27,7 @@ var features = providers.DocumentationNotes{
 	providers.CanUseSRV:              providers.Can(),
 	providers.CanUseSSHFP:            providers.Cannot(),
 	providers.CanUseTLSA:             providers.Cannot(),
+	providers.ManagedApexRecords:     providers.Can(),
 	providers.DocCreateDomains:       providers.Cannot(),
 	providers.DocDualHost:            providers.Cannot("Netlify does not allow sufficient control over the apex NS records"),
 	providers.DocOfficiallySupported: providers.Cannot(),
@@ -244,6 +244,9 @@ func (n *nsone) GetZoneRecordsCorrections(dc *models.DomainConfig, existingRecor
 }
 
 func (n *nsone) add(recs models.Records, domain string) error {
+	if err := providers.CheckWrite("create records in " + domain); err != nil {
+		return err
+	}
 	for rtr := 0; ; rtr++ {
 		httpResp, err := n.Records.Create(buildRecord(recs, domain, ""))
 		if httpResp.StatusCode == http.StatusTooManyRequests && rtr < clientRetries {
@@ -254,6 +257,9 @@ func (n *nsone) add(recs models.Records, domain string) error {
 }
 
 func (n *nsone) remove(key models.RecordKey, domain string) error {
+	if err := providers.CheckWrite("delete records of " + domain); err != nil {
+		return err
+	}
 	if key.Type == "NS1_URLFWD" {
 		key.Type = "URLFWD"
 	}
@@ -268,6 +274,9 @@ func (n *nsone) remove(key models.RecordKey, domain string) error {
 }
 
 func (n *nsone) modify(recs models.Records, domain string) error {
+	if err := providers.CheckWrite("modify records of " + domain); err != nil {
+		return err
+	}
 	for rtr := 0; ; rtr++ {
 		httpResp, err := n.Records.Update(buildRecord(recs, domain, ""))
 		if httpResp.StatusCode == http.StatusTooManyRequests && rtr < clientRetries {
@@ -286,6 +295,9 @@ func (n *nsone) modify(recs models.Records, domain string) error {
 //
 // Unfortunately this is not detectable otherwise, so given that we have a nice error message, we just let this through.
 func (n *nsone) configureDNSSEC(domain string, enabled bool) error {
+	if err := providers.CheckWrite("change the DNSSEC of " + domain); err != nil {
+		return err
+	}
 	z, _, err := n.Zones.Get(domain, true)
 	if err != nil {
 		return err
@@ -9,6 +9,7 @@ import (
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/dns"
@@ -78,7 +79,7 @@ func New(settings map[string]string, _ json.RawMessage) (providers.DNSServicePro
 
 // ListZones lists the zones on this account.
 func (o *oracleProvider) ListZones() ([]string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	ctx, cancel := context.WithTimeout(runctx.Context(), time.Minute)
 	defer cancel()
 
 	listResp, err := o.client.ListZones(ctx, dns.ListZonesRequest{
@@ -97,7 +98,7 @@ func (o *oracleProvider) ListZones() ([]string, error) {
 
 // EnsureZoneExists creates a zone if it does not exist
 func (o *oracleProvider) EnsureZoneExists(domain string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	ctx, cancel := context.WithTimeout(runctx.Context(), time.Minute)
 	defer cancel()
 
 	getResp, err := o.client.GetZone(ctx, dns.GetZoneRequest{
@@ -139,7 +140,7 @@ func (o *oracleProvider) EnsureZoneExists(domain string) error {
 }
 
 func (o *oracleProvider) GetNameservers(domain string) ([]*models.Nameserver, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	ctx, cancel := context.WithTimeout(runctx.Context(), time.Minute)
 	defer cancel()
 
 	getResp, err := o.client.GetZone(ctx, dns.GetZoneRequest{
@@ -159,7 +160,7 @@ func (o *oracleProvider) GetNameservers(domain string) ([]*models.Nameserver, er
 }
 
 func (o *oracleProvider) GetZoneRecords(zone string, meta map[string]string) (models.Records, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	ctx, cancel := context.WithTimeout(runctx.Context(), time.Minute)
 	defer cancel()
 
 	records := models.Records{}
@@ -292,7 +293,7 @@ func (o *oracleProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, exis
 }
 
 func (o *oracleProvider) patch(createRecords, deleteRecords models.Records, domain string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	ctx, cancel := context.WithTimeout(runctx.Context(), time.Minute)
 	defer cancel()
 
 	patchReq := dns.PatchZoneRecordsRequest{
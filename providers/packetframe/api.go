@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 const (
@@ -94,6 +96,9 @@ func (api *packetframeProvider) getRecords(zoneID string) ([]domainRecord, error
 }
 
 func (api *packetframeProvider) createRecord(rec *domainRecord) (*domainRecord, error) {
+	if err := providers.CheckWrite("create a record in zone " + rec.Zone); err != nil {
+		return nil, err
+	}
 	endpoint := "dns/records"
 
 	req, err := api.newRequest(http.MethodPost, endpoint, rec)
@@ -110,6 +115,9 @@ func (api *packetframeProvider) createRecord(rec *domainRecord) (*domainRecord,
 }
 
 func (api *packetframeProvider) modifyRecord(rec *domainRecord) error {
+	if err := providers.CheckWrite("modify record " + rec.ID); err != nil {
+		return err
+	}
 	endpoint := "dns/records"
 
 	req, err := api.newRequest(http.MethodPut, endpoint, rec)
@@ -126,6 +134,9 @@ func (api *packetframeProvider) modifyRecord(rec *domainRecord) error {
 }
 
 func (api *packetframeProvider) deleteRecord(zoneID string, recordID string) error {
+	if err := providers.CheckWrite("delete record " + recordID + " of zone " + zoneID); err != nil {
+		return err
+	}
 	endpoint := "dns/records"
 	req, err := api.newRequest(http.MethodDelete, endpoint, deleteRequest{Zone: zoneID, Record: recordID})
 	if err != nil {
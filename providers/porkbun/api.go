@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 const (
@@ -108,6 +109,9 @@ retry:
 }
 
 func (c *porkbunProvider) createRecord(domain string, rec requestParams) error {
+	if err := providers.CheckWrite("create a record in " + domain); err != nil {
+		return err
+	}
 	if _, err := c.post("/dns/create/"+domain, rec); err != nil {
 		return fmt.Errorf("failed create record (porkbun): %w", err)
 	}
@@ -115,6 +119,9 @@ func (c *porkbunProvider) createRecord(domain string, rec requestParams) error {
 }
 
 func (c *porkbunProvider) deleteRecord(domain string, recordID string) error {
+	if err := providers.CheckWrite("delete record " + recordID + " of " + domain); err != nil {
+		return err
+	}
 	params := requestParams{}
 	if _, err := c.post(fmt.Sprintf("/dns/delete/%s/%s", domain, recordID), params); err != nil {
 		return fmt.Errorf("failed delete record (porkbun): %w", err)
@@ -123,6 +130,9 @@ func (c *porkbunProvider) deleteRecord(domain string, recordID string) error {
 }
 
 func (c *porkbunProvider) modifyRecord(domain string, recordID string, rec requestParams) error {
+	if err := providers.CheckWrite("modify record " + recordID + " of " + domain); err != nil {
+		return err
+	}
 	if _, err := c.post(fmt.Sprintf("/dns/edit/%s/%s", domain, recordID), rec); err != nil {
 		return fmt.Errorf("failed update (porkbun): %w", err)
 	}
@@ -153,6 +163,9 @@ func (c *porkbunProvider) getRecords(domain string) ([]domainRecord, error) {
 }
 
 func (c *porkbunProvider) createUrlForwardingRecord(domain string, rec requestParams) error {
+	if err := providers.CheckWrite("create a URL forward in " + domain); err != nil {
+		return err
+	}
 	if _, err := c.post("/domain/addUrlForward/"+domain, rec); err != nil {
 		return fmt.Errorf("failed create url forwarding record (porkbun): %w", err)
 	}
@@ -160,6 +173,9 @@ func (c *porkbunProvider) createUrlForwardingRecord(domain string, rec requestPa
 }
 
 func (c *porkbunProvider) deleteUrlForwardingRecord(domain string, recordID string) error {
+	if err := providers.CheckWrite("delete URL forward " + recordID + " of " + domain); err != nil {
+		return err
+	}
 	params := requestParams{}
 	if _, err := c.post(fmt.Sprintf("/domain/deleteUrlForward/%s/%s", domain, recordID), params); err != nil {
 		return fmt.Errorf("failed delete url forwarding record (porkbun): %w", err)
@@ -223,6 +239,9 @@ func (c *porkbunProvider) getNameservers(domain string) ([]string, error) {
 }
 
 func (c *porkbunProvider) updateNameservers(ns []string, domain string) error {
+	if err := providers.CheckWrite("update the nameservers of " + domain); err != nil {
+		return err
+	}
 	params := requestParams{}
 	params["ns"] = ns
 	if _, err := c.post(fmt.Sprintf("/domain/updateNs/%s", domain), params); err != nil {
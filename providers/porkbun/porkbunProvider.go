@@ -84,7 +84,7 @@ func init() {
 		Initializer:   newDsp,
 		RecordAuditor: AuditRecords,
 	}
-	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterDomainServiceProviderType(providerName, fns, features, providers.TTLRange{Min: minimumTTL})
 	providers.RegisterMaintainer(providerName, providerMaintainer)
 	providers.RegisterCustomRecordType("PORKBUN_URLFWD", providerName, "")
 }
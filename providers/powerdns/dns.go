@@ -1,10 +1,10 @@
 package powerdns
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/mittwald/go-powerdns/apis/zones"
 	"github.com/mittwald/go-powerdns/pdnshttp"
 )
@@ -20,7 +20,7 @@ func (dsp *powerdnsProvider) GetNameservers(string) ([]*models.Nameserver, error
 
 // GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
 func (dsp *powerdnsProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
-	zone, err := dsp.client.Zones().GetZone(context.Background(), dsp.ServerName, canonical(domain))
+	zone, err := dsp.client.Zones().GetZone(runctx.Context(), dsp.ServerName, canonical(domain))
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +63,7 @@ func (dsp *powerdnsProvider) GetZoneRecordsCorrections(dc *models.DomainConfig,
 
 // EnsureZoneExists creates a zone if it does not exist
 func (dsp *powerdnsProvider) EnsureZoneExists(domain string) error {
-	if _, err := dsp.client.Zones().GetZone(context.Background(), dsp.ServerName, canonical(domain)); err != nil {
+	if _, err := dsp.client.Zones().GetZone(runctx.Context(), dsp.ServerName, canonical(domain)); err != nil {
 		if e, ok := err.(pdnshttp.ErrUnexpectedStatus); ok {
 			if e.StatusCode != http.StatusNotFound {
 				return err
@@ -73,7 +73,7 @@ func (dsp *powerdnsProvider) EnsureZoneExists(domain string) error {
 		return nil
 	}
 
-	_, err := dsp.client.Zones().CreateZone(context.Background(), dsp.ServerName, zones.Zone{
+	_, err := dsp.client.Zones().CreateZone(runctx.Context(), dsp.ServerName, zones.Zone{
 		Name:        canonical(domain),
 		Type:        zones.ZoneTypeZone,
 		DNSSec:      dsp.DNSSecOnCreate,
@@ -1,15 +1,14 @@
 package powerdns
 
 import (
-	"context"
-
 	"github.com/StackExchange/dnscontrol/v4/models"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/mittwald/go-powerdns/apis/cryptokeys"
 )
 
 // getDNSSECCorrections returns corrections that update a domain's DNSSEC state.
 func (dsp *powerdnsProvider) getDNSSECCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
-	zoneCryptokeys, getErr := dsp.client.Cryptokeys().ListCryptokeys(context.Background(), dsp.ServerName, dc.Name)
+	zoneCryptokeys, getErr := dsp.client.Cryptokeys().ListCryptokeys(runctx.Context(), dsp.ServerName, dc.Name)
 	if getErr != nil {
 		return nil, getErr
 	}
@@ -34,7 +33,7 @@ func (dsp *powerdnsProvider) getDNSSECCorrections(dc *models.DomainConfig) ([]*m
 			{
 				Msg: "Disable DNSSEC",
 				F: func() error {
-					return dsp.client.Cryptokeys().DeleteCryptokey(context.Background(), dsp.ServerName, dc.Name, keyID)
+					return dsp.client.Cryptokeys().DeleteCryptokey(runctx.Context(), dsp.ServerName, dc.Name, keyID)
 				},
 			},
 		}, nil
@@ -46,7 +45,7 @@ func (dsp *powerdnsProvider) getDNSSECCorrections(dc *models.DomainConfig) ([]*m
 			{
 				Msg: "Enable DNSSEC",
 				F: func() (err error) {
-					_, err = dsp.client.Cryptokeys().CreateCryptokey(context.Background(), dsp.ServerName, dc.Name, cryptokeys.Cryptokey{
+					_, err = dsp.client.Cryptokeys().CreateCryptokey(runctx.Context(), dsp.ServerName, dc.Name, cryptokeys.Cryptokey{
 						KeyType:   "csk",
 						Active:    true,
 						Published: true,
@@ -1,14 +1,15 @@
 package powerdns
 
 import (
-	"context"
 	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 )
 
 // ListZones returns all the zones in an account
 func (dsp *powerdnsProvider) ListZones() ([]string, error) {
 	var result []string
-	myZones, err := dsp.client.Zones().ListZones(context.Background(), dsp.ServerName)
+	myZones, err := dsp.client.Zones().ListZones(runctx.Context(), dsp.ServerName)
 	if err != nil {
 		return result, err
 	}
@@ -1,6 +1,7 @@
 package providers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -19,7 +20,8 @@ type DNSServiceProvider interface {
 }
 
 // ZoneCreator should be implemented by providers that have the ability to create zones
-// (used for automatically creating zones if they don't exist)
+// (used for automatically creating zones if they don't exist).
+// EnsureZoneExists writes: the commands call CheckWrite before it.
 type ZoneCreator interface {
 	EnsureZoneExists(domain string) error
 }
@@ -31,6 +33,14 @@ type ZoneLister interface {
 	ListZones() ([]string, error)
 }
 
+// ChangeWaiter should be implemented by providers that apply changes
+// asynchronously, so that "push --wait" can wait for them.
+// WaitForChanges returns when the changes made by the corrections run
+// since its last call are live, or when ctx is done.
+type ChangeWaiter interface {
+	WaitForChanges(ctx context.Context) error
+}
+
 // RegistrarInitializer is a function to create a registrar. Function will be passed the unprocessed json payload from the configuration file for the given provider.
 type RegistrarInitializer func(map[string]string) (Registrar, error)
 
@@ -66,7 +76,7 @@ func RegisterRegistrarType(name string, init RegistrarInitializer, pm ...Provide
 // RegisterDomainServiceProviderType adds a dsp to the registry with the given initialization function.
 func RegisterDomainServiceProviderType(name string, fns DspFuncs, pm ...ProviderMetadata) {
 	if _, ok := DNSProviderTypes[name]; ok {
-		log.Fatalf("Cannot register registrar type %q multiple times", name)
+		log.Fatalf("Cannot register DNS service provider type %q multiple times", name)
 	}
 	DNSProviderTypes[name] = fns
 	unwrapProviderCapabilities(name, pm)
@@ -0,0 +1,44 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// The provider APIs that DNSControl calls are either reads or writes:
+//
+//   - Reads: the methods of DNSServiceProvider and Registrar (which only
+//     compute corrections), and ZoneLister.ListZones.
+//   - Writes: the F of a models.Correction, and ZoneCreator.EnsureZoneExists.
+//
+// Read-only (audit) mode is enforced twice. The commands call CheckWrite
+// before they run a correction or call EnsureZoneExists. And the providers
+// with their own API client call CheckWrite in its functions that write,
+// so that a provider that writes outside of the corrections is caught too.
+// Which requests write can't be told from the HTTP method: some APIs
+// (netcup, Loopia) read with POST.
+
+var readOnly atomic.Bool
+
+// ErrReadOnly is returned by CheckWrite in read-only mode.
+var ErrReadOnly = errors.New("read-only audit mode")
+
+// SetReadOnly turns read-only mode on or off.
+func SetReadOnly(on bool) {
+	readOnly.Store(on)
+}
+
+// IsReadOnly reports whether read-only mode is on.
+func IsReadOnly() bool {
+	return readOnly.Load()
+}
+
+// CheckWrite returns an error wrapping ErrReadOnly if read-only mode is on.
+// what describes the write, e.g. "create zone example.com".
+func CheckWrite(what string) error {
+	if readOnly.Load() {
+		return fmt.Errorf("%w: refusing to %s", ErrReadOnly, what)
+	}
+	return nil
+}
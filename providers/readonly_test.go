@@ -0,0 +1,21 @@
+package providers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckWrite(t *testing.T) {
+	if err := CheckWrite("create zone example.com"); err != nil {
+		t.Errorf("refused without read-only mode: %v", err)
+	}
+	SetReadOnly(true)
+	defer SetReadOnly(false)
+	err := CheckWrite("create zone example.com")
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("err = %v, want ErrReadOnly", err)
+	}
+	if want := "read-only audit mode: refusing to create zone example.com"; err.Error() != want {
+		t.Errorf("err = %q, want %q", err, want)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 type realtimeregisterAPI struct {
@@ -65,8 +67,8 @@ func (api *realtimeregisterAPI) request(method string, url string, body io.Reade
 	bodyString, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("realtime Register API error on request to %s: %d, %s", url, resp.StatusCode,
-			string(bodyString))
+		return nil, providers.WrapStatus(resp.StatusCode, fmt.Errorf("realtime Register API error on request to %s: %d, %s", url, resp.StatusCode,
+			string(bodyString)))
 	}
 
 	return bodyString, nil
@@ -182,6 +184,9 @@ func (api *realtimeregisterAPI) updateZone(domain string, body *Zone) error {
 }
 
 func (api *realtimeregisterAPI) updateNameservers(domainName string, nameservers []string) error {
+	if err := providers.CheckWrite("update the nameservers of " + domainName); err != nil {
+		return err
+	}
 	domain := &Domain{
 		Nameservers: nameservers,
 	}
@@ -204,6 +209,9 @@ func (api *realtimeregisterAPI) updateNameservers(domainName string, nameservers
 }
 
 func (api *realtimeregisterAPI) createOrUpdateZone(body *Zone, url string) error {
+	if err := providers.CheckWrite("update zone " + body.Name); err != nil {
+		return err
+	}
 	bodyBytes, err := json.Marshal(body)
 
 	if err != nil {
@@ -0,0 +1,69 @@
+package route53
+
+import (
+	"encoding/json"
+
+	r53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+)
+
+// changeset returns a ChangeResourceRecordSets request as the JSON that
+// "aws route53 change-resource-record-sets --cli-input-json" accepts.
+//
+// The SDK types have no JSON tags, so their unset fields would be output
+// as null (or ""), which the AWS CLI rejects. They are removed.
+func changeset(zoneID *string, batch *r53Types.ChangeBatch) json.RawMessage {
+	b, err := json.Marshal(struct {
+		HostedZoneId *string
+		ChangeBatch  *r53Types.ChangeBatch
+	}{zoneID, batch})
+	if err != nil {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil
+	}
+	b, err = json.Marshal(dropUnset(v))
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// dropUnset removes the null, empty string, empty list and empty object
+// values from v, recursively. false and 0 are kept: they can be set.
+func dropUnset(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		for k, e := range x {
+			if e = dropUnset(e); isUnset(e) {
+				delete(x, k)
+			} else {
+				x[k] = e
+			}
+		}
+	case []any:
+		kept := x[:0]
+		for _, e := range x {
+			if e = dropUnset(e); !isUnset(e) {
+				kept = append(kept, e)
+			}
+		}
+		return kept
+	}
+	return v
+}
+
+func isUnset(v any) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case string:
+		return x == ""
+	case map[string]any:
+		return len(x) == 0
+	case []any:
+		return len(x) == 0
+	}
+	return false
+}
@@ -9,12 +9,14 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/StackExchange/dnscontrol/v4/pkg/txtutil"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -32,6 +34,9 @@ type route53Provider struct {
 	delegationSet *string
 	zonesByID     map[string]r53Types.HostedZone
 	zonesByDomain map[string]r53Types.HostedZone
+
+	mu             sync.Mutex
+	pendingChanges []*string // the IDs of the changes that WaitForChanges waits for
 }
 
 func newRoute53Reg(conf map[string]string) (providers.Registrar, error) {
@@ -56,7 +61,7 @@ func newRoute53(m map[string]string, _ json.RawMessage) (*route53Provider, error
 		optFns = append(optFns, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(keyID, secretKey, tokenID)))
 	}
 
-	config, err := config.LoadDefaultConfig(context.Background(), optFns...)
+	config, err := config.LoadDefaultConfig(runctx.Context(), optFns...)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +84,7 @@ var features = providers.DocumentationNotes{
 	// See providers/capabilities.go for the entire list of capabilities.
 	providers.CanGetZones:            providers.Can(),
 	providers.CanConcur:              providers.Can(),
+	providers.CanSplitHorizon:        providers.Can("Using R53_ZONE()"),
 	providers.CanUseAlias:            providers.Cannot("R53 does not provide a generic ALIAS functionality. Use R53_ALIAS instead."),
 	providers.CanUseCAA:              providers.Can(),
 	providers.CanUseLOC:              providers.Cannot(),
@@ -97,7 +103,9 @@ func init() {
 		Initializer:   newRoute53Dsp,
 		RecordAuditor: AuditRecords,
 	}
-	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	// The default "records per hosted zone" quota. AWS will raise it on request.
+	limits := providers.RecordLimits{MaxRecords: 10000}
+	providers.RegisterDomainServiceProviderType(providerName, fns, features, limits)
 	providers.RegisterRegistrarType(providerName, newRoute53Reg)
 	providers.RegisterCustomRecordType("R53_ALIAS", providerName, "")
 	providers.RegisterMaintainer(providerName, providerMaintainer)
@@ -152,7 +160,7 @@ func (r *route53Provider) getZones() error {
 		var err error
 		withRetry(func() error {
 			inp := &r53.ListHostedZonesInput{Marker: nextMarker}
-			out, err = r.client.ListHostedZones(context.Background(), inp)
+			out, err = r.client.ListHostedZones(runctx.Context(), inp)
 			return err
 		})
 		if err != nil && strings.Contains(err.Error(), "is not authorized") {
@@ -202,7 +210,7 @@ func (r *route53Provider) GetNameservers(domain string) ([]*models.Nameserver, e
 	var z *r53.GetHostedZoneOutput
 	var err error
 	withRetry(func() error {
-		z, err = r.client.GetHostedZone(context.Background(), &r53.GetHostedZoneInput{Id: zone.Id})
+		z, err = r.client.GetHostedZone(runctx.Context(), &r53.GetHostedZoneInput{Id: zone.Id})
 		return err
 	})
 	if err != nil {
@@ -378,14 +386,21 @@ func (r *route53Provider) GetZoneRecordsCorrections(dc *models.DomainConfig, exi
 	addCorrection := func(msg string, req *r53.ChangeResourceRecordSetsInput) {
 		corrections = append(corrections,
 			&models.Correction{
-				Msg: msg,
+				Msg:       msg,
+				Changeset: changeset(zone.Id, req.ChangeBatch),
 				F: func() error {
 					var err error
 					req.HostedZoneId = zone.Id
+					var out *r53.ChangeResourceRecordSetsOutput
 					withRetry(func() error {
-						_, err = r.client.ChangeResourceRecordSets(context.Background(), req)
+						out, err = r.client.ChangeResourceRecordSets(runctx.Context(), req)
 						return err
 					})
+					if err == nil && out.ChangeInfo != nil {
+						r.mu.Lock()
+						r.pendingChanges = append(r.pendingChanges, out.ChangeInfo.Id)
+						r.mu.Unlock()
+					}
 					return err
 				},
 			})
@@ -570,7 +585,7 @@ func (r *route53Provider) getRegistrarNameservers(domainName *string) ([]string,
 	var domainDetail *r53d.GetDomainDetailOutput
 	var err error
 	withRetry(func() error {
-		domainDetail, err = r.registrar.GetDomainDetail(context.Background(), &r53d.GetDomainDetailInput{DomainName: domainName})
+		domainDetail, err = r.registrar.GetDomainDetail(runctx.Context(), &r53d.GetDomainDetailInput{DomainName: domainName})
 		return err
 	})
 	if err != nil {
@@ -593,7 +608,7 @@ func (r *route53Provider) updateRegistrarNameservers(domainName string, nameserv
 	var domainUpdate *r53d.UpdateDomainNameserversOutput
 	var err error
 	withRetry(func() error {
-		domainUpdate, err = r.registrar.UpdateDomainNameservers(context.Background(), &r53d.UpdateDomainNameserversInput{
+		domainUpdate, err = r.registrar.UpdateDomainNameservers(runctx.Context(), &r53d.UpdateDomainNameserversInput{
 			DomainName:  aws.String(domainName),
 			Nameservers: servers,
 		})
@@ -623,7 +638,7 @@ func (r *route53Provider) fetchRecordSets(zoneID *string) ([]r53Types.ResourceRe
 		var list *r53.ListResourceRecordSetsOutput
 		var err error
 		withRetry(func() error {
-			list, err = r.client.ListResourceRecordSets(context.Background(), listInput)
+			list, err = r.client.ListResourceRecordSets(runctx.Context(), listInput)
 			return err
 		})
 		if err != nil {
@@ -651,6 +666,31 @@ func unescape(s *string) string {
 	return name
 }
 
+// WaitForChanges waits for the changes made since its last call to be
+// INSYNC, which Route 53 reports once all its nameservers serve them.
+func (r *route53Provider) WaitForChanges(ctx context.Context) error {
+	r.mu.Lock()
+	ids := r.pendingChanges
+	r.pendingChanges = nil
+	r.mu.Unlock()
+
+	// The waiter requires a maximum; ctx is what ends the wait.
+	maxWait := 24 * time.Hour
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = time.Until(deadline)
+	}
+	waiter := r53.NewResourceRecordSetsChangedWaiter(r.client, func(o *r53.ResourceRecordSetsChangedWaiterOptions) {
+		o.MinDelay = 5 * time.Second
+		o.MaxDelay = 30 * time.Second
+	})
+	for _, id := range ids {
+		if err := waiter.Wait(ctx, &r53.GetChangeInput{Id: id}, maxWait); err != nil {
+			return fmt.Errorf("waiting for change %s: %w", aws.ToString(id), err)
+		}
+	}
+	return nil
+}
+
 func (r *route53Provider) EnsureZoneExists(domain string) error {
 	if err := r.getZones(); err != nil {
 		return err
@@ -676,7 +716,7 @@ func (r *route53Provider) EnsureZoneExists(domain string) error {
 
 	var err error
 	withRetry(func() error {
-		_, err := r.client.CreateHostedZone(context.Background(), in)
+		_, err := r.client.CreateHostedZone(runctx.Context(), in)
 		return err
 	})
 	return err
@@ -141,3 +141,36 @@ func Test_changeBatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestChangeset(t *testing.T) {
+	batch := &r53Types.ChangeBatch{
+		Comment: aws.String("www.example.com A"),
+		Changes: []r53Types.Change{{
+			Action: r53Types.ChangeActionUpsert,
+			ResourceRecordSet: &r53Types.ResourceRecordSet{
+				Name:            aws.String("www.example.com"),
+				Type:            r53Types.RRTypeA,
+				TTL:             aws.Int64(300),
+				ResourceRecords: []r53Types.ResourceRecord{{Value: aws.String("192.0.2.1")}},
+			},
+		}, {
+			Action: r53Types.ChangeActionCreate,
+			ResourceRecordSet: &r53Types.ResourceRecordSet{
+				Name: aws.String("example.com"),
+				Type: r53Types.RRTypeA,
+				AliasTarget: &r53Types.AliasTarget{
+					DNSName:              aws.String("lb.example.net"),
+					EvaluateTargetHealth: false,
+					HostedZoneId:         aws.String("Z2"),
+				},
+			},
+		}},
+	}
+	expected := `{"ChangeBatch":{"Changes":[` +
+		`{"Action":"UPSERT","ResourceRecordSet":{"Name":"www.example.com","ResourceRecords":[{"Value":"192.0.2.1"}],"TTL":300,"Type":"A"}},` +
+		`{"Action":"CREATE","ResourceRecordSet":{"AliasTarget":{"DNSName":"lb.example.net","EvaluateTargetHealth":false,"HostedZoneId":"Z2"},"Name":"example.com","Type":"A"}}],` +
+		`"Comment":"www.example.com A"},"HostedZoneId":"/hostedzone/Z1"}`
+	if actual := string(changeset(aws.String("/hostedzone/Z1"), batch)); actual != expected {
+		t.Errorf("got\n%s\nexpected\n%s", actual, expected)
+	}
+}
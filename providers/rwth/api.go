@@ -15,6 +15,7 @@ import (
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/printer"
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 const (
@@ -67,6 +68,9 @@ func checkIsLockedSystemRecord(record *models.RecordConfig) error {
 }
 
 func (api *rwthProvider) createRecord(record *models.RecordConfig) error {
+	if err := providers.CheckWrite("create a record in " + record.GetLabelFQDN()); err != nil {
+		return err
+	}
 	if err := checkIsLockedSystemRecord(record); err != nil {
 		return err
 	}
@@ -77,6 +81,9 @@ func (api *rwthProvider) createRecord(record *models.RecordConfig) error {
 }
 
 func (api *rwthProvider) destroyRecord(record RecordReply) error {
+	if err := providers.CheckWrite("delete record " + strconv.Itoa(record.ID)); err != nil {
+		return err
+	}
 	if err := checkIsLockedSystemAPIRecord(record); err != nil {
 		return err
 	}
@@ -86,6 +93,9 @@ func (api *rwthProvider) destroyRecord(record RecordReply) error {
 }
 
 func (api *rwthProvider) updateRecord(id int, record models.RecordConfig) error {
+	if err := providers.CheckWrite("update record " + strconv.Itoa(id)); err != nil {
+		return err
+	}
 	if err := checkIsLockedSystemRecord(&record); err != nil {
 		return err
 	}
@@ -156,6 +166,9 @@ func (api *rwthProvider) getZone(name string) (*zone, error) {
 
 // Deploy the zone
 func (api *rwthProvider) deployZone(domain string) error {
+	if err := providers.CheckWrite("deploy zone " + domain); err != nil {
+		return err
+	}
 	zone, err := api.getZone(domain)
 	if err != nil {
 		return err
@@ -190,7 +203,7 @@ func (api *rwthProvider) request(endpoint string, method string, request url.Val
 	if resp.StatusCode != http.StatusOK {
 		data, _ := io.ReadAll(resp.Body)
 		printer.Printf(string(data))
-		return fmt.Errorf("bad status code from RWTH: %d not 200", resp.StatusCode)
+		return providers.WrapStatus(resp.StatusCode, fmt.Errorf("bad status code from RWTH: %d not 200", resp.StatusCode))
 	}
 	if target == nil {
 		return nil
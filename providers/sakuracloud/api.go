@@ -15,6 +15,8 @@ import (
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/StackExchange/dnscontrol/v4/providers"
 )
 
 // requestCommonServiceItem is the body structure of the request to create a zone or update zone data.
@@ -408,6 +410,9 @@ func (api *sakuracloudAPI) postCommonServiceItem(reqItem requestCommonServiceIte
 
 // CreateZone submits a CommonServiceItem to the API and create the zone.
 func (api *sakuracloudAPI) CreateZone(domain string) error {
+	if err := providers.CheckWrite("create zone " + domain); err != nil {
+		return err
+	}
 	reqItem := requestCommonServiceItem{
 		CommonServiceItem: commonServiceItem{
 			Name: domain,
@@ -458,6 +463,9 @@ func (api *sakuracloudAPI) putCommonServiceItem(id string, reqItem requestCommon
 
 // UpdateZone submits a CommonServiceItem to the API and updates the zone data.
 func (api *sakuracloudAPI) UpdateZone(domain string, domainRecords []domainRecord) error {
+	if err := providers.CheckWrite("update zone " + domain); err != nil {
+		return err
+	}
 	drs := make([]domainRecord, 0, len(domainRecords)-2) // Removes 2 NS records.
 	for _, r := range domainRecords {
 		if r.Type == "NS" && r.Name == "@" {
@@ -37,7 +37,7 @@ func init() {
 		Initializer:   newReg,
 		RecordAuditor: AuditRecords,
 	}
-	providers.RegisterDomainServiceProviderType(providerName, fns, features)
+	providers.RegisterDomainServiceProviderType(providerName, fns, features, providers.TTLRange{Min: 60})
 	providers.RegisterMaintainer(providerName, providerMaintainer)
 }
 
@@ -1,7 +1,6 @@
 package vultr
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +11,7 @@ import (
 
 	"github.com/StackExchange/dnscontrol/v4/models"
 	"github.com/StackExchange/dnscontrol/v4/pkg/diff2"
+	"github.com/StackExchange/dnscontrol/v4/pkg/runctx"
 	"github.com/StackExchange/dnscontrol/v4/providers"
 	"github.com/vultr/govultr/v2"
 )
@@ -73,17 +73,17 @@ func NewProvider(m map[string]string, metadata json.RawMessage) (providers.DNSSe
 
 	config := &oauth2.Config{}
 
-	client := govultr.NewClient(config.Client(context.Background(), &oauth2.Token{AccessToken: token}))
+	client := govultr.NewClient(config.Client(runctx.Context(), &oauth2.Token{AccessToken: token}))
 	client.SetUserAgent("dnscontrol")
 
-	_, err := client.Account.Get(context.Background())
+	_, err := client.Account.Get(runctx.Context())
 	return &vultrProvider{client, token}, err
 }
 
 // GetZoneRecords gets the records of a zone and returns them in RecordConfig format.
 func (api *vultrProvider) GetZoneRecords(domain string, meta map[string]string) (models.Records, error) {
 	listOptions := &govultr.ListOptions{}
-	records, recordsMeta, err := api.client.DomainRecord.List(context.Background(), domain, listOptions)
+	records, recordsMeta, err := api.client.DomainRecord.List(runctx.Context(), domain, listOptions)
 	curRecords := make(models.Records, recordsMeta.Total)
 	nextI := 0
 
@@ -106,7 +106,7 @@ func (api *vultrProvider) GetZoneRecords(domain string, meta map[string]string)
 			break
 		} else {
 			listOptions.Cursor = recordsMeta.Links.Next
-			records, recordsMeta, err = api.client.DomainRecord.List(context.Background(), domain, listOptions)
+			records, recordsMeta, err = api.client.DomainRecord.List(runctx.Context(), domain, listOptions)
 			continue
 		}
 	}
@@ -147,7 +147,7 @@ func (api *vultrProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, cur
 			corrections = append(corrections, &models.Correction{
 				Msg: change.Msgs[0],
 				F: func() error {
-					_, err := api.client.DomainRecord.Create(context.Background(), dc.Name, &govultr.DomainRecordReq{Name: r.Name, Type: r.Type, Data: r.Data, TTL: r.TTL, Priority: &r.Priority})
+					_, err := api.client.DomainRecord.Create(runctx.Context(), dc.Name, &govultr.DomainRecordReq{Name: r.Name, Type: r.Type, Data: r.Data, TTL: r.TTL, Priority: &r.Priority})
 					return err
 				},
 			})
@@ -156,7 +156,7 @@ func (api *vultrProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, cur
 			corrections = append(corrections, &models.Correction{
 				Msg: fmt.Sprintf("%s; Vultr RecordID: %v", change.Msgs[0], r.ID),
 				F: func() error {
-					return api.client.DomainRecord.Update(context.Background(), dc.Name, r.ID, &govultr.DomainRecordReq{Name: r.Name, Type: r.Type, Data: r.Data, TTL: r.TTL, Priority: &r.Priority})
+					return api.client.DomainRecord.Update(runctx.Context(), dc.Name, r.ID, &govultr.DomainRecordReq{Name: r.Name, Type: r.Type, Data: r.Data, TTL: r.TTL, Priority: &r.Priority})
 				},
 			})
 		case diff2.DELETE:
@@ -164,7 +164,7 @@ func (api *vultrProvider) GetZoneRecordsCorrections(dc *models.DomainConfig, cur
 			corrections = append(corrections, &models.Correction{
 				Msg: fmt.Sprintf("%s; Vultr RecordID: %v", change.Msgs[0], id),
 				F: func() error {
-					return api.client.DomainRecord.Delete(context.Background(), dc.Name, id)
+					return api.client.DomainRecord.Delete(runctx.Context(), dc.Name, id)
 				},
 			})
 		default:
@@ -189,13 +189,13 @@ func (api *vultrProvider) EnsureZoneExists(domain string) error {
 	}
 
 	// Vultr requires an initial IP, use a dummy one.
-	_, err := api.client.Domain.Create(context.Background(), &govultr.DomainReq{Domain: domain, IP: "0.0.0.0", DNSSec: "disabled"})
+	_, err := api.client.Domain.Create(runctx.Context(), &govultr.DomainReq{Domain: domain, IP: "0.0.0.0", DNSSec: "disabled"})
 	return err
 }
 
 func (api *vultrProvider) isDomainInAccount(domain string) (bool, error) {
 	listOptions := &govultr.ListOptions{}
-	domains, meta, err := api.client.Domain.List(context.Background(), listOptions)
+	domains, meta, err := api.client.Domain.List(runctx.Context(), listOptions)
 
 	for {
 		if err != nil {
@@ -212,7 +212,7 @@ func (api *vultrProvider) isDomainInAccount(domain string) (bool, error) {
 			break
 		} else {
 			listOptions.Cursor = meta.Links.Next
-			domains, meta, err = api.client.Domain.List(context.Background(), listOptions)
+			domains, meta, err = api.client.Domain.List(runctx.Context(), listOptions)
 			continue
 		}
 	}